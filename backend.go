@@ -86,6 +86,9 @@ type BackendStorage[T D] interface {
 	// Conv2d performs 2D convolution using im2col + BLAS for supported types.
 	Conv2d(layout *Layout, kernel BackendStorage[T], kernelLayout *Layout, params *Conv2DParams) (BackendStorage[T], error)
 
+	// Conv2dBackward computes the input and kernel gradients of Conv2d from the output gradient.
+	Conv2dBackward(layout *Layout, kernel BackendStorage[T], kernelLayout *Layout, grad BackendStorage[T], gradLayout *Layout, params *Conv2DParams) (BackendStorage[T], BackendStorage[T], error)
+
 	// ConvTranspose2d performs 2D transposed convolution (deconvolution) for supported types.
 	ConvTranspose2d(layout *Layout, kernel BackendStorage[T], kernelLayout *Layout, params *ConvT2DParams) (BackendStorage[T], error)
 
@@ -98,6 +101,33 @@ type BackendStorage[T D] interface {
 	// UpsampleNearest2d performs 2D nearest neighbor upsampling for supported types.
 	UpsampleNearest2d(layout *Layout, targetH, targetW int) (BackendStorage[T], error)
 
+	// Conv3d performs 3D convolution using vol2col + BLAS for supported types.
+	Conv3d(layout *Layout, kernel BackendStorage[T], kernelLayout *Layout, params *Conv3DParams) (BackendStorage[T], error)
+
+	// Conv3dBackward computes the input and kernel gradients of Conv3d from the output gradient.
+	Conv3dBackward(layout *Layout, kernel BackendStorage[T], kernelLayout *Layout, grad BackendStorage[T], gradLayout *Layout, params *Conv3DParams) (BackendStorage[T], BackendStorage[T], error)
+
+	// ConvTranspose3d performs 3D transposed convolution (deconvolution) for supported types.
+	ConvTranspose3d(layout *Layout, kernel BackendStorage[T], kernelLayout *Layout, params *ConvT3DParams) (BackendStorage[T], error)
+
+	// AvgPool3d performs 3D average pooling for supported types.
+	AvgPool3d(layout *Layout, kD, kH, kW, sD, sH, sW int) (BackendStorage[T], error)
+
+	// AvgPool3dBackward spreads the output gradient of AvgPool3d over the input described by layout.
+	AvgPool3dBackward(layout *Layout, grad BackendStorage[T], gradLayout *Layout, kD, kH, kW, sD, sH, sW int) (BackendStorage[T], error)
+
+	// MaxPool3d performs 3D max pooling for supported types.
+	MaxPool3d(layout *Layout, kD, kH, kW, sD, sH, sW int) (BackendStorage[T], error)
+
+	// MaxPool3dBackward routes the output gradient of MaxPool3d to the first maximum of each window.
+	MaxPool3dBackward(layout *Layout, grad BackendStorage[T], gradLayout *Layout, kD, kH, kW, sD, sH, sW int) (BackendStorage[T], error)
+
+	// UpsampleNearest3d performs 3D nearest neighbor upsampling for supported types.
+	UpsampleNearest3d(layout *Layout, targetD, targetH, targetW int) (BackendStorage[T], error)
+
+	// UpsampleNearest3dBackward sums the output gradient of UpsampleNearest3d into the input described by layout.
+	UpsampleNearest3dBackward(layout *Layout, grad BackendStorage[T], gradLayout *Layout, targetD, targetH, targetW int) (BackendStorage[T], error)
+
 	// ConstSet sets all elements to a constant value for supported types.
 	ConstSet(layout *Layout, val T) error
 
@@ -110,6 +140,15 @@ type BackendStorage[T D] interface {
 	// ScatterAdd performs scatter-add operation along a specified dimension.
 	ScatterAdd(layout *Layout, ids BackendStorage[T], idsLayout *Layout, src BackendStorage[T], srcLayout *Layout, dim int) (BackendStorage[T], error)
 
+	// ArgsortU32 returns uint32 indices that sort each row along the last dimension.
+	ArgsortU32(layout *Layout, descending bool) (BackendStorage[uint32], error)
+
+	// IndexSelect selects slices along a dimension using a 1D index storage of type uint8, uint32 or int64.
+	IndexSelect(layout *Layout, ids any, idsLayout *Layout, dim int) (BackendStorage[T], error)
+
+	// IndexAdd adds src slices into a copy of this storage at 1D indices of type uint8, uint32 or int64 along a dimension.
+	IndexAdd(layout *Layout, ids any, idsLayout *Layout, src BackendStorage[T], srcLayout *Layout, dim int) (BackendStorage[T], error)
+
 	// Copy2d copies a 2D region from source to destination for supported types.
 	Copy2d(dst BackendStorage[T], d1, d2, srcStride1, dstStride1, srcOffset, dstOffset int) error
 
@@ -61,30 +61,109 @@ func (p ConvT1DParams) OutDims() []int {
 	return []int{p.Batch, p.OutCh, p.OutLen()}
 }
 
+// PadMode selects how convolution padding is filled.
+type PadMode int
+
+const (
+	PadZeros     PadMode = iota // Pad with zeros
+	PadReflect                  // Mirror the input, excluding the edge
+	PadReplicate                // Repeat the edge value
+	PadCircular                 // Wrap around to the opposite edge
+)
+
+func (m PadMode) String() string {
+	switch m {
+	case PadZeros:
+		return "zeros"
+	case PadReflect:
+		return "reflect"
+	case PadReplicate:
+		return "replicate"
+	case PadCircular:
+		return "circular"
+	default:
+		return "unknown"
+	}
+}
+
 // Conv2DParams holds parameters for 2D convolution.
-// Assumes uniform padding, stride, and dilation for height and width.
+// Pad, Stride and Dilate apply to both axes; the per-axis fields override
+// them for one axis when non-zero. Zero means unset, so a per-axis padding
+// of 0 cannot undo a non-zero Pad: to pad the axes differently, leave Pad
+// at 0 and set PadH and PadW. The kernel has shape
+// [OutCh, InCh/Groups, KH, KW].
 type Conv2DParams struct {
-	Batch  int // Batch size
-	InH    int // Input height
-	InW    int // Input width
-	KH     int // Kernel height
-	KW     int // Kernel width
-	OutCh  int // Output channels
-	InCh   int // Input channels
-	Pad    int
-	Stride int
-	Dilate int
-	Algo   *FwdAlgo // Optional cuDNN forward algorithm
+	Batch   int // Batch size
+	InH     int // Input height
+	InW     int // Input width
+	KH      int // Kernel height
+	KW      int // Kernel width
+	OutCh   int // Output channels
+	InCh    int // Input channels
+	Pad     int
+	Stride  int
+	Dilate  int
+	PadH    int      // Height padding, overrides Pad when non-zero; 0 falls back to Pad
+	PadW    int      // Width padding, overrides Pad when non-zero; 0 falls back to Pad
+	StrideH int      // Height stride, overrides Stride when non-zero
+	StrideW int      // Width stride, overrides Stride when non-zero
+	DilateH int      // Height dilation, overrides Dilate when non-zero
+	DilateW int      // Width dilation, overrides Dilate when non-zero
+	Groups  int      // Channel groups; 0 means 1
+	PadMode PadMode  // How padded positions are filled
+	Algo    *FwdAlgo // Optional cuDNN forward algorithm
+}
+
+func orDefault(v, def int) int {
+	if v != 0 {
+		return v
+	}
+	return def
+}
+
+// Padding returns the effective height and width padding.
+func (p Conv2DParams) Padding() (h, w int) {
+	return orDefault(p.PadH, p.Pad), orDefault(p.PadW, p.Pad)
+}
+
+// Strides returns the effective height and width stride.
+func (p Conv2DParams) Strides() (h, w int) {
+	return orDefault(p.StrideH, p.Stride), orDefault(p.StrideW, p.Stride)
+}
+
+// Dilations returns the effective height and width dilation.
+func (p Conv2DParams) Dilations() (h, w int) {
+	return orDefault(p.DilateH, p.Dilate), orDefault(p.DilateW, p.Dilate)
+}
+
+// NumGroups returns the number of channel groups, at least 1.
+func (p Conv2DParams) NumGroups() int {
+	return max(p.Groups, 1)
+}
+
+// IsUniform reports whether the convolution uses equal padding, stride and
+// dilation on both axes, one group and zero padding.
+func (p Conv2DParams) IsUniform() bool {
+	ph, pw := p.Padding()
+	sh, sw := p.Strides()
+	dh, dw := p.Dilations()
+	return ph == pw && sh == sw && dh == dw && p.NumGroups() == 1 && p.PadMode == PadZeros
 }
 
 // OutH computes the output height for 2D convolution.
 func (p Conv2DParams) OutH() int {
-	return (p.InH+2*p.Pad-p.Dilate*(p.KH-1)-1)/p.Stride + 1
+	pad, _ := p.Padding()
+	stride, _ := p.Strides()
+	dilate, _ := p.Dilations()
+	return (p.InH+2*pad-dilate*(p.KH-1)-1)/stride + 1
 }
 
 // OutW computes the output width for 2D convolution.
 func (p Conv2DParams) OutW() int {
-	return (p.InW+2*p.Pad-p.Dilate*(p.KW-1)-1)/p.Stride + 1
+	_, pad := p.Padding()
+	_, stride := p.Strides()
+	_, dilate := p.Dilations()
+	return (p.InW+2*pad-dilate*(p.KW-1)-1)/stride + 1
 }
 
 // OutDims returns the output dimensions [batch, out_channels, out_height, out_width].
@@ -122,3 +201,142 @@ func (p ConvT2DParams) OutW() int {
 func (p ConvT2DParams) OutDims() []int {
 	return []int{p.Batch, p.OutCh, p.OutH(), p.OutW()}
 }
+
+// Conv3DParams holds parameters for 3D convolution over NCDHW input.
+// Pad, Stride and Dilate apply to all axes; the per-axis fields override
+// them for one axis when non-zero. Zero means unset, so a per-axis padding
+// of 0 cannot undo a non-zero Pad: to pad the axes differently, leave Pad
+// at 0 and set PadD, PadH and PadW. The kernel has shape
+// [OutCh, InCh/Groups, KD, KH, KW].
+type Conv3DParams struct {
+	Batch   int // Batch size
+	InD     int // Input depth
+	InH     int // Input height
+	InW     int // Input width
+	KD      int // Kernel depth
+	KH      int // Kernel height
+	KW      int // Kernel width
+	OutCh   int // Output channels
+	InCh    int // Input channels
+	Pad     int
+	Stride  int
+	Dilate  int
+	PadD    int // Depth padding, overrides Pad when non-zero; 0 falls back to Pad
+	PadH    int // Height padding, overrides Pad when non-zero; 0 falls back to Pad
+	PadW    int // Width padding, overrides Pad when non-zero; 0 falls back to Pad
+	StrideD int // Depth stride, overrides Stride when non-zero
+	StrideH int // Height stride, overrides Stride when non-zero
+	StrideW int // Width stride, overrides Stride when non-zero
+	DilateD int // Depth dilation, overrides Dilate when non-zero
+	DilateH int // Height dilation, overrides Dilate when non-zero
+	DilateW int // Width dilation, overrides Dilate when non-zero
+	Groups  int // Channel groups; 0 means 1
+}
+
+// Padding returns the effective depth, height and width padding.
+func (p Conv3DParams) Padding() (d, h, w int) {
+	return orDefault(p.PadD, p.Pad), orDefault(p.PadH, p.Pad), orDefault(p.PadW, p.Pad)
+}
+
+// Strides returns the effective depth, height and width stride.
+func (p Conv3DParams) Strides() (d, h, w int) {
+	return orDefault(p.StrideD, p.Stride), orDefault(p.StrideH, p.Stride), orDefault(p.StrideW, p.Stride)
+}
+
+// Dilations returns the effective depth, height and width dilation.
+func (p Conv3DParams) Dilations() (d, h, w int) {
+	return orDefault(p.DilateD, p.Dilate), orDefault(p.DilateH, p.Dilate), orDefault(p.DilateW, p.Dilate)
+}
+
+// NumGroups returns the number of channel groups, at least 1.
+func (p Conv3DParams) NumGroups() int {
+	return max(p.Groups, 1)
+}
+
+// OutD computes the output depth for 3D convolution.
+func (p Conv3DParams) OutD() int {
+	pad, _, _ := p.Padding()
+	stride, _, _ := p.Strides()
+	dilate, _, _ := p.Dilations()
+	return (p.InD+2*pad-dilate*(p.KD-1)-1)/stride + 1
+}
+
+// OutH computes the output height for 3D convolution.
+func (p Conv3DParams) OutH() int {
+	_, pad, _ := p.Padding()
+	_, stride, _ := p.Strides()
+	_, dilate, _ := p.Dilations()
+	return (p.InH+2*pad-dilate*(p.KH-1)-1)/stride + 1
+}
+
+// OutW computes the output width for 3D convolution.
+func (p Conv3DParams) OutW() int {
+	_, _, pad := p.Padding()
+	_, _, stride := p.Strides()
+	_, _, dilate := p.Dilations()
+	return (p.InW+2*pad-dilate*(p.KW-1)-1)/stride + 1
+}
+
+// OutDims returns the output dimensions [batch, out_channels, out_depth, out_height, out_width].
+func (p Conv3DParams) OutDims() []int {
+	return []int{p.Batch, p.OutCh, p.OutD(), p.OutH(), p.OutW()}
+}
+
+// ConvT3DParams holds parameters for 3D transposed convolution.
+// Assumes uniform padding, output_padding, stride, and dilation for all axes.
+// The kernel has shape [InCh, OutCh, KD, KH, KW].
+type ConvT3DParams struct {
+	Batch  int // Batch size
+	InD    int // Input depth
+	InH    int // Input height
+	InW    int // Input width
+	KD     int // Kernel depth
+	KH     int // Kernel height
+	KW     int // Kernel width
+	OutCh  int // Output channels
+	InCh   int // Input channels
+	Pad    int
+	OutPad int
+	Stride int
+	Dilate int
+}
+
+// OutD computes the output depth for 3D transposed convolution.
+func (p ConvT3DParams) OutD() int {
+	return (p.InD-1)*p.Stride + p.Dilate*(p.KD-1) + p.OutPad + 1 - 2*p.Pad
+}
+
+// OutH computes the output height for 3D transposed convolution.
+func (p ConvT3DParams) OutH() int {
+	return (p.InH-1)*p.Stride + p.Dilate*(p.KH-1) + p.OutPad + 1 - 2*p.Pad
+}
+
+// OutW computes the output width for 3D transposed convolution.
+func (p ConvT3DParams) OutW() int {
+	return (p.InW-1)*p.Stride + p.Dilate*(p.KW-1) + p.OutPad + 1 - 2*p.Pad
+}
+
+// OutDims returns the output dimensions [batch, out_channels, out_depth, out_height, out_width].
+func (p ConvT3DParams) OutDims() []int {
+	return []int{p.Batch, p.OutCh, p.OutD(), p.OutH(), p.OutW()}
+}
+
+// Conv3D returns the parameters of the 3D convolution whose input gradient
+// is this transposed convolution: it maps the transposed output back to its
+// input using the same kernel.
+func (p ConvT3DParams) Conv3D() Conv3DParams {
+	return Conv3DParams{
+		Batch:  p.Batch,
+		InD:    p.OutD(),
+		InH:    p.OutH(),
+		InW:    p.OutW(),
+		KD:     p.KD,
+		KH:     p.KH,
+		KW:     p.KW,
+		OutCh:  p.InCh,
+		InCh:   p.OutCh,
+		Pad:    p.Pad,
+		Stride: p.Stride,
+		Dilate: p.Dilate,
+	}
+}
@@ -2,7 +2,7 @@ package mnist
 
 import (
 	"iter"
-	"math/rand"
+	"math/rand/v2"
 
 	"github.com/gocnn/candy"
 	"github.com/gocnn/candy/tensor"
@@ -15,9 +15,12 @@ type DataLoader[T candy.D] struct {
 	shuffle   bool
 	indices   []int
 	device    candy.Device
+	rng       *rand.Rand
 }
 
-// NewDataLoader creates a DataLoader for batch iteration.
+// NewDataLoader creates a DataLoader for batch iteration. A shuffling loader
+// draws its generator from the CPU device generator, so calling
+// tensor.ManualSeed beforehand replays the same batch order.
 func (ds *Dataset[T]) NewDataLoader(batchSize int, shuffle bool, device candy.Device) *DataLoader[T] {
 	indices := make([]int, ds.Len())
 	for i := range indices {
@@ -31,7 +34,8 @@ func (ds *Dataset[T]) NewDataLoader(batchSize int, shuffle bool, device candy.De
 		device:    device,
 	}
 	if shuffle {
-		rand.Shuffle(len(indices), func(i, j int) { indices[i], indices[j] = indices[j], indices[i] })
+		dl.rng = tensor.NewRand()
+		dl.shuffleIndices()
 	}
 	return dl
 }
@@ -93,10 +97,33 @@ func (dl *DataLoader[T]) AllTensors() iter.Seq2[*tensor.Tensor[T], *tensor.Tenso
 // Reset reshuffles the indices if shuffle is enabled.
 func (dl *DataLoader[T]) Reset() {
 	if dl.shuffle {
-		rand.Shuffle(len(dl.indices), func(i, j int) { dl.indices[i], dl.indices[j] = dl.indices[j], dl.indices[i] })
+		dl.shuffleIndices()
 	}
 }
 
+// SetRand sets the generator used for shuffling and reshuffles from dataset order,
+// so a seeded generator replays the same batch sequence. A nil r draws a new
+// generator from the CPU device generator.
+func (dl *DataLoader[T]) SetRand(r *rand.Rand) {
+	if r == nil {
+		r = tensor.NewRand()
+	}
+	dl.rng = r
+	for i := range dl.indices {
+		dl.indices[i] = i
+	}
+	if dl.shuffle {
+		dl.shuffleIndices()
+	}
+}
+
+// shuffleIndices permutes the indices with the loader's generator.
+func (dl *DataLoader[T]) shuffleIndices() {
+	dl.rng.Shuffle(len(dl.indices), func(i, j int) {
+		dl.indices[i], dl.indices[j] = dl.indices[j], dl.indices[i]
+	})
+}
+
 // Len returns the number of batches in the DataLoader.
 func (dl *DataLoader[T]) Len() int {
 	return (len(dl.indices) + dl.batchSize - 1) / dl.batchSize
@@ -77,6 +77,19 @@ type BackendDevice[T D] interface {
 	// Full creates a storage filled with a specific value.
 	Full(*Shape, DType, float64) (BackendStorage[T], error)
 
+	// Arange creates a 1D storage of n values start, start+step, ...
+	Arange(start, step float64, n int, dtype DType) (BackendStorage[T], error)
+
+	// Logspace creates a 1D storage of n values base^start, base^(start+step), ...
+	Logspace(start, step float64, n int, base float64, dtype DType) (BackendStorage[T], error)
+
+	// Eye creates an n x n identity matrix storage.
+	Eye(n int, dtype DType) (BackendStorage[T], error)
+
+	// Tri creates a rows x cols storage of ones on and below (lower) or on and above (upper)
+	// the k-th diagonal, and zeros elsewhere.
+	Tri(rows, cols, k int, lower bool, dtype DType) (BackendStorage[T], error)
+
 	// Synchronize blocks until all operations on the device are complete.
 	Synchronize() error
 }
@@ -2,7 +2,12 @@ package candy
 
 // D is the type constraint for matrices defined in this package.
 type D interface {
-	float32 | float64 | uint8 | uint32 | int64
+	float32 | float64 | uint8 | uint32 | int64 | Float16 | BFloat16
+}
+
+// I is the set of element types usable as indices.
+type I interface {
+	uint8 | uint32 | int64
 }
 
 type DType int
@@ -19,7 +24,7 @@ const (
 
 func (d DType) IsFloat() bool {
 	switch d {
-	case F32, F64:
+	case F32, F64, F16, BF16:
 		return true
 	default:
 		return false
@@ -35,6 +40,22 @@ func (d DType) IsInteger() bool {
 	}
 }
 
+// Size returns the number of bytes of one element.
+func (d DType) Size() int {
+	switch d {
+	case U8:
+		return 1
+	case F16, BF16:
+		return 2
+	case F32, U32:
+		return 4
+	case F64, I64:
+		return 8
+	default:
+		return 0
+	}
+}
+
 func (d DType) String() string {
 	switch d {
 	case F32:
@@ -70,6 +91,10 @@ func DTypeOf[T D]() DType {
 		return U32
 	case int64:
 		return I64
+	case Float16:
+		return F16
+	case BFloat16:
+		return BF16
 	default:
 		// This should never happen due to type constraint
 		panic("unsupported type for DTypeOf")
@@ -22,7 +22,7 @@ func main() {
 	e := tensor.MustReadNPY[int64]("i64.npy")
 	fmt.Printf("%v\n", e)
 
-	z := tensor.MustReadNPZ("pack.npz")
+	z := tensor.MustReadNPZ[float32]("pack.npz")
 	for name, t := range z {
 		fmt.Printf("%s:\n%v\n", name, t)
 	}
@@ -9,7 +9,6 @@ import (
 	"image/png"
 	"math"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -110,16 +109,13 @@ type kv struct {
 	Val float64
 }
 
-func topK(probs []float32, k int) []kv {
-	tmp := make([]kv, len(probs))
-	for i, v := range probs {
-		tmp[i] = kv{i, float64(v)}
+func topK(probs *tensor.Tensor[float32], k int) []kv {
+	vals, idx := probs.MustTopK(min(k, probs.Dim(-1)), -1)
+	res := make([]kv, len(vals.Data()))
+	for i, v := range vals.Data() {
+		res[i] = kv{Idx: int(idx.Data()[i]), Val: float64(v)}
 	}
-	sort.Slice(tmp, func(i, j int) bool { return tmp[i].Val > tmp[j].Val })
-	if k > len(tmp) {
-		k = len(tmp)
-	}
-	return tmp[:k]
+	return res
 }
 
 func loadLabels(path string) ([]string, error) {
@@ -190,10 +186,9 @@ func main() {
 
 	logits := net.MustForward(x)
 	probs := logits.MustSoftmax(-1).MustSqueeze(0)
-	p := probs.Data()
 	labels, _ := loadLabels(*labelsPath)
 
-	top5 := topK(p, 5)
+	top5 := topK(probs, 5)
 	fmt.Println("Top-5:")
 	for _, it := range top5 {
 		name := ""
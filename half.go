@@ -0,0 +1,119 @@
+package candy
+
+import (
+	"math"
+	"strconv"
+)
+
+// Float16 is an IEEE 754 half-precision value stored as its bit pattern.
+type Float16 uint16
+
+// BFloat16 is a bfloat16 value, the upper 16 bits of a float32, stored as its bit pattern.
+type BFloat16 uint16
+
+// NewFloat16 converts f to half precision, rounding to nearest even.
+func NewFloat16(f float32) Float16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int(b>>23) & 0xff
+	mant := b & 0x7fffff
+	if exp == 0xff {
+		if mant != 0 {
+			return Float16(sign | 0x7e00)
+		}
+		return Float16(sign | 0x7c00)
+	}
+	e := exp - 127 + 15
+	if e >= 0x1f {
+		return Float16(sign | 0x7c00)
+	}
+	if e <= 0 {
+		if e < -10 {
+			return Float16(sign)
+		}
+		mant |= 0x800000
+		shift := uint32(14 - e)
+		h := mant >> shift
+		rem, halfway := mant&(1<<shift-1), uint32(1)<<(shift-1)
+		if rem > halfway || (rem == halfway && h&1 == 1) {
+			h++
+		}
+		return Float16(sign | uint16(h))
+	}
+	h := uint32(e)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && h&1 == 1) {
+		h++ // may carry into the exponent, rounding up to the next binade or infinity
+	}
+	return Float16(sign | uint16(h))
+}
+
+// Float32 returns h as a float32.
+func (h Float16) Float32() float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+	switch exp {
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		e := uint32(127 - 14)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		return math.Float32frombits(sign | e<<23 | (mant&0x3ff)<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// String formats h as its float value.
+func (h Float16) String() string {
+	return strconv.FormatFloat(float64(h.Float32()), 'g', -1, 32)
+}
+
+// NewBFloat16 converts f to bfloat16, rounding to nearest even.
+func NewBFloat16(f float32) BFloat16 {
+	b := math.Float32bits(f)
+	if b&0x7fffffff > 0x7f800000 {
+		return BFloat16(b>>16 | 0x40)
+	}
+	b += 0x7fff + (b>>16)&1
+	return BFloat16(b >> 16)
+}
+
+// Float32 returns h as a float32.
+func (h BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(h) << 16)
+}
+
+// String formats h as its float value.
+func (h BFloat16) String() string {
+	return strconv.FormatFloat(float64(h.Float32()), 'g', -1, 32)
+}
+
+// FromFloat64 converts v to T, encoding half-precision types from their float value.
+func FromFloat64[T D](v float64) T {
+	var zero T
+	switch any(zero).(type) {
+	case Float16:
+		return any(NewFloat16(float32(v))).(T)
+	case BFloat16:
+		return any(NewBFloat16(float32(v))).(T)
+	}
+	return T(v)
+}
+
+// ToFloat64 converts v to float64, decoding half-precision types.
+func ToFloat64[T D](v T) float64 {
+	switch x := any(v).(type) {
+	case Float16:
+		return float64(x.Float32())
+	case BFloat16:
+		return float64(x.Float32())
+	}
+	return float64(v)
+}
@@ -93,6 +93,20 @@ func (bn *BatchNorm2d[T]) Parameters() []*tensor.Tensor[T] {
 	return ps
 }
 
+func (bn *BatchNorm2d[T]) NamedParameters() map[string]*tensor.Tensor[T] {
+	return namedOptional(map[string]*tensor.Tensor[T]{"weight": bn.weight, "bias": bn.bias})
+}
+
+func (bn *BatchNorm2d[T]) NamedBuffers() map[string]*tensor.Tensor[T] {
+	return map[string]*tensor.Tensor[T]{"running_mean": bn.runningMean, "running_var": bn.runningVar}
+}
+
+func (bn *BatchNorm2d[T]) Children() map[string]Module[T] { return map[string]Module[T]{} }
+
+func (bn *BatchNorm2d[T]) ZeroGrad(gs *tensor.GradStore[T]) {
+	zeroGrad(bn.NamedParameters(), gs)
+}
+
 func (bn *BatchNorm2d[T]) forwardTrain(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
 	dims := x.Dims()
 	if len(dims) < 2 {
@@ -0,0 +1,215 @@
+package nn
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// moduleList holds indexed sub-modules named "0", "1", ... and implements
+// everything but Forward for Sequential and ModuleList.
+type moduleList[T candy.D] struct {
+	modules []Module[T]
+}
+
+// Len returns the number of sub-modules.
+func (l *moduleList[T]) Len() int {
+	return len(l.modules)
+}
+
+// At returns the i-th sub-module.
+func (l *moduleList[T]) At(i int) Module[T] {
+	return l.modules[i]
+}
+
+// Modules returns the sub-modules in order.
+func (l *moduleList[T]) Modules() []Module[T] {
+	return slices.Clone(l.modules)
+}
+
+// Children returns the sub-modules keyed by index.
+func (l *moduleList[T]) Children() map[string]Module[T] {
+	cs := make(map[string]Module[T], len(l.modules))
+	for i, m := range l.modules {
+		cs[strconv.Itoa(i)] = m
+	}
+	return cs
+}
+
+// NamedParameters returns the parameters of all sub-modules.
+func (l *moduleList[T]) NamedParameters() map[string]*tensor.Tensor[T] {
+	return ChildParameters(l.Children())
+}
+
+// NamedBuffers returns the buffers of all sub-modules.
+func (l *moduleList[T]) NamedBuffers() map[string]*tensor.Tensor[T] {
+	return ChildBuffers(l.Children())
+}
+
+// Train switches every sub-module to training mode.
+func (l *moduleList[T]) Train() {
+	for _, m := range l.modules {
+		m.Train()
+	}
+}
+
+// Eval switches every sub-module to evaluation mode.
+func (l *moduleList[T]) Eval() {
+	for _, m := range l.modules {
+		m.Eval()
+	}
+}
+
+// ZeroGrad removes the gradients of every sub-module from gs.
+func (l *moduleList[T]) ZeroGrad(gs *tensor.GradStore[T]) {
+	for _, m := range l.modules {
+		m.ZeroGrad(gs)
+	}
+}
+
+// Sequential chains modules, feeding the output of each to the next.
+// Sub-modules are named by index, so the weight of the first layer of a
+// Sequential registered as "features" is "features.0.weight".
+type Sequential[T candy.D] struct {
+	moduleList[T]
+}
+
+// NewSequential creates a Sequential running modules in order.
+func NewSequential[T candy.D](modules ...Module[T]) *Sequential[T] {
+	return &Sequential[T]{moduleList[T]{modules: modules}}
+}
+
+// Add appends m and returns s.
+func (s *Sequential[T]) Add(m Module[T]) *Sequential[T] {
+	s.modules = append(s.modules, m)
+	return s
+}
+
+// Forward runs the modules in order. Errors name the failing layer.
+func (s *Sequential[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	for i, m := range s.modules {
+		var err error
+		x, err = m.Forward(x)
+		if err != nil {
+			return nil, fmt.Errorf("sequential: layer %d (%T): %w", i, m, err)
+		}
+	}
+	return x, nil
+}
+
+// MustForward runs the modules in order, panicking on error.
+func (s *Sequential[T]) MustForward(x *tensor.Tensor[T]) *tensor.Tensor[T] {
+	r, err := s.Forward(x)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ModuleList holds indexed sub-modules for models that call them from their
+// own Forward, registering their tensors as "0.weight", "1.weight", ...
+type ModuleList[T candy.D] struct {
+	moduleList[T]
+}
+
+// NewModuleList creates a ModuleList of modules.
+func NewModuleList[T candy.D](modules ...Module[T]) *ModuleList[T] {
+	return &ModuleList[T]{moduleList[T]{modules: modules}}
+}
+
+// Append adds m to the end of the list and returns l.
+func (l *ModuleList[T]) Append(m Module[T]) *ModuleList[T] {
+	l.modules = append(l.modules, m)
+	return l
+}
+
+// Forward is not defined for a list; iterate the modules instead.
+func (l *ModuleList[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	return nil, errors.New("modulelist: Forward is not supported, call the modules individually")
+}
+
+// ModuleDict holds named sub-modules for models that call them from their
+// own Forward, registering their tensors under each name.
+type ModuleDict[T candy.D] struct {
+	modules map[string]Module[T]
+}
+
+// NewModuleDict creates a ModuleDict of modules, which may be nil.
+func NewModuleDict[T candy.D](modules map[string]Module[T]) *ModuleDict[T] {
+	return &ModuleDict[T]{modules: maps.Clone(modules)}
+}
+
+// Set registers m under name, replacing any module of that name, and
+// returns d.
+func (d *ModuleDict[T]) Set(name string, m Module[T]) *ModuleDict[T] {
+	if d.modules == nil {
+		d.modules = map[string]Module[T]{}
+	}
+	d.modules[name] = m
+	return d
+}
+
+// Get returns the module registered under name.
+func (d *ModuleDict[T]) Get(name string) (Module[T], bool) {
+	m, ok := d.modules[name]
+	return m, ok
+}
+
+// Keys returns the module names in sorted order.
+func (d *ModuleDict[T]) Keys() []string {
+	return slices.Sorted(maps.Keys(d.modules))
+}
+
+// Len returns the number of modules.
+func (d *ModuleDict[T]) Len() int {
+	return len(d.modules)
+}
+
+// Forward is not defined for a dict; look up the modules instead.
+func (d *ModuleDict[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	return nil, errors.New("moduledict: Forward is not supported, call the modules individually")
+}
+
+// Children returns the modules keyed by name.
+func (d *ModuleDict[T]) Children() map[string]Module[T] {
+	if d.modules == nil {
+		return map[string]Module[T]{}
+	}
+	return maps.Clone(d.modules)
+}
+
+// NamedParameters returns the parameters of all modules.
+func (d *ModuleDict[T]) NamedParameters() map[string]*tensor.Tensor[T] {
+	return ChildParameters(d.modules)
+}
+
+// NamedBuffers returns the buffers of all modules.
+func (d *ModuleDict[T]) NamedBuffers() map[string]*tensor.Tensor[T] {
+	return ChildBuffers(d.modules)
+}
+
+// Train switches every module to training mode.
+func (d *ModuleDict[T]) Train() {
+	for _, m := range d.modules {
+		m.Train()
+	}
+}
+
+// Eval switches every module to evaluation mode.
+func (d *ModuleDict[T]) Eval() {
+	for _, m := range d.modules {
+		m.Eval()
+	}
+}
+
+// ZeroGrad removes the gradients of every module from gs.
+func (d *ModuleDict[T]) ZeroGrad(gs *tensor.GradStore[T]) {
+	for _, m := range d.modules {
+		m.ZeroGrad(gs)
+	}
+}
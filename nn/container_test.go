@@ -0,0 +1,94 @@
+package nn_test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/nn"
+	"github.com/gocnn/candy/tensor"
+)
+
+// tinyAlexNet mirrors torchvision's AlexNet layout at a small size.
+func tinyAlexNet() *nn.ModuleDict[float32] {
+	return nn.NewModuleDict(map[string]nn.Module[float32]{
+		"features": nn.NewSequential[float32](
+			nn.NewConv2d[float32](3, 4, 3, 1, 1, candy.CPU),
+			nn.NewReLU[float32](),
+			nn.NewMaxPool2d[float32](2, 2),
+			nn.NewConv2d[float32](4, 8, 3, 1, 1, candy.CPU),
+			nn.NewReLU[float32](),
+		),
+		"avgpool": nn.NewAdaptiveAvgPool2d[float32](2, 2),
+		"classifier": nn.NewSequential[float32](
+			nn.NewDropout[float32](0.5),
+			nn.NewLinearLayer[float32](8*2*2, 16, true, candy.CPU),
+			nn.NewReLU[float32](),
+			nn.NewLinearLayer[float32](16, 10, true, candy.CPU),
+		),
+	})
+}
+
+func TestSequential(t *testing.T) {
+	t.Parallel()
+	d := tinyAlexNet()
+	features, _ := d.Get("features")
+	avgpool, _ := d.Get("avgpool")
+	classifier, _ := d.Get("classifier")
+	net := nn.NewSequential(features, avgpool, nn.NewFlatten[float32](1, -1), classifier)
+	net.Eval()
+
+	x := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 3, 8, 8), candy.CPU)
+	y := net.MustForward(x)
+	if got := y.Dims(); !slices.Equal(got, []int{2, 10}) {
+		t.Fatalf("output dims = %v", got)
+	}
+	if !slices.Equal(net.MustForward(x).Data(), y.Data()) {
+		t.Fatal("eval mode is not deterministic")
+	}
+
+	_, err := net.Forward(tensor.MustOnes[float32](candy.NewShape(2, 3, 8), candy.CPU))
+	if err == nil || !strings.Contains(err.Error(), "sequential: layer 0 (*nn.Sequential[float32]): sequential: layer 0 (*nn.Conv2d[float32])") {
+		t.Fatalf("error = %v", err)
+	}
+}
+
+func TestContainerNames(t *testing.T) {
+	t.Parallel()
+	d := tinyAlexNet()
+	want := []string{
+		"classifier.1.bias", "classifier.1.weight", "classifier.3.bias", "classifier.3.weight",
+		"features.0.bias", "features.0.weight", "features.3.bias", "features.3.weight",
+	}
+	if got := slices.Sorted(maps.Keys(d.NamedParameters())); !slices.Equal(got, want) {
+		t.Fatalf("parameters = %v, want %v", got, want)
+	}
+	if got := d.Keys(); !slices.Equal(got, []string{"avgpool", "classifier", "features"}) {
+		t.Fatalf("keys = %v", got)
+	}
+
+	l := nn.NewModuleList[float32](nn.NewBatchNorm2d[float32](2, candy.CPU)).
+		Append(nn.NewBatchNorm2d[float32](2, candy.CPU))
+	if got := slices.Sorted(maps.Keys(l.NamedBuffers())); !slices.Equal(got, []string{"0.running_mean", "0.running_var", "1.running_mean", "1.running_var"}) {
+		t.Fatalf("buffers = %v", got)
+	}
+	if _, err := l.Forward(nil); err == nil {
+		t.Fatal("expected error from ModuleList.Forward")
+	}
+}
+
+func TestDropoutMode(t *testing.T) {
+	t.Parallel()
+	d := nn.NewDropout[float32](0.5)
+	s := nn.NewSequential[float32](d)
+	x := tensor.MustOnes[float32](candy.NewShape(64), candy.CPU)
+	if slices.Equal(s.MustForward(x).Data(), x.Data()) {
+		t.Fatal("dropout inactive in training mode")
+	}
+	s.Eval()
+	if !slices.Equal(s.MustForward(x).Data(), x.Data()) {
+		t.Fatal("dropout active in evaluation mode")
+	}
+}
@@ -10,38 +10,77 @@ import (
 
 // Conv2d represents a 2D convolutional layer: y = conv2d(x, w) + b.
 type Conv2d[T candy.D] struct {
-	w      *tensor.Tensor[T]   // Weight tensor
-	b      *tensor.Tensor[T]   // Bias tensor
-	params *candy.Conv2DParams // Convolution parameters
+	convBase[T]                      // Weight of shape (outCh, inCh/groups, kH, kW) and optional bias
+	params       *candy.Conv2DParams // Convolution parameters
+	cropH, cropW int                 // Leading output rows/cols dropped for asymmetric same padding
 }
 
-// NewConv2d creates a new 2D convolutional layer with Xavier initialization.
+// Conv2dOptions configures a Conv2d layer beyond its channels and kernel
+// size. Pairs are given as {height, width}.
+type Conv2dOptions struct {
+	Stride   [2]int        // Stride per axis
+	Padding  [2]int        // Padding on both sides of each axis
+	Dilation [2]int        // Kernel dilation per axis
+	Groups   int           // Channel groups; InCh and OutCh must be divisible
+	Bias     bool          // Whether to learn an additive bias
+	PadSame  bool          // Pad so the output matches the input size; overrides Padding, needs stride 1
+	PadMode  candy.PadMode // How padded positions are filled
+}
+
+// DefaultConv2dOptions returns unit stride and dilation, no padding, one
+// group and a bias, matching PyTorch's defaults.
+func DefaultConv2dOptions() Conv2dOptions {
+	return Conv2dOptions{
+		Stride:   [2]int{1, 1},
+		Dilation: [2]int{1, 1},
+		Groups:   1,
+		Bias:     true,
+	}
+}
+
+// NewConv2d creates a new 2D convolutional layer with a square kernel, uniform stride and padding, and Kaiming initialization.
 func NewConv2d[T candy.D](inCh, outCh, kSize, stride, pad int, device candy.Device) *Conv2d[T] {
-	std := math.Sqrt(2.0 / float64(inCh*kSize*kSize))
-	w, err := tensor.RandN[T](0, std, candy.NewShape(outCh, inCh, kSize, kSize), device)
-	if err != nil {
-		panic(fmt.Errorf("conv2d: failed to create weight: %w", err))
+	opts := DefaultConv2dOptions()
+	opts.Stride = [2]int{stride, stride}
+	opts.Padding = [2]int{pad, pad}
+	return NewConv2dWithOptions[T](inCh, outCh, kSize, kSize, opts, device)
+}
+
+// NewConv2dWithOptions creates a 2D convolutional layer with a kH x kW kernel configured by opts.
+func NewConv2dWithOptions[T candy.D](inCh, outCh, kH, kW int, opts Conv2dOptions, device candy.Device) *Conv2d[T] {
+	groups := max(opts.Groups, 1)
+	checkGroups("conv2d", inCh, outCh, groups)
+	p := &candy.Conv2DParams{
+		Batch:   1, // Updated dynamically
+		InCh:    inCh,
+		OutCh:   outCh,
+		KH:      kH,
+		KW:      kW,
+		StrideH: opts.Stride[0],
+		StrideW: opts.Stride[1],
+		PadH:    opts.Padding[0],
+		PadW:    opts.Padding[1],
+		DilateH: opts.Dilation[0],
+		DilateW: opts.Dilation[1],
+		Groups:  groups,
+		PadMode: opts.PadMode,
 	}
-	w.SetIsVar(true)
-	b, err := tensor.Zeros[T](candy.NewShape(outCh), device)
-	if err != nil {
-		panic(fmt.Errorf("conv2d: failed to create bias: %w", err))
+	if p.StrideH <= 0 || p.StrideW <= 0 || p.DilateH <= 0 || p.DilateW <= 0 {
+		panic(fmt.Errorf("conv2d: stride %v and dilation %v must be positive", opts.Stride, opts.Dilation))
 	}
-	b.SetIsVar(true)
-	return &Conv2d[T]{
-		w: w,
-		b: b,
-		params: &candy.Conv2DParams{
-			Batch:  1, // Updated dynamically
-			InCh:   inCh,
-			OutCh:  outCh,
-			KH:     kSize,
-			KW:     kSize,
-			Stride: stride,
-			Pad:    pad,
-			Dilate: 1,
-		},
+	var cropH, cropW int
+	if opts.PadSame {
+		if p.StrideH != 1 || p.StrideW != 1 {
+			panic(fmt.Errorf("conv2d: same padding requires stride 1, got %v", opts.Stride))
+		}
+		// An odd total padding puts the extra row/col at the end: pad the
+		// larger half on both sides and drop the first output row/col.
+		th, tw := p.DilateH*(kH-1), p.DilateW*(kW-1)
+		p.PadH, p.PadW = (th+1)/2, (tw+1)/2
+		cropH, cropW = th%2, tw%2
 	}
+	base := newConvBase[T]("conv2d", candy.NewShape(outCh, inCh/groups, kH, kW), inCh/groups*kH*kW, outCh, opts.Bias, device)
+	return &Conv2d[T]{convBase: base, params: p, cropH: cropH, cropW: cropW}
 }
 
 // Forward applies the convolutional layer.
@@ -49,20 +88,27 @@ func (c *Conv2d[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
 	if len(x.Dims()) != 4 {
 		return nil, fmt.Errorf("conv2d: expected 4D input, got %dD", len(x.Dims()))
 	}
-	c.params.Batch, c.params.InH, c.params.InW = x.Dim(0), x.Dim(2), x.Dim(3)
-	r, err := x.Conv2d(c.w, c.params)
+	p := *c.params
+	p.Batch, p.InH, p.InW = x.Dim(0), x.Dim(2), x.Dim(3)
+	r, err := x.Conv2d(c.w, &p)
 	if err != nil {
 		return nil, fmt.Errorf("conv2d: failed to conv2d: %w", err)
 	}
-	br, err := c.b.Reshape(1, c.params.OutCh, 1, 1)
-	if err != nil {
-		return nil, fmt.Errorf("conv2d: failed to reshape bias: %w", err)
+	if c.cropH > 0 {
+		if r, err = r.Narrow(2, c.cropH, r.Dim(2)-c.cropH); err != nil {
+			return nil, fmt.Errorf("conv2d: failed to crop height: %w", err)
+		}
 	}
-	r, err = r.BroadcastAdd(br)
-	if err != nil {
-		return nil, fmt.Errorf("conv2d: failed to add bias: %w", err)
+	if c.cropW > 0 {
+		if r, err = r.Narrow(3, c.cropW, r.Dim(3)-c.cropW); err != nil {
+			return nil, fmt.Errorf("conv2d: failed to crop width: %w", err)
+		}
 	}
-	return r, nil
+	// Cropping leaves a view into the uncropped output; hand back a copy.
+	if r, err = r.Contiguous(); err != nil {
+		return nil, fmt.Errorf("conv2d: failed to copy cropped output: %w", err)
+	}
+	return c.addBias("conv2d", r)
 }
 
 // MustForward applies the convolutional layer.
@@ -74,7 +120,125 @@ func (c *Conv2d[T]) MustForward(x *tensor.Tensor[T]) *tensor.Tensor[T] {
 	return r
 }
 
+// convBase holds the weight and optional bias shared by the convolution
+// layers and implements their Module bookkeeping.
+type convBase[T candy.D] struct {
+	w *tensor.Tensor[T] // Weight tensor
+	b *tensor.Tensor[T] // Bias tensor of shape (outCh), nil when disabled
+}
+
+// newConvBase creates a Kaiming-initialized weight of the given shape and,
+// if bias is set, a zero bias of outCh entries. name prefixes panics.
+func newConvBase[T candy.D](name string, shape *candy.Shape, fanIn, outCh int, bias bool, device candy.Device) convBase[T] {
+	std := math.Sqrt(2.0 / float64(fanIn))
+	w, err := tensor.RandN[T](0, std, shape, device)
+	if err != nil {
+		panic(fmt.Errorf("%s: failed to create weight: %w", name, err))
+	}
+	w.SetIsVar(true)
+	if !bias {
+		return convBase[T]{w: w}
+	}
+	b, err := tensor.Zeros[T](candy.NewShape(outCh), device)
+	if err != nil {
+		panic(fmt.Errorf("%s: failed to create bias: %w", name, err))
+	}
+	b.SetIsVar(true)
+	return convBase[T]{w: w, b: b}
+}
+
+// addBias broadcasts the bias over the channel dim of r, if there is one.
+func (c *convBase[T]) addBias(name string, r *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	if c.b == nil {
+		return r, nil
+	}
+	dims := make([]int, r.Rank())
+	for i := range dims {
+		dims[i] = 1
+	}
+	dims[1] = c.b.Dim(0)
+	br, err := c.b.Reshape(dims...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to reshape bias: %w", name, err)
+	}
+	r, err = r.BroadcastAdd(br)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to add bias: %w", name, err)
+	}
+	return r, nil
+}
+
+// Weight returns the weight tensor.
+func (c *convBase[T]) Weight() *tensor.Tensor[T] {
+	return c.w
+}
+
+// Bias returns the bias tensor (may be nil).
+func (c *convBase[T]) Bias() *tensor.Tensor[T] {
+	return c.b
+}
+
 // Parameters returns the trainable parameters.
-func (c *Conv2d[T]) Parameters() []*tensor.Tensor[T] {
+func (c *convBase[T]) Parameters() []*tensor.Tensor[T] {
+	if c.b == nil {
+		return []*tensor.Tensor[T]{c.w}
+	}
 	return []*tensor.Tensor[T]{c.w, c.b}
 }
+
+// NamedParameters returns the weight and bias.
+func (c *convBase[T]) NamedParameters() map[string]*tensor.Tensor[T] {
+	return namedOptional(map[string]*tensor.Tensor[T]{"weight": c.w, "bias": c.b})
+}
+
+// NamedBuffers returns no buffers; convolutions are stateless apart from parameters.
+func (c *convBase[T]) NamedBuffers() map[string]*tensor.Tensor[T] {
+	return map[string]*tensor.Tensor[T]{}
+}
+
+// Children returns no sub-modules.
+func (c *convBase[T]) Children() map[string]Module[T] {
+	return map[string]Module[T]{}
+}
+
+// Train is a no-op; convolutions behave the same in both modes.
+func (c *convBase[T]) Train() {}
+
+// Eval is a no-op; convolutions behave the same in both modes.
+func (c *convBase[T]) Eval() {}
+
+// ZeroGrad removes the gradients of the parameters from gs.
+func (c *convBase[T]) ZeroGrad(gs *tensor.GradStore[T]) {
+	zeroGrad(c.NamedParameters(), gs)
+}
+
+// convGroups applies conv to each of groups channel groups, splitting x
+// along dim 1 and w along dim 0, and concatenates the outputs by channel.
+// Layers use it for ops without native group support.
+func convGroups[T candy.D](x, w *tensor.Tensor[T], groups int, conv func(x, w *tensor.Tensor[T]) (*tensor.Tensor[T], error)) (*tensor.Tensor[T], error) {
+	if groups == 1 {
+		return conv(x, w)
+	}
+	xs, err := x.Chunk(groups, 1)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := w.Chunk(groups, 0)
+	if err != nil {
+		return nil, err
+	}
+	ys := make([]*tensor.Tensor[T], groups)
+	for g := range groups {
+		if ys[g], err = conv(xs[g], ws[g]); err != nil {
+			return nil, fmt.Errorf("group %d: %w", g, err)
+		}
+	}
+	return tensor.Cat(ys, 1)
+}
+
+// checkGroups panics unless groups is positive and divides both channel counts.
+func checkGroups(name string, inCh, outCh, groups int) {
+	if groups < 1 || inCh%groups != 0 || outCh%groups != 0 {
+		panic(fmt.Errorf("%s: %d groups do not divide %d input and %d output channels", name, groups, inCh, outCh))
+	}
+}
@@ -0,0 +1,86 @@
+package nn
+
+import (
+	"fmt"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// Conv1d represents a 1D convolutional layer over (batch, channels, length)
+// input: y = conv1d(x, w) + b.
+type Conv1d[T candy.D] struct {
+	convBase[T]                     // Weight of shape (outCh, inCh/groups, k) and optional bias
+	params      *candy.Conv1DParams // Per-group convolution parameters
+	groups      int                 // Channel groups
+}
+
+// Conv1dOptions configures a Conv1d layer beyond its channels and kernel size.
+type Conv1dOptions struct {
+	Stride   int  // Stride
+	Padding  int  // Zero padding on both sides
+	Dilation int  // Kernel dilation
+	Groups   int  // Channel groups; InCh and OutCh must be divisible
+	Bias     bool // Whether to learn an additive bias
+}
+
+// DefaultConv1dOptions returns unit stride and dilation, no padding, one
+// group and a bias, matching PyTorch's defaults.
+func DefaultConv1dOptions() Conv1dOptions {
+	return Conv1dOptions{Stride: 1, Dilation: 1, Groups: 1, Bias: true}
+}
+
+// NewConv1d creates a 1D convolutional layer with Kaiming initialization.
+func NewConv1d[T candy.D](inCh, outCh, kSize, stride, pad int, device candy.Device) *Conv1d[T] {
+	opts := DefaultConv1dOptions()
+	opts.Stride, opts.Padding = stride, pad
+	return NewConv1dWithOptions[T](inCh, outCh, kSize, opts, device)
+}
+
+// NewConv1dWithOptions creates a 1D convolutional layer configured by opts.
+func NewConv1dWithOptions[T candy.D](inCh, outCh, kSize int, opts Conv1dOptions, device candy.Device) *Conv1d[T] {
+	groups := max(opts.Groups, 1)
+	checkGroups("conv1d", inCh, outCh, groups)
+	if opts.Stride <= 0 || opts.Dilation <= 0 {
+		panic(fmt.Errorf("conv1d: stride %d and dilation %d must be positive", opts.Stride, opts.Dilation))
+	}
+	p := &candy.Conv1DParams{
+		Batch:  1, // Updated dynamically
+		InCh:   inCh / groups,
+		OutCh:  outCh / groups,
+		KSize:  kSize,
+		Pad:    opts.Padding,
+		Stride: opts.Stride,
+		Dilate: opts.Dilation,
+	}
+	base := newConvBase[T]("conv1d", candy.NewShape(outCh, inCh/groups, kSize), inCh/groups*kSize, outCh, opts.Bias, device)
+	return &Conv1d[T]{convBase: base, params: p, groups: groups}
+}
+
+// Forward applies the convolutional layer.
+func (c *Conv1d[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	if x.Rank() != 3 {
+		return nil, fmt.Errorf("conv1d: expected 3D input, got %dD", x.Rank())
+	}
+	if inCh := c.params.InCh * c.groups; x.Dim(1) != inCh {
+		return nil, fmt.Errorf("conv1d: expected %d input channels, got %d", inCh, x.Dim(1))
+	}
+	p := *c.params
+	p.Batch, p.InLen = x.Dim(0), x.Dim(2)
+	r, err := convGroups(x, c.w, c.groups, func(x, w *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+		return x.Conv1d(w, &p)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("conv1d: failed to conv1d: %w", err)
+	}
+	return c.addBias("conv1d", r)
+}
+
+// MustForward applies the convolutional layer.
+func (c *Conv1d[T]) MustForward(x *tensor.Tensor[T]) *tensor.Tensor[T] {
+	r, err := c.Forward(x)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
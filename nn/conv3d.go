@@ -0,0 +1,101 @@
+package nn
+
+import (
+	"fmt"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// Conv3d represents a 3D convolutional layer over NCDHW input:
+// y = conv3d(x, w) + b.
+type Conv3d[T candy.D] struct {
+	convBase[T]                     // Weight of shape (outCh, inCh/groups, kD, kH, kW) and optional bias
+	params      *candy.Conv3DParams // Convolution parameters
+}
+
+// Conv3dOptions configures a Conv3d layer beyond its channels and kernel
+// size. Triples are given as {depth, height, width}.
+type Conv3dOptions struct {
+	Stride   [3]int // Stride per axis
+	Padding  [3]int // Zero padding on both sides of each axis
+	Dilation [3]int // Kernel dilation per axis
+	Groups   int    // Channel groups; InCh and OutCh must be divisible
+	Bias     bool   // Whether to learn an additive bias
+}
+
+// DefaultConv3dOptions returns unit stride and dilation, no padding, one
+// group and a bias, matching PyTorch's defaults.
+func DefaultConv3dOptions() Conv3dOptions {
+	return Conv3dOptions{
+		Stride:   [3]int{1, 1, 1},
+		Dilation: [3]int{1, 1, 1},
+		Groups:   1,
+		Bias:     true,
+	}
+}
+
+// NewConv3d creates a 3D convolutional layer with a cubic kernel, uniform stride and padding, and Kaiming initialization.
+func NewConv3d[T candy.D](inCh, outCh, kSize, stride, pad int, device candy.Device) *Conv3d[T] {
+	opts := DefaultConv3dOptions()
+	opts.Stride = [3]int{stride, stride, stride}
+	opts.Padding = [3]int{pad, pad, pad}
+	return NewConv3dWithOptions[T](inCh, outCh, kSize, kSize, kSize, opts, device)
+}
+
+// NewConv3dWithOptions creates a 3D convolutional layer with a kD x kH x kW kernel configured by opts.
+func NewConv3dWithOptions[T candy.D](inCh, outCh, kD, kH, kW int, opts Conv3dOptions, device candy.Device) *Conv3d[T] {
+	groups := max(opts.Groups, 1)
+	checkGroups("conv3d", inCh, outCh, groups)
+	for i := range 3 {
+		if opts.Stride[i] <= 0 || opts.Dilation[i] <= 0 {
+			panic(fmt.Errorf("conv3d: stride %v and dilation %v must be positive", opts.Stride, opts.Dilation))
+		}
+	}
+	p := &candy.Conv3DParams{
+		Batch:   1, // Updated dynamically
+		InCh:    inCh,
+		OutCh:   outCh,
+		KD:      kD,
+		KH:      kH,
+		KW:      kW,
+		StrideD: opts.Stride[0],
+		StrideH: opts.Stride[1],
+		StrideW: opts.Stride[2],
+		PadD:    opts.Padding[0],
+		PadH:    opts.Padding[1],
+		PadW:    opts.Padding[2],
+		DilateD: opts.Dilation[0],
+		DilateH: opts.Dilation[1],
+		DilateW: opts.Dilation[2],
+		Groups:  groups,
+	}
+	base := newConvBase[T]("conv3d", candy.NewShape(outCh, inCh/groups, kD, kH, kW), inCh/groups*kD*kH*kW, outCh, opts.Bias, device)
+	return &Conv3d[T]{convBase: base, params: p}
+}
+
+// Forward applies the convolutional layer.
+func (c *Conv3d[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	if x.Rank() != 5 {
+		return nil, fmt.Errorf("conv3d: expected 5D input, got %dD", x.Rank())
+	}
+	if x.Dim(1) != c.params.InCh {
+		return nil, fmt.Errorf("conv3d: expected %d input channels, got %d", c.params.InCh, x.Dim(1))
+	}
+	p := *c.params
+	p.Batch, p.InD, p.InH, p.InW = x.Dim(0), x.Dim(2), x.Dim(3), x.Dim(4)
+	r, err := x.Conv3d(c.w, &p)
+	if err != nil {
+		return nil, fmt.Errorf("conv3d: failed to conv3d: %w", err)
+	}
+	return c.addBias("conv3d", r)
+}
+
+// MustForward applies the convolutional layer.
+func (c *Conv3d[T]) MustForward(x *tensor.Tensor[T]) *tensor.Tensor[T] {
+	r, err := c.Forward(x)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
@@ -1 +1,341 @@
 package nn_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/nn"
+	"github.com/gocnn/candy/tensor"
+)
+
+// naiveConv correlates x with w over its trailing spatial axes by direct
+// loops, with PyTorch's semantics: axis a of the input is padded by padLo[a]
+// in front according to mode, and b, when not nil, is added per channel.
+func naiveConv(x, w, b *tensor.Tensor[float32], outDims, stride, padLo, dilate []int, groups int, mode candy.PadMode) []float32 {
+	xd, wd := x.Dims(), w.Dims()
+	xs, ws := x.Data(), w.Data()
+	xStride := candy.NewShapeFrom(xd).StrideContiguous()
+	oShape, kShape := candy.NewShapeFrom(outDims), candy.NewShapeFrom(wd[2:])
+	cg, og, kn := xd[1]/groups, wd[0]/groups, kShape.Numel()
+	out := make([]float32, oShape.Numel())
+	o, k := make([]int, len(outDims)), make([]int, len(wd)-2)
+	for i := range out {
+		unravel(i, oShape, o)
+		var sum float32
+		for c := range cg {
+			base := o[0]*xStride[0] + (o[1]/og*cg+c)*xStride[1]
+			for j := range kn {
+				unravel(j, kShape, k)
+				xi, ok := base, true
+				for a := range k {
+					var pos int
+					if pos, ok = padIndex(o[2+a]*stride[a]+k[a]*dilate[a]-padLo[a], xd[2+a], mode); !ok {
+						break
+					}
+					xi += pos * xStride[2+a]
+				}
+				if ok {
+					sum += xs[xi] * ws[(o[1]*cg+c)*kn+j]
+				}
+			}
+		}
+		if b != nil {
+			sum += b.Data()[o[1]]
+		}
+		out[i] = sum
+	}
+	return out
+}
+
+// naiveConvTranspose scatters each input element of x through w into an
+// output of outDims by direct loops, cropping pad from the front of every
+// spatial axis, and adds b, when not nil, per channel.
+func naiveConvTranspose(x, w, b *tensor.Tensor[float32], outDims, stride, pad, dilate []int, groups int) []float32 {
+	xd, wd := x.Dims(), w.Dims()
+	xs, ws := x.Data(), w.Data()
+	oStride := candy.NewShapeFrom(outDims).StrideContiguous()
+	xShape, kShape := candy.NewShapeFrom(xd), candy.NewShapeFrom(wd[2:])
+	cg, og, kn := xd[1]/groups, wd[1], kShape.Numel()
+	out := make([]float32, candy.NewShapeFrom(outDims).Numel())
+	in, k := make([]int, len(xd)), make([]int, len(wd)-2)
+	for i, v := range xs {
+		unravel(i, xShape, in)
+		for co := range og {
+			for j := range kn {
+				unravel(j, kShape, k)
+				oi, ok := in[0]*oStride[0]+(in[1]/cg*og+co)*oStride[1], true
+				for a := range k {
+					pos := in[2+a]*stride[a] + k[a]*dilate[a] - pad[a]
+					if ok = pos >= 0 && pos < outDims[2+a]; !ok {
+						break
+					}
+					oi += pos * oStride[2+a]
+				}
+				if ok {
+					out[oi] += v * ws[(in[1]*og+co)*kn+j]
+				}
+			}
+		}
+	}
+	if b != nil {
+		for i := range out {
+			out[i] += b.Data()[i/oStride[1]%outDims[1]]
+		}
+	}
+	return out
+}
+
+// padIndex maps position i on an axis of size n into the input, reporting
+// false where mode pads with zeros.
+func padIndex(i, n int, mode candy.PadMode) (int, bool) {
+	switch {
+	case i >= 0 && i < n:
+		return i, true
+	case mode == candy.PadReflect && i < 0:
+		return -i, true
+	case mode == candy.PadReflect:
+		return 2*(n-1) - i, true
+	case mode == candy.PadReplicate:
+		return min(max(i, 0), n-1), true
+	case mode == candy.PadCircular:
+		return (i%n + n) % n, true
+	}
+	return 0, false
+}
+
+// unravel writes the multi-index of flat index i in s into idx.
+func unravel(i int, s *candy.Shape, idx []int) {
+	for a := s.Rank() - 1; a >= 0; a-- {
+		idx[a] = i % s.Dim(a)
+		i /= s.Dim(a)
+	}
+}
+
+// randomize replaces the parameters of m with normal samples, so a zero
+// bias cannot hide a missing term.
+func randomize(m nn.Module[float32]) {
+	ps := map[string]*tensor.Tensor[float32]{}
+	for k, p := range m.NamedParameters() {
+		ps[k] = tensor.MustRandN[float32](0, 1, p.Shape(), candy.CPU)
+	}
+	nn.MustSetStateDict(m, ps, true)
+}
+
+// relu clamps v at zero in place and returns it.
+func relu(v []float32) []float32 {
+	for i := range v {
+		v[i] = max(v[i], 0)
+	}
+	return v
+}
+
+// checkClose reports the first element of got that differs from want.
+func checkClose(t *testing.T, name string, got, want []float32) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(float64(got[i]-want[i])) > 1e-4 {
+			t.Fatalf("%s: output[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestConv2dOptions(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 4, 7, 8), candy.CPU)
+	cases := []struct {
+		name       string
+		outCh      int
+		kH, kW     int
+		opts       func(*nn.Conv2dOptions)
+		want       []int
+		wantWeight []int
+		params     int
+	}{
+		{"depthwise", 4, 3, 3, func(o *nn.Conv2dOptions) { o.Groups, o.Padding = 4, [2]int{1, 1} }, []int{2, 4, 7, 8}, []int{4, 1, 3, 3}, 2},
+		{"1x7", 6, 1, 7, func(o *nn.Conv2dOptions) { o.Padding, o.Bias = [2]int{0, 3}, false }, []int{2, 6, 7, 8}, []int{6, 4, 1, 7}, 1},
+		{"strided", 2, 3, 3, func(o *nn.Conv2dOptions) { o.Stride, o.Dilation = [2]int{2, 1}, [2]int{1, 2} }, []int{2, 2, 3, 4}, []int{2, 4, 3, 3}, 2},
+		{"same even", 4, 2, 4, func(o *nn.Conv2dOptions) { o.PadSame = true }, []int{2, 4, 7, 8}, []int{4, 4, 2, 4}, 2},
+		{"same even no bias", 4, 2, 4, func(o *nn.Conv2dOptions) { o.PadSame, o.Bias = true, false }, []int{2, 4, 7, 8}, []int{4, 4, 2, 4}, 1},
+		{"same reflect", 2, 3, 3, func(o *nn.Conv2dOptions) { o.PadSame, o.PadMode, o.Groups = true, candy.PadReflect, 2 }, []int{2, 2, 7, 8}, []int{2, 2, 3, 3}, 2},
+	}
+	for _, c := range cases {
+		opts := nn.DefaultConv2dOptions()
+		c.opts(&opts)
+		conv := nn.NewConv2dWithOptions[float32](4, c.outCh, c.kH, c.kW, opts, candy.CPU)
+		if got := conv.Weight().Dims(); !slices.Equal(got, c.wantWeight) {
+			t.Errorf("%s: weight dims = %v, want %v", c.name, got, c.wantWeight)
+		}
+		if got := len(conv.NamedParameters()); got != c.params {
+			t.Errorf("%s: %d parameters, want %d", c.name, got, c.params)
+		}
+		randomize(conv)
+		y, err := conv.Forward(x)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := y.Dims(); !slices.Equal(got, c.want) {
+			t.Fatalf("%s: output dims = %v, want %v", c.name, got, c.want)
+		}
+		if !y.IsContiguous() {
+			t.Errorf("%s: output is not contiguous", c.name)
+		}
+		// Same padding puts the smaller half of an odd total in front.
+		pad := opts.Padding
+		if opts.PadSame {
+			pad = [2]int{opts.Dilation[0] * (c.kH - 1) / 2, opts.Dilation[1] * (c.kW - 1) / 2}
+		}
+		want := naiveConv(x, conv.Weight(), conv.Bias(), c.want, opts.Stride[:], pad[:], opts.Dilation[:], max(opts.Groups, 1), opts.PadMode)
+		checkClose(t, c.name, y.MustRelu().Data(), relu(want))
+	}
+}
+
+func TestConvLayers(t *testing.T) {
+	t.Parallel()
+	x1 := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 4, 9), candy.CPU)
+	x2 := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 4, 5, 6), candy.CPU)
+	ct := nn.DefaultConvTransposeOptions()
+	ct.Stride, ct.Padding, ct.OutputPadding, ct.Groups = 2, 1, 1, 2
+	c1 := nn.DefaultConv1dOptions()
+	c1.Stride, c1.Dilation, c1.Groups, c1.Bias = 2, 2, 4, false
+	cases := []struct {
+		name                        string
+		m                           nn.Module[float32]
+		x                           *tensor.Tensor[float32]
+		want                        []int
+		wantWeight                  []int
+		stride, pad, dilate, groups int
+		transpose                   bool
+	}{
+		{"conv1d", nn.NewConv1d[float32](4, 6, 3, 1, 1, candy.CPU), x1, []int{2, 6, 9}, []int{6, 4, 3}, 1, 1, 1, 1, false},
+		{"conv1d depthwise", nn.NewConv1dWithOptions[float32](4, 8, 3, c1, candy.CPU), x1, []int{2, 8, 3}, []int{8, 1, 3}, 2, 0, 2, 4, false},
+		{"convtranspose1d", nn.NewConvTranspose1dWithOptions[float32](4, 6, 3, ct, candy.CPU), x1, []int{2, 6, 18}, []int{4, 3, 3}, 2, 1, 1, 2, true},
+		{"convtranspose2d", nn.NewConvTranspose2d[float32](4, 3, 4, 2, 1, candy.CPU), x2, []int{2, 3, 10, 12}, []int{4, 3, 4, 4}, 2, 1, 1, 1, true},
+		{"convtranspose2d groups", nn.NewConvTranspose2dWithOptions[float32](4, 6, 3, 2, ct, candy.CPU), x2, []int{2, 6, 10, 11}, []int{4, 3, 3, 2}, 2, 1, 1, 2, true},
+	}
+	for _, c := range cases {
+		if got := c.m.NamedParameters()["weight"].Dims(); !slices.Equal(got, c.wantWeight) {
+			t.Errorf("%s: weight dims = %v, want %v", c.name, got, c.wantWeight)
+		}
+		randomize(c.m)
+		y, err := c.m.Forward(c.x)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := y.Dims(); !slices.Equal(got, c.want) {
+			t.Fatalf("%s: output dims = %v, want %v", c.name, got, c.want)
+		}
+		if !y.IsContiguous() {
+			t.Errorf("%s: output is not contiguous", c.name)
+		}
+		ps := c.m.NamedParameters()
+		axes := c.x.Rank() - 2
+		stride, pad, dilate := slices.Repeat([]int{c.stride}, axes), slices.Repeat([]int{c.pad}, axes), slices.Repeat([]int{c.dilate}, axes)
+		var want []float32
+		if c.transpose {
+			want = naiveConvTranspose(c.x, ps["weight"], ps["bias"], c.want, stride, pad, dilate, c.groups)
+		} else {
+			want = naiveConv(c.x, ps["weight"], ps["bias"], c.want, stride, pad, dilate, c.groups, candy.PadZeros)
+		}
+		z := y.MustRelu()
+		checkClose(t, c.name, z.Data(), relu(want))
+		gs, err := z.MustSumAll().Backward()
+		if err != nil {
+			t.Fatalf("%s: backward: %v", c.name, err)
+		}
+		for k, p := range ps {
+			if g := gs.Get(p); g == nil || !g.Shape().Equal(p.Shape()) {
+				t.Errorf("%s: %s has no gradient of its shape", c.name, k)
+			}
+		}
+	}
+}
+
+func TestConvTransposeGroups(t *testing.T) {
+	t.Parallel()
+	// A grouped layer must match an ungrouped one with a block-diagonal weight.
+	opts := nn.DefaultConvTransposeOptions()
+	opts.Stride, opts.Groups, opts.Bias = 2, 2, false
+	grouped := nn.NewConvTranspose1dWithOptions[float32](4, 6, 3, opts, candy.CPU)
+	opts.Groups = 1
+	full := nn.NewConvTranspose1dWithOptions[float32](4, 6, 3, opts, candy.CPU)
+
+	w := grouped.Weight().Data()
+	fw := make([]float32, 4*6*3)
+	for ci := range 4 {
+		g := ci / 2
+		for j := range 3 {
+			for k := range 3 {
+				fw[ci*18+(g*3+j)*3+k] = w[ci*9+j*3+k]
+			}
+		}
+	}
+	nn.MustSetStateDict(full, map[string]*tensor.Tensor[float32]{
+		"weight": tensor.MustNew(fw, candy.NewShape(4, 6, 3), candy.CPU),
+	}, true)
+
+	x := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 4, 5), candy.CPU)
+	got, want := grouped.MustForward(x).Data(), full.MustForward(x).Data()
+	for i := range want {
+		if math.Abs(float64(got[i]-want[i])) > 1e-5 {
+			t.Fatalf("output[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestConv3d(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 4, 5, 6, 7), candy.CPU)
+	cubic := nn.DefaultConv3dOptions()
+	cubic.Padding = [3]int{1, 1, 1}
+	opts := nn.DefaultConv3dOptions()
+	opts.Stride, opts.Padding, opts.Groups = [3]int{1, 2, 2}, [3]int{1, 0, 1}, 2
+	dilated := nn.DefaultConv3dOptions()
+	dilated.Dilation, dilated.Bias = [3]int{2, 1, 1}, false
+	cases := []struct {
+		name       string
+		conv       *nn.Conv3d[float32]
+		opts       nn.Conv3dOptions
+		want       []int
+		wantWeight []int
+	}{
+		{"cubic", nn.NewConv3d[float32](4, 6, 3, 1, 1, candy.CPU), cubic, []int{2, 6, 5, 6, 7}, []int{6, 4, 3, 3, 3}},
+		{"options", nn.NewConv3dWithOptions[float32](4, 2, 3, 2, 3, opts, candy.CPU), opts, []int{2, 2, 5, 3, 4}, []int{2, 2, 3, 2, 3}},
+		{"dilated no bias", nn.NewConv3dWithOptions[float32](4, 4, 2, 2, 2, dilated, candy.CPU), dilated, []int{2, 4, 3, 5, 6}, []int{4, 4, 2, 2, 2}},
+	}
+	for _, c := range cases {
+		if got := c.conv.Weight().Dims(); !slices.Equal(got, c.wantWeight) {
+			t.Errorf("%s: weight dims = %v, want %v", c.name, got, c.wantWeight)
+		}
+		randomize(c.conv)
+		y, err := c.conv.Forward(x)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := y.Dims(); !slices.Equal(got, c.want) {
+			t.Fatalf("%s: output dims = %v, want %v", c.name, got, c.want)
+		}
+		if !y.IsContiguous() {
+			t.Errorf("%s: output is not contiguous", c.name)
+		}
+		want := naiveConv(x, c.conv.Weight(), c.conv.Bias(), c.want, c.opts.Stride[:], c.opts.Padding[:], c.opts.Dilation[:], max(c.opts.Groups, 1), candy.PadZeros)
+		z := y.MustRelu()
+		checkClose(t, c.name, z.Data(), relu(want))
+		gs, err := z.MustSumAll().Backward()
+		if err != nil {
+			t.Fatalf("%s: backward: %v", c.name, err)
+		}
+		for k, p := range c.conv.NamedParameters() {
+			if g := gs.Get(p); g == nil || !g.Shape().Equal(p.Shape()) {
+				t.Errorf("%s: %s has no gradient of its shape", c.name, k)
+			}
+		}
+	}
+	if _, err := nn.NewConv3d[float32](3, 6, 3, 1, 1, candy.CPU).Forward(x); err == nil {
+		t.Error("expected an error for mismatched input channels")
+	}
+}
@@ -0,0 +1,159 @@
+package nn
+
+import (
+	"fmt"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// ConvTransposeOptions configures a ConvTranspose1d or ConvTranspose2d layer
+// beyond its channels and kernel size. Values apply to every spatial axis.
+type ConvTransposeOptions struct {
+	Stride        int  // Stride
+	Padding       int  // Implicit padding removed from both sides of the output
+	OutputPadding int  // Extra size added to one side of the output; must be less than Stride or Dilation
+	Dilation      int  // Kernel dilation
+	Groups        int  // Channel groups; InCh and OutCh must be divisible
+	Bias          bool // Whether to learn an additive bias
+}
+
+// DefaultConvTransposeOptions returns unit stride and dilation, no padding,
+// one group and a bias, matching PyTorch's defaults.
+func DefaultConvTransposeOptions() ConvTransposeOptions {
+	return ConvTransposeOptions{Stride: 1, Dilation: 1, Groups: 1, Bias: true}
+}
+
+// check panics if opts are invalid for a layer with the given channels.
+func (o ConvTransposeOptions) check(name string, inCh, outCh int) int {
+	groups := max(o.Groups, 1)
+	checkGroups(name, inCh, outCh, groups)
+	if o.Stride <= 0 || o.Dilation <= 0 {
+		panic(fmt.Errorf("%s: stride %d and dilation %d must be positive", name, o.Stride, o.Dilation))
+	}
+	if o.OutputPadding < 0 || (o.OutputPadding >= o.Stride && o.OutputPadding >= o.Dilation) {
+		panic(fmt.Errorf("%s: output padding %d must be smaller than stride %d or dilation %d", name, o.OutputPadding, o.Stride, o.Dilation))
+	}
+	return groups
+}
+
+// ConvTranspose1d represents a 1D transposed convolutional layer over
+// (batch, channels, length) input: y = conv_transpose1d(x, w) + b.
+type ConvTranspose1d[T candy.D] struct {
+	convBase[T]                      // Weight of shape (inCh, outCh/groups, k) and optional bias
+	params      *candy.ConvT1DParams // Per-group convolution parameters
+	groups      int                  // Channel groups
+}
+
+// NewConvTranspose1d creates a 1D transposed convolutional layer with Kaiming initialization.
+func NewConvTranspose1d[T candy.D](inCh, outCh, kSize, stride, pad int, device candy.Device) *ConvTranspose1d[T] {
+	opts := DefaultConvTransposeOptions()
+	opts.Stride, opts.Padding = stride, pad
+	return NewConvTranspose1dWithOptions[T](inCh, outCh, kSize, opts, device)
+}
+
+// NewConvTranspose1dWithOptions creates a 1D transposed convolutional layer configured by opts.
+func NewConvTranspose1dWithOptions[T candy.D](inCh, outCh, kSize int, opts ConvTransposeOptions, device candy.Device) *ConvTranspose1d[T] {
+	groups := opts.check("convtranspose1d", inCh, outCh)
+	p := &candy.ConvT1DParams{
+		Batch:  1, // Updated dynamically
+		InCh:   inCh / groups,
+		OutCh:  outCh / groups,
+		KSize:  kSize,
+		Pad:    opts.Padding,
+		OutPad: opts.OutputPadding,
+		Stride: opts.Stride,
+		Dilate: opts.Dilation,
+	}
+	base := newConvBase[T]("convtranspose1d", candy.NewShape(inCh, outCh/groups, kSize), outCh/groups*kSize, outCh, opts.Bias, device)
+	return &ConvTranspose1d[T]{convBase: base, params: p, groups: groups}
+}
+
+// Forward applies the transposed convolutional layer.
+func (c *ConvTranspose1d[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	if x.Rank() != 3 {
+		return nil, fmt.Errorf("convtranspose1d: expected 3D input, got %dD", x.Rank())
+	}
+	if x.Dim(1) != c.w.Dim(0) {
+		return nil, fmt.Errorf("convtranspose1d: expected %d input channels, got %d", c.w.Dim(0), x.Dim(1))
+	}
+	p := *c.params
+	p.Batch, p.InLen = x.Dim(0), x.Dim(2)
+	r, err := convGroups(x, c.w, c.groups, func(x, w *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+		return x.ConvTranspose1d(w, &p)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("convtranspose1d: failed to convTranspose1d: %w", err)
+	}
+	return c.addBias("convtranspose1d", r)
+}
+
+// MustForward applies the transposed convolutional layer.
+func (c *ConvTranspose1d[T]) MustForward(x *tensor.Tensor[T]) *tensor.Tensor[T] {
+	r, err := c.Forward(x)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ConvTranspose2d represents a 2D transposed convolutional layer over NCHW
+// input: y = conv_transpose2d(x, w) + b.
+type ConvTranspose2d[T candy.D] struct {
+	convBase[T]                      // Weight of shape (inCh, outCh/groups, kH, kW) and optional bias
+	params      *candy.ConvT2DParams // Per-group convolution parameters
+	groups      int                  // Channel groups
+}
+
+// NewConvTranspose2d creates a 2D transposed convolutional layer with a square kernel and Kaiming initialization.
+func NewConvTranspose2d[T candy.D](inCh, outCh, kSize, stride, pad int, device candy.Device) *ConvTranspose2d[T] {
+	opts := DefaultConvTransposeOptions()
+	opts.Stride, opts.Padding = stride, pad
+	return NewConvTranspose2dWithOptions[T](inCh, outCh, kSize, kSize, opts, device)
+}
+
+// NewConvTranspose2dWithOptions creates a 2D transposed convolutional layer with a kH x kW kernel configured by opts.
+func NewConvTranspose2dWithOptions[T candy.D](inCh, outCh, kH, kW int, opts ConvTransposeOptions, device candy.Device) *ConvTranspose2d[T] {
+	groups := opts.check("convtranspose2d", inCh, outCh)
+	p := &candy.ConvT2DParams{
+		Batch:  1, // Updated dynamically
+		InCh:   inCh / groups,
+		OutCh:  outCh / groups,
+		KH:     kH,
+		KW:     kW,
+		Pad:    opts.Padding,
+		OutPad: opts.OutputPadding,
+		Stride: opts.Stride,
+		Dilate: opts.Dilation,
+	}
+	base := newConvBase[T]("convtranspose2d", candy.NewShape(inCh, outCh/groups, kH, kW), outCh/groups*kH*kW, outCh, opts.Bias, device)
+	return &ConvTranspose2d[T]{convBase: base, params: p, groups: groups}
+}
+
+// Forward applies the transposed convolutional layer.
+func (c *ConvTranspose2d[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	if x.Rank() != 4 {
+		return nil, fmt.Errorf("convtranspose2d: expected 4D input, got %dD", x.Rank())
+	}
+	if x.Dim(1) != c.w.Dim(0) {
+		return nil, fmt.Errorf("convtranspose2d: expected %d input channels, got %d", c.w.Dim(0), x.Dim(1))
+	}
+	p := *c.params
+	p.Batch, p.InH, p.InW = x.Dim(0), x.Dim(2), x.Dim(3)
+	r, err := convGroups(x, c.w, c.groups, func(x, w *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+		return x.ConvTranspose2d(w, &p)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("convtranspose2d: failed to convTranspose2d: %w", err)
+	}
+	return c.addBias("convtranspose2d", r)
+}
+
+// MustForward applies the transposed convolutional layer.
+func (c *ConvTranspose2d[T]) MustForward(x *tensor.Tensor[T]) *tensor.Tensor[T] {
+	r, err := c.Forward(x)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
@@ -0,0 +1,133 @@
+package nn
+
+import (
+	"fmt"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// Embedding represents a lookup table mapping integer ids to rows of a weight matrix.
+type Embedding[T candy.D] struct {
+	w          *tensor.Tensor[T] // Weight tensor of shape (num, dim)
+	paddingIdx int               // Id looked up as zeros without gradient; -1 for none
+}
+
+// EmbeddingOptions configures an Embedding beyond its weight.
+type EmbeddingOptions struct {
+	PaddingIdx int // Id whose lookups are zero and receive no gradient; -1 for none
+}
+
+// DefaultEmbeddingOptions returns options with no padding id, matching PyTorch's defaults.
+func DefaultEmbeddingOptions() EmbeddingOptions {
+	return EmbeddingOptions{PaddingIdx: -1}
+}
+
+// NewEmbedding creates a new embedding layer with the given weight.
+func NewEmbedding[T candy.D](w *tensor.Tensor[T]) *Embedding[T] {
+	return NewEmbeddingWithOptions(w, DefaultEmbeddingOptions())
+}
+
+// NewEmbeddingWithOptions creates an embedding layer with the given weight configured by opts.
+func NewEmbeddingWithOptions[T candy.D](w *tensor.Tensor[T], opts EmbeddingOptions) *Embedding[T] {
+	if opts.PaddingIdx < -1 || opts.PaddingIdx >= w.Dim(0) {
+		panic(fmt.Errorf("embedding: padding id %d out of range for %d embeddings", opts.PaddingIdx, w.Dim(0)))
+	}
+	return &Embedding[T]{w: w, paddingIdx: opts.PaddingIdx}
+}
+
+// NewEmbeddingLayer creates an embedding layer with PyTorch-style N(0, 1) initialization.
+func NewEmbeddingLayer[T candy.D](num, dim int, device candy.Device) *Embedding[T] {
+	w, err := tensor.RandN[T](0, 1, candy.NewShape(num, dim), device)
+	if err != nil {
+		panic(fmt.Errorf("embedding: failed to create weight: %w", err))
+	}
+	w.SetIsVar(true)
+	return NewEmbedding(w)
+}
+
+// Weight returns the weight tensor.
+func (e *Embedding[T]) Weight() *tensor.Tensor[T] {
+	return e.w
+}
+
+// PaddingIdx returns the padding id, or -1 if there is none.
+func (e *Embedding[T]) PaddingIdx() int {
+	return e.paddingIdx
+}
+
+// Forward looks up uint32 ids of any shape, returning a tensor of shape (ids..., dim).
+// Use Lookup for ids of another index dtype.
+func (e *Embedding[T]) Forward(ids *tensor.Tensor[uint32]) (*tensor.Tensor[T], error) {
+	return Lookup(e, ids)
+}
+
+// MustForward looks up ids, panicking on error.
+func (e *Embedding[T]) MustForward(ids *tensor.Tensor[uint32]) *tensor.Tensor[T] {
+	return MustLookup(e, ids)
+}
+
+// Lookup looks up ids of any shape and index dtype in e, returning a tensor
+// of shape (ids..., dim). Every id below the vocabulary size is valid; only
+// the configured padding id maps to zeros.
+func Lookup[T candy.D, I candy.I](e *Embedding[T], ids *tensor.Tensor[I]) (*tensor.Tensor[T], error) {
+	// Widening to int64 also copies views, and lets the padding mask
+	// compare ids in a single dtype.
+	idc, err := ids.ToInt64()
+	if err != nil {
+		return nil, fmt.Errorf("embedding: failed to convert ids: %w", err)
+	}
+	flat, err := idc.Reshape(idc.Numel())
+	if err != nil {
+		return nil, fmt.Errorf("embedding: failed to flatten ids: %w", err)
+	}
+	r, err := tensor.IndexSelect(e.w, flat, 0)
+	if err != nil {
+		return nil, fmt.Errorf("embedding: failed to index select: %w", err)
+	}
+	if e.paddingIdx >= 0 {
+		if r, err = e.maskPadding(r, flat); err != nil {
+			return nil, fmt.Errorf("embedding: failed to mask padding: %w", err)
+		}
+	}
+	r, err = r.Reshape(append(ids.Dims(), e.w.Dim(1))...)
+	if err != nil {
+		return nil, fmt.Errorf("embedding: failed to reshape output: %w", err)
+	}
+	return r, nil
+}
+
+// MustLookup looks up ids in e, panicking on error.
+func MustLookup[T candy.D, I candy.I](e *Embedding[T], ids *tensor.Tensor[I]) *tensor.Tensor[T] {
+	r, err := Lookup(e, ids)
+	if err != nil {
+		panic(fmt.Errorf("embedding: failed forward: %w", err))
+	}
+	return r
+}
+
+// maskPadding zeroes the rows of r looked up by the padding id, which also
+// stops their gradient from reaching the weight.
+func (e *Embedding[T]) maskPadding(r *tensor.Tensor[T], ids *tensor.Tensor[int64]) (*tensor.Tensor[T], error) {
+	pad, err := tensor.Full[int64](float64(e.paddingIdx), ids.Shape(), ids.Device())
+	if err != nil {
+		return nil, err
+	}
+	keep, err := ids.Ne(pad)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := tensor.ToDtype[int64, T](keep, e.w.DType())
+	if err != nil {
+		return nil, err
+	}
+	if mask, err = mask.Reshape(ids.Numel(), 1); err != nil {
+		return nil, err
+	}
+	return r.BroadcastMul(mask)
+}
+
+// Parameters returns the trainable parameters.
+func (e *Embedding[T]) Parameters() []*tensor.Tensor[T] {
+	return []*tensor.Tensor[T]{e.w}
+}
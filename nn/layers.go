@@ -0,0 +1,141 @@
+package nn
+
+import (
+	"fmt"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// stateless provides the Module methods of layers without parameters,
+// buffers or children, for embedding.
+type stateless[T candy.D] struct{}
+
+func (stateless[T]) NamedParameters() map[string]*tensor.Tensor[T] {
+	return map[string]*tensor.Tensor[T]{}
+}
+
+func (stateless[T]) NamedBuffers() map[string]*tensor.Tensor[T] {
+	return map[string]*tensor.Tensor[T]{}
+}
+
+func (stateless[T]) Children() map[string]Module[T] {
+	return map[string]Module[T]{}
+}
+
+func (stateless[T]) Train()                           {}
+func (stateless[T]) Eval()                            {}
+func (stateless[T]) ZeroGrad(gs *tensor.GradStore[T]) {}
+
+// ReLU applies max(x, 0) elementwise.
+type ReLU[T candy.D] struct{ stateless[T] }
+
+// NewReLU creates a ReLU activation.
+func NewReLU[T candy.D]() *ReLU[T] {
+	return &ReLU[T]{}
+}
+
+// Forward applies the activation.
+func (r *ReLU[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	y, err := x.Relu()
+	if err != nil {
+		return nil, fmt.Errorf("relu: %w", err)
+	}
+	return y, nil
+}
+
+// Flatten merges the dims from start to end, inclusive, into one. Negative
+// dims count from the end, so NewFlatten(1, -1) keeps the batch dim.
+type Flatten[T candy.D] struct {
+	stateless[T]
+	start, end int
+}
+
+// NewFlatten creates a flatten layer over dims start..end.
+func NewFlatten[T candy.D](start, end int) *Flatten[T] {
+	return &Flatten[T]{start: start, end: end}
+}
+
+// Forward flattens x, copying it first if it is not contiguous.
+func (f *Flatten[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	xc, err := x.Contiguous()
+	if err != nil {
+		return nil, fmt.Errorf("flatten: failed to make input contiguous: %w", err)
+	}
+	y, err := xc.Flatten(f.start, f.end)
+	if err != nil {
+		return nil, fmt.Errorf("flatten: %w", err)
+	}
+	return y, nil
+}
+
+// MaxPool2d takes the maximum over kH x kW windows of NCHW input.
+type MaxPool2d[T candy.D] struct {
+	stateless[T]
+	kH, kW, sH, sW int
+}
+
+// NewMaxPool2d creates a max pooling layer with a square kernel and stride.
+func NewMaxPool2d[T candy.D](kSize, stride int) *MaxPool2d[T] {
+	return &MaxPool2d[T]{kH: kSize, kW: kSize, sH: stride, sW: stride}
+}
+
+// Forward applies the pooling.
+func (p *MaxPool2d[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	y, err := x.MaxPool2d(p.kH, p.kW, p.sH, p.sW)
+	if err != nil {
+		return nil, fmt.Errorf("maxpool2d: %w", err)
+	}
+	return y, nil
+}
+
+// AdaptiveAvgPool2d averages NCHW input down to a fixed outH x outW size.
+type AdaptiveAvgPool2d[T candy.D] struct {
+	stateless[T]
+	outH, outW int
+}
+
+// NewAdaptiveAvgPool2d creates an adaptive average pooling layer.
+func NewAdaptiveAvgPool2d[T candy.D](outH, outW int) *AdaptiveAvgPool2d[T] {
+	return &AdaptiveAvgPool2d[T]{outH: outH, outW: outW}
+}
+
+// Forward applies the pooling.
+func (p *AdaptiveAvgPool2d[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	y, err := x.AdaptiveAvgPool2d(p.outH, p.outW)
+	if err != nil {
+		return nil, fmt.Errorf("adaptive avgpool2d: %w", err)
+	}
+	return y, nil
+}
+
+// Dropout zeroes elements with probability p in training mode and passes
+// input through unchanged in evaluation mode.
+type Dropout[T candy.D] struct {
+	stateless[T]
+	p     float64
+	train bool
+}
+
+// NewDropout creates a dropout layer in training mode.
+func NewDropout[T candy.D](p float64) *Dropout[T] {
+	return &Dropout[T]{p: p, train: true}
+}
+
+// Train enables dropout.
+func (d *Dropout[T]) Train() { d.train = true }
+
+// Eval disables dropout.
+func (d *Dropout[T]) Eval() { d.train = false }
+
+// Forward applies dropout in training mode.
+func (d *Dropout[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	if !d.train || d.p == 0 {
+		return x, nil
+	}
+	y, err := x.Dropout(d.p)
+	if err != nil {
+		return nil, fmt.Errorf("dropout: %w", err)
+	}
+	return y, nil
+}
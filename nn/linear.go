@@ -69,7 +69,7 @@ func (l *Linear[T]) Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
 
 	var r *tensor.Tensor[T]
 	switch {
-	case x.Layout().IsContiguous():
+	case x.IsContiguous():
 		// Reshape input for efficient matmul
 		bs := dims[:rank-1]
 		k := dims[rank-1]
@@ -119,3 +119,37 @@ func (l *Linear[T]) MustForward(x *tensor.Tensor[T]) *tensor.Tensor[T] {
 	}
 	return r
 }
+
+// Parameters returns the trainable parameters.
+func (l *Linear[T]) Parameters() []*tensor.Tensor[T] {
+	if l.b == nil {
+		return []*tensor.Tensor[T]{l.w}
+	}
+	return []*tensor.Tensor[T]{l.w, l.b}
+}
+
+// NamedParameters returns the weight and, if present, the bias.
+func (l *Linear[T]) NamedParameters() map[string]*tensor.Tensor[T] {
+	return namedOptional(map[string]*tensor.Tensor[T]{"weight": l.w, "bias": l.b})
+}
+
+// NamedBuffers returns no buffers; Linear is stateless apart from parameters.
+func (l *Linear[T]) NamedBuffers() map[string]*tensor.Tensor[T] {
+	return map[string]*tensor.Tensor[T]{}
+}
+
+// Children returns no sub-modules.
+func (l *Linear[T]) Children() map[string]Module[T] {
+	return map[string]Module[T]{}
+}
+
+// Train is a no-op; Linear behaves the same in both modes.
+func (l *Linear[T]) Train() {}
+
+// Eval is a no-op; Linear behaves the same in both modes.
+func (l *Linear[T]) Eval() {}
+
+// ZeroGrad removes the gradients of the parameters from gs.
+func (l *Linear[T]) ZeroGrad(gs *tensor.GradStore[T]) {
+	zeroGrad(l.NamedParameters(), gs)
+}
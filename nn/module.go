@@ -0,0 +1,98 @@
+package nn
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// Module is the common interface of layers and models built from them.
+//
+// Tensor names are relative to the module and follow PyTorch's dotted
+// convention: a child registered as "conv1" contributes "conv1.weight".
+// NamedParameters and NamedBuffers include the tensors of all descendants,
+// and Train, Eval and ZeroGrad propagate to every child.
+type Module[T candy.D] interface {
+	// Forward applies the module to x.
+	Forward(x *tensor.Tensor[T]) (*tensor.Tensor[T], error)
+	// NamedParameters returns the trainable tensors keyed by dotted name.
+	NamedParameters() map[string]*tensor.Tensor[T]
+	// NamedBuffers returns the non-trainable state, such as batch norm
+	// running statistics, keyed by dotted name.
+	NamedBuffers() map[string]*tensor.Tensor[T]
+	// Children returns the direct sub-modules keyed by name.
+	Children() map[string]Module[T]
+	// Train switches the module and its children to training mode.
+	Train()
+	// Eval switches the module and its children to evaluation mode.
+	Eval()
+	// ZeroGrad removes the gradients of all parameters from gs.
+	ZeroGrad(gs *tensor.GradStore[T])
+}
+
+var (
+	_ Module[float32] = (*Linear[float32])(nil)
+	_ Module[float32] = (*Conv2d[float32])(nil)
+	_ Module[float32] = (*Conv1d[float32])(nil)
+	_ Module[float32] = (*ConvTranspose1d[float32])(nil)
+	_ Module[float32] = (*ConvTranspose2d[float32])(nil)
+	_ Module[float32] = (*Conv3d[float32])(nil)
+	_ Module[float32] = (*BatchNorm2d[float32])(nil)
+	_ Module[float32] = (*Sequential[float32])(nil)
+	_ Module[float32] = (*ModuleList[float32])(nil)
+	_ Module[float32] = (*ModuleDict[float32])(nil)
+	_ Module[float32] = (*ReLU[float32])(nil)
+	_ Module[float32] = (*Flatten[float32])(nil)
+	_ Module[float32] = (*MaxPool2d[float32])(nil)
+	_ Module[float32] = (*AdaptiveAvgPool2d[float32])(nil)
+	_ Module[float32] = (*Dropout[float32])(nil)
+)
+
+// Parameters returns the parameters of m ordered by name.
+func Parameters[T candy.D](m Module[T]) []*tensor.Tensor[T] {
+	ps := m.NamedParameters()
+	out := make([]*tensor.Tensor[T], 0, len(ps))
+	for _, name := range slices.Sorted(maps.Keys(ps)) {
+		out = append(out, ps[name])
+	}
+	return out
+}
+
+// ChildParameters collects the parameters of children, prefixing each name
+// with the child's name. Containers use it to implement NamedParameters.
+func ChildParameters[T candy.D](children map[string]Module[T]) map[string]*tensor.Tensor[T] {
+	out := map[string]*tensor.Tensor[T]{}
+	for name, c := range children {
+		for k, p := range c.NamedParameters() {
+			out[name+"."+k] = p
+		}
+	}
+	return out
+}
+
+// ChildBuffers collects the buffers of children, prefixing each name with
+// the child's name. Containers use it to implement NamedBuffers.
+func ChildBuffers[T candy.D](children map[string]Module[T]) map[string]*tensor.Tensor[T] {
+	out := map[string]*tensor.Tensor[T]{}
+	for name, c := range children {
+		for k, b := range c.NamedBuffers() {
+			out[name+"."+k] = b
+		}
+	}
+	return out
+}
+
+// zeroGrad deletes the gradients of ps from gs.
+func zeroGrad[T candy.D](ps map[string]*tensor.Tensor[T], gs *tensor.GradStore[T]) {
+	for _, p := range ps {
+		gs.Delete(p)
+	}
+}
+
+// namedOptional returns the non-nil tensors of ts.
+func namedOptional[T candy.D](ts map[string]*tensor.Tensor[T]) map[string]*tensor.Tensor[T] {
+	maps.DeleteFunc(ts, func(_ string, t *tensor.Tensor[T]) bool { return t == nil })
+	return ts
+}
@@ -1 +1,156 @@
 package nn_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/nn"
+	"github.com/gocnn/candy/tensor"
+)
+
+// block is a minimal composite module used to exercise recursion.
+type block struct {
+	conv *nn.Conv2d[float32]
+	bn   *nn.BatchNorm2d[float32]
+	fc   *nn.Linear[float32]
+}
+
+func (b *block) Forward(x *tensor.Tensor[float32]) (*tensor.Tensor[float32], error) {
+	return x, nil
+}
+
+func (b *block) Children() map[string]nn.Module[float32] {
+	return map[string]nn.Module[float32]{"conv": b.conv, "bn": b.bn, "fc": b.fc}
+}
+
+func (b *block) NamedParameters() map[string]*tensor.Tensor[float32] {
+	return nn.ChildParameters(b.Children())
+}
+
+func (b *block) NamedBuffers() map[string]*tensor.Tensor[float32] {
+	return nn.ChildBuffers(b.Children())
+}
+
+func (b *block) Train() {
+	for _, c := range b.Children() {
+		c.Train()
+	}
+}
+
+func (b *block) Eval() {
+	for _, c := range b.Children() {
+		c.Eval()
+	}
+}
+
+func (b *block) ZeroGrad(gs *tensor.GradStore[float32]) {
+	for _, c := range b.Children() {
+		c.ZeroGrad(gs)
+	}
+}
+
+func TestModuleNames(t *testing.T) {
+	t.Parallel()
+	b := &block{
+		conv: nn.NewConv2d[float32](1, 2, 3, 1, 1, candy.CPU),
+		bn:   nn.NewBatchNorm2d[float32](2, candy.CPU),
+		fc:   nn.NewLinearNoBias[float32](4, 3, candy.CPU),
+	}
+	var m nn.Module[float32] = b
+	if got, want := slices.Sorted(maps.Keys(m.NamedParameters())), []string{"bn.bias", "bn.weight", "conv.bias", "conv.weight", "fc.weight"}; !slices.Equal(got, want) {
+		t.Errorf("parameters = %v, want %v", got, want)
+	}
+	if got, want := slices.Sorted(maps.Keys(m.NamedBuffers())), []string{"bn.running_mean", "bn.running_var"}; !slices.Equal(got, want) {
+		t.Errorf("buffers = %v, want %v", got, want)
+	}
+	ps := nn.Parameters(m)
+	if len(ps) != 5 || ps[0] != b.bn.Bias() || ps[4] != b.fc.Weight() {
+		t.Errorf("parameters not ordered by name")
+	}
+}
+
+func TestModuleTrainEval(t *testing.T) {
+	t.Parallel()
+	b := &block{
+		conv: nn.NewConv2d[float32](1, 2, 1, 1, 0, candy.CPU),
+		bn:   nn.NewBatchNorm2d[float32](2, candy.CPU),
+		fc:   nn.NewLinearLayer[float32](2, 2, true, candy.CPU),
+	}
+	x := tensor.MustNew([]float32{1, 2, 3, 4}, candy.NewShape(1, 1, 2, 2), candy.CPU)
+	y := b.conv.MustForward(x)
+
+	b.Eval()
+	before := b.bn.RunningMean().Data()
+	b.bn.MustForward(y)
+	if !slices.Equal(b.bn.RunningMean().Data(), before) {
+		t.Fatal("eval mode updated running mean")
+	}
+	b.Train()
+	b.bn.MustForward(y)
+	if slices.Equal(b.bn.RunningMean().Data(), before) {
+		t.Fatal("train mode did not update running mean")
+	}
+}
+
+func TestModuleZeroGrad(t *testing.T) {
+	t.Parallel()
+	fc := nn.NewLinearLayer[float32](3, 2, true, candy.CPU)
+	x := tensor.MustOnes[float32](candy.NewShape(4, 3), candy.CPU)
+	gs := fc.MustForward(x).MustSum([]int{0, 1}).MustBackward()
+	if gs.Get(fc.Weight()) == nil || gs.Get(fc.Bias()) == nil {
+		t.Fatal("missing parameter gradients")
+	}
+	fc.ZeroGrad(gs)
+	if gs.Get(fc.Weight()) != nil || gs.Get(fc.Bias()) != nil {
+		t.Fatal("gradients survived ZeroGrad")
+	}
+}
+
+func TestEmbeddingLookup(t *testing.T) {
+	t.Parallel()
+	w := tensor.MustNew([]float32{0, 1, 10, 11, 20, 21, 30, 31}, candy.NewShape(4, 2), candy.CPU)
+	w.SetIsVar(true)
+	e := nn.NewEmbedding(w)
+	want := []float32{30, 31, 10, 11, 10, 11, 0, 1}
+	shape := candy.NewShape(2, 2)
+	outs := map[string]*tensor.Tensor[float32]{
+		"uint8":  nn.MustLookup(e, tensor.MustNew([]uint8{3, 1, 1, 0}, shape, candy.CPU)),
+		"uint32": e.MustForward(tensor.MustNew([]uint32{3, 1, 1, 0}, shape, candy.CPU)),
+		"int64":  nn.MustLookup(e, tensor.MustNew([]int64{3, 1, 1, 0}, shape, candy.CPU)),
+	}
+	for name, y := range outs {
+		if got := y.Dims(); !slices.Equal(got, []int{2, 2, 2}) {
+			t.Errorf("%s: dims = %v, want [2 2 2]", name, got)
+		}
+		if got := y.Data(); !slices.Equal(got, want) {
+			t.Errorf("%s: data = %v, want %v", name, got, want)
+		}
+		gs, err := y.MustSumAll().Backward()
+		if err != nil {
+			t.Fatalf("%s: backward: %v", name, err)
+		}
+		if got, want := gs.Get(w).Data(), []float32{1, 1, 2, 2, 0, 0, 1, 1}; !slices.Equal(got, want) {
+			t.Errorf("%s: weight grad = %v, want %v", name, got, want)
+		}
+	}
+
+	// 255 is an ordinary uint8 id in a 256-entry vocabulary.
+	big := nn.NewEmbedding(tensor.MustArange[float32](0, 256, 1, candy.CPU).MustReshape(256, 1))
+	if got := nn.MustLookup(big, tensor.MustNew([]uint8{255, 0}, candy.NewShape(2), candy.CPU)).Data(); !slices.Equal(got, []float32{255, 0}) {
+		t.Errorf("uint8 id 255: data = %v, want [255 0]", got)
+	}
+
+	opts := nn.DefaultEmbeddingOptions()
+	opts.PaddingIdx = 1
+	p := nn.NewEmbeddingWithOptions(w, opts)
+	y := nn.MustLookup(p, tensor.MustNew([]uint8{3, 1, 1, 0}, shape, candy.CPU))
+	if got, want := y.Data(), []float32{30, 31, 0, 0, 0, 0, 0, 1}; !slices.Equal(got, want) {
+		t.Errorf("padding: data = %v, want %v", got, want)
+	}
+	gs := y.MustSumAll().MustBackward()
+	if got, want := gs.Get(w).Data(), []float32{1, 1, 0, 0, 0, 0, 1, 1}; !slices.Equal(got, want) {
+		t.Errorf("padding: weight grad = %v, want %v", got, want)
+	}
+}
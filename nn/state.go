@@ -0,0 +1,237 @@
+package nn
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// StateDictKeys lists the keys that did not match when loading a state dict.
+type StateDictKeys struct {
+	Missing    []string // keys of the module absent from the source
+	Unexpected []string // keys of the source the module does not have
+}
+
+// StateDict returns the parameters and buffers of m keyed by dotted name,
+// such as "layer1.0.conv1.weight". The tensors are shared, not copied.
+func StateDict[T candy.D](m Module[T]) map[string]*tensor.Tensor[T] {
+	sd := m.NamedParameters()
+	maps.Copy(sd, m.NamedBuffers())
+	return sd
+}
+
+// SaveStateDict writes the state dict of m to path. The format is chosen by
+// the extension: ".npz" for NumPy archives, ".safetensors" for safetensors.
+func SaveStateDict[T candy.D](m Module[T], path string) error {
+	sd := StateDict(m)
+	switch ext := filepath.Ext(path); ext {
+	case ".npz":
+		return tensor.WriteNPZ(path, sd)
+	case ".safetensors":
+		items := make(map[string]tensor.TensorView, len(sd))
+		for k, t := range sd {
+			items[k] = t
+		}
+		return tensor.WriteSafetensors(path, items, nil)
+	default:
+		return fmt.Errorf("nn: unsupported state dict format %q", ext)
+	}
+}
+
+// MustSaveStateDict writes the state dict of m to path, panicking on error.
+func MustSaveStateDict[T candy.D](m Module[T], path string) {
+	if err := SaveStateDict(m, path); err != nil {
+		panic(err)
+	}
+}
+
+// stateEntry is the stored dtype and shape of one tensor in a checkpoint.
+type stateEntry struct {
+	dtype string
+	shape *candy.Shape
+}
+
+// LoadStateDict loads the parameters and buffers of m from an .npz or
+// .safetensors file written by SaveStateDict or by PyTorch tooling.
+//
+// Every matched tensor must have the module's shape and be stored with the
+// dtype of T; all keys are validated before any tensor is replaced. In
+// strict mode missing or unexpected keys are an error; otherwise they are
+// only reported in the returned StateDictKeys. The int64
+// "num_batches_tracked" counters PyTorch stores for batch norm layers are
+// skipped unless m has a tensor of that name, since BatchNorm2d keeps none.
+func LoadStateDict[T candy.D](m Module[T], path string, strict bool) (StateDictKeys, error) {
+	var (
+		entries map[string]stateEntry
+		read    func(names []string) ([]*tensor.Tensor[T], error)
+	)
+	switch ext := filepath.Ext(path); ext {
+	case ".npz":
+		info, err := tensor.ReadNPZInfo(path)
+		if err != nil {
+			return StateDictKeys{}, err
+		}
+		entries = make(map[string]stateEntry, len(info))
+		for k, i := range info {
+			dt := i.Descr
+			if d, ok := i.DType(); ok {
+				dt = d.String()
+			}
+			entries[k] = stateEntry{dtype: dt, shape: i.Shape}
+		}
+		read = func(names []string) ([]*tensor.Tensor[T], error) {
+			return tensor.ReadNPZByName[T](path, names)
+		}
+	case ".safetensors":
+		s, err := tensor.OpenSafetensors(path)
+		if err != nil {
+			return StateDictKeys{}, err
+		}
+		defer s.Close()
+		entries = map[string]stateEntry{}
+		for _, k := range s.Names() {
+			i, _ := s.Info(k)
+			entries[k] = stateEntry{dtype: strings.ToLower(i.DType), shape: i.Shape}
+		}
+		read = func(names []string) ([]*tensor.Tensor[T], error) {
+			ts := make([]*tensor.Tensor[T], len(names))
+			for i, k := range names {
+				t, err := tensor.LoadSafetensor[T](s, k)
+				if err != nil {
+					return nil, err
+				}
+				ts[i] = t
+			}
+			return ts, nil
+		}
+	default:
+		return StateDictKeys{}, fmt.Errorf("nn: unsupported state dict format %q", ext)
+	}
+
+	sd := StateDict(m)
+	keys, matched := matchStateDict(sd, slices.Collect(maps.Keys(entries)))
+	if err := keys.check(strict); err != nil {
+		return keys, err
+	}
+	want := candy.DTypeOf[T]().String()
+	var errs []error
+	for _, k := range matched {
+		e := entries[k]
+		if e.dtype != want {
+			errs = append(errs, fmt.Errorf("nn: %s: stored dtype %s, module dtype %s", k, e.dtype, want))
+		}
+		if !e.shape.Equal(sd[k].Shape()) {
+			errs = append(errs, fmt.Errorf("nn: %s: stored shape %v, module shape %v", k, e.shape, sd[k].Shape()))
+		}
+	}
+	if len(errs) > 0 {
+		return keys, errors.Join(errs...)
+	}
+	ts, err := read(matched)
+	if err != nil {
+		return keys, err
+	}
+	src := make(map[string]*tensor.Tensor[T], len(matched))
+	for i, k := range matched {
+		src[k] = ts[i]
+	}
+	return keys, copyStateDict(sd, src, matched)
+}
+
+// MustLoadStateDict loads the state dict of m from path, panicking on error.
+func MustLoadStateDict[T candy.D](m Module[T], path string, strict bool) StateDictKeys {
+	keys, err := LoadStateDict(m, path, strict)
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+// SetStateDict copies the tensors of src into the matching parameters and
+// buffers of m, with the same validation and strictness as LoadStateDict.
+func SetStateDict[T candy.D](m Module[T], src map[string]*tensor.Tensor[T], strict bool) (StateDictKeys, error) {
+	sd := StateDict(m)
+	keys, matched := matchStateDict(sd, slices.Collect(maps.Keys(src)))
+	if err := keys.check(strict); err != nil {
+		return keys, err
+	}
+	var errs []error
+	for _, k := range matched {
+		if !src[k].Shape().Equal(sd[k].Shape()) {
+			errs = append(errs, fmt.Errorf("nn: %s: source shape %v, module shape %v", k, src[k].Shape(), sd[k].Shape()))
+		}
+	}
+	if len(errs) > 0 {
+		return keys, errors.Join(errs...)
+	}
+	return keys, copyStateDict(sd, src, matched)
+}
+
+// MustSetStateDict copies src into m, panicking on error.
+func MustSetStateDict[T candy.D](m Module[T], src map[string]*tensor.Tensor[T], strict bool) StateDictKeys {
+	keys, err := SetStateDict(m, src, strict)
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+// matchStateDict splits names into those present in sd, in sorted order,
+// and reports the keys missing on either side. Batch norm counters absent
+// from sd are dropped rather than reported.
+func matchStateDict[T candy.D](sd map[string]*tensor.Tensor[T], names []string) (StateDictKeys, []string) {
+	var keys StateDictKeys
+	var matched []string
+	seen := make(map[string]bool, len(names))
+	for _, k := range names {
+		seen[k] = true
+		if _, ok := sd[k]; ok {
+			matched = append(matched, k)
+		} else if k != "num_batches_tracked" && !strings.HasSuffix(k, ".num_batches_tracked") {
+			keys.Unexpected = append(keys.Unexpected, k)
+		}
+	}
+	for k := range sd {
+		if !seen[k] {
+			keys.Missing = append(keys.Missing, k)
+		}
+	}
+	slices.Sort(matched)
+	slices.Sort(keys.Missing)
+	slices.Sort(keys.Unexpected)
+	return keys, matched
+}
+
+// check returns an error listing the mismatched keys in strict mode.
+func (k StateDictKeys) check(strict bool) error {
+	if !strict || (len(k.Missing) == 0 && len(k.Unexpected) == 0) {
+		return nil
+	}
+	var parts []string
+	if len(k.Missing) > 0 {
+		parts = append(parts, "missing keys: "+strings.Join(k.Missing, ", "))
+	}
+	if len(k.Unexpected) > 0 {
+		parts = append(parts, "unexpected keys: "+strings.Join(k.Unexpected, ", "))
+	}
+	return fmt.Errorf("nn: state dict mismatch: %s", strings.Join(parts, "; "))
+}
+
+// copyStateDict replaces the storage of each matched destination tensor with
+// a contiguous copy of its source, so the module never aliases src.
+func copyStateDict[T candy.D](dst, src map[string]*tensor.Tensor[T], matched []string) error {
+	for _, k := range matched {
+		c, err := src[k].Copy()
+		if err != nil {
+			return fmt.Errorf("nn: %s: %w", k, err)
+		}
+		dst[k].SetStorage(c.Storage())
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package nn_test
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/nn"
+	"github.com/gocnn/candy/tensor"
+)
+
+func newBlock() *block {
+	return &block{
+		conv: nn.NewConv2d[float32](1, 2, 3, 1, 1, candy.CPU),
+		bn:   nn.NewBatchNorm2d[float32](2, candy.CPU),
+		fc:   nn.NewLinearLayer[float32](4, 3, true, candy.CPU),
+	}
+}
+
+func TestStateDictRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, ext := range []string{".npz", ".safetensors"} {
+		src, dst := newBlock(), newBlock()
+		src.bn.RunningMean().SetStorage(tensor.MustNew([]float32{3, 4}, candy.NewShape(2), candy.CPU).Storage())
+		path := filepath.Join(t.TempDir(), "model"+ext)
+		nn.MustSaveStateDict(src, path)
+		keys := nn.MustLoadStateDict(dst, path, true)
+		if len(keys.Missing) != 0 || len(keys.Unexpected) != 0 {
+			t.Fatalf("%s: unmatched keys %+v", ext, keys)
+		}
+		want := nn.StateDict(src)
+		for k, got := range nn.StateDict(dst) {
+			if !slices.Equal(got.Data(), want[k].Data()) {
+				t.Errorf("%s: %s = %v, want %v", ext, k, got.Data(), want[k].Data())
+			}
+		}
+	}
+}
+
+func TestStateDictStrict(t *testing.T) {
+	t.Parallel()
+	m := newBlock()
+	before := m.fc.Weight().Data()
+	src := nn.StateDict(newBlock())
+	delete(src, "bn.running_var")
+	src["head.weight"] = tensor.MustOnes[float32](candy.NewShape(1), candy.CPU)
+
+	keys, err := nn.SetStateDict(m, src, true)
+	if err == nil || !strings.Contains(err.Error(), "bn.running_var") || !strings.Contains(err.Error(), "head.weight") {
+		t.Fatalf("strict error = %v", err)
+	}
+	if !slices.Equal(m.fc.Weight().Data(), before) {
+		t.Fatal("strict failure modified the module")
+	}
+	keys, err = nn.SetStateDict(m, src, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(keys.Missing, []string{"bn.running_var"}) || !slices.Equal(keys.Unexpected, []string{"head.weight"}) {
+		t.Fatalf("keys = %+v", keys)
+	}
+	if !slices.Equal(m.fc.Weight().Data(), src["fc.weight"].Data()) {
+		t.Fatal("non-strict load skipped matching keys")
+	}
+}
+
+func TestStateDictPyTorchBatchNorm(t *testing.T) {
+	t.Parallel()
+	// torch.nn.BatchNorm2d(2).state_dict() plus the rest of the block, as
+	// written by safetensors.torch.save_file.
+	items := map[string]tensor.TensorView{
+		"bn.weight":              tensor.MustNew([]float32{1.5, 2}, candy.NewShape(2), candy.CPU),
+		"bn.bias":                tensor.MustNew([]float32{0.1, -0.1}, candy.NewShape(2), candy.CPU),
+		"bn.running_mean":        tensor.MustNew([]float32{0.3, 0.4}, candy.NewShape(2), candy.CPU),
+		"bn.running_var":         tensor.MustNew([]float32{0.9, 1.1}, candy.NewShape(2), candy.CPU),
+		"bn.num_batches_tracked": tensor.MustNew([]int64{120}, candy.NewShape(), candy.CPU),
+	}
+	for k, v := range nn.StateDict(newBlock()) {
+		if !strings.HasPrefix(k, "bn.") {
+			items[k] = v
+		}
+	}
+	path := filepath.Join(t.TempDir(), "torch.safetensors")
+	if err := tensor.WriteSafetensors(path, items, nil); err != nil {
+		t.Fatal(err)
+	}
+	m := newBlock()
+	keys, err := nn.LoadStateDict(m, path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys.Missing) != 0 || len(keys.Unexpected) != 0 {
+		t.Fatalf("unmatched keys %+v", keys)
+	}
+	if got := m.bn.RunningVar().Data(); !slices.Equal(got, []float32{0.9, 1.1}) {
+		t.Fatalf("running_var = %v", got)
+	}
+
+	// The counter is also skipped when a converted dict is set directly.
+	src := nn.StateDict(newBlock())
+	src["bn.num_batches_tracked"] = tensor.MustFull[float32](120, candy.NewShape(), candy.CPU)
+	if _, err := nn.SetStateDict(m, src, true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStateDictValidation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	m := newBlock()
+
+	src := nn.StateDict(newBlock())
+	src["fc.weight"] = tensor.MustOnes[float32](candy.NewShape(4, 3), candy.CPU)
+	path := filepath.Join(dir, "shape.npz")
+	tensor.MustWriteNPZ(path, src)
+	if _, err := nn.LoadStateDict(m, path, true); err == nil || !strings.Contains(err.Error(), "fc.weight: stored shape") {
+		t.Fatalf("shape error = %v", err)
+	}
+
+	path = filepath.Join(dir, "dtype.safetensors")
+	nn.MustSaveStateDict(nn.NewLinearLayer[float32](4, 3, true, candy.CPU), path)
+	fc := nn.NewLinearLayer[float64](4, 3, true, candy.CPU)
+	if _, err := nn.LoadStateDict(fc, path, true); err == nil || !strings.Contains(err.Error(), "weight: stored dtype f32, module dtype f64") {
+		t.Fatalf("dtype error = %v", err)
+	}
+	if _, err := nn.LoadStateDict[float32](m, filepath.Join(dir, "model.bin"), false); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
@@ -0,0 +1,303 @@
+// Package onnx loads ONNX models and executes them on CPU with candy tensors.
+//
+// The ModelProto protobuf is decoded directly from its wire format, so no
+// generated code is needed. Initializers become the weights of the graph and
+// every node is mapped onto the equivalent tensor operation. Values are
+// computed in the element type T. Integer tensors, namely integer initializers
+// and constants, Shape outputs and anything computed from those alone, are
+// also tracked as int64. Reshape shapes, Gather indices and (Un)Squeeze axes
+// are read from that copy, so they stay exact even when T is a half type.
+package onnx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// Model is a decoded ONNX model whose initializers have been converted to
+// tensors of type T, ready to run.
+type Model[T candy.D] struct {
+	Proto   *ModelProto
+	opset   int64
+	weights map[string]*tensor.Tensor[T]
+	ints    map[string]*tensor.Tensor[int64] // Exact copies of integer initializers
+}
+
+// Load reads and prepares the ONNX model at path.
+func Load[T candy.D](path string) (*Model[T], error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse[T](data)
+}
+
+// MustLoad reads and prepares an ONNX model, panicking on error.
+func MustLoad[T candy.D](path string) *Model[T] {
+	m, err := Load[T](path)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Parse decodes a serialized ONNX model and converts its initializers to T.
+// Models using operators without a mapping are rejected up front.
+func Parse[T candy.D](data []byte) (*Model[T], error) {
+	p, err := DecodeModel(data)
+	if err != nil {
+		return nil, err
+	}
+	m := &Model[T]{Proto: p, opset: 1, weights: map[string]*tensor.Tensor[T]{}, ints: map[string]*tensor.Tensor[int64]{}}
+	for _, o := range p.OpsetImports {
+		if o.Domain == "" || o.Domain == "ai.onnx" {
+			m.opset = o.Version
+		}
+	}
+	var unsupported []string
+	for _, n := range p.Graph.Nodes {
+		if (n.Domain != "" && n.Domain != "ai.onnx") || !supportedOps[n.OpType] {
+			unsupported = append(unsupported, n.OpType)
+		}
+	}
+	if len(unsupported) > 0 {
+		slices.Sort(unsupported)
+		return nil, fmt.Errorf("onnx: unsupported operators: %s", strings.Join(slices.Compact(unsupported), ", "))
+	}
+	for _, init := range p.Graph.Initializers {
+		t, err := TensorFromProto[T](init)
+		if err != nil {
+			return nil, fmt.Errorf("onnx: initializer %s: %w", init.Name, err)
+		}
+		m.weights[init.Name] = t
+		if init.DataType.isInteger() {
+			if m.ints[init.Name], err = TensorFromProto[int64](init); err != nil {
+				return nil, fmt.Errorf("onnx: initializer %s: %w", init.Name, err)
+			}
+		}
+	}
+	return m, nil
+}
+
+// MustParse decodes an ONNX model, panicking on error.
+func MustParse[T candy.D](data []byte) *Model[T] {
+	m, err := Parse[T](data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Opset returns the version of the default operator set the model targets.
+func (m *Model[T]) Opset() int64 {
+	return m.opset
+}
+
+// Inputs returns the graph inputs that must be fed to Run, excluding those
+// provided by initializers.
+func (m *Model[T]) Inputs() []ValueInfo {
+	var out []ValueInfo
+	for _, v := range m.Proto.Graph.Inputs {
+		if _, ok := m.weights[v.Name]; !ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Outputs returns the graph outputs produced by Run.
+func (m *Model[T]) Outputs() []ValueInfo {
+	return m.Proto.Graph.Outputs
+}
+
+// Weights returns the initializer tensors by name.
+func (m *Model[T]) Weights() map[string]*tensor.Tensor[T] {
+	return m.weights
+}
+
+// Run executes the graph on the given inputs and returns the graph outputs
+// by name. Inputs override initializers of the same name.
+func (m *Model[T]) Run(inputs map[string]*tensor.Tensor[T]) (map[string]*tensor.Tensor[T], error) {
+	env := maps.Clone(m.weights)
+	maps.Copy(env, inputs)
+	// ienv holds the int64 copy of every integer value in env.
+	ienv := maps.Clone(m.ints)
+	for name := range inputs {
+		delete(ienv, name)
+	}
+	for _, v := range m.Inputs() {
+		if _, ok := env[v.Name]; !ok {
+			return nil, fmt.Errorf("onnx: missing input %s", v.Name)
+		}
+	}
+	for _, n := range m.Proto.Graph.Nodes {
+		in := make([]*tensor.Tensor[T], len(n.Inputs))
+		iin := make([]*tensor.Tensor[int64], len(n.Inputs))
+		integer := len(n.Inputs) > 0
+		for i, name := range n.Inputs {
+			if name == "" {
+				continue
+			}
+			t, ok := env[name]
+			if !ok {
+				return nil, fmt.Errorf("onnx: node %s (%s): input %s not computed", n.Name, n.OpType, name)
+			}
+			in[i], iin[i] = t, ienv[name]
+			integer = integer && iin[i] != nil
+		}
+		out, iout, err := run(n, in, iin, integer, m.opset)
+		if err != nil {
+			return nil, fmt.Errorf("onnx: node %s (%s): %w", n.Name, n.OpType, err)
+		}
+		for i, name := range n.Outputs {
+			if name == "" {
+				continue
+			}
+			if i >= len(out) {
+				return nil, fmt.Errorf("onnx: node %s (%s): output %s is not supported", n.Name, n.OpType, name)
+			}
+			env[name] = out[i]
+			if i < len(iout) && iout[i] != nil {
+				ienv[name] = iout[i]
+			} else {
+				delete(ienv, name)
+			}
+		}
+	}
+	res := make(map[string]*tensor.Tensor[T], len(m.Proto.Graph.Outputs))
+	for _, v := range m.Proto.Graph.Outputs {
+		t, ok := env[v.Name]
+		if !ok {
+			return nil, fmt.Errorf("onnx: output %s not computed", v.Name)
+		}
+		res[v.Name] = t
+	}
+	return res, nil
+}
+
+// MustRun executes the graph, panicking on error.
+func (m *Model[T]) MustRun(inputs map[string]*tensor.Tensor[T]) map[string]*tensor.Tensor[T] {
+	res, err := m.Run(inputs)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TensorFromProto converts a serialized tensor to a CPU tensor of type T.
+func TensorFromProto[T candy.D](p *TensorProto) (*tensor.Tensor[T], error) {
+	if p.External {
+		return nil, errors.New("external tensor data is not supported")
+	}
+	dims := make([]int, len(p.Dims))
+	n := 1
+	for i, d := range p.Dims {
+		if d < 0 {
+			return nil, fmt.Errorf("invalid dimension %d", d)
+		}
+		dims[i] = int(d)
+		n *= dims[i]
+	}
+	vals, err := protoValues(p, n)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]T, n)
+	for i, v := range vals {
+		data[i] = candy.FromFloat64[T](v)
+	}
+	return tensor.New(data, candy.NewShapeFrom(dims), candy.CPU)
+}
+
+// protoValues decodes the n elements of p to float64.
+func protoValues(p *TensorProto, n int) ([]float64, error) {
+	out := make([]float64, 0, n)
+	le := binary.LittleEndian
+	if p.RawData != nil {
+		size := map[DataType]int{
+			Float: 4, Uint8: 1, Int8: 1, Uint16: 2, Int16: 2, Int32: 4, Int64: 8,
+			Bool: 1, Float16: 2, Double: 8, Uint32: 4, Uint64: 8, BFloat16: 2,
+		}[p.DataType]
+		if size == 0 {
+			return nil, fmt.Errorf("unsupported data type %v", p.DataType)
+		}
+		if len(p.RawData) != n*size {
+			return nil, fmt.Errorf("raw data has %d bytes, want %d", len(p.RawData), n*size)
+		}
+		b := p.RawData
+		for i := range n {
+			var v float64
+			switch p.DataType {
+			case Float:
+				v = float64(math.Float32frombits(le.Uint32(b[4*i:])))
+			case Uint8, Bool:
+				v = float64(b[i])
+			case Int8:
+				v = float64(int8(b[i]))
+			case Uint16:
+				v = float64(le.Uint16(b[2*i:]))
+			case Int16:
+				v = float64(int16(le.Uint16(b[2*i:])))
+			case Int32:
+				v = float64(int32(le.Uint32(b[4*i:])))
+			case Int64:
+				v = float64(int64(le.Uint64(b[8*i:])))
+			case Float16:
+				v = float64(candy.Float16(le.Uint16(b[2*i:])).Float32())
+			case Double:
+				v = math.Float64frombits(le.Uint64(b[8*i:]))
+			case Uint32:
+				v = float64(le.Uint32(b[4*i:]))
+			case Uint64:
+				v = float64(le.Uint64(b[8*i:]))
+			case BFloat16:
+				v = float64(candy.BFloat16(le.Uint16(b[2*i:])).Float32())
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	switch p.DataType {
+	case Float:
+		for _, v := range p.FloatData {
+			out = append(out, float64(v))
+		}
+	case Uint8, Int8, Uint16, Int16, Int32, Bool:
+		for _, v := range p.Int32Data {
+			out = append(out, float64(v))
+		}
+	case Float16, BFloat16:
+		for _, v := range p.Int32Data {
+			if p.DataType == Float16 {
+				out = append(out, float64(candy.Float16(v).Float32()))
+			} else {
+				out = append(out, float64(candy.BFloat16(v).Float32()))
+			}
+		}
+	case Int64:
+		for _, v := range p.Int64Data {
+			out = append(out, float64(v))
+		}
+	case Double:
+		out = append(out, p.DoubleData...)
+	case Uint32, Uint64:
+		for _, v := range p.Uint64Data {
+			out = append(out, float64(v))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data type %v", p.DataType)
+	}
+	if len(out) != n {
+		return nil, fmt.Errorf("tensor has %d elements, want %d", len(out), n)
+	}
+	return out, nil
+}
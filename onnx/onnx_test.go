@@ -0,0 +1,362 @@
+package onnx_test
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/onnx"
+	"github.com/gocnn/candy/tensor"
+)
+
+// pb appends protobuf fields, enough to hand-build ONNX fixtures.
+type pb []byte
+
+func (b pb) key(f, wt int) pb { return binary.AppendUvarint(b, uint64(f<<3|wt)) }
+
+func (b pb) varint(f int, v int64) pb { return binary.AppendUvarint(b.key(f, 0), uint64(v)) }
+
+func (b pb) bytes(f int, v []byte) pb {
+	return append(binary.AppendUvarint(b.key(f, 2), uint64(len(v))), v...)
+}
+
+func (b pb) str(f int, s string) pb { return b.bytes(f, []byte(s)) }
+
+func (b pb) float(f int, v float32) pb {
+	return binary.LittleEndian.AppendUint32(b.key(f, 5), math.Float32bits(v))
+}
+
+func (b pb) packed(f int, vs ...int64) pb {
+	var p []byte
+	for _, v := range vs {
+		p = binary.AppendUvarint(p, uint64(v))
+	}
+	return b.bytes(f, p)
+}
+
+// floatTensor encodes float data as raw_data.
+func floatTensor(name string, dims []int64, vals ...float32) pb {
+	raw := []byte{}
+	for _, v := range vals {
+		raw = binary.LittleEndian.AppendUint32(raw, math.Float32bits(v))
+	}
+	return pb{}.packed(1, dims...).varint(2, int64(onnx.Float)).str(8, name).bytes(9, raw)
+}
+
+// int64Tensor encodes int64 data in the typed int64_data field.
+func int64Tensor(name string, dims []int64, vals ...int64) pb {
+	return pb{}.packed(1, dims...).varint(2, int64(onnx.Int64)).packed(7, vals...).str(8, name)
+}
+
+func attrInt(name string, v int64) pb { return pb{}.str(1, name).varint(3, v).varint(20, 2) }
+
+func attrInts(name string, vs ...int64) pb { return pb{}.str(1, name).packed(8, vs...).varint(20, 7) }
+
+func attrFloat(name string, v float32) pb { return pb{}.str(1, name).float(2, v).varint(20, 1) }
+
+func attrString(name, v string) pb { return pb{}.str(1, name).str(4, v).varint(20, 3) }
+
+func attrTensor(name string, t pb) pb { return pb{}.str(1, name).bytes(5, t).varint(20, 4) }
+
+func node(op string, inputs, outputs []string, attrs ...pb) pb {
+	n := pb{}
+	for _, in := range inputs {
+		n = n.str(1, in)
+	}
+	for _, out := range outputs {
+		n = n.str(2, out)
+	}
+	n = n.str(3, op+"_"+outputs[0]).str(4, op)
+	for _, a := range attrs {
+		n = n.bytes(5, a)
+	}
+	return n
+}
+
+func valueInfo(name string, dims ...int64) pb {
+	shape := pb{}
+	for _, d := range dims {
+		shape = shape.bytes(1, pb{}.varint(1, d))
+	}
+	tt := pb{}.varint(1, int64(onnx.Float)).bytes(2, shape)
+	return pb{}.str(1, name).bytes(2, pb{}.bytes(1, tt))
+}
+
+type graph struct {
+	nodes, inits, inputs, outputs []pb
+}
+
+func (g graph) model(opset int64) []byte {
+	gr := pb{}
+	for _, n := range g.nodes {
+		gr = gr.bytes(1, n)
+	}
+	gr = gr.str(2, "test")
+	for _, t := range g.inits {
+		gr = gr.bytes(5, t)
+	}
+	for _, v := range g.inputs {
+		gr = gr.bytes(11, v)
+	}
+	for _, v := range g.outputs {
+		gr = gr.bytes(12, v)
+	}
+	return pb{}.varint(1, 8).str(2, "candy-test").bytes(7, gr).bytes(8, pb{}.varint(2, opset))
+}
+
+func closeTo(got, want []float32) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(float64(got[i]-want[i])) > 1e-4 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCNN(t *testing.T) {
+	t.Parallel()
+	convW := []float32{1, 0, -1, 2, 0, -2, 1, 0, -1, 0.5, 0.5, 0.5, 0, 0, 0, -0.5, -0.5, -0.5}
+	g := graph{
+		nodes: []pb{
+			node("Conv", []string{"x", "cw", "cb"}, []string{"c"}, attrInts("pads", 1, 1, 1, 1), attrInts("kernel_shape", 3, 3)),
+			node("BatchNormalization", []string{"c", "s", "b", "m", "v"}, []string{"n"}, attrFloat("epsilon", 1e-3)),
+			node("Relu", []string{"n"}, []string{"r"}),
+			node("MaxPool", []string{"r"}, []string{"p"}, attrInts("kernel_shape", 2, 2), attrInts("strides", 2, 2)),
+			node("GlobalAveragePool", []string{"p"}, []string{"gap"}),
+			node("Flatten", []string{"gap"}, []string{"f"}),
+			node("Gemm", []string{"f", "fw", "fb"}, []string{"logits"}, attrInt("transB", 1), attrFloat("alpha", 0.5)),
+			node("Softmax", []string{"logits"}, []string{"y"}, attrInt("axis", 1)),
+		},
+		inits: []pb{
+			floatTensor("cw", []int64{2, 1, 3, 3}, convW...),
+			floatTensor("cb", []int64{2}, 0.1, -0.2),
+			floatTensor("s", []int64{2}, 1, 2),
+			floatTensor("b", []int64{2}, 0, 0.5),
+			floatTensor("m", []int64{2}, 0.2, -0.1),
+			floatTensor("v", []int64{2}, 1, 4),
+			floatTensor("fw", []int64{3, 2}, 1, -1, 0.5, 2, -1, 0),
+			floatTensor("fb", []int64{3}, 0, 0.1, 0.2),
+		},
+		inputs:  []pb{valueInfo("x", 1, 1, 4, 4), valueInfo("cw", 2, 1, 3, 3)},
+		outputs: []pb{valueInfo("y", 1, 3)},
+	}
+	path := filepath.Join(t.TempDir(), "cnn.onnx")
+	if err := os.WriteFile(path, g.model(11), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := onnx.MustLoad[float32](path)
+	if m.Opset() != 11 || len(m.Inputs()) != 1 || m.Inputs()[0].Name != "x" || !slices.Equal(m.Inputs()[0].Shape, []int64{1, 1, 4, 4}) {
+		t.Fatalf("opset %d inputs %+v", m.Opset(), m.Inputs())
+	}
+	x := tensor.MustNew([]float32{
+		0, 1, 2, 3,
+		1, 3, 5, 7,
+		-1, 0, 1, 0,
+		2, 2, -2, -2,
+	}, candy.NewShape(1, 1, 4, 4), candy.CPU)
+	y := m.MustRun(map[string]*tensor.Tensor[float32]{"x": x})["y"]
+
+	// Reference computed with the tensor API directly.
+	w := m.Weights()
+	c := x.MustConv2d(w["cw"], &candy.Conv2DParams{Batch: 1, InH: 4, InW: 4, KH: 3, KW: 3, OutCh: 2, InCh: 1, Pad: 1, Stride: 1, Dilate: 1})
+	c = c.MustBroadcastAdd(w["cb"].MustReshape(1, 2, 1, 1))
+	n := c.MustBroadcastSub(w["m"].MustReshape(1, 2, 1, 1)).
+		MustBroadcastDiv(w["v"].MustReshape(1, 2, 1, 1).MustAddScalar(1e-3).MustSqrt()).
+		MustBroadcastMul(w["s"].MustReshape(1, 2, 1, 1)).
+		MustBroadcastAdd(w["b"].MustReshape(1, 2, 1, 1))
+	p := n.MustRelu().MustMaxPool2d(2, 2, 2, 2).MustMean([]int{2, 3})
+	want := p.MustMatMul(w["fw"].MustT()).MustMulScalar(0.5).MustBroadcastAdd(w["fb"]).MustFastSoftmax()
+	if !y.Shape().Equal(candy.NewShape(1, 3)) || !closeTo(y.Data(), want.Data()) {
+		t.Fatalf("y = %v, want %v", y.Data(), want.Data())
+	}
+}
+
+func TestGlueOps(t *testing.T) {
+	t.Parallel()
+	g := graph{
+		nodes: []pb{
+			node("Transpose", []string{"x"}, []string{"xt"}),
+			node("Shape", []string{"x"}, []string{"shape"}),
+			node("Gather", []string{"shape", "zero"}, []string{"rows"}),
+			node("Unsqueeze", []string{"rows", "axes"}, []string{"rows1"}),
+			node("Constant", []string{}, []string{"neg"}, attrTensor("value", int64Tensor("", []int64{1}, -1))),
+			node("Concat", []string{"rows1", "neg"}, []string{"target"}, attrInt("axis", 0)),
+			node("Reshape", []string{"xt", "target"}, []string{"r"}),
+			node("MatMul", []string{"r", "vec"}, []string{"mv"}),
+			node("Mul", []string{"mv", "half"}, []string{"scaled"}),
+			node("Add", []string{"scaled", "bias"}, []string{"sum"}),
+			node("Gather", []string{"x", "rowsIdx"}, []string{"picked"}, attrInt("axis", 0)),
+			node("Softmax", []string{"x"}, []string{"sm"}, attrInt("axis", 0)),
+			node("Relu", []string{"sm"}, []string{"smr"}),
+			node("Transpose", []string{"z"}, []string{"zt"}, attrInts("perm", 1, 0)),
+			node("Relu", []string{"zt"}, []string{"ztr"}),
+			node("Squeeze", []string{"picked", "axes1"}, []string{"picked2"}),
+			node("Identity", []string{"picked2"}, []string{"out"}),
+		},
+		inits: []pb{
+			int64Tensor("zero", nil, 0),
+			int64Tensor("axes", []int64{1}, 0),
+			int64Tensor("axes1", []int64{1}, 1),
+			int64Tensor("rowsIdx", []int64{2, 1}, 1, 0),
+			floatTensor("vec", []int64{3}, 1, 10, 100),
+			floatTensor("half", nil, 0.5),
+			floatTensor("bias", []int64{2}, 1, 2),
+		},
+		inputs:  []pb{valueInfo("x", 2, 3), valueInfo("z", 2, 3)},
+		outputs: []pb{valueInfo("r"), valueInfo("sum"), valueInfo("out"), valueInfo("sm"), valueInfo("smr"), valueInfo("zt"), valueInfo("ztr")},
+	}
+	m := onnx.MustParse[float32](g.model(13))
+	x := tensor.MustNew([]float32{0, 1, 2, 3, 4, 5}, candy.NewShape(2, 3), candy.CPU)
+	z := tensor.MustNew([]float32{0, -1, 2, -3, 4, -5}, candy.NewShape(2, 3), candy.CPU)
+	res := m.MustRun(map[string]*tensor.Tensor[float32]{"x": x, "z": z})
+
+	if r := res["r"]; !r.Shape().Equal(candy.NewShape(2, 3)) || !slices.Equal(r.Data(), []float32{0, 3, 1, 4, 2, 5}) {
+		t.Fatalf("r = %v", r)
+	}
+	if s := res["sum"]; !slices.Equal(s.Data(), []float32{66, 264}) {
+		t.Fatalf("sum = %v", s)
+	}
+	if o := res["out"]; !o.Shape().Equal(candy.NewShape(2, 3)) || !slices.Equal(o.Data(), []float32{3, 4, 5, 0, 1, 2}) {
+		t.Fatalf("out = %v", o)
+	}
+	lo, hi := float32(1/(1+math.Exp(3))), float32(math.Exp(3)/(1+math.Exp(3)))
+	if sm := res["sm"]; !closeTo(sm.Data(), []float32{lo, lo, lo, hi, hi, hi}) {
+		t.Fatalf("sm = %v", sm)
+	}
+	// Ops after a transposing node must read the permuted values.
+	for _, name := range []string{"sm", "smr", "zt", "ztr"} {
+		if !res[name].IsContiguous() {
+			t.Errorf("%s is not contiguous", name)
+		}
+	}
+	if smr := res["smr"]; !closeTo(smr.Data(), []float32{lo, lo, lo, hi, hi, hi}) {
+		t.Fatalf("relu(sm) = %v", smr)
+	}
+	if zt := res["ztr"]; !zt.Shape().Equal(candy.NewShape(3, 2)) || !slices.Equal(zt.Data(), []float32{0, 0, 0, 4, 2, 0}) {
+		t.Fatalf("relu(transpose(z)) = %v", zt)
+	}
+}
+
+func TestHalfPrecisionInts(t *testing.T) {
+	t.Parallel()
+	// 259 and 257 round to 260 and 256 in bfloat16, so shapes and indices
+	// must not pass through T.
+	g := graph{
+		nodes: []pb{
+			node("Shape", []string{"x"}, []string{"shape"}),
+			node("Gather", []string{"shape", "one"}, []string{"cols"}),
+			node("Unsqueeze", []string{"cols", "axes"}, []string{"cols1"}),
+			node("Constant", []string{}, []string{"neg"}, attrTensor("value", int64Tensor("", []int64{1}, -1))),
+			node("Concat", []string{"neg", "cols1"}, []string{"target"}, attrInt("axis", 0)),
+			node("Reshape", []string{"x", "target"}, []string{"r"}),
+			node("Gather", []string{"x", "idx"}, []string{"picked"}, attrInt("axis", 1)),
+		},
+		inits: []pb{
+			int64Tensor("one", nil, 1),
+			int64Tensor("axes", []int64{1}, 0),
+			int64Tensor("idx", []int64{2}, 257, 3),
+		},
+		inputs:  []pb{valueInfo("x", 2, 259)},
+		outputs: []pb{valueInfo("r"), valueInfo("picked")},
+	}
+	m := onnx.MustParse[candy.BFloat16](g.model(13))
+	data := make([]float32, 2*259)
+	for i := range data {
+		data[i] = float32(i % 7)
+	}
+	x := tensor.MustNew(data, candy.NewShape(2, 259), candy.CPU).MustToBFloat16()
+	res := m.MustRun(map[string]*tensor.Tensor[candy.BFloat16]{"x": x})
+	if r := res["r"]; !r.Shape().Equal(candy.NewShape(2, 259)) {
+		t.Fatalf("r shape = %v", r.Shape())
+	}
+	want := []float32{data[257], data[3], data[259+257], data[259+3]}
+	if p := res["picked"].MustToFloat32(); !p.Shape().Equal(candy.NewShape(2, 2)) || !slices.Equal(p.Data(), want) {
+		t.Fatalf("picked = %v, want %v", p, want)
+	}
+}
+
+func TestPoolingAndGroupedConv(t *testing.T) {
+	t.Parallel()
+	g := graph{
+		nodes: []pb{
+			node("AveragePool", []string{"a"}, []string{"avg"}, attrInts("kernel_shape", 2, 2), attrInts("pads", 1, 1, 1, 1)),
+			node("AveragePool", []string{"a"}, []string{"avgInc"}, attrInts("kernel_shape", 2, 2), attrInts("pads", 1, 1, 1, 1), attrInt("count_include_pad", 1)),
+			node("MaxPool", []string{"b"}, []string{"max"}, attrInts("kernel_shape", 2, 2), attrInts("strides", 2, 2), attrInt("ceil_mode", 1)),
+			node("Conv", []string{"c", "w"}, []string{"conv"}, attrInt("group", 2), attrInts("pads", 0, 0, 1, 0)),
+		},
+		inits: []pb{
+			floatTensor("w", []int64{2, 1, 1, 1}, 2, 3),
+		},
+		inputs:  []pb{valueInfo("a"), valueInfo("b"), valueInfo("c")},
+		outputs: []pb{valueInfo("avg"), valueInfo("avgInc"), valueInfo("max"), valueInfo("conv")},
+	}
+	m := onnx.MustParse[float32](g.model(13))
+	res := m.MustRun(map[string]*tensor.Tensor[float32]{
+		"a": tensor.MustNew([]float32{1, 2, 3, 4}, candy.NewShape(1, 1, 2, 2), candy.CPU),
+		"b": tensor.MustNew([]float32{0, 1, 2, 3, 4, 5, 6, 7, 8}, candy.NewShape(1, 1, 3, 3), candy.CPU),
+		"c": tensor.MustNew([]float32{1, 2, 3, 4}, candy.NewShape(1, 2, 1, 2), candy.CPU),
+	})
+	if avg := res["avg"]; !closeTo(avg.Data(), []float32{1, 1.5, 2, 2, 2.5, 3, 3, 3.5, 4}) {
+		t.Fatalf("avg = %v", avg)
+	}
+	if avg := res["avgInc"]; !closeTo(avg.Data(), []float32{0.25, 0.75, 0.5, 1, 2.5, 1.5, 0.75, 1.75, 1}) {
+		t.Fatalf("avg include pad = %v", avg)
+	}
+	if mx := res["max"]; !mx.Shape().Equal(candy.NewShape(1, 1, 2, 2)) || !slices.Equal(mx.Data(), []float32{4, 5, 7, 8}) {
+		t.Fatalf("max = %v", mx)
+	}
+	if c := res["conv"]; !c.Shape().Equal(candy.NewShape(1, 2, 2, 2)) || !slices.Equal(c.Data(), []float32{2, 4, 0, 0, 9, 12, 0, 0}) {
+		t.Fatalf("conv = %v", c)
+	}
+}
+
+func TestInvalidModels(t *testing.T) {
+	t.Parallel()
+	g := graph{
+		nodes:   []pb{node("Relu", []string{"x"}, []string{"y"}), node("LSTM", []string{"y"}, []string{"z"})},
+		inputs:  []pb{valueInfo("x")},
+		outputs: []pb{valueInfo("z")},
+	}
+	if _, err := onnx.Parse[float32](g.model(13)); err == nil {
+		t.Fatal("expected error for unsupported operator")
+	}
+	g.nodes = g.nodes[:1]
+	g.outputs = []pb{valueInfo("y")}
+	data := g.model(13)
+	if _, err := onnx.Parse[float32](data[:len(data)-3]); err == nil {
+		t.Fatal("expected error for truncated model")
+	}
+	m := onnx.MustParse[float32](data)
+	if _, err := m.Run(nil); err == nil {
+		t.Fatal("expected error for missing input")
+	}
+
+	// Zero strides or dilations must fail instead of dividing by zero.
+	x := tensor.MustOnes[float32](candy.NewShape(1, 1, 4, 4), candy.CPU)
+	for name, n := range map[string]pb{
+		"conv strides":   node("Conv", []string{"x", "w"}, []string{"y"}, attrInts("strides", 0, 0), attrString("auto_pad", "SAME_UPPER")),
+		"conv dilations": node("Conv", []string{"x", "w"}, []string{"y"}, attrInts("dilations", 0, 1)),
+		"pool strides":   node("MaxPool", []string{"x"}, []string{"y"}, attrInts("kernel_shape", 2, 2), attrInts("strides", -1, 2), attrInt("ceil_mode", 1)),
+	} {
+		g := graph{
+			nodes:   []pb{n},
+			inits:   []pb{floatTensor("w", []int64{1, 1, 1, 1}, 1)},
+			inputs:  []pb{valueInfo("x")},
+			outputs: []pb{valueInfo("y")},
+		}
+		m, err := onnx.Parse[float32](g.model(13))
+		if err == nil {
+			_, err = m.Run(map[string]*tensor.Tensor[float32]{"x": x})
+		}
+		if err == nil {
+			t.Errorf("%s: expected error for non-positive values", name)
+		}
+	}
+}
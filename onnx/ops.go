@@ -0,0 +1,797 @@
+package onnx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// supportedOps lists the operators of the default domain that apply maps.
+var supportedOps = map[string]bool{
+	"Add": true, "AveragePool": true, "BatchNormalization": true, "Concat": true,
+	"Constant": true, "Conv": true, "Dropout": true, "Flatten": true,
+	"Gather": true, "Gemm": true, "GlobalAveragePool": true, "Identity": true,
+	"MatMul": true, "MaxPool": true, "Mul": true, "Relu": true, "Reshape": true,
+	"Shape": true, "Softmax": true, "Squeeze": true, "Transpose": true,
+	"Unsqueeze": true,
+}
+
+// run executes one node. When integer is set every input is an integer
+// tensor and the node runs on the int64 copies, its outputs converted to T.
+// Alongside the outputs it returns the int64 copies of those that are
+// integer, nil where an output is not.
+func run[T candy.D](n *Node, in []*tensor.Tensor[T], iin []*tensor.Tensor[int64], integer bool, opset int64) ([]*tensor.Tensor[T], []*tensor.Tensor[int64], error) {
+	if integer {
+		iout, err := apply(n, iin, iin, opset)
+		if err != nil {
+			return nil, nil, err
+		}
+		out := make([]*tensor.Tensor[T], len(iout))
+		for i, t := range iout {
+			if out[i], err = tensor.ToDtype[int64, T](t, candy.DTypeOf[T]()); err != nil {
+				return nil, nil, err
+			}
+		}
+		return out, iout, nil
+	}
+	out, err := apply(n, in, iin, opset)
+	if err != nil {
+		return nil, nil, err
+	}
+	var y *tensor.Tensor[int64]
+	switch {
+	case n.OpType == "Shape":
+		y, err = shape[int64](n, in[0].Dims())
+	case n.OpType == "Constant" && intConstant(n):
+		y, err = constant[int64](n)
+	}
+	if err != nil || y == nil {
+		return out, nil, err
+	}
+	return out, []*tensor.Tensor[int64]{y}, nil
+}
+
+// apply runs one node on its inputs. Omitted optional inputs are nil. iin
+// holds the int64 copies of integer inputs, read where exact integers are
+// needed.
+func apply[T candy.D](n *Node, in []*tensor.Tensor[T], iin []*tensor.Tensor[int64], opset int64) ([]*tensor.Tensor[T], error) {
+	req := requiredInputs(n.OpType)
+	if len(in) < req {
+		return nil, fmt.Errorf("expected at least %d inputs, got %d", req, len(in))
+	}
+	for i, t := range in[:req] {
+		if t == nil {
+			return nil, fmt.Errorf("missing required input %d", i)
+		}
+	}
+	var y *tensor.Tensor[T]
+	var err error
+	switch n.OpType {
+	case "Add":
+		y, err = in[0].BroadcastAdd(in[1])
+	case "Mul":
+		y, err = in[0].BroadcastMul(in[1])
+	case "Relu":
+		y, err = in[0].Relu()
+	case "Identity", "Dropout":
+		y = in[0]
+	case "Constant":
+		y, err = constant[T](n)
+	case "Conv":
+		y, err = conv(n, in)
+	case "Gemm":
+		y, err = gemm(n, in)
+	case "MatMul":
+		y, err = matmul(in[0], in[1])
+	case "BatchNormalization":
+		y, err = batchNorm(n, in)
+	case "MaxPool":
+		y, err = pool(n, in[0], true)
+	case "AveragePool":
+		y, err = pool(n, in[0], false)
+	case "GlobalAveragePool":
+		y, err = globalAvgPool(in[0])
+	case "Reshape":
+		y, err = reshape(n, in, iin)
+	case "Flatten":
+		y, err = flatten(n, in[0])
+	case "Softmax":
+		y, err = softmax(n, in[0], opset)
+	case "Transpose":
+		y, err = transpose(n, in[0])
+	case "Concat":
+		y, err = concat(n, in)
+	case "Gather":
+		y, err = gather(n, in[0], in[1], iin[1])
+	case "Shape":
+		y, err = shape[T](n, in[0].Dims())
+	case "Unsqueeze":
+		y, err = unsqueeze(n, in, iin)
+	case "Squeeze":
+		y, err = squeeze(n, in, iin)
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", n.OpType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []*tensor.Tensor[T]{y}, nil
+}
+
+// requiredInputs returns the number of leading inputs an operator needs.
+func requiredInputs(op string) int {
+	switch op {
+	case "Constant":
+		return 0
+	case "Add", "Mul", "MatMul", "Reshape", "Gather", "Gemm", "Conv":
+		return 2
+	case "BatchNormalization":
+		return 5
+	default:
+		return 1
+	}
+}
+
+func (n *Node) attrInt(name string, def int64) int64 {
+	if a, ok := n.Attrs[name]; ok {
+		return a.I
+	}
+	return def
+}
+
+func (n *Node) attrFloat(name string, def float32) float32 {
+	if a, ok := n.Attrs[name]; ok {
+		return a.F
+	}
+	return def
+}
+
+func (n *Node) attrString(name, def string) string {
+	if a, ok := n.Attrs[name]; ok {
+		return string(a.S)
+	}
+	return def
+}
+
+func (n *Node) attrInts(name string) ([]int64, bool) {
+	a, ok := n.Attrs[name]
+	if !ok {
+		return nil, false
+	}
+	return a.Ints, true
+}
+
+// ints reads a shape, index or axes tensor as int64 values, from its exact
+// copy when there is one.
+func ints[T candy.D](t *tensor.Tensor[T], exact *tensor.Tensor[int64]) []int64 {
+	if exact != nil {
+		return slices.Clone(exact.Data())
+	}
+	data := t.Data()
+	out := make([]int64, len(data))
+	for i, v := range data {
+		out[i] = int64(candy.ToFloat64(v))
+	}
+	return out
+}
+
+// intConstant reports whether a Constant node holds integers.
+func intConstant(n *Node) bool {
+	if a, ok := n.Attrs["value"]; ok && a.T != nil {
+		return a.T.DataType.isInteger()
+	}
+	_, isInt := n.Attrs["value_int"]
+	_, isInts := n.Attrs["value_ints"]
+	return isInt || isInts
+}
+
+func constant[T candy.D](n *Node) (*tensor.Tensor[T], error) {
+	if a, ok := n.Attrs["value"]; ok && a.T != nil {
+		return TensorFromProto[T](a.T)
+	}
+	if a, ok := n.Attrs["value_float"]; ok {
+		return tensor.Full[T](float64(a.F), candy.NewShape(), candy.CPU)
+	}
+	if a, ok := n.Attrs["value_int"]; ok {
+		return tensor.Full[T](float64(a.I), candy.NewShape(), candy.CPU)
+	}
+	var data []T
+	if a, ok := n.Attrs["value_floats"]; ok {
+		for _, v := range a.Floats {
+			data = append(data, candy.FromFloat64[T](float64(v)))
+		}
+	} else if a, ok := n.Attrs["value_ints"]; ok {
+		for _, v := range a.Ints {
+			data = append(data, candy.FromFloat64[T](float64(v)))
+		}
+	} else {
+		return nil, errors.New("unsupported constant value")
+	}
+	return tensor.New(data, candy.NewShape(len(data)), candy.CPU)
+}
+
+// spatialPads resolves the begin and end padding of each spatial axis from
+// the pads or auto_pad attributes.
+func spatialPads(n *Node, in, kernel, strides, dilations []int) ([]int, []int, error) {
+	k := len(in)
+	begin, end := make([]int, k), make([]int, k)
+	switch autoPad := n.attrString("auto_pad", "NOTSET"); autoPad {
+	case "NOTSET":
+		if pads, ok := n.attrInts("pads"); ok {
+			if len(pads) != 2*k {
+				return nil, nil, fmt.Errorf("pads %v for %d spatial axes", pads, k)
+			}
+			for i := range k {
+				begin[i], end[i] = int(pads[i]), int(pads[k+i])
+			}
+		}
+	case "VALID":
+	case "SAME_UPPER", "SAME_LOWER":
+		for i := range k {
+			out := (in[i] + strides[i] - 1) / strides[i]
+			total := max((out-1)*strides[i]+(kernel[i]-1)*dilations[i]+1-in[i], 0)
+			begin[i], end[i] = total/2, total-total/2
+			if autoPad == "SAME_LOWER" {
+				begin[i], end[i] = end[i], begin[i]
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported auto_pad %s", autoPad)
+	}
+	return begin, end, nil
+}
+
+// attrAxes returns a positive per-spatial-axis attribute such as strides,
+// defaulting every axis to def.
+func attrAxes(n *Node, name string, k, def int) ([]int, error) {
+	v, ok := n.attrInts(name)
+	out := make([]int, k)
+	for i := range out {
+		out[i] = def
+		if ok {
+			if len(v) != k {
+				return nil, fmt.Errorf("%s %v for %d spatial axes", name, v, k)
+			}
+			if v[i] < 1 {
+				return nil, fmt.Errorf("%s %v must be positive", name, v)
+			}
+			out[i] = int(v[i])
+		}
+	}
+	return out, nil
+}
+
+// pad2d pads the last two axes of an NCHW tensor with value.
+func pad2d[T candy.D](x *tensor.Tensor[T], top, left, bottom, right int, value float64) (*tensor.Tensor[T], error) {
+	fill := func(dim, size int) (*tensor.Tensor[T], error) {
+		dims := x.Dims()
+		dims[dim] = size
+		return tensor.Full[T](value, candy.NewShapeFrom(dims), candy.CPU)
+	}
+	for _, p := range []struct{ dim, before, after int }{{2, top, bottom}, {3, left, right}} {
+		if p.before == 0 && p.after == 0 {
+			continue
+		}
+		var parts []*tensor.Tensor[T]
+		if p.before > 0 {
+			f, err := fill(p.dim, p.before)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, f)
+		}
+		parts = append(parts, x)
+		if p.after > 0 {
+			f, err := fill(p.dim, p.after)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, f)
+		}
+		var err error
+		if x, err = tensor.Cat(parts, p.dim); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func conv[T candy.D](n *Node, in []*tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	x, w := in[0], in[1]
+	if x.Rank() != 4 || w.Rank() != 4 {
+		return nil, fmt.Errorf("only 2D convolution is supported, got input %v and weight %v", x.Shape(), w.Shape())
+	}
+	kernel := []int{w.Dim(2), w.Dim(3)}
+	strides, err := attrAxes(n, "strides", 2, 1)
+	if err != nil {
+		return nil, err
+	}
+	dilations, err := attrAxes(n, "dilations", 2, 1)
+	if err != nil {
+		return nil, err
+	}
+	begin, end, err := spatialPads(n, []int{x.Dim(2), x.Dim(3)}, kernel, strides, dilations)
+	if err != nil {
+		return nil, err
+	}
+	padH, padW := begin[0], begin[1]
+	if end[0] != padH || end[1] != padW {
+		if x, err = pad2d(x, begin[0], begin[1], end[0], end[1], 0); err != nil {
+			return nil, err
+		}
+		padH, padW = 0, 0
+	}
+	group := int(n.attrInt("group", 1))
+	if group < 1 || x.Dim(1) != w.Dim(1)*group || w.Dim(0)%group != 0 {
+		return nil, fmt.Errorf("input %v and weight %v do not match group %d", x.Shape(), w.Shape(), group)
+	}
+	p := &candy.Conv2DParams{
+		Batch:   x.Dim(0),
+		InH:     x.Dim(2),
+		InW:     x.Dim(3),
+		KH:      kernel[0],
+		KW:      kernel[1],
+		OutCh:   w.Dim(0),
+		InCh:    x.Dim(1),
+		PadH:    padH,
+		PadW:    padW,
+		StrideH: strides[0],
+		StrideW: strides[1],
+		DilateH: dilations[0],
+		DilateW: dilations[1],
+		Groups:  group,
+	}
+	y, err := x.Conv2d(w, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(in) > 2 && in[2] != nil {
+		b, err := in[2].Reshape(1, w.Dim(0), 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return y.BroadcastAdd(b)
+	}
+	return y, nil
+}
+
+func pool[T candy.D](n *Node, x *tensor.Tensor[T], isMax bool) (*tensor.Tensor[T], error) {
+	if x.Rank() != 4 {
+		return nil, fmt.Errorf("only 2D pooling is supported, got input %v", x.Shape())
+	}
+	kernel, err := attrAxes(n, "kernel_shape", 2, 0)
+	if err != nil {
+		return nil, err
+	}
+	if kernel[0] <= 0 || kernel[1] <= 0 {
+		return nil, errors.New("kernel_shape is required")
+	}
+	strides, err := attrAxes(n, "strides", 2, 1)
+	if err != nil {
+		return nil, err
+	}
+	dilations, err := attrAxes(n, "dilations", 2, 1)
+	if err != nil {
+		return nil, err
+	}
+	if dilations[0] != 1 || dilations[1] != 1 {
+		return nil, fmt.Errorf("dilations %v are not supported", dilations)
+	}
+	in := []int{x.Dim(2), x.Dim(3)}
+	begin, end, err := spatialPads(n, in, kernel, strides, dilations)
+	if err != nil {
+		return nil, err
+	}
+	// ceil_mode keeps a final partial window by padding the end further.
+	extra := make([]int, 2)
+	if n.attrInt("ceil_mode", 0) != 0 {
+		for i := range 2 {
+			span := in[i] + begin[i] + end[i] - kernel[i]
+			out := (span+strides[i]-1)/strides[i] + 1
+			if (out-1)*strides[i] >= in[i]+begin[i] {
+				out--
+			}
+			extra[i] = max((out-1)*strides[i]+kernel[i]-(in[i]+begin[i]+end[i]), 0)
+		}
+	}
+	padded := begin[0]+begin[1]+end[0]+end[1]+extra[0]+extra[1] > 0
+	if isMax {
+		if padded {
+			if x, err = pad2d(x, begin[0], begin[1], end[0]+extra[0], end[1]+extra[1], math.Inf(-1)); err != nil {
+				return nil, err
+			}
+		}
+		return x.MaxPool2d(kernel[0], kernel[1], strides[0], strides[1])
+	}
+	if !padded {
+		return x.AvgPool2d(kernel[0], kernel[1], strides[0], strides[1])
+	}
+	// Average the padded sum by the number of counted cells per window: pads
+	// count only with count_include_pad, ceil_mode cells never do.
+	sum, err := pad2d(x, begin[0], begin[1], end[0]+extra[0], end[1]+extra[1], 0)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := tensor.Ones[T](x.Shape(), candy.CPU)
+	if err != nil {
+		return nil, err
+	}
+	if mask, err = pad2d(mask, begin[0], begin[1], end[0], end[1], float64(n.attrInt("count_include_pad", 0))); err != nil {
+		return nil, err
+	}
+	if mask, err = pad2d(mask, 0, 0, extra[0], extra[1], 0); err != nil {
+		return nil, err
+	}
+	if sum, err = sum.AvgPool2d(kernel[0], kernel[1], strides[0], strides[1]); err != nil {
+		return nil, err
+	}
+	if mask, err = mask.AvgPool2d(kernel[0], kernel[1], strides[0], strides[1]); err != nil {
+		return nil, err
+	}
+	return sum.Div(mask)
+}
+
+func globalAvgPool[T candy.D](x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	if x.Rank() < 3 {
+		return nil, fmt.Errorf("expected rank >= 3, got %v", x.Shape())
+	}
+	dims := make([]int, 0, x.Rank()-2)
+	for d := 2; d < x.Rank(); d++ {
+		dims = append(dims, d)
+	}
+	return x.ReduceMean(dims, true)
+}
+
+func batchNorm[T candy.D](n *Node, in []*tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	x := in[0]
+	if x.Rank() < 2 {
+		return nil, fmt.Errorf("expected rank >= 2, got %v", x.Shape())
+	}
+	ts := make([]int, x.Rank())
+	for i := range ts {
+		ts[i] = 1
+	}
+	ts[1] = x.Dim(1)
+	p := make([]*tensor.Tensor[T], 4)
+	for i := range p {
+		var err error
+		if p[i], err = in[i+1].Reshape(ts...); err != nil {
+			return nil, err
+		}
+	}
+	scale, bias, mean, variance := p[0], p[1], p[2], p[3]
+	den, err := variance.AddScalar(float64(n.attrFloat("epsilon", 1e-5)))
+	if err != nil {
+		return nil, err
+	}
+	if den, err = den.Sqrt(); err != nil {
+		return nil, err
+	}
+	y, err := x.BroadcastSub(mean)
+	if err != nil {
+		return nil, err
+	}
+	if y, err = y.BroadcastDiv(den); err != nil {
+		return nil, err
+	}
+	if y, err = y.BroadcastMul(scale); err != nil {
+		return nil, err
+	}
+	return y.BroadcastAdd(bias)
+}
+
+func gemm[T candy.D](n *Node, in []*tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	a, b := in[0], in[1]
+	var err error
+	if n.attrInt("transA", 0) != 0 {
+		if a, err = a.T(); err != nil {
+			return nil, err
+		}
+	}
+	if n.attrInt("transB", 0) != 0 {
+		if b, err = b.T(); err != nil {
+			return nil, err
+		}
+	}
+	y, err := a.MatMul(b)
+	if err != nil {
+		return nil, err
+	}
+	if alpha := n.attrFloat("alpha", 1); alpha != 1 {
+		if y, err = y.MulScalar(float64(alpha)); err != nil {
+			return nil, err
+		}
+	}
+	if len(in) < 3 || in[2] == nil {
+		return y, nil
+	}
+	c := in[2]
+	if beta := n.attrFloat("beta", 1); beta != 1 {
+		if c, err = c.MulScalar(float64(beta)); err != nil {
+			return nil, err
+		}
+	}
+	return y.BroadcastAdd(c)
+}
+
+// matmul follows numpy.matmul: 1-D operands are promoted to matrices and
+// batch dimensions broadcast.
+func matmul[T candy.D](a, b *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	var err error
+	va, vb := a.Rank() == 1, b.Rank() == 1
+	if va {
+		if a, err = a.Unsqueeze(0); err != nil {
+			return nil, err
+		}
+	}
+	if vb {
+		if b, err = b.Unsqueeze(1); err != nil {
+			return nil, err
+		}
+	}
+	sa, sb, err := a.Shape().BroadcastShapeMatmul(b.Shape())
+	if err != nil {
+		return nil, err
+	}
+	if !a.Shape().Equal(sa) {
+		if a, err = a.BroadcastAs(sa); err != nil {
+			return nil, err
+		}
+	}
+	if !b.Shape().Equal(sb) {
+		if b, err = b.BroadcastAs(sb); err != nil {
+			return nil, err
+		}
+	}
+	y, err := a.MatMul(b)
+	if err != nil {
+		return nil, err
+	}
+	if vb {
+		if y, err = y.Squeeze(-1); err != nil {
+			return nil, err
+		}
+	}
+	if va {
+		return y.Squeeze(-2)
+	}
+	return y, nil
+}
+
+func reshape[T candy.D](n *Node, in []*tensor.Tensor[T], iin []*tensor.Tensor[int64]) (*tensor.Tensor[T], error) {
+	x := in[0]
+	target := ints(in[1], iin[1])
+	allowZero := n.attrInt("allowzero", 0) != 0
+	dims := make([]int, len(target))
+	for i, d := range target {
+		switch {
+		case d == 0 && !allowZero:
+			if i >= x.Rank() {
+				return nil, fmt.Errorf("shape %v copies missing dimension %d of %v", target, i, x.Shape())
+			}
+			dims[i] = x.Dim(i)
+		case d < -1:
+			return nil, fmt.Errorf("invalid shape %v", target)
+		default:
+			dims[i] = int(d)
+		}
+	}
+	return reshapeTo(x, dims...)
+}
+
+// reshapeTo reshapes x, copying strided views first since reshapes need
+// contiguous data.
+func reshapeTo[T candy.D](x *tensor.Tensor[T], dims ...int) (*tensor.Tensor[T], error) {
+	x, err := x.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+	return x.Reshape(dims...)
+}
+
+func flatten[T candy.D](n *Node, x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	axis := int(n.attrInt("axis", 1))
+	if axis < 0 {
+		axis += x.Rank()
+	}
+	if axis < 0 || axis > x.Rank() {
+		return nil, fmt.Errorf("axis %d out of range for %v", axis, x.Shape())
+	}
+	outer := 1
+	for _, d := range x.Dims()[:axis] {
+		outer *= d
+	}
+	return reshapeTo(x, outer, x.Numel()/max(outer, 1))
+}
+
+// softmax normalizes along axis. Before opset 13 the input is coerced to 2D
+// at axis and normalized over the flattened trailing dimensions.
+func softmax[T candy.D](n *Node, x *tensor.Tensor[T], opset int64) (*tensor.Tensor[T], error) {
+	if opset < 13 {
+		axis, err := candy.ResolveAxis(int(n.attrInt("axis", 1)), x.Rank())
+		if err != nil {
+			return nil, err
+		}
+		outer := 1
+		for _, d := range x.Dims()[:axis] {
+			outer *= d
+		}
+		y, err := reshapeTo(x, outer, x.Numel()/max(outer, 1))
+		if err != nil {
+			return nil, err
+		}
+		if y, err = y.FastSoftmax(); err != nil {
+			return nil, err
+		}
+		return y.Reshape(x.Dims()...)
+	}
+	axis, err := candy.ResolveAxis(int(n.attrInt("axis", -1)), x.Rank())
+	if err != nil {
+		return nil, err
+	}
+	last := x.Rank() - 1
+	if axis == last {
+		return x.FastSoftmax()
+	}
+	y, err := x.Transpose(axis, last)
+	if err != nil {
+		return nil, err
+	}
+	if y, err = y.Contiguous(); err != nil {
+		return nil, err
+	}
+	if y, err = y.FastSoftmax(); err != nil {
+		return nil, err
+	}
+	if y, err = y.Transpose(axis, last); err != nil {
+		return nil, err
+	}
+	return y.Contiguous()
+}
+
+func transpose[T candy.D](n *Node, x *tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	perm := make([]int, x.Rank())
+	if p, ok := n.attrInts("perm"); ok {
+		if len(p) != len(perm) {
+			return nil, fmt.Errorf("perm %v for rank %d", p, x.Rank())
+		}
+		for i, v := range p {
+			perm[i] = int(v)
+		}
+	} else {
+		for i := range perm {
+			perm[i] = len(perm) - 1 - i
+		}
+	}
+	y, err := x.Permute(perm...)
+	if err != nil {
+		return nil, err
+	}
+	// Graph values are materialized so later nodes never see strided views.
+	return y.Contiguous()
+}
+
+func concat[T candy.D](n *Node, in []*tensor.Tensor[T]) (*tensor.Tensor[T], error) {
+	ts := slices.DeleteFunc(slices.Clone(in), func(t *tensor.Tensor[T]) bool { return t == nil })
+	if len(ts) == 0 {
+		return nil, errors.New("no inputs")
+	}
+	axis, err := candy.ResolveAxis(int(n.attrInt("axis", 0)), ts[0].Rank())
+	if err != nil {
+		return nil, err
+	}
+	return tensor.Cat(ts, axis)
+}
+
+// gather selects entries of x along axis; the indices' dimensions replace
+// that axis in the output.
+func gather[T candy.D](n *Node, x, indices *tensor.Tensor[T], exact *tensor.Tensor[int64]) (*tensor.Tensor[T], error) {
+	axis, err := candy.ResolveAxis(int(n.attrInt("axis", 0)), x.Rank())
+	if err != nil {
+		return nil, err
+	}
+	idx := ints(indices, exact)
+	size := int64(x.Dim(axis))
+	for i, v := range idx {
+		if v < 0 {
+			v += size
+		}
+		if v < 0 || v >= size {
+			return nil, fmt.Errorf("index %d out of range for axis of size %d", idx[i], size)
+		}
+		idx[i] = v
+	}
+	ids, err := tensor.New(idx, candy.NewShape(len(idx)), candy.CPU)
+	if err != nil {
+		return nil, err
+	}
+	y, err := tensor.IndexSelect(x, ids, axis)
+	if err != nil {
+		return nil, err
+	}
+	dims := slices.Concat(x.Dims()[:axis], indices.Dims(), x.Dims()[axis+1:])
+	return reshapeTo(y, dims...)
+}
+
+// shape returns the dims of a Shape node's input between its start and end.
+func shape[T candy.D](n *Node, dims []int) (*tensor.Tensor[T], error) {
+	clamp := func(v int64) int {
+		if v < 0 {
+			v += int64(len(dims))
+		}
+		return int(min(max(v, 0), int64(len(dims))))
+	}
+	start, end := clamp(n.attrInt("start", 0)), clamp(n.attrInt("end", int64(len(dims))))
+	data := []T{}
+	for i := start; i < end; i++ {
+		data = append(data, candy.FromFloat64[T](float64(dims[i])))
+	}
+	return tensor.New(data, candy.NewShape(len(data)), candy.CPU)
+}
+
+// axesOf reads the axes of Squeeze and Unsqueeze, an input since opset 13
+// and an attribute before.
+func axesOf[T candy.D](n *Node, in []*tensor.Tensor[T], iin []*tensor.Tensor[int64]) ([]int64, bool) {
+	if len(in) > 1 && in[1] != nil {
+		return ints(in[1], iin[1]), true
+	}
+	return n.attrInts("axes")
+}
+
+func unsqueeze[T candy.D](n *Node, in []*tensor.Tensor[T], iin []*tensor.Tensor[int64]) (*tensor.Tensor[T], error) {
+	axes, ok := axesOf(n, in, iin)
+	if !ok {
+		return nil, errors.New("axes are required")
+	}
+	rank := in[0].Rank() + len(axes)
+	norm := make([]int, len(axes))
+	for i, a := range axes {
+		d, err := candy.ResolveAxis(int(a), rank)
+		if err != nil {
+			return nil, err
+		}
+		norm[i] = d
+	}
+	slices.Sort(norm)
+	y := in[0]
+	for _, d := range norm {
+		var err error
+		if y, err = y.Unsqueeze(d); err != nil {
+			return nil, err
+		}
+	}
+	return y, nil
+}
+
+func squeeze[T candy.D](n *Node, in []*tensor.Tensor[T], iin []*tensor.Tensor[int64]) (*tensor.Tensor[T], error) {
+	x := in[0]
+	axes, ok := axesOf(n, in, iin)
+	var norm []int
+	if ok {
+		for _, a := range axes {
+			d, err := candy.ResolveAxis(int(a), x.Rank())
+			if err != nil {
+				return nil, err
+			}
+			norm = append(norm, d)
+		}
+	} else {
+		for d, size := range x.Dims() {
+			if size == 1 {
+				norm = append(norm, d)
+			}
+		}
+	}
+	slices.Sort(norm)
+	for i := len(norm) - 1; i >= 0; i-- {
+		var err error
+		if x, err = x.Squeeze(norm[i]); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
@@ -0,0 +1,605 @@
+package onnx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DataType is an ONNX TensorProto element type.
+type DataType int32
+
+const (
+	Undefined DataType = 0
+	Float     DataType = 1
+	Uint8     DataType = 2
+	Int8      DataType = 3
+	Uint16    DataType = 4
+	Int16     DataType = 5
+	Int32     DataType = 6
+	Int64     DataType = 7
+	String    DataType = 8
+	Bool      DataType = 9
+	Float16   DataType = 10
+	Double    DataType = 11
+	Uint32    DataType = 12
+	Uint64    DataType = 13
+	BFloat16  DataType = 16
+)
+
+// isInteger reports whether d is an integer element type.
+func (d DataType) isInteger() bool {
+	switch d {
+	case Uint8, Int8, Uint16, Int16, Int32, Int64, Uint32, Uint64:
+		return true
+	}
+	return false
+}
+
+func (d DataType) String() string {
+	switch d {
+	case Float:
+		return "float"
+	case Uint8:
+		return "uint8"
+	case Int8:
+		return "int8"
+	case Uint16:
+		return "uint16"
+	case Int16:
+		return "int16"
+	case Int32:
+		return "int32"
+	case Int64:
+		return "int64"
+	case String:
+		return "string"
+	case Bool:
+		return "bool"
+	case Float16:
+		return "float16"
+	case Double:
+		return "double"
+	case Uint32:
+		return "uint32"
+	case Uint64:
+		return "uint64"
+	case BFloat16:
+		return "bfloat16"
+	default:
+		return fmt.Sprintf("DataType(%d)", int32(d))
+	}
+}
+
+// AttributeType is an ONNX AttributeProto type.
+type AttributeType int32
+
+const (
+	AttrFloat   AttributeType = 1
+	AttrInt     AttributeType = 2
+	AttrString  AttributeType = 3
+	AttrTensor  AttributeType = 4
+	AttrFloats  AttributeType = 6
+	AttrInts    AttributeType = 7
+	AttrStrings AttributeType = 8
+)
+
+// TensorProto is a serialized tensor: an initializer or a Constant value.
+// Element data is kept in whichever field the producer used.
+type TensorProto struct {
+	Name       string
+	DataType   DataType
+	Dims       []int64
+	RawData    []byte
+	FloatData  []float32
+	Int32Data  []int32
+	Int64Data  []int64
+	DoubleData []float64
+	Uint64Data []uint64
+	External   bool
+}
+
+// Attribute is a named node attribute.
+type Attribute struct {
+	Name    string
+	Type    AttributeType
+	F       float32
+	I       int64
+	S       []byte
+	T       *TensorProto
+	Floats  []float32
+	Ints    []int64
+	Strings [][]byte
+}
+
+// Node is one operator invocation in a graph. Empty input names denote
+// omitted optional inputs.
+type Node struct {
+	Name    string
+	OpType  string
+	Domain  string
+	Inputs  []string
+	Outputs []string
+	Attrs   map[string]*Attribute
+}
+
+// ValueInfo describes a graph input or output. Unknown dimensions are -1,
+// with the symbolic name, if any, in DimParams.
+type ValueInfo struct {
+	Name      string
+	ElemType  DataType
+	Shape     []int64
+	DimParams []string
+}
+
+// Graph is a topologically sorted list of nodes with their initializers.
+type Graph struct {
+	Name         string
+	Nodes        []*Node
+	Initializers []*TensorProto
+	Inputs       []ValueInfo
+	Outputs      []ValueInfo
+}
+
+// OpsetID names the operator set version a model was exported against.
+type OpsetID struct {
+	Domain  string
+	Version int64
+}
+
+// ModelProto is the decoded top-level ONNX message.
+type ModelProto struct {
+	IRVersion       int64
+	ProducerName    string
+	ProducerVersion string
+	OpsetImports    []OpsetID
+	Graph           *Graph
+}
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// pbReader walks the fields of one protobuf message. Errors are sticky and
+// stop the walk.
+type pbReader struct {
+	b   []byte
+	err error
+}
+
+// next advances to the next field, returning its number and wire type.
+func (r *pbReader) next() (int, int, bool) {
+	if r.err != nil || len(r.b) == 0 {
+		return 0, 0, false
+	}
+	key := r.varint()
+	if r.err != nil {
+		return 0, 0, false
+	}
+	if key>>3 == 0 || key>>3 > math.MaxInt32 {
+		r.err = fmt.Errorf("invalid field number %d", key>>3)
+		return 0, 0, false
+	}
+	return int(key >> 3), int(key & 7), true
+}
+
+func (r *pbReader) varint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.fail(errors.New("malformed varint"))
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *pbReader) fixed32() uint32 {
+	if len(r.b) < 4 {
+		r.fail(errors.New("truncated fixed32"))
+		return 0
+	}
+	v := binary.LittleEndian.Uint32(r.b)
+	r.b = r.b[4:]
+	return v
+}
+
+func (r *pbReader) fixed64() uint64 {
+	if len(r.b) < 8 {
+		r.fail(errors.New("truncated fixed64"))
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(r.b)
+	r.b = r.b[8:]
+	return v
+}
+
+func (r *pbReader) bytes() []byte {
+	n := r.varint()
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.b)) {
+		r.fail(errors.New("truncated length-delimited field"))
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *pbReader) skip(wt int) {
+	switch wt {
+	case wireVarint:
+		r.varint()
+	case wireFixed64:
+		r.fixed64()
+	case wireBytes:
+		r.bytes()
+	case wireFixed32:
+		r.fixed32()
+	default:
+		r.fail(fmt.Errorf("unsupported wire type %d", wt))
+	}
+}
+
+func (r *pbReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// expect checks the wire type of a known field.
+func (r *pbReader) expect(wt, want int) bool {
+	if wt != want {
+		r.fail(fmt.Errorf("wire type %d, want %d", wt, want))
+		return false
+	}
+	return true
+}
+
+func (r *pbReader) str(wt int) string {
+	if !r.expect(wt, wireBytes) {
+		return ""
+	}
+	return string(r.bytes())
+}
+
+func (r *pbReader) int64(wt int) int64 {
+	if !r.expect(wt, wireVarint) {
+		return 0
+	}
+	return int64(r.varint())
+}
+
+// message decodes an embedded message with fn.
+func (r *pbReader) message(wt int, fn func(*pbReader)) {
+	if !r.expect(wt, wireBytes) {
+		return
+	}
+	sub := &pbReader{b: r.bytes()}
+	if r.err != nil {
+		return
+	}
+	fn(sub)
+	r.fail(sub.err)
+}
+
+// varints appends a repeated varint field in packed or unpacked encoding.
+func (r *pbReader) varints(wt int, dst []int64) []int64 {
+	if wt == wireVarint {
+		return append(dst, int64(r.varint()))
+	}
+	if !r.expect(wt, wireBytes) {
+		return dst
+	}
+	sub := &pbReader{b: r.bytes()}
+	for len(sub.b) > 0 && sub.err == nil {
+		dst = append(dst, int64(sub.varint()))
+	}
+	r.fail(sub.err)
+	return dst
+}
+
+// floats appends a repeated float field in packed or unpacked encoding.
+func (r *pbReader) floats(wt int, dst []float32) []float32 {
+	if wt == wireFixed32 {
+		return append(dst, math.Float32frombits(r.fixed32()))
+	}
+	if !r.expect(wt, wireBytes) {
+		return dst
+	}
+	b := r.bytes()
+	if len(b)%4 != 0 {
+		r.fail(errors.New("packed floats not a multiple of 4 bytes"))
+		return dst
+	}
+	for i := 0; i < len(b); i += 4 {
+		dst = append(dst, math.Float32frombits(binary.LittleEndian.Uint32(b[i:])))
+	}
+	return dst
+}
+
+// doubles appends a repeated double field in packed or unpacked encoding.
+func (r *pbReader) doubles(wt int, dst []float64) []float64 {
+	if wt == wireFixed64 {
+		return append(dst, math.Float64frombits(r.fixed64()))
+	}
+	if !r.expect(wt, wireBytes) {
+		return dst
+	}
+	b := r.bytes()
+	if len(b)%8 != 0 {
+		r.fail(errors.New("packed doubles not a multiple of 8 bytes"))
+		return dst
+	}
+	for i := 0; i < len(b); i += 8 {
+		dst = append(dst, math.Float64frombits(binary.LittleEndian.Uint64(b[i:])))
+	}
+	return dst
+}
+
+// DecodeModel decodes a serialized ModelProto.
+func DecodeModel(data []byte) (*ModelProto, error) {
+	m := &ModelProto{}
+	r := &pbReader{b: data}
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			break
+		}
+		switch f {
+		case 1:
+			m.IRVersion = r.int64(wt)
+		case 2:
+			m.ProducerName = r.str(wt)
+		case 3:
+			m.ProducerVersion = r.str(wt)
+		case 7:
+			r.message(wt, func(s *pbReader) { m.Graph = decodeGraph(s) })
+		case 8:
+			r.message(wt, func(s *pbReader) { m.OpsetImports = append(m.OpsetImports, decodeOpset(s)) })
+		default:
+			r.skip(wt)
+		}
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("onnx: decode model: %w", r.err)
+	}
+	if m.Graph == nil {
+		return nil, errors.New("onnx: model has no graph")
+	}
+	return m, nil
+}
+
+func decodeOpset(r *pbReader) OpsetID {
+	var o OpsetID
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			return o
+		}
+		switch f {
+		case 1:
+			o.Domain = r.str(wt)
+		case 2:
+			o.Version = r.int64(wt)
+		default:
+			r.skip(wt)
+		}
+	}
+}
+
+func decodeGraph(r *pbReader) *Graph {
+	g := &Graph{}
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			return g
+		}
+		switch f {
+		case 1:
+			r.message(wt, func(s *pbReader) { g.Nodes = append(g.Nodes, decodeNode(s)) })
+		case 2:
+			g.Name = r.str(wt)
+		case 5:
+			r.message(wt, func(s *pbReader) { g.Initializers = append(g.Initializers, decodeTensor(s)) })
+		case 11:
+			r.message(wt, func(s *pbReader) { g.Inputs = append(g.Inputs, decodeValueInfo(s)) })
+		case 12:
+			r.message(wt, func(s *pbReader) { g.Outputs = append(g.Outputs, decodeValueInfo(s)) })
+		default:
+			r.skip(wt)
+		}
+	}
+}
+
+func decodeNode(r *pbReader) *Node {
+	n := &Node{Attrs: map[string]*Attribute{}}
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			return n
+		}
+		switch f {
+		case 1:
+			n.Inputs = append(n.Inputs, r.str(wt))
+		case 2:
+			n.Outputs = append(n.Outputs, r.str(wt))
+		case 3:
+			n.Name = r.str(wt)
+		case 4:
+			n.OpType = r.str(wt)
+		case 5:
+			r.message(wt, func(s *pbReader) {
+				a := decodeAttribute(s)
+				n.Attrs[a.Name] = a
+			})
+		case 7:
+			n.Domain = r.str(wt)
+		default:
+			r.skip(wt)
+		}
+	}
+}
+
+func decodeAttribute(r *pbReader) *Attribute {
+	a := &Attribute{}
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			return a
+		}
+		switch f {
+		case 1:
+			a.Name = r.str(wt)
+		case 2:
+			if r.expect(wt, wireFixed32) {
+				a.F = math.Float32frombits(r.fixed32())
+			}
+		case 3:
+			a.I = r.int64(wt)
+		case 4:
+			if r.expect(wt, wireBytes) {
+				a.S = r.bytes()
+			}
+		case 5:
+			r.message(wt, func(s *pbReader) { a.T = decodeTensor(s) })
+		case 7:
+			a.Floats = r.floats(wt, a.Floats)
+		case 8:
+			a.Ints = r.varints(wt, a.Ints)
+		case 9:
+			if r.expect(wt, wireBytes) {
+				a.Strings = append(a.Strings, r.bytes())
+			}
+		case 20:
+			a.Type = AttributeType(r.int64(wt))
+		default:
+			r.skip(wt)
+		}
+	}
+}
+
+func decodeTensor(r *pbReader) *TensorProto {
+	t := &TensorProto{}
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			return t
+		}
+		switch f {
+		case 1:
+			t.Dims = r.varints(wt, t.Dims)
+		case 2:
+			t.DataType = DataType(r.int64(wt))
+		case 4:
+			t.FloatData = r.floats(wt, t.FloatData)
+		case 5:
+			for _, v := range r.varints(wt, nil) {
+				t.Int32Data = append(t.Int32Data, int32(v))
+			}
+		case 7:
+			t.Int64Data = r.varints(wt, t.Int64Data)
+		case 8:
+			t.Name = r.str(wt)
+		case 9:
+			if r.expect(wt, wireBytes) {
+				t.RawData = r.bytes()
+			}
+		case 10:
+			t.DoubleData = r.doubles(wt, t.DoubleData)
+		case 11:
+			for _, v := range r.varints(wt, nil) {
+				t.Uint64Data = append(t.Uint64Data, uint64(v))
+			}
+		case 14:
+			t.External = r.int64(wt) == 1
+		default:
+			r.skip(wt)
+		}
+	}
+}
+
+func decodeValueInfo(r *pbReader) ValueInfo {
+	var v ValueInfo
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			return v
+		}
+		switch f {
+		case 1:
+			v.Name = r.str(wt)
+		case 2:
+			// TypeProto.tensor_type
+			r.message(wt, func(tp *pbReader) {
+				for {
+					f, wt, ok := tp.next()
+					if !ok {
+						return
+					}
+					if f != 1 {
+						tp.skip(wt)
+						continue
+					}
+					tp.message(wt, func(tt *pbReader) { decodeTensorType(tt, &v) })
+				}
+			})
+		default:
+			r.skip(wt)
+		}
+	}
+}
+
+func decodeTensorType(r *pbReader, v *ValueInfo) {
+	for {
+		f, wt, ok := r.next()
+		if !ok {
+			return
+		}
+		switch f {
+		case 1:
+			v.ElemType = DataType(r.int64(wt))
+		case 2:
+			// TensorShapeProto.dim
+			r.message(wt, func(sp *pbReader) {
+				for {
+					f, wt, ok := sp.next()
+					if !ok {
+						return
+					}
+					if f != 1 {
+						sp.skip(wt)
+						continue
+					}
+					dim, param := int64(-1), ""
+					sp.message(wt, func(d *pbReader) {
+						for {
+							f, wt, ok := d.next()
+							if !ok {
+								return
+							}
+							switch f {
+							case 1:
+								dim = d.int64(wt)
+							case 2:
+								param = d.str(wt)
+							default:
+								d.skip(wt)
+							}
+						}
+					})
+					v.Shape = append(v.Shape, dim)
+					v.DimParams = append(v.DimParams, param)
+				}
+			})
+		default:
+			r.skip(wt)
+		}
+	}
+}
@@ -1 +1,290 @@
 package tensor_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+func TestNarrowBackward(t *testing.T) {
+	t.Parallel()
+	x, err := tensor.New([]float32{1, 2, 3, 4, 5, 6}, candy.NewShapeFrom([]int{2, 3}), candy.CPU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.SetIsVar(true)
+	y := x.MustNarrow(1, 1, 2)
+	loss := y.MustMul(y).MustSumAll()
+	grads, err := loss.Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	if want := []float32{0, 4, 6, 0, 10, 12}; !slices.Equal(grads.Get(x).Data(), want) {
+		t.Fatalf("grad: got %v want %v", grads.Get(x).Data(), want)
+	}
+}
+
+func TestSliceBackward(t *testing.T) {
+	t.Parallel()
+	x, err := tensor.Ones[float32](candy.NewShapeFrom([]int{3, 4}), candy.CPU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.SetIsVar(true)
+	y := x.MustSlice(tensor.Range{Start: 0, Stop: 3, Step: 2}, tensor.Range{Start: 1, Stop: 3})
+	grads, err := y.MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	want := []float32{0, 1, 1, 0, 0, 0, 0, 0, 0, 1, 1, 0}
+	if !slices.Equal(grads.Get(x).Data(), want) {
+		t.Fatalf("grad: got %v want %v", grads.Get(x).Data(), want)
+	}
+}
+
+func TestSliceBackwardLargeIndex(t *testing.T) {
+	t.Parallel()
+	// Strided slice gradients must not round-trip positions through T,
+	// which wraps at 256 for uint8.
+	x := tensor.MustOnes[uint8](candy.NewShape(2, 600), candy.CPU)
+	x.SetIsVar(true)
+	y := x.MustSlice(tensor.All, tensor.Range{Start: 250, Stop: 600, Step: 3})
+	grads, err := y.MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	for i, v := range grads.Get(x).Data() {
+		var want uint8
+		if c := i % 600; c >= 250 && (c-250)%3 == 0 {
+			want = 1
+		}
+		if v != want {
+			t.Fatalf("grad[%d] = %v, want %v", i, v, want)
+		}
+	}
+}
+
+func TestPermuteBackward(t *testing.T) {
+	t.Parallel()
+	x, err := tensor.New([]float32{1, 2, 3, 4, 5, 6}, candy.NewShapeFrom([]int{1, 2, 3}), candy.CPU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.SetIsVar(true)
+	w, err := tensor.New([]float32{1, 2, 3, 4, 5, 6}, candy.NewShapeFrom([]int{3, 1, 2}), candy.CPU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y := x.MustPermute(2, 0, 1).MustMul(w)
+	grads, err := y.MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	g := grads.Get(x)
+	if want := []int{1, 2, 3}; !slices.Equal(g.Dims(), want) {
+		t.Fatalf("grad dims: got %v want %v", g.Dims(), want)
+	}
+	if want := []float32{1, 3, 5, 2, 4, 6}; !slices.Equal(g.Data(), want) {
+		t.Fatalf("grad: got %v want %v", g.Data(), want)
+	}
+}
+
+func TestCatBackward(t *testing.T) {
+	t.Parallel()
+	a := tensor.MustNew([]float32{1, 2}, candy.NewShapeFrom([]int{2, 1}), candy.CPU).SetIsVar(true)
+	b := tensor.MustNew([]float32{3, 4, 5, 6}, candy.NewShapeFrom([]int{2, 2}), candy.CPU).SetIsVar(true)
+	c := tensor.MustCat([]*tensor.Tensor[float32]{a, b}, 1)
+	grads, err := c.MustMul(c).MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	if want := []float32{2, 4}; !slices.Equal(grads.Get(a).Data(), want) {
+		t.Fatalf("grad a: got %v want %v", grads.Get(a).Data(), want)
+	}
+	if want := []float32{6, 8, 10, 12}; !slices.Equal(grads.Get(b).Data(), want) {
+		t.Fatalf("grad b: got %v want %v", grads.Get(b).Data(), want)
+	}
+}
+
+func TestIndexSelectBackward(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustOnes[float32](candy.NewShapeFrom([]int{3, 2}), candy.CPU).SetIsVar(true)
+	ids := tensor.MustNew([]uint32{2, 0, 2}, candy.NewShapeFrom([]int{3}), candy.CPU)
+	grads, err := tensor.MustIndexSelect(x, ids, 0).MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	if want := []float32{1, 1, 0, 0, 2, 2}; !slices.Equal(grads.Get(x).Data(), want) {
+		t.Fatalf("grad: got %v want %v", grads.Get(x).Data(), want)
+	}
+}
+
+func TestTopKBackward(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustNew([]float32{3, 1, 2, 0, 5, 4}, candy.NewShapeFrom([]int{2, 3}), candy.CPU).SetIsVar(true)
+	vals, _ := x.MustTopK(2, 1)
+	grads, err := vals.MustMul(vals).MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	if want := []float32{6, 0, 4, 0, 10, 8}; !slices.Equal(grads.Get(x).Data(), want) {
+		t.Fatalf("grad: got %v want %v", grads.Get(x).Data(), want)
+	}
+}
+
+// checkGrad compares the analytic gradient of sum(f(xs)*w) against central differences for every input.
+func checkGrad(t *testing.T, f func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64], xs []*tensor.Tensor[float64]) {
+	t.Helper()
+	for _, x := range xs {
+		x.SetIsVar(true)
+	}
+	y := f(xs)
+	w := tensor.MustRandN[float64](0, 1, y.Shape(), candy.CPU)
+	loss := func() float64 { return f(xs).MustMul(w).MustSumAll().Data()[0] }
+	grads, err := y.MustMul(w).MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	const h = 1e-6
+	for i, x := range xs {
+		got := grads.Get(x).Data()
+		data := x.Data()
+		for j := range data {
+			v := data[j]
+			data[j] = v + h
+			lp := loss()
+			data[j] = v - h
+			lm := loss()
+			data[j] = v
+			if want := (lp - lm) / (2 * h); math.Abs(got[j]-want) > 1e-5*(1+math.Abs(want)) {
+				t.Fatalf("input %d grad[%d]: got %v want %v", i, j, got[j], want)
+			}
+		}
+	}
+}
+
+func TestRmsNormBackward(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustRandN[float64](0, 1, candy.NewShapeFrom([]int{2, 3, 4}), candy.CPU)
+	a := tensor.MustRandN[float64](1, 0.5, candy.NewShapeFrom([]int{4}), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		return xs[0].MustRmsNorm(xs[1], 1e-5)
+	}, []*tensor.Tensor[float64]{x, a})
+}
+
+func TestLayerNormBackward(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustRandN[float64](0, 1, candy.NewShapeFrom([]int{3, 5}), candy.CPU)
+	a := tensor.MustRandN[float64](1, 0.5, candy.NewShapeFrom([]int{5}), candy.CPU)
+	b := tensor.MustRandN[float64](0, 0.5, candy.NewShapeFrom([]int{5}), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		return xs[0].MustLayerNorm(xs[1], xs[2], 1e-5)
+	}, []*tensor.Tensor[float64]{x, a, b})
+}
+
+func TestRopeBackward(t *testing.T) {
+	t.Parallel()
+	variants := map[string]func(x, c, s *tensor.Tensor[float64]) *tensor.Tensor[float64]{
+		"ropeI":   (*tensor.Tensor[float64]).MustRopeI,
+		"rope":    (*tensor.Tensor[float64]).MustRope,
+		"ropeThd": (*tensor.Tensor[float64]).MustRopeThd,
+	}
+	for name, rope := range variants {
+		for _, cs := range [][]int{{3, 2}, {2, 3, 2}} {
+			x := tensor.MustRandN[float64](0, 1, candy.NewShapeFrom([]int{2, 3, 3, 4}), candy.CPU)
+			c := tensor.MustRandN[float64](0, 1, candy.NewShapeFrom(cs), candy.CPU)
+			s := tensor.MustRandN[float64](0, 1, candy.NewShapeFrom(cs), candy.CPU)
+			t.Run(name, func(t *testing.T) {
+				checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+					return rope(xs[0], xs[1], xs[2])
+				}, []*tensor.Tensor[float64]{x, c, s})
+			})
+		}
+	}
+}
+
+func TestConv2dBackward(t *testing.T) {
+	t.Parallel()
+	for name, p := range map[string]candy.Conv2DParams{
+		"uniform":   {Pad: 1, Stride: 2, Dilate: 1},
+		"per-axis":  {PadH: 1, StrideW: 2, StrideH: 1, DilateH: 2, DilateW: 1},
+		"groups":    {Pad: 1, Stride: 1, Dilate: 1, Groups: 2},
+		"reflect":   {Pad: 1, Stride: 1, Dilate: 1, PadMode: candy.PadReflect},
+		"replicate": {Pad: 2, Stride: 1, Dilate: 1, PadMode: candy.PadReplicate},
+		"circular":  {Pad: 1, Stride: 1, Dilate: 1, PadMode: candy.PadCircular},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p.Batch, p.InCh, p.OutCh, p.InH, p.InW, p.KH, p.KW = 2, 2, 4, 5, 4, 3, 2
+			x := tensor.MustRandN[float64](0, 1, candy.NewShape(2, 2, 4, 5), candy.CPU)
+			w := tensor.MustRandN[float64](0, 1, candy.NewShape(4, 2/p.NumGroups(), 3, 2), candy.CPU)
+			checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+				// The transpose feeds the kernel a strided NCHW view.
+				return xs[0].MustTranspose(2, 3).MustConv2d(xs[1], &p)
+			}, []*tensor.Tensor[float64]{x, w})
+		})
+	}
+}
+
+func TestConvStridedInputBackward(t *testing.T) {
+	t.Parallel()
+	// Every input is fed through a transpose so the kernels see strided views.
+	x1 := tensor.MustRandN[float64](0, 1, candy.NewShape(2, 6, 3), candy.CPU)
+	w1 := tensor.MustRandN[float64](0, 1, candy.NewShape(3, 4, 2), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		p := &candy.Conv1DParams{Batch: 2, InCh: 3, InLen: 6, OutCh: 4, KSize: 2, Pad: 1, Stride: 2, Dilate: 1}
+		return xs[0].MustTranspose(1, 2).MustConv1d(xs[1].MustTranspose(0, 1), p)
+	}, []*tensor.Tensor[float64]{x1, w1})
+
+	wt1 := tensor.MustRandN[float64](0, 1, candy.NewShape(2, 3, 3), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		p := &candy.ConvT1DParams{Batch: 2, InCh: 3, InLen: 6, OutCh: 2, KSize: 3, Pad: 1, OutPad: 1, Stride: 2, Dilate: 1}
+		return xs[0].MustTranspose(1, 2).MustConvTranspose1d(xs[1].MustTranspose(0, 1), p)
+	}, []*tensor.Tensor[float64]{x1, wt1})
+
+	x2 := tensor.MustRandN[float64](0, 1, candy.NewShape(2, 3, 4, 2), candy.CPU)
+	wt2 := tensor.MustRandN[float64](0, 1, candy.NewShape(2, 3, 2, 3), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		p := &candy.ConvT2DParams{Batch: 2, InCh: 2, InH: 4, InW: 3, OutCh: 3, KH: 3, KW: 2, Pad: 1, OutPad: 1, Stride: 2, Dilate: 1}
+		return xs[0].MustTranspose(1, 3).MustConvTranspose2d(xs[1].MustTranspose(2, 3), p)
+	}, []*tensor.Tensor[float64]{x2, wt2})
+}
+
+func TestConv3dBackward(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustRandN[float64](0, 1, candy.NewShape(2, 4, 3, 4, 3), candy.CPU)
+	w := tensor.MustRandN[float64](0, 1, candy.NewShape(6, 2, 2, 3, 2), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		p := &candy.Conv3DParams{Batch: 2, InCh: 4, InD: 3, InH: 4, InW: 3, OutCh: 6, KD: 2, KH: 3, KW: 2,
+			Pad: 1, StrideH: 2, Stride: 1, Dilate: 1, DilateW: 2, Groups: 2}
+		return xs[0].MustConv3d(xs[1], p)
+	}, []*tensor.Tensor[float64]{x, w})
+
+	// A (d, w) transposed input exercises the strided gather.
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		p := &candy.Conv3DParams{Batch: 2, InCh: 4, InD: 3, InH: 4, InW: 3, OutCh: 3, KD: 2, KH: 2, KW: 2, Pad: 1, Stride: 2, Dilate: 1}
+		return xs[0].MustTranspose(2, 4).MustConv3d(xs[1], p)
+	}, []*tensor.Tensor[float64]{x, tensor.MustRandN[float64](0, 1, candy.NewShape(3, 4, 2, 2, 2), candy.CPU)})
+
+	wt := tensor.MustRandN[float64](0, 1, candy.NewShape(4, 2, 3, 2, 2), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		p := &candy.ConvT3DParams{Batch: 2, InCh: 4, InD: 3, InH: 4, InW: 3, OutCh: 2, KD: 3, KH: 2, KW: 2, Pad: 1, OutPad: 1, Stride: 2, Dilate: 1}
+		return xs[0].MustConvTranspose3d(xs[1], p)
+	}, []*tensor.Tensor[float64]{x, wt})
+}
+
+func TestPool3dBackward(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustRandN[float64](0, 1, candy.NewShape(2, 2, 4, 5, 3), candy.CPU)
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		return xs[0].MustAvgPool3d(2, 3, 2, 1, 2, 1) // Overlapping windows
+	}, []*tensor.Tensor[float64]{x})
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		return xs[0].MustTranspose(3, 4).MustMaxPool3d(2, 2, 3, 2, 1, 2)
+	}, []*tensor.Tensor[float64]{x})
+	checkGrad(t, func(xs []*tensor.Tensor[float64]) *tensor.Tensor[float64] {
+		return xs[0].MustUpsampleNearest3d(8, 7, 6)
+	}, []*tensor.Tensor[float64]{x})
+}
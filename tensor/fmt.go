@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"strings"
+
+	"github.com/gocnn/candy"
 )
 
 // String returns a compact, PyTorch-style string representation of the tensor.
@@ -99,6 +101,10 @@ func (t *Tensor[T]) formatFloat(v T) string {
 		f = float64(x)
 	case float64:
 		f = x
+	case candy.Float16:
+		f = float64(x.Float32())
+	case candy.BFloat16:
+		f = float64(x.Float32())
 	default:
 		return fmt.Sprintf("%v", v)
 	}
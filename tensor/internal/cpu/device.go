@@ -15,6 +15,8 @@ var _ candy.BackendDevice[float64] = (*CpuDevice[float64])(nil)
 var _ candy.BackendDevice[uint8] = (*CpuDevice[uint8])(nil)
 var _ candy.BackendDevice[uint32] = (*CpuDevice[uint32])(nil)
 var _ candy.BackendDevice[int64] = (*CpuDevice[int64])(nil)
+var _ candy.BackendDevice[candy.Float16] = (*CpuDevice[candy.Float16])(nil)
+var _ candy.BackendDevice[candy.BFloat16] = (*CpuDevice[candy.BFloat16])(nil)
 
 // CpuDevice is a CPU-based implementation of the BackendDevice interface.
 type CpuDevice[T kernels.D] struct{}
@@ -37,7 +39,7 @@ func (c *CpuDevice[T]) IsSame(other candy.BackendDevice[T]) bool {
 
 // StorageFromSlice creates a CpuStorage from a slice of data.
 func (c *CpuDevice[T]) StorageFromSlice(data []T) (candy.BackendStorage[T], error) {
-	return &CpuStorage[T]{data: slices.Clone(data)}, nil
+	return New(slices.Clone(data)), nil
 }
 
 // StorageFromCpuStorage creates a copy of the given CpuStorage.
@@ -45,54 +47,21 @@ func (c *CpuDevice[T]) StorageFromCpuStorage(s *CpuStorage[T]) (candy.BackendSto
 	return s.Clone()
 }
 
-// SetSeed is unsupported for CPU RNG (no global seed in math/rand/v2).
+// SetSeed reseeds the generator shared by all CPU devices.
 func (c *CpuDevice[T]) SetSeed(seed uint64) error {
-	return errors.New("CPU RNG seeding unsupported")
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	rng = rand.New(rand.NewPCG(seed, 0))
+	return nil
 }
 
 // RandUniform generates a storage with uniformly distributed random values.
 func (c *CpuDevice[T]) RandUniform(shape *candy.Shape, dtype candy.DType, min, max float64) (candy.BackendStorage[T], error) {
 	storage := New(make([]T, shape.Numel()))
-	data := storage.data
-
-	switch dtype {
-	case candy.F32:
-		for i := range data {
-			data[i] = any(float32(min + rand.Float64()*(max-min))).(T)
-		}
-	case candy.F64:
-		for i := range data {
-			data[i] = any(min + rand.Float64()*(max-min)).(T)
-		}
-	case candy.U8:
-		minU8, maxU8 := uint8(min), uint8(max)
-		if min < 0 || max > math.MaxUint8 || max < min {
-			return nil, errors.New("invalid range for uint8")
-		}
-		rangeSize := uint32(maxU8 - minU8 + 1)
-		for i := range data {
-			data[i] = any(minU8 + uint8(rand.Uint32N(rangeSize))).(T)
-		}
-	case candy.U32:
-		minU32, maxU32 := uint32(min), uint32(max)
-		if min < 0 || max > math.MaxUint32 || max < min {
-			return nil, errors.New("invalid range for uint32")
-		}
-		rangeSize := maxU32 - minU32 + 1
-		for i := range data {
-			data[i] = any(minU32 + rand.Uint32N(rangeSize)).(T)
-		}
-	case candy.I64:
-		minI64, maxI64 := int64(min), int64(max)
-		if max < min {
-			return nil, errors.New("invalid range for int64")
-		}
-		rangeSize := maxI64 - minI64 + 1
-		for i := range data {
-			data[i] = any(minI64 + rand.Int64N(rangeSize)).(T)
-		}
-	default:
-		return nil, errors.New("unsupported dtype")
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	if err := FillUniform(rng, storage.data, min, max); err != nil {
+		return nil, err
 	}
 	return storage, nil
 }
@@ -100,52 +69,10 @@ func (c *CpuDevice[T]) RandUniform(shape *candy.Shape, dtype candy.DType, min, m
 // RandNormal generates a storage with normally distributed random values.
 func (c *CpuDevice[T]) RandNormal(shape *candy.Shape, dtype candy.DType, mean, std float64) (candy.BackendStorage[T], error) {
 	storage := New(make([]T, shape.Numel()))
-	data := storage.data
-
-	switch dtype {
-	case candy.F32:
-		for i := range data {
-			val := mean + std*rand.NormFloat64()
-			data[i] = any(float32(val)).(T)
-		}
-	case candy.F64:
-		for i := range data {
-			val := mean + std*rand.NormFloat64()
-			data[i] = any(val).(T)
-		}
-	case candy.U8:
-		for i := range data {
-			val := mean + std*rand.NormFloat64()
-			if val < 0 {
-				val = 0 // Clamp for unsigned.
-			} else if val > math.MaxUint8 {
-				val = math.MaxUint8
-			}
-			data[i] = any(uint8(val)).(T)
-		}
-	case candy.U32:
-		for i := range data {
-			val := mean + std*rand.NormFloat64()
-			if val < 0 {
-				val = 0 // Clamp for unsigned.
-			} else if val > math.MaxUint32 {
-				val = math.MaxUint32
-			}
-			data[i] = any(uint32(val)).(T)
-		}
-	case candy.I64:
-		for i := range data {
-			val := mean + std*rand.NormFloat64()
-			// Check for int64 overflow (rare but possible).
-			if val < math.MinInt64 {
-				val = math.MinInt64
-			} else if val > math.MaxInt64 {
-				val = math.MaxInt64
-			}
-			data[i] = any(int64(val)).(T)
-		}
-	default:
-		return nil, errors.New("unsupported dtype")
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	if err := FillNormal(rng, storage.data, mean, std); err != nil {
+		return nil, err
 	}
 	return storage, nil
 }
@@ -171,19 +98,65 @@ func (c *CpuDevice[T]) Full(shape *candy.Shape, dtype candy.DType, value float64
 	data := storage.data
 
 	for i := range data {
-		switch any(data[i]).(type) {
-		case float32:
-			data[i] = any(float32(value)).(T)
-		case float64:
-			data[i] = any(value).(T)
-		default:
-			data[i] = any(value).(T)
-		}
+		data[i] = candy.FromFloat64[T](value)
 	}
 
 	return storage, nil
 }
 
+// Arange creates a 1D storage of n values start, start+step, ...
+func (c *CpuDevice[T]) Arange(start, step float64, n int, dtype candy.DType) (candy.BackendStorage[T], error) {
+	if n < 0 {
+		return nil, errors.New("arange length must be non-negative")
+	}
+	storage := New(make([]T, n))
+	for i := range storage.data {
+		storage.data[i] = candy.FromFloat64[T](start + float64(i)*step)
+	}
+	return storage, nil
+}
+
+// Logspace creates a 1D storage of n values base^start, base^(start+step), ...
+func (c *CpuDevice[T]) Logspace(start, step float64, n int, base float64, dtype candy.DType) (candy.BackendStorage[T], error) {
+	if n < 0 {
+		return nil, errors.New("logspace length must be non-negative")
+	}
+	storage := New(make([]T, n))
+	for i := range storage.data {
+		storage.data[i] = candy.FromFloat64[T](math.Pow(base, start+float64(i)*step))
+	}
+	return storage, nil
+}
+
+// Eye creates an n x n identity matrix storage.
+func (c *CpuDevice[T]) Eye(n int, dtype candy.DType) (candy.BackendStorage[T], error) {
+	if n < 0 {
+		return nil, errors.New("eye size must be non-negative")
+	}
+	storage := New(make([]T, n*n))
+	for i := range n {
+		storage.data[i*n+i] = candy.FromFloat64[T](1)
+	}
+	return storage, nil
+}
+
+// Tri creates a rows x cols storage of ones on and below (lower) or on and above (upper)
+// the k-th diagonal, and zeros elsewhere.
+func (c *CpuDevice[T]) Tri(rows, cols, k int, lower bool, dtype candy.DType) (candy.BackendStorage[T], error) {
+	if rows < 0 || cols < 0 {
+		return nil, errors.New("tri dims must be non-negative")
+	}
+	storage := New(make([]T, rows*cols))
+	for i := range rows {
+		for j := range cols {
+			if (lower && j-i <= k) || (!lower && j-i >= k) {
+				storage.data[i*cols+j] = candy.FromFloat64[T](1)
+			}
+		}
+	}
+	return storage, nil
+}
+
 // Synchronize is a no-op for CPU operations.
 func (c *CpuDevice[T]) Synchronize() error {
 	return nil
@@ -0,0 +1,50 @@
+package cpu
+
+import (
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
+)
+
+var _ candy.BackendStorage[candy.Float16] = (*CpuStorage[candy.Float16])(nil)
+var _ candy.BackendStorage[candy.BFloat16] = (*CpuStorage[candy.BFloat16])(nil)
+
+// isHalf reports whether s holds F16 or BF16 values. Half-precision storages
+// compute through float32: operands are widened, the float32 op runs, and the
+// result is rounded back.
+func (s *CpuStorage[T]) isHalf() bool {
+	return s.dtype == candy.F16 || s.dtype == candy.BF16
+}
+
+// widen returns a float32 copy of a half-precision storage with identical
+// indexing, so the caller's layouts apply to it unchanged.
+func widen[T kernels.D](x candy.BackendStorage[T]) *CpuStorage[float32] {
+	out := make([]float32, len(x.Data()))
+	switch src := any(x.Data()).(type) {
+	case []candy.Float16:
+		kernels.CastF16F32(len(src), src, out)
+	case []candy.BFloat16:
+		kernels.CastBF16F32(len(src), src, out)
+	}
+	return New(out)
+}
+
+// narrow rounds the float32 result of a widened op back to T.
+func narrow[T kernels.D](r candy.BackendStorage[float32], err error) (candy.BackendStorage[T], error) {
+	if err != nil {
+		return nil, err
+	}
+	src := r.Data()
+	out := make([]T, len(src))
+	switch dst := any(out).(type) {
+	case []candy.Float16:
+		kernels.CastF32F16(len(src), src, dst)
+	case []candy.BFloat16:
+		kernels.CastF32BF16(len(src), src, dst)
+	}
+	return New(out), nil
+}
+
+// f32 converts a half-precision scalar to float32.
+func f32[T kernels.D](v T) float32 {
+	return float32(candy.ToFloat64(v))
+}
@@ -2,44 +2,56 @@ package kernels
 
 // BAdd performs y = x1 + x2 for any supported numeric type
 func BAdd[T D](numel int, x1, x2, y []T) {
-	for i := range numel {
-		y[i] = x1[i] + x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] + x2[i]
+		}
+	})
 }
 
 // BAddF32 performs y = x1 + x2 for float32
 func BAddF32(numel int, x1, x2, y []float32) {
-	for i := range numel {
-		y[i] = x1[i] + x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] + x2[i]
+		}
+	})
 }
 
 // BAddF64 performs y = x1 + x2 for float64
 func BAddF64(numel int, x1, x2, y []float64) {
-	for i := range numel {
-		y[i] = x1[i] + x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] + x2[i]
+		}
+	})
 }
 
 // BAddU8 performs y = x1 + x2 for uint8
 func BAddU8(numel int, x1, x2, y []uint8) {
-	for i := range numel {
-		y[i] = x1[i] + x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] + x2[i]
+		}
+	})
 }
 
 // BAddU32 performs y = x1 + x2 for uint32
 func BAddU32(numel int, x1, x2, y []uint32) {
-	for i := range numel {
-		y[i] = x1[i] + x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] + x2[i]
+		}
+	})
 }
 
 // BAddI64 performs y = x1 + x2 for int64
 func BAddI64(numel int, x1, x2, y []int64) {
-	for i := range numel {
-		y[i] = x1[i] + x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] + x2[i]
+		}
+	})
 }
 
 // BAddStrided performs y = x1 + x2 for any supported numeric type with strided memory
@@ -48,11 +60,13 @@ func BAddStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		BAdd(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
+		}
+	})
 }
 
 // BAddStridedF32 performs y = x1 + x2 for float32 with strided memory
@@ -61,11 +75,13 @@ func BAddStridedF32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BAddF32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
+		}
+	})
 }
 
 // BAddStridedF64 performs y = x1 + x2 for float64 with strided memory
@@ -74,11 +90,13 @@ func BAddStridedF64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BAddF64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
+		}
+	})
 }
 
 // BAddStridedU8 performs y = x1 + x2 for uint8 with strided memory
@@ -87,11 +105,13 @@ func BAddStridedU8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		BAddU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
+		}
+	})
 }
 
 // BAddStridedU32 performs y = x1 + x2 for uint32 with strided memory
@@ -100,11 +120,13 @@ func BAddStridedU32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BAddU32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
+		}
+	})
 }
 
 // BAddStridedI64 performs y = x1 + x2 for int64 with strided memory
@@ -113,53 +135,67 @@ func BAddStridedI64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BAddI64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] + x2[idx2]
+		}
+	})
 }
 
 // BSub performs y = x1 - x2 for any supported numeric type
 func BSub[T D](numel int, x1, x2, y []T) {
-	for i := range numel {
-		y[i] = x1[i] - x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] - x2[i]
+		}
+	})
 }
 
 // BSubF32 performs y = x1 - x2 for float32
 func BSubF32(numel int, x1, x2, y []float32) {
-	for i := range numel {
-		y[i] = x1[i] - x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] - x2[i]
+		}
+	})
 }
 
 // BSubF64 performs y = x1 - x2 for float64
 func BSubF64(numel int, x1, x2, y []float64) {
-	for i := range numel {
-		y[i] = x1[i] - x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] - x2[i]
+		}
+	})
 }
 
 // BSubU8 performs y = x1 - x2 for uint8
 func BSubU8(numel int, x1, x2, y []uint8) {
-	for i := range numel {
-		y[i] = x1[i] - x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] - x2[i]
+		}
+	})
 }
 
 // BSubU32 performs y = x1 - x2 for uint32
 func BSubU32(numel int, x1, x2, y []uint32) {
-	for i := range numel {
-		y[i] = x1[i] - x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] - x2[i]
+		}
+	})
 }
 
 // BSubI64 performs y = x1 - x2 for int64
 func BSubI64(numel int, x1, x2, y []int64) {
-	for i := range numel {
-		y[i] = x1[i] - x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] - x2[i]
+		}
+	})
 }
 
 // BSubStrided performs y = x1 - x2 for any supported numeric type with strided memory
@@ -168,11 +204,13 @@ func BSubStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		BSub(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
+		}
+	})
 }
 
 // BSubStridedF32 performs y = x1 - x2 for float32 with strided memory
@@ -181,11 +219,13 @@ func BSubStridedF32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BSubF32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
+		}
+	})
 }
 
 // BSubStridedF64 performs y = x1 - x2 for float64 with strided memory
@@ -194,11 +234,13 @@ func BSubStridedF64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BSubF64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
+		}
+	})
 }
 
 // BSubStridedU8 performs y = x1 - x2 for uint8 with strided memory
@@ -207,11 +249,13 @@ func BSubStridedU8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		BSubU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
+		}
+	})
 }
 
 // BSubStridedU32 performs y = x1 - x2 for uint32 with strided memory
@@ -220,11 +264,13 @@ func BSubStridedU32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BSubU32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
+		}
+	})
 }
 
 // BSubStridedI64 performs y = x1 - x2 for int64 with strided memory
@@ -233,53 +279,67 @@ func BSubStridedI64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BSubI64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] - x2[idx2]
+		}
+	})
 }
 
 // BMul performs y = x1 * x2 for any supported numeric type
 func BMul[T D](numel int, x1, x2, y []T) {
-	for i := range numel {
-		y[i] = x1[i] * x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] * x2[i]
+		}
+	})
 }
 
 // BMulF32 performs y = x1 * x2 for float32
 func BMulF32(numel int, x1, x2, y []float32) {
-	for i := range numel {
-		y[i] = x1[i] * x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] * x2[i]
+		}
+	})
 }
 
 // BMulF64 performs y = x1 * x2 for float64
 func BMulF64(numel int, x1, x2, y []float64) {
-	for i := range numel {
-		y[i] = x1[i] * x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] * x2[i]
+		}
+	})
 }
 
 // BMulU8 performs y = x1 * x2 for uint8
 func BMulU8(numel int, x1, x2, y []uint8) {
-	for i := range numel {
-		y[i] = x1[i] * x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] * x2[i]
+		}
+	})
 }
 
 // BMulU32 performs y = x1 * x2 for uint32
 func BMulU32(numel int, x1, x2, y []uint32) {
-	for i := range numel {
-		y[i] = x1[i] * x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] * x2[i]
+		}
+	})
 }
 
 // BMulI64 performs y = x1 * x2 for int64
 func BMulI64(numel int, x1, x2, y []int64) {
-	for i := range numel {
-		y[i] = x1[i] * x2[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x1[i] * x2[i]
+		}
+	})
 }
 
 // BMulStrided performs y = x1 * x2 for any supported numeric type with strided memory
@@ -288,11 +348,13 @@ func BMulStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		BMul(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
+		}
+	})
 }
 
 // BMulStridedF32 performs y = x1 * x2 for float32 with strided memory
@@ -301,11 +363,13 @@ func BMulStridedF32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BMulF32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
+		}
+	})
 }
 
 // BMulStridedF64 performs y = x1 * x2 for float64 with strided memory
@@ -314,11 +378,13 @@ func BMulStridedF64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BMulF64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
+		}
+	})
 }
 
 // BMulStridedU8 performs y = x1 * x2 for uint8 with strided memory
@@ -327,11 +393,13 @@ func BMulStridedU8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		BMulU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
+		}
+	})
 }
 
 // BMulStridedU32 performs y = x1 * x2 for uint32 with strided memory
@@ -340,11 +408,13 @@ func BMulStridedU32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BMulU32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
+		}
+	})
 }
 
 // BMulStridedI64 performs y = x1 * x2 for int64 with strided memory
@@ -353,77 +423,91 @@ func BMulStridedI64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BMulI64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] * x2[idx2]
+		}
+	})
 }
 
 // BDiv performs y = x1 / x2 for any supported numeric type
 func BDiv[T D](numel int, x1, x2, y []T) {
-	for i := range numel {
-		if x2[i] != 0 {
-			y[i] = x1[i] / x2[i]
-		} else {
-			y[i] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x2[i] != 0 {
+				y[i] = x1[i] / x2[i]
+			} else {
+				y[i] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivF32 performs y = x1 / x2 for float32
 func BDivF32(numel int, x1, x2, y []float32) {
-	for i := range numel {
-		if x2[i] != 0 {
-			y[i] = x1[i] / x2[i]
-		} else {
-			y[i] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x2[i] != 0 {
+				y[i] = x1[i] / x2[i]
+			} else {
+				y[i] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivF64 performs y = x1 / x2 for float64
 func BDivF64(numel int, x1, x2, y []float64) {
-	for i := range numel {
-		if x2[i] != 0 {
-			y[i] = x1[i] / x2[i]
-		} else {
-			y[i] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x2[i] != 0 {
+				y[i] = x1[i] / x2[i]
+			} else {
+				y[i] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivU8 performs y = x1 / x2 for uint8
 func BDivU8(numel int, x1, x2, y []uint8) {
-	for i := range numel {
-		if x2[i] != 0 {
-			y[i] = x1[i] / x2[i]
-		} else {
-			y[i] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x2[i] != 0 {
+				y[i] = x1[i] / x2[i]
+			} else {
+				y[i] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivU32 performs y = x1 / x2 for uint32
 func BDivU32(numel int, x1, x2, y []uint32) {
-	for i := range numel {
-		if x2[i] != 0 {
-			y[i] = x1[i] / x2[i]
-		} else {
-			y[i] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x2[i] != 0 {
+				y[i] = x1[i] / x2[i]
+			} else {
+				y[i] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivI64 performs y = x1 / x2 for int64
 func BDivI64(numel int, x1, x2, y []int64) {
-	for i := range numel {
-		if x2[i] != 0 {
-			y[i] = x1[i] / x2[i]
-		} else {
-			y[i] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x2[i] != 0 {
+				y[i] = x1[i] / x2[i]
+			} else {
+				y[i] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivStrided performs y = x1 / x2 for any supported numeric type with strided memory
@@ -432,15 +516,17 @@ func BDivStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		BDiv(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x2[idx2] != 0 {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x2[idx2] != 0 {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivStridedF32 performs y = x1 / x2 for float32 with strided memory
@@ -449,15 +535,17 @@ func BDivStridedF32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BDivF32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x2[idx2] != 0 {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x2[idx2] != 0 {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivStridedF64 performs y = x1 / x2 for float64 with strided memory
@@ -466,15 +554,17 @@ func BDivStridedF64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BDivF64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x2[idx2] != 0 {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x2[idx2] != 0 {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivStridedU8 performs y = x1 / x2 for uint8 with strided memory
@@ -483,15 +573,17 @@ func BDivStridedU8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		BDivU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x2[idx2] != 0 {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x2[idx2] != 0 {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivStridedU32 performs y = x1 / x2 for uint32 with strided memory
@@ -500,15 +592,17 @@ func BDivStridedU32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BDivU32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x2[idx2] != 0 {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x2[idx2] != 0 {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BDivStridedI64 performs y = x1 / x2 for int64 with strided memory
@@ -517,57 +611,71 @@ func BDivStridedI64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		BDivI64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x2[idx2] != 0 {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x2[idx2] != 0 {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = x1[idx1] / x2[idx2]
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0 // Handle division by zero
+			}
 		}
-	}
+	})
 }
 
 // BMax performs y = max(x1, x2) for any supported numeric type
 func BMaximum[T D](numel int, x1, x2, y []T) {
-	for i := range numel {
-		y[i] = max(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = max(x1[i], x2[i])
+		}
+	})
 }
 
 // BMaximumF32 performs y = max(x1, x2) for float32
 func BMaximumF32(numel int, x1, x2, y []float32) {
-	for i := range numel {
-		y[i] = max(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = max(x1[i], x2[i])
+		}
+	})
 }
 
 // BMaximumF64 performs y = max(x1, x2) for float64
 func BMaximumF64(numel int, x1, x2, y []float64) {
-	for i := range numel {
-		y[i] = max(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = max(x1[i], x2[i])
+		}
+	})
 }
 
 // BMaximumU8 performs y = max(x1, x2) for uint8
 func BMaximumU8(numel int, x1, x2, y []uint8) {
-	for i := range numel {
-		y[i] = max(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = max(x1[i], x2[i])
+		}
+	})
 }
 
 // BMaximumU32 performs y = max(x1, x2) for uint32
 func BMaximumU32(numel int, x1, x2, y []uint32) {
-	for i := range numel {
-		y[i] = max(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = max(x1[i], x2[i])
+		}
+	})
 }
 
 // BMaximumI64 performs y = max(x1, x2) for int64
 func BMaximumI64(numel int, x1, x2, y []int64) {
-	for i := range numel {
-		y[i] = max(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = max(x1[i], x2[i])
+		}
+	})
 }
 
 // BMaxStrided performs y = max(x1, x2) for any supported numeric type with strided memory
@@ -576,11 +684,13 @@ func BMaximumStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY
 		BMaximum(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMaximumStridedF32 performs y = max(x1, x2) for float32 with strided memory
@@ -589,11 +699,13 @@ func BMaximumStridedF32(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMaximumF32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMaximumStridedF64 performs y = max(x1, x2) for float64 with strided memory
@@ -602,11 +714,13 @@ func BMaximumStridedF64(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMaximumF64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMaximumStridedU8 performs y = max(x1, x2) for uint8 with strided memory
@@ -615,11 +729,13 @@ func BMaximumStridedU8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []
 		BMaximumU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMaximumStridedU32 performs y = max(x1, x2) for uint32 with strided memory
@@ -628,11 +744,13 @@ func BMaximumStridedU32(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMaximumU32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMaximumStridedI64 performs y = max(x1, x2) for int64 with strided memory
@@ -641,53 +759,67 @@ func BMaximumStridedI64(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMaximumI64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = max(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMin performs y = min(x1, x2) for any supported numeric type
 func BMinimum[T D](numel int, x1, x2, y []T) {
-	for i := range numel {
-		y[i] = min(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = min(x1[i], x2[i])
+		}
+	})
 }
 
 // BMinimumF32 performs y = min(x1, x2) for float32
 func BMinimumF32(numel int, x1, x2, y []float32) {
-	for i := range numel {
-		y[i] = min(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = min(x1[i], x2[i])
+		}
+	})
 }
 
 // BMinimumF64 performs y = min(x1, x2) for float64
 func BMinimumF64(numel int, x1, x2, y []float64) {
-	for i := range numel {
-		y[i] = min(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = min(x1[i], x2[i])
+		}
+	})
 }
 
 // BMinimumU8 performs y = min(x1, x2) for uint8
 func BMinimumU8(numel int, x1, x2, y []uint8) {
-	for i := range numel {
-		y[i] = min(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = min(x1[i], x2[i])
+		}
+	})
 }
 
 // BMinimumU32 performs y = min(x1, x2) for uint32
 func BMinimumU32(numel int, x1, x2, y []uint32) {
-	for i := range numel {
-		y[i] = min(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = min(x1[i], x2[i])
+		}
+	})
 }
 
 // BMinimumI64 performs y = min(x1, x2) for int64
 func BMinimumI64(numel int, x1, x2, y []int64) {
-	for i := range numel {
-		y[i] = min(x1[i], x2[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = min(x1[i], x2[i])
+		}
+	})
 }
 
 // BMinStrided performs y = min(x1, x2) for any supported numeric type with strided memory
@@ -696,11 +828,13 @@ func BMinimumStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY
 		BMinimum(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMinimumStridedF32 performs y = min(x1, x2) for float32 with strided memory
@@ -709,11 +843,13 @@ func BMinimumStridedF32(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMinimumF32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMinimumStridedF64 performs y = min(x1, x2) for float64 with strided memory
@@ -722,11 +858,13 @@ func BMinimumStridedF64(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMinimumF64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMinimumStridedU8 performs y = min(x1, x2) for uint8 with strided memory
@@ -735,11 +873,13 @@ func BMinimumStridedU8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []
 		BMinimumU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMinimumStridedU32 performs y = min(x1, x2) for uint32 with strided memory
@@ -748,11 +888,13 @@ func BMinimumStridedU32(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMinimumU32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // BMinimumStridedI64 performs y = min(x1, x2) for int64 with strided memory
@@ -761,132 +903,156 @@ func BMinimumStridedI64(numel, ndims int, dims, stridesX1, stridesX2, stridesY [
 		BMinimumI64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = min(x1[idx1], x2[idx2])
+		}
+	})
 }
 
 // Eq performs y = (x1 == x2) ? 1 : 0 for any supported numeric type
 func Eq[T D](numel int, x1, x2 []T, y []T) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqF32F32 performs y = (x1 == x2) ? 1 : 0 for float32
 func EqF32F32(numel int, x1, x2 []float32, y []float32) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqF64F64 performs y = (x1 == x2) ? 1 : 0 for float64
 func EqF64F64(numel int, x1, x2 []float64, y []float64) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqU32U32 performs y = (x1 == x2) ? 1 : 0 for uint32
 func EqU32U32(numel int, x1, x2 []uint32, y []uint32) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqI64I64 performs y = (x1 == x2) ? 1 : 0 for int64
 func EqI64I64(numel int, x1, x2 []int64, y []int64) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqU8 performs y = (x1 == x2) ? 1 : 0 for any supported numeric type with uint8 output
 func EqU8[T D](numel int, x1, x2 []T, y []uint8) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqU8F32 performs y = (x1 == x2) ? 1 : 0 for float32
 func EqU8F32(numel int, x1, x2 []float32, y []uint8) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqU8F64 performs y = (x1 == x2) ? 1 : 0 for float64
 func EqU8F64(numel int, x1, x2 []float64, y []uint8) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqU8U8 performs y = (x1 == x2) ? 1 : 0 for uint8
 func EqU8U8(numel int, x1, x2 []uint8, y []uint8) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqU8U32 performs y = (x1 == x2) ? 1 : 0 for uint32
 func EqU8U32(numel int, x1, x2 []uint32, y []uint8) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqU8I64 performs y = (x1 == x2) ? 1 : 0 for int64
 func EqU8I64(numel int, x1, x2 []int64, y []uint8) {
-	for i := range numel {
-		if x1[i] == x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] == x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStrided performs y = (x1 == x2) ? 1 : 0 for any supported numeric type with strided memory
@@ -895,15 +1061,17 @@ func EqStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		Eq(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedF32F32 performs y = (x1 == x2) ? 1 : 0 for float32 with strided memory
@@ -912,15 +1080,17 @@ func EqStridedF32F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		EqF32F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedF64F64 performs y = (x1 == x2) ? 1 : 0 for float64 with strided memory
@@ -929,15 +1099,17 @@ func EqStridedF64F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		EqF64F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedU32U32 performs y = (x1 == x2) ? 1 : 0 for uint32 with strided memory
@@ -946,15 +1118,17 @@ func EqStridedU32U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		EqU32U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedI64I64 performs y = (x1 == x2) ? 1 : 0 for int64 with strided memory
@@ -963,15 +1137,17 @@ func EqStridedI64I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		EqI64I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedU8 performs y = (x1 == x2) ? 1 : 0 for any supported numeric type with uint8 output with strided memory
@@ -980,15 +1156,17 @@ func EqStridedU8[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		EqU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedU8F32 performs y = (x1 == x2) ? 1 : 0 for float32 with strided memory
@@ -997,15 +1175,17 @@ func EqStridedU8F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		EqU8F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedU8F64 performs y = (x1 == x2) ? 1 : 0 for float64 with strided memory
@@ -1014,15 +1194,17 @@ func EqStridedU8F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		EqU8F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedU8U8 performs y = (x1 == x2) ? 1 : 0 for uint8 with strided memory
@@ -1031,15 +1213,17 @@ func EqStridedU8U8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		EqU8U8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedU8U32 performs y = (x1 == x2) ? 1 : 0 for uint32 with strided memory
@@ -1048,15 +1232,17 @@ func EqStridedU8U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		EqU8U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // EqStridedU8I64 performs y = (x1 == x2) ? 1 : 0 for int64 with strided memory
@@ -1065,136 +1251,160 @@ func EqStridedU8I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		EqU8I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] == x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] == x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // Ne performs y = (x1 != x2) ? 1 : 0 for any supported numeric type with same-type output
 func Ne[T D](numel int, x1, x2 []T, y []T) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeF32F32 performs y = (x1 != x2) ? 1 : 0 for float32 with float32 output
 func NeF32F32(numel int, x1, x2 []float32, y []float32) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeF64F64 performs y = (x1 != x2) ? 1 : 0 for float64 with float64 output
 func NeF64F64(numel int, x1, x2 []float64, y []float64) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeU32U32 performs y = (x1 != x2) ? 1 : 0 for uint32 with uint32 output
 func NeU32U32(numel int, x1, x2 []uint32, y []uint32) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeI64I64 performs y = (x1 != x2) ? 1 : 0 for int64 with int64 output
 func NeI64I64(numel int, x1, x2 []int64, y []int64) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeU8 performs y = (x1 != x2) ? 1 : 0 for any supported numeric type with uint8 output
 func NeU8[T D](numel int, x1, x2 []T, y []uint8) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeU8F32 performs y = (x1 != x2) ? 1 : 0 for float32 with uint8 output
 func NeU8F32(numel int, x1, x2 []float32, y []uint8) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeU8F64 performs y = (x1 != x2) ? 1 : 0 for float64 with uint8 output
 func NeU8F64(numel int, x1, x2 []float64, y []uint8) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeU8U8 performs y = (x1 != x2) ? 1 : 0 for uint8 with uint8 output
 func NeU8U8(numel int, x1, x2 []uint8, y []uint8) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeU8U32 performs y = (x1 != x2) ? 1 : 0 for uint32 with uint8 output
 func NeU8U32(numel int, x1, x2 []uint32, y []uint8) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeU8I64 performs y = (x1 != x2) ? 1 : 0 for int64 with uint8 output
 func NeU8I64(numel int, x1, x2 []int64, y []uint8) {
-	for i := range numel {
-		if x1[i] != x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] != x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStrided performs y = (x1 != x2) ? 1 : 0 for any supported numeric type with strided memory and same-type output
@@ -1203,15 +1413,17 @@ func NeStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		Ne(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedF32F32 performs y = (x1 != x2) ? 1 : 0 for float32 with strided memory and float32 output
@@ -1220,15 +1432,17 @@ func NeStridedF32F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		NeF32F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedF64F64 performs y = (x1 != x2) ? 1 : 0 for float64 with strided memory and float64 output
@@ -1237,15 +1451,17 @@ func NeStridedF64F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		NeF64F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedU32U32 performs y = (x1 != x2) ? 1 : 0 for uint32 with strided memory and uint32 output
@@ -1254,15 +1470,17 @@ func NeStridedU32U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		NeU32U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedI64I64 performs y = (x1 != x2) ? 1 : 0 for int64 with strided memory and int64 output
@@ -1271,15 +1489,17 @@ func NeStridedI64I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		NeI64I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedU8 performs y = (x1 != x2) ? 1 : 0 for any supported numeric type with strided memory and uint8 output
@@ -1288,15 +1508,17 @@ func NeStridedU8[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		NeU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedU8F32 performs y = (x1 != x2) ? 1 : 0 for float32 with strided memory and uint8 output
@@ -1305,15 +1527,17 @@ func NeStridedU8F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		NeU8F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedU8F64 performs y = (x1 != x2) ? 1 : 0 for float64 with strided memory and uint8 output
@@ -1322,15 +1546,17 @@ func NeStridedU8F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		NeU8F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedU8U8 performs y = (x1 != x2) ? 1 : 0 for uint8 with strided memory and uint8 output
@@ -1339,15 +1565,17 @@ func NeStridedU8U8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		NeU8U8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedU8U32 performs y = (x1 != x2) ? 1 : 0 for uint32 with strided memory and uint8 output
@@ -1356,15 +1584,17 @@ func NeStridedU8U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		NeU8U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // NeStridedU8I64 performs y = (x1 != x2) ? 1 : 0 for int64 with strided memory and uint8 output
@@ -1373,136 +1603,160 @@ func NeStridedU8I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		NeU8I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] != x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] != x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // Lt performs y = (x1 < x2) ? 1 : 0 for any supported numeric type with same-type output
 func Lt[T D](numel int, x1, x2 []T, y []T) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtF32F32 performs y = (x1 < x2) ? 1 : 0 for float32 with float32 output
 func LtF32F32(numel int, x1, x2 []float32, y []float32) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtF64F64 performs y = (x1 < x2) ? 1 : 0 for float64 with float64 output
 func LtF64F64(numel int, x1, x2 []float64, y []float64) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtU32U32 performs y = (x1 < x2) ? 1 : 0 for uint32 with uint32 output
 func LtU32U32(numel int, x1, x2 []uint32, y []uint32) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtI64I64 performs y = (x1 < x2) ? 1 : 0 for int64 with int64 output
 func LtI64I64(numel int, x1, x2 []int64, y []int64) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtU8 performs y = (x1 < x2) ? 1 : 0 for any supported numeric type with uint8 output
 func LtU8[T D](numel int, x1, x2 []T, y []uint8) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtU8F32 performs y = (x1 < x2) ? 1 : 0 for float32 with uint8 output
 func LtU8F32(numel int, x1, x2 []float32, y []uint8) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtU8F64 performs y = (x1 < x2) ? 1 : 0 for float64 with uint8 output
 func LtU8F64(numel int, x1, x2 []float64, y []uint8) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtU8U8 performs y = (x1 < x2) ? 1 : 0 for uint8 with uint8 output
 func LtU8U8(numel int, x1, x2 []uint8, y []uint8) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtU8U32 performs y = (x1 < x2) ? 1 : 0 for uint32 with uint8 output
 func LtU8U32(numel int, x1, x2 []uint32, y []uint8) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtU8I64 performs y = (x1 < x2) ? 1 : 0 for int64 with uint8 output
 func LtU8I64(numel int, x1, x2 []int64, y []uint8) {
-	for i := range numel {
-		if x1[i] < x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] < x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStrided performs y = (x1 < x2) ? 1 : 0 for any supported numeric type with strided memory and same-type output
@@ -1511,15 +1765,17 @@ func LtStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		Lt(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedF32F32 performs y = (x1 < x2) ? 1 : 0 for float32 with strided memory and float32 output
@@ -1528,15 +1784,17 @@ func LtStridedF32F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LtF32F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedF64F64 performs y = (x1 < x2) ? 1 : 0 for float64 with strided memory and float64 output
@@ -1545,15 +1803,17 @@ func LtStridedF64F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LtF64F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedU32U32 performs y = (x1 < x2) ? 1 : 0 for uint32 with strided memory and uint32 output
@@ -1562,15 +1822,17 @@ func LtStridedU32U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LtU32U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedI64I64 performs y = (x1 < x2) ? 1 : 0 for int64 with strided memory and int64 output
@@ -1579,15 +1841,17 @@ func LtStridedI64I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LtI64I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedU8 performs y = (x1 < x2) ? 1 : 0 for any supported numeric type with strided memory and uint8 output
@@ -1596,15 +1860,17 @@ func LtStridedU8[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		LtU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedU8F32 performs y = (x1 < x2) ? 1 : 0 for float32 with strided memory and uint8 output
@@ -1613,15 +1879,17 @@ func LtStridedU8F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LtU8F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedU8F64 performs y = (x1 < x2) ? 1 : 0 for float64 with strided memory and uint8 output
@@ -1630,15 +1898,17 @@ func LtStridedU8F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LtU8F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedU8U8 performs y = (x1 < x2) ? 1 : 0 for uint8 with strided memory and uint8 output
@@ -1647,15 +1917,17 @@ func LtStridedU8U8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		LtU8U8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedU8U32 performs y = (x1 < x2) ? 1 : 0 for uint32 with strided memory and uint8 output
@@ -1664,15 +1936,17 @@ func LtStridedU8U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LtU8U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LtStridedU8I64 performs y = (x1 < x2) ? 1 : 0 for int64 with strided memory and uint8 output
@@ -1681,136 +1955,160 @@ func LtStridedU8I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LtU8I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] < x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] < x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // Le performs y = (x1 <= x2) ? 1 : 0 for any supported numeric type with same-type output
 func Le[T D](numel int, x1, x2 []T, y []T) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeF32F32 performs y = (x1 <= x2) ? 1 : 0 for float32 with float32 output
 func LeF32F32(numel int, x1, x2 []float32, y []float32) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeF64F64 performs y = (x1 <= x2) ? 1 : 0 for float64 with float64 output
 func LeF64F64(numel int, x1, x2 []float64, y []float64) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeU32U32 performs y = (x1 <= x2) ? 1 : 0 for uint32 with uint32 output
 func LeU32U32(numel int, x1, x2 []uint32, y []uint32) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeI64I64 performs y = (x1 <= x2) ? 1 : 0 for int64 with int64 output
 func LeI64I64(numel int, x1, x2 []int64, y []int64) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeU8 performs y = (x1 <= x2) ? 1 : 0 for any supported numeric type with uint8 output
 func LeU8[T D](numel int, x1, x2 []T, y []uint8) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeU8F32 performs y = (x1 <= x2) ? 1 : 0 for float32 with uint8 output
 func LeU8F32(numel int, x1, x2 []float32, y []uint8) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeU8F64 performs y = (x1 <= x2) ? 1 : 0 for float64 with uint8 output
 func LeU8F64(numel int, x1, x2 []float64, y []uint8) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeU8U8 performs y = (x1 <= x2) ? 1 : 0 for uint8 with uint8 output
 func LeU8U8(numel int, x1, x2 []uint8, y []uint8) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeU8U32 performs y = (x1 <= x2) ? 1 : 0 for uint32 with uint8 output
 func LeU8U32(numel int, x1, x2 []uint32, y []uint8) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeU8I64 performs y = (x1 <= x2) ? 1 : 0 for int64 with uint8 output
 func LeU8I64(numel int, x1, x2 []int64, y []uint8) {
-	for i := range numel {
-		if x1[i] <= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] <= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStrided performs y = (x1 <= x2) ? 1 : 0 for any supported numeric type with strided memory and same-type output
@@ -1819,15 +2117,17 @@ func LeStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		Le(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedF32F32 performs y = (x1 <= x2) ? 1 : 0 for float32 with strided memory and float32 output
@@ -1836,15 +2136,17 @@ func LeStridedF32F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LeF32F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedF64F64 performs y = (x1 <= x2) ? 1 : 0 for float64 with strided memory and float64 output
@@ -1853,15 +2155,17 @@ func LeStridedF64F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LeF64F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedU32U32 performs y = (x1 <= x2) ? 1 : 0 for uint32 with strided memory and uint32 output
@@ -1870,15 +2174,17 @@ func LeStridedU32U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LeU32U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedI64I64 performs y = (x1 <= x2) ? 1 : 0 for int64 with strided memory and int64 output
@@ -1887,15 +2193,17 @@ func LeStridedI64I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		LeI64I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedU8 performs y = (x1 <= x2) ? 1 : 0 for any supported numeric type with strided memory and uint8 output
@@ -1904,15 +2212,17 @@ func LeStridedU8[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		LeU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedU8F32 performs y = (x1 <= x2) ? 1 : 0 for float32 with strided memory and uint8 output
@@ -1921,15 +2231,17 @@ func LeStridedU8F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LeU8F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedU8F64 performs y = (x1 <= x2) ? 1 : 0 for float64 with strided memory and uint8 output
@@ -1938,15 +2250,17 @@ func LeStridedU8F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LeU8F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedU8U8 performs y = (x1 <= x2) ? 1 : 0 for uint8 with strided memory and uint8 output
@@ -1955,15 +2269,17 @@ func LeStridedU8U8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		LeU8U8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedU8U32 performs y = (x1 <= x2) ? 1 : 0 for uint32 with strided memory and uint8 output
@@ -1972,15 +2288,17 @@ func LeStridedU8U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LeU8U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // LeStridedU8I64 performs y = (x1 <= x2) ? 1 : 0 for int64 with strided memory and uint8 output
@@ -1989,136 +2307,160 @@ func LeStridedU8I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		LeU8I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] <= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] <= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // Gt performs y = (x1 > x2) ? 1 : 0 for any supported numeric type with same-type output
 func Gt[T D](numel int, x1, x2 []T, y []T) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtF32F32 performs y = (x1 > x2) ? 1 : 0 for float32 with float32 output
 func GtF32F32(numel int, x1, x2 []float32, y []float32) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtF64F64 performs y = (x1 > x2) ? 1 : 0 for float64 with float64 output
 func GtF64F64(numel int, x1, x2 []float64, y []float64) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtU32U32 performs y = (x1 > x2) ? 1 : 0 for uint32 with uint32 output
 func GtU32U32(numel int, x1, x2 []uint32, y []uint32) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtI64I64 performs y = (x1 > x2) ? 1 : 0 for int64 with int64 output
 func GtI64I64(numel int, x1, x2 []int64, y []int64) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtU8 performs y = (x1 > x2) ? 1 : 0 for any supported numeric type with uint8 output
 func GtU8[T D](numel int, x1, x2 []T, y []uint8) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtU8F32 performs y = (x1 > x2) ? 1 : 0 for float32 with uint8 output
 func GtU8F32(numel int, x1, x2 []float32, y []uint8) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtU8F64 performs y = (x1 > x2) ? 1 : 0 for float64 with uint8 output
 func GtU8F64(numel int, x1, x2 []float64, y []uint8) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtU8U8 performs y = (x1 > x2) ? 1 : 0 for uint8 with uint8 output
 func GtU8U8(numel int, x1, x2 []uint8, y []uint8) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtU8U32 performs y = (x1 > x2) ? 1 : 0 for uint32 with uint8 output
 func GtU8U32(numel int, x1, x2 []uint32, y []uint8) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtU8I64 performs y = (x1 > x2) ? 1 : 0 for int64 with uint8 output
 func GtU8I64(numel int, x1, x2 []int64, y []uint8) {
-	for i := range numel {
-		if x1[i] > x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] > x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStrided performs y = (x1 > x2) ? 1 : 0 for any supported numeric type with strided memory and same-type output
@@ -2127,15 +2469,17 @@ func GtStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		Gt(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedF32F32 performs y = (x1 > x2) ? 1 : 0 for float32 with strided memory and float32 output
@@ -2144,15 +2488,17 @@ func GtStridedF32F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GtF32F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedF64F64 performs y = (x1 > x2) ? 1 : 0 for float64 with strided memory and float64 output
@@ -2161,15 +2507,17 @@ func GtStridedF64F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GtF64F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedU32U32 performs y = (x1 > x2) ? 1 : 0 for uint32 with strided memory and uint32 output
@@ -2178,15 +2526,17 @@ func GtStridedU32U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GtU32U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedI64I64 performs y = (x1 > x2) ? 1 : 0 for int64 with strided memory and int64 output
@@ -2195,15 +2545,17 @@ func GtStridedI64I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GtI64I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedU8 performs y = (x1 > x2) ? 1 : 0 for any supported numeric type with strided memory and uint8 output
@@ -2212,15 +2564,17 @@ func GtStridedU8[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		GtU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedU8F32 performs y = (x1 > x2) ? 1 : 0 for float32 with strided memory and uint8 output
@@ -2229,15 +2583,17 @@ func GtStridedU8F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GtU8F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedU8F64 performs y = (x1 > x2) ? 1 : 0 for float64 with strided memory and uint8 output
@@ -2246,15 +2602,17 @@ func GtStridedU8F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GtU8F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedU8U8 performs y = (x1 > x2) ? 1 : 0 for uint8 with strided memory and uint8 output
@@ -2263,15 +2621,17 @@ func GtStridedU8U8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		GtU8U8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedU8U32 performs y = (x1 > x2) ? 1 : 0 for uint32 with strided memory and uint8 output
@@ -2280,15 +2640,17 @@ func GtStridedU8U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GtU8U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GtStridedU8I64 performs y = (x1 > x2) ? 1 : 0 for int64 with strided memory and uint8 output
@@ -2297,136 +2659,160 @@ func GtStridedU8I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GtU8I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] > x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] > x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // Ge performs y = (x1 >= x2) ? 1 : 0 for any supported numeric type with same-type output
 func Ge[T D](numel int, x1, x2 []T, y []T) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeF32F32 performs y = (x1 >= x2) ? 1 : 0 for float32 with float32 output
 func GeF32F32(numel int, x1, x2 []float32, y []float32) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeF64F64 performs y = (x1 >= x2) ? 1 : 0 for float64 with float64 output
 func GeF64F64(numel int, x1, x2 []float64, y []float64) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeU32U32 performs y = (x1 >= x2) ? 1 : 0 for uint32 with uint32 output
 func GeU32U32(numel int, x1, x2 []uint32, y []uint32) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeI64I64 performs y = (x1 >= x2) ? 1 : 0 for int64 with int64 output
 func GeI64I64(numel int, x1, x2 []int64, y []int64) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeU8 performs y = (x1 >= x2) ? 1 : 0 for any supported numeric type with uint8 output
 func GeU8[T D](numel int, x1, x2 []T, y []uint8) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeU8F32 performs y = (x1 >= x2) ? 1 : 0 for float32 with uint8 output
 func GeU8F32(numel int, x1, x2 []float32, y []uint8) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeU8F64 performs y = (x1 >= x2) ? 1 : 0 for float64 with uint8 output
 func GeU8F64(numel int, x1, x2 []float64, y []uint8) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeU8U8 performs y = (x1 >= x2) ? 1 : 0 for uint8 with uint8 output
 func GeU8U8(numel int, x1, x2 []uint8, y []uint8) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeU8U32 performs y = (x1 >= x2) ? 1 : 0 for uint32 with uint8 output
 func GeU8U32(numel int, x1, x2 []uint32, y []uint8) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeU8I64 performs y = (x1 >= x2) ? 1 : 0 for int64 with uint8 output
 func GeU8I64(numel int, x1, x2 []int64, y []uint8) {
-	for i := range numel {
-		if x1[i] >= x2[i] {
-			y[i] = 1
-		} else {
-			y[i] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			if x1[i] >= x2[i] {
+				y[i] = 1
+			} else {
+				y[i] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStrided performs y = (x1 >= x2) ? 1 : 0 for any supported numeric type with strided memory and same-type output
@@ -2435,15 +2821,17 @@ func GeStrided[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		Ge(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedF32F32 performs y = (x1 >= x2) ? 1 : 0 for float32 with strided memory and float32 output
@@ -2452,15 +2840,17 @@ func GeStridedF32F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GeF32F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedF64F64 performs y = (x1 >= x2) ? 1 : 0 for float64 with strided memory and float64 output
@@ -2469,15 +2859,17 @@ func GeStridedF64F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GeF64F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedU32U32 performs y = (x1 >= x2) ? 1 : 0 for uint32 with strided memory and uint32 output
@@ -2486,15 +2878,17 @@ func GeStridedU32U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GeU32U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedI64I64 performs y = (x1 >= x2) ? 1 : 0 for int64 with strided memory and int64 output
@@ -2503,15 +2897,17 @@ func GeStridedI64I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []in
 		GeI64I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedU8 performs y = (x1 >= x2) ? 1 : 0 for any supported numeric type with strided memory and uint8 output
@@ -2520,15 +2916,17 @@ func GeStridedU8[T D](numel, ndims int, dims, stridesX1, stridesX2, stridesY []i
 		GeU8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedU8F32 performs y = (x1 >= x2) ? 1 : 0 for float32 with strided memory and uint8 output
@@ -2537,15 +2935,17 @@ func GeStridedU8F32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GeU8F32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedU8F64 performs y = (x1 >= x2) ? 1 : 0 for float64 with strided memory and uint8 output
@@ -2554,15 +2954,17 @@ func GeStridedU8F64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GeU8F64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedU8U8 performs y = (x1 >= x2) ? 1 : 0 for uint8 with strided memory and uint8 output
@@ -2571,15 +2973,17 @@ func GeStridedU8U8(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int,
 		GeU8U8(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedU8U32 performs y = (x1 >= x2) ? 1 : 0 for uint32 with strided memory and uint8 output
@@ -2588,15 +2992,17 @@ func GeStridedU8U32(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GeU8U32(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
 
 // GeStridedU8I64 performs y = (x1 >= x2) ? 1 : 0 for int64 with strided memory and uint8 output
@@ -2605,13 +3011,15 @@ func GeStridedU8I64(numel, ndims int, dims, stridesX1, stridesX2, stridesY []int
 		GeU8I64(numel, x1, x2, y)
 		return
 	}
-	for i := range numel {
-		idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
-		idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
-		if x1[idx1] >= x2[idx2] {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
-		} else {
-			y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			idx1 := GetStridedIndex(i, ndims, dims, stridesX1)
+			idx2 := GetStridedIndex(i, ndims, dims, stridesX2)
+			if x1[idx1] >= x2[idx2] {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 1
+			} else {
+				y[GetStridedIndex(i, ndims, dims, stridesY)] = 0
+			}
 		}
-	}
+	})
 }
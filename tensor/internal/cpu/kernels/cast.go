@@ -1,38 +1,50 @@
 package kernels
 
+import "github.com/gocnn/candy"
+
 // CastF32F32 converts float32 to float32
 func CastF32F32(numel int, x []float32, y []float32) {
-	for i := range numel {
-		y[i] = x[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x[i]
+		}
+	})
 }
 
 // CastF32F64 converts float32 to float64
 func CastF32F64(numel int, x []float32, y []float64) {
-	for i := range numel {
-		y[i] = float64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float64(x[i])
+		}
+	})
 }
 
 // CastF32U8 converts float32 to uint8
 func CastF32U8(numel int, x []float32, y []uint8) {
-	for i := range numel {
-		y[i] = uint8(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint8(x[i])
+		}
+	})
 }
 
 // CastF32U32 converts float32 to uint32
 func CastF32U32(numel int, x []float32, y []uint32) {
-	for i := range numel {
-		y[i] = uint32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint32(x[i])
+		}
+	})
 }
 
 // CastF32I64 converts float32 to int64
 func CastF32I64(numel int, x []float32, y []int64) {
-	for i := range numel {
-		y[i] = int64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = int64(x[i])
+		}
+	})
 }
 
 // CastStridedF32F32 converts float32 to float32 with strided memory
@@ -41,9 +53,11 @@ func CastStridedF32F32(numel, ndims int, dims, stridesX, stridesY []int, x, y []
 		CastF32F32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
+		}
+	})
 }
 
 // CastStridedF32F64 converts float32 to float64 with strided memory
@@ -52,9 +66,11 @@ func CastStridedF32F64(numel, ndims int, dims, stridesX, stridesY []int, x []flo
 		CastF32F64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedF32U8 converts float32 to uint8 with strided memory
@@ -63,9 +79,11 @@ func CastStridedF32U8(numel, ndims int, dims, stridesX, stridesY []int, x []floa
 		CastF32U8(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedF32U32 converts float32 to uint32 with strided memory
@@ -74,9 +92,11 @@ func CastStridedF32U32(numel, ndims int, dims, stridesX, stridesY []int, x []flo
 		CastF32U32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedF32I64 converts float32 to int64 with strided memory
@@ -85,44 +105,56 @@ func CastStridedF32I64(numel, ndims int, dims, stridesX, stridesY []int, x []flo
 		CastF32I64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastF64F32 converts float64 to float32
 func CastF64F32(numel int, x []float64, y []float32) {
-	for i := range numel {
-		y[i] = float32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float32(x[i])
+		}
+	})
 }
 
 // CastF64F64 converts float64 to float64
 func CastF64F64(numel int, x []float64, y []float64) {
-	for i := range numel {
-		y[i] = x[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x[i]
+		}
+	})
 }
 
 // CastF64U8 converts float64 to uint8
 func CastF64U8(numel int, x []float64, y []uint8) {
-	for i := range numel {
-		y[i] = uint8(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint8(x[i])
+		}
+	})
 }
 
 // CastF64U32 converts float64 to uint32
 func CastF64U32(numel int, x []float64, y []uint32) {
-	for i := range numel {
-		y[i] = uint32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint32(x[i])
+		}
+	})
 }
 
 // CastF64I64 converts float64 to int64
 func CastF64I64(numel int, x []float64, y []int64) {
-	for i := range numel {
-		y[i] = int64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = int64(x[i])
+		}
+	})
 }
 
 // CastStridedF64F32 converts float64 to float32 with strided memory
@@ -131,9 +163,11 @@ func CastStridedF64F32(numel, ndims int, dims, stridesX, stridesY []int, x []flo
 		CastF64F32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedF64F64 converts float64 to float64 with strided memory
@@ -142,9 +176,11 @@ func CastStridedF64F64(numel, ndims int, dims, stridesX, stridesY []int, x, y []
 		CastF64F64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
+		}
+	})
 }
 
 // CastStridedF64U8 converts float64 to uint8 with strided memory
@@ -153,9 +189,11 @@ func CastStridedF64U8(numel, ndims int, dims, stridesX, stridesY []int, x []floa
 		CastF64U8(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedF64U32 converts float64 to uint32 with strided memory
@@ -164,9 +202,11 @@ func CastStridedF64U32(numel, ndims int, dims, stridesX, stridesY []int, x []flo
 		CastF64U32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedF64I64 converts float64 to int64 with strided memory
@@ -175,44 +215,56 @@ func CastStridedF64I64(numel, ndims int, dims, stridesX, stridesY []int, x []flo
 		CastF64I64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastU8F32 converts uint8 to float32
 func CastU8F32(numel int, x []uint8, y []float32) {
-	for i := range numel {
-		y[i] = float32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float32(x[i])
+		}
+	})
 }
 
 // CastU8F64 converts uint8 to float64
 func CastU8F64(numel int, x []uint8, y []float64) {
-	for i := range numel {
-		y[i] = float64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float64(x[i])
+		}
+	})
 }
 
 // CastU8U8 converts uint8 to uint8
 func CastU8U8(numel int, x []uint8, y []uint8) {
-	for i := range numel {
-		y[i] = x[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x[i]
+		}
+	})
 }
 
 // CastU8U32 converts uint8 to uint32
 func CastU8U32(numel int, x []uint8, y []uint32) {
-	for i := range numel {
-		y[i] = uint32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint32(x[i])
+		}
+	})
 }
 
 // CastU8I64 converts uint8 to int64
 func CastU8I64(numel int, x []uint8, y []int64) {
-	for i := range numel {
-		y[i] = int64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = int64(x[i])
+		}
+	})
 }
 
 // CastStridedU8F32 converts uint8 to float32 with strided memory
@@ -221,9 +273,11 @@ func CastStridedU8F32(numel, ndims int, dims, stridesX, stridesY []int, x []uint
 		CastU8F32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedU8F64 converts uint8 to float64 with strided memory
@@ -232,9 +286,11 @@ func CastStridedU8F64(numel, ndims int, dims, stridesX, stridesY []int, x []uint
 		CastU8F64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedU8U8 converts uint8 to uint8 with strided memory
@@ -243,9 +299,11 @@ func CastStridedU8U8(numel, ndims int, dims, stridesX, stridesY []int, x []uint8
 		CastU8U8(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
+		}
+	})
 }
 
 // CastStridedU8U32 converts uint8 to uint32 with strided memory
@@ -254,9 +312,11 @@ func CastStridedU8U32(numel, ndims int, dims, stridesX, stridesY []int, x []uint
 		CastU8U32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedU8I64 converts uint8 to int64 with strided memory
@@ -265,44 +325,56 @@ func CastStridedU8I64(numel, ndims int, dims, stridesX, stridesY []int, x []uint
 		CastU8I64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastU32F32 converts uint32 to float32
 func CastU32F32(numel int, x []uint32, y []float32) {
-	for i := range numel {
-		y[i] = float32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float32(x[i])
+		}
+	})
 }
 
 // CastU32F64 converts uint32 to float64
 func CastU32F64(numel int, x []uint32, y []float64) {
-	for i := range numel {
-		y[i] = float64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float64(x[i])
+		}
+	})
 }
 
 // CastU32U8 converts uint32 to uint8
 func CastU32U8(numel int, x []uint32, y []uint8) {
-	for i := range numel {
-		y[i] = uint8(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint8(x[i])
+		}
+	})
 }
 
 // CastU32U32 converts uint32 to uint32
 func CastU32U32(numel int, x []uint32, y []uint32) {
-	for i := range numel {
-		y[i] = x[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x[i]
+		}
+	})
 }
 
 // CastU32I64 converts uint32 to int64
 func CastU32I64(numel int, x []uint32, y []int64) {
-	for i := range numel {
-		y[i] = int64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = int64(x[i])
+		}
+	})
 }
 
 // CastStridedU32F32 converts uint32 to float32 with strided memory
@@ -311,9 +383,11 @@ func CastStridedU32F32(numel, ndims int, dims, stridesX, stridesY []int, x []uin
 		CastU32F32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedU32F64 converts uint32 to float64 with strided memory
@@ -322,9 +396,11 @@ func CastStridedU32F64(numel, ndims int, dims, stridesX, stridesY []int, x []uin
 		CastU32F64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedU32U8 converts uint32 to uint8 with strided memory
@@ -333,9 +409,11 @@ func CastStridedU32U8(numel, ndims int, dims, stridesX, stridesY []int, x []uint
 		CastU32U8(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedU32U32 converts uint32 to uint32 with strided memory
@@ -344,9 +422,11 @@ func CastStridedU32U32(numel, ndims int, dims, stridesX, stridesY []int, x []uin
 		CastU32U32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
+		}
+	})
 }
 
 // CastStridedU32I64 converts uint32 to int64 with strided memory
@@ -355,44 +435,56 @@ func CastStridedU32I64(numel, ndims int, dims, stridesX, stridesY []int, x []uin
 		CastU32I64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = int64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastI64F32 converts int64 to float32
 func CastI64F32(numel int, x []int64, y []float32) {
-	for i := range numel {
-		y[i] = float32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float32(x[i])
+		}
+	})
 }
 
 // CastI64F64 converts int64 to float64
 func CastI64F64(numel int, x []int64, y []float64) {
-	for i := range numel {
-		y[i] = float64(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = float64(x[i])
+		}
+	})
 }
 
 // CastI64U8 converts int64 to uint8
 func CastI64U8(numel int, x []int64, y []uint8) {
-	for i := range numel {
-		y[i] = uint8(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint8(x[i])
+		}
+	})
 }
 
 // CastI64U32 converts int64 to uint32
 func CastI64U32(numel int, x []int64, y []uint32) {
-	for i := range numel {
-		y[i] = uint32(x[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = uint32(x[i])
+		}
+	})
 }
 
 // CastI64I64 converts int64 to int64
 func CastI64I64(numel int, x []int64, y []int64) {
-	for i := range numel {
-		y[i] = x[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x[i]
+		}
+	})
 }
 
 // CastStridedI64F32 converts int64 to float32 with strided memory
@@ -401,9 +493,11 @@ func CastStridedI64F32(numel, ndims int, dims, stridesX, stridesY []int, x []int
 		CastI64F32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedI64F64 converts int64 to float64 with strided memory
@@ -412,9 +506,11 @@ func CastStridedI64F64(numel, ndims int, dims, stridesX, stridesY []int, x []int
 		CastI64F64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = float64(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedI64U8 converts int64 to uint8 with strided memory
@@ -423,9 +519,11 @@ func CastStridedI64U8(numel, ndims int, dims, stridesX, stridesY []int, x []int6
 		CastI64U8(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint8(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedI64U32 converts int64 to uint32 with strided memory
@@ -434,9 +532,11 @@ func CastStridedI64U32(numel, ndims int, dims, stridesX, stridesY []int, x []int
 		CastI64U32(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = uint32(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
 
 // CastStridedI64I64 converts int64 to int64 with strided memory
@@ -445,7 +545,97 @@ func CastStridedI64I64(numel, ndims int, dims, stridesX, stridesY []int, x []int
 		CastI64I64(numel, x, y)
 		return
 	}
-	for i := range numel {
-		y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)]
+		}
+	})
+}
+
+// CastF16F32 converts float16 to float32
+func CastF16F32(numel int, x []candy.Float16, y []float32) {
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x[i].Float32()
+		}
+	})
+}
+
+// CastStridedF16F32 converts float16 to float32 with strided memory
+func CastStridedF16F32(numel, ndims int, dims, stridesX, stridesY []int, x []candy.Float16, y []float32) {
+	if IsContiguous(ndims, dims, stridesX) && IsContiguous(ndims, dims, stridesY) {
+		CastF16F32(numel, x, y)
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)].Float32()
+		}
+	})
+}
+
+// CastF32F16 converts float32 to float16
+func CastF32F16(numel int, x []float32, y []candy.Float16) {
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = candy.NewFloat16(x[i])
+		}
+	})
+}
+
+// CastStridedF32F16 converts float32 to float16 with strided memory
+func CastStridedF32F16(numel, ndims int, dims, stridesX, stridesY []int, x []float32, y []candy.Float16) {
+	if IsContiguous(ndims, dims, stridesX) && IsContiguous(ndims, dims, stridesY) {
+		CastF32F16(numel, x, y)
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = candy.NewFloat16(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
+}
+
+// CastBF16F32 converts bfloat16 to float32
+func CastBF16F32(numel int, x []candy.BFloat16, y []float32) {
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = x[i].Float32()
+		}
+	})
+}
+
+// CastStridedBF16F32 converts bfloat16 to float32 with strided memory
+func CastStridedBF16F32(numel, ndims int, dims, stridesX, stridesY []int, x []candy.BFloat16, y []float32) {
+	if IsContiguous(ndims, dims, stridesX) && IsContiguous(ndims, dims, stridesY) {
+		CastBF16F32(numel, x, y)
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = x[GetStridedIndex(i, ndims, dims, stridesX)].Float32()
+		}
+	})
+}
+
+// CastF32BF16 converts float32 to bfloat16
+func CastF32BF16(numel int, x []float32, y []candy.BFloat16) {
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[i] = candy.NewBFloat16(x[i])
+		}
+	})
+}
+
+// CastStridedF32BF16 converts float32 to bfloat16 with strided memory
+func CastStridedF32BF16(numel, ndims int, dims, stridesX, stridesY []int, x []float32, y []candy.BFloat16) {
+	if IsContiguous(ndims, dims, stridesX) && IsContiguous(ndims, dims, stridesY) {
+		CastF32BF16(numel, x, y)
+		return
 	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			y[GetStridedIndex(i, ndims, dims, stridesY)] = candy.NewBFloat16(x[GetStridedIndex(i, ndims, dims, stridesX)])
+		}
+	})
 }
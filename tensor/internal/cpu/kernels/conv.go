@@ -3,6 +3,7 @@ package kernels
 import (
 	"math"
 
+	"github.com/gocnn/candy"
 	"github.com/gocnn/gomat/blas"
 	"github.com/gocnn/gomat/blas/blas32"
 	"github.com/gocnn/gomat/blas/blas64"
@@ -40,201 +41,312 @@ func MatMulBatchedF64(batch, m, n, k int, a, b, c []float64) {
 	}
 }
 
+// GemmLayout reports how a rows×cols matrix with the given row and column
+// strides can be passed to BLAS gemm: whether it must be read transposed and
+// its leading dimension. ok is false when neither layout applies.
+func GemmLayout(rows, cols, rowStride, colStride int) (trans bool, ld int, ok bool) {
+	if cols == 1 {
+		colStride = 1
+	}
+	if rows == 1 {
+		rowStride = cols
+	}
+	switch {
+	case colStride == 1 && rowStride >= cols:
+		return false, rowStride, true
+	case rowStride == 1 && colStride >= rows:
+		return true, colStride, true
+	}
+	return false, 0, false
+}
+
+// BatchedGemmF32 computes C[i] = A[i] * B[i] for float32 with BLAS gemm, where
+// A[i] and B[i] start at the given offsets and are optionally transposed, and
+// C is contiguous. Equal offsets let one matrix serve every batch.
+func BatchedGemmF32(transA, transB bool, m, n, k int, a []float32, aOffsets []int, lda int, b []float32, bOffsets []int, ldb int, c []float32) {
+	tA, tB := gemmTranspose(transA), gemmTranspose(transB)
+	for i := range aOffsets {
+		blas32.Gemm(tA, tB, m, n, k, 1.0, a[aOffsets[i]:], lda, b[bOffsets[i]:], ldb, 0.0, c[i*m*n:], n)
+	}
+}
+
+// BatchedGemmF64 computes C[i] = A[i] * B[i] for float64 with BLAS gemm, where
+// A[i] and B[i] start at the given offsets and are optionally transposed, and
+// C is contiguous. Equal offsets let one matrix serve every batch. blas64
+// gemm is wrong when both operands are transposed, so that case is tiled.
+func BatchedGemmF64(transA, transB bool, m, n, k int, a []float64, aOffsets []int, lda int, b []float64, bOffsets []int, ldb int, c []float64) {
+	if transA && transB {
+		TiledBatchedMatMul(m, n, k, a, aOffsets, 1, lda, b, bOffsets, 1, ldb, c)
+		return
+	}
+	tA, tB := gemmTranspose(transA), gemmTranspose(transB)
+	for i := range aOffsets {
+		blas64.Gemm(tA, tB, m, n, k, 1.0, a[aOffsets[i]:], lda, b[bOffsets[i]:], ldb, 0.0, c[i*m*n:], n)
+	}
+}
+
+// gemmTranspose converts a transpose flag to its BLAS constant.
+func gemmTranspose(trans bool) blas.Transpose {
+	if trans {
+		return blas.Trans
+	}
+	return blas.NoTrans
+}
+
+// matMulTile is the block edge used by TiledBatchedMatMul.
+const matMulTile = 64
+
+// TiledBatchedMatMul computes C[i] = A[i] * B[i] for any numeric type using
+// cache-sized tiles. A[i] and B[i] start at the given offsets and are read
+// through arbitrary row and column strides; C is contiguous and zeroed.
+func TiledBatchedMatMul[T D](m, n, k int, a []T, aOffsets []int, aRow, aCol int, b []T, bOffsets []int, bRow, bCol int, c []T) {
+	rowTiles := (m + matMulTile - 1) / matMulTile
+	blocks := len(aOffsets) * rowTiles
+	ParallelFor(blocks, rowGrain(len(aOffsets)*m*n*k, blocks), func(start, end int) {
+		for blk := start; blk < end; blk++ {
+			bb, i0 := blk/rowTiles, (blk%rowTiles)*matMulTile
+			i1 := min(i0+matMulTile, m)
+			aBase, bBase, cBase := aOffsets[bb], bOffsets[bb], bb*m*n
+			for l0 := 0; l0 < k; l0 += matMulTile {
+				l1 := min(l0+matMulTile, k)
+				for j0 := 0; j0 < n; j0 += matMulTile {
+					j1 := min(j0+matMulTile, n)
+					for i := i0; i < i1; i++ {
+						row := c[cBase+i*n : cBase+i*n+n]
+						for l := l0; l < l1; l++ {
+							av := a[aBase+i*aRow+l*aCol]
+							bl := bBase + l*bRow
+							for j := j0; j < j1; j++ {
+								row[j] += av * b[bl+j*bCol]
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
 // NaiveMatMul performs matrix multiplication for any numeric type using direct loops
 func NaiveMatMul[T D](m, n, k int, a, b, c []T) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum T
-			for l := range k {
-				sum += a[i*k+l] * b[l*n+j]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum T
+				for l := range k {
+					sum += a[i*k+l] * b[l*n+j]
+				}
+				c[i*n+j] = sum
 			}
-			c[i*n+j] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulF32 performs matrix multiplication for float32 using direct loops
 func NaiveMatMulF32(m, n, k int, a, b, c []float32) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum float32
-			for l := range k {
-				sum += a[i*k+l] * b[l*n+j]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum float32
+				for l := range k {
+					sum += a[i*k+l] * b[l*n+j]
+				}
+				c[i*n+j] = sum
 			}
-			c[i*n+j] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulF64 performs matrix multiplication for float64 using direct loops
 func NaiveMatMulF64(m, n, k int, a, b, c []float64) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum float64
-			for l := range k {
-				sum += a[i*k+l] * b[l*n+j]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum float64
+				for l := range k {
+					sum += a[i*k+l] * b[l*n+j]
+				}
+				c[i*n+j] = sum
 			}
-			c[i*n+j] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulU8 performs matrix multiplication for uint8 using direct loops
 func NaiveMatMulU8(m, n, k int, a, b, c []uint8) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum uint8
-			for l := range k {
-				sum += a[i*k+l] * b[l*n+j]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum uint8
+				for l := range k {
+					sum += a[i*k+l] * b[l*n+j]
+				}
+				c[i*n+j] = sum
 			}
-			c[i*n+j] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulU32 performs matrix multiplication for uint32 using direct loops
 func NaiveMatMulU32(m, n, k int, a, b, c []uint32) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum uint32
-			for l := range k {
-				sum += a[i*k+l] * b[l*n+j]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum uint32
+				for l := range k {
+					sum += a[i*k+l] * b[l*n+j]
+				}
+				c[i*n+j] = sum
 			}
-			c[i*n+j] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulI64 performs matrix multiplication for int64 using direct loops
 func NaiveMatMulI64(m, n, k int, a, b, c []int64) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum int64
-			for l := range k {
-				sum += a[i*k+l] * b[l*n+j]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum int64
+				for l := range k {
+					sum += a[i*k+l] * b[l*n+j]
+				}
+				c[i*n+j] = sum
 			}
-			c[i*n+j] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulStrided performs matrix multiplication for any numeric type using direct loops with support for non-contiguous memory
 func NaiveMatMulStrided[T D](m, n, k int, a, b, c []T, aStrides, bStrides, cStrides []int) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum T
-			for l := range k {
-				aIdx := i*aStrides[0] + l*aStrides[1]
-				bIdx := l*bStrides[0] + j*bStrides[1]
-				sum += a[aIdx] * b[bIdx]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum T
+				for l := range k {
+					aIdx := i*aStrides[0] + l*aStrides[1]
+					bIdx := l*bStrides[0] + j*bStrides[1]
+					sum += a[aIdx] * b[bIdx]
+				}
+				cIdx := i*cStrides[0] + j*cStrides[1]
+				c[cIdx] = sum
 			}
-			cIdx := i*cStrides[0] + j*cStrides[1]
-			c[cIdx] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulStridedF32 performs matrix multiplication for float32 using direct loops with support for non-contiguous memory
 func NaiveMatMulStridedF32(m, n, k int, a, b, c []float32, aStrides, bStrides, cStrides []int) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum float32
-			for l := range k {
-				aIdx := i*aStrides[0] + l*aStrides[1]
-				bIdx := l*bStrides[0] + j*bStrides[1]
-				sum += a[aIdx] * b[bIdx]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum float32
+				for l := range k {
+					aIdx := i*aStrides[0] + l*aStrides[1]
+					bIdx := l*bStrides[0] + j*bStrides[1]
+					sum += a[aIdx] * b[bIdx]
+				}
+				cIdx := i*cStrides[0] + j*cStrides[1]
+				c[cIdx] = sum
 			}
-			cIdx := i*cStrides[0] + j*cStrides[1]
-			c[cIdx] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulStridedF64 performs matrix multiplication for float64 using direct loops with support for non-contiguous memory
 func NaiveMatMulStridedF64(m, n, k int, a, b, c []float64, aStrides, bStrides, cStrides []int) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum float64
-			for l := range k {
-				aIdx := i*aStrides[0] + l*aStrides[1]
-				bIdx := l*bStrides[0] + j*bStrides[1]
-				sum += a[aIdx] * b[bIdx]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum float64
+				for l := range k {
+					aIdx := i*aStrides[0] + l*aStrides[1]
+					bIdx := l*bStrides[0] + j*bStrides[1]
+					sum += a[aIdx] * b[bIdx]
+				}
+				cIdx := i*cStrides[0] + j*cStrides[1]
+				c[cIdx] = sum
 			}
-			cIdx := i*cStrides[0] + j*cStrides[1]
-			c[cIdx] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulStridedU8 performs matrix multiplication for uint8 using direct loops with support for non-contiguous memory
 func NaiveMatMulStridedU8(m, n, k int, a, b, c []uint8, aStrides, bStrides, cStrides []int) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum uint8
-			for l := range k {
-				aIdx := i*aStrides[0] + l*aStrides[1]
-				bIdx := l*bStrides[0] + j*bStrides[1]
-				sum += a[aIdx] * b[bIdx]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum uint8
+				for l := range k {
+					aIdx := i*aStrides[0] + l*aStrides[1]
+					bIdx := l*bStrides[0] + j*bStrides[1]
+					sum += a[aIdx] * b[bIdx]
+				}
+				cIdx := i*cStrides[0] + j*cStrides[1]
+				c[cIdx] = sum
 			}
-			cIdx := i*cStrides[0] + j*cStrides[1]
-			c[cIdx] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulStridedU32 performs matrix multiplication for uint32 using direct loops with support for non-contiguous memory
 func NaiveMatMulStridedU32(m, n, k int, a, b, c []uint32, aStrides, bStrides, cStrides []int) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum uint32
-			for l := range k {
-				aIdx := i*aStrides[0] + l*aStrides[1]
-				bIdx := l*bStrides[0] + j*bStrides[1]
-				sum += a[aIdx] * b[bIdx]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum uint32
+				for l := range k {
+					aIdx := i*aStrides[0] + l*aStrides[1]
+					bIdx := l*bStrides[0] + j*bStrides[1]
+					sum += a[aIdx] * b[bIdx]
+				}
+				cIdx := i*cStrides[0] + j*cStrides[1]
+				c[cIdx] = sum
 			}
-			cIdx := i*cStrides[0] + j*cStrides[1]
-			c[cIdx] = sum
 		}
-	}
+	})
 }
 
 // NaiveMatMulStridedI64 performs matrix multiplication for int64 using direct loops with support for non-contiguous memory
 func NaiveMatMulStridedI64(m, n, k int, a, b, c []int64, aStrides, bStrides, cStrides []int) {
 	// C[i,j] = sum(A[i,l] * B[l,j]) for l in [0,k)
-	for i := range m {
-		for j := range n {
-			var sum int64
-			for l := range k {
-				aIdx := i*aStrides[0] + l*aStrides[1]
-				bIdx := l*bStrides[0] + j*bStrides[1]
-				sum += a[aIdx] * b[bIdx]
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum int64
+				for l := range k {
+					aIdx := i*aStrides[0] + l*aStrides[1]
+					bIdx := l*bStrides[0] + j*bStrides[1]
+					sum += a[aIdx] * b[bIdx]
+				}
+				cIdx := i*cStrides[0] + j*cStrides[1]
+				c[cIdx] = sum
 			}
-			cIdx := i*cStrides[0] + j*cStrides[1]
-			c[cIdx] = sum
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMul performs batched matrix multiplication for any numeric type using direct loops
 // Assumes both A and B are batched with contiguous memory layout: A[bSize*m*k], B[bSize*k*n], C[bSize*m*n]
 func NaiveBatchedMatMul[T D](bSize, m, n, k int, a, b, c []T) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		aBase := bb * m * k
-		bBase := bb * k * n
-		cBase := bb * m * n
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
+			aBase := bb * m * k
+			bBase := bb * k * n
+			cBase := bb * m * n
 			for j := range n {
 				var sum T
 				for l := range k {
@@ -243,18 +355,19 @@ func NaiveBatchedMatMul[T D](bSize, m, n, k int, a, b, c []T) {
 				c[cBase+i*n+j] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulF32 performs batched matrix multiplication for float32 using direct loops
 // Assumes both A and B are batched with contiguous memory layout: A[bSize*m*k], B[bSize*k*n], C[bSize*m*n]
 func NaiveBatchedMatMulF32(bSize, m, n, k int, a, b, c []float32) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		aBase := bb * m * k
-		bBase := bb * k * n
-		cBase := bb * m * n
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
+			aBase := bb * m * k
+			bBase := bb * k * n
+			cBase := bb * m * n
 			for j := range n {
 				var sum float32
 				for l := range k {
@@ -263,18 +376,19 @@ func NaiveBatchedMatMulF32(bSize, m, n, k int, a, b, c []float32) {
 				c[cBase+i*n+j] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulF64 performs batched matrix multiplication for float64 using direct loops
 // Assumes both A and B are batched with contiguous memory layout: A[bSize*m*k], B[bSize*k*n], C[bSize*m*n]
 func NaiveBatchedMatMulF64(bSize, m, n, k int, a, b, c []float64) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		aBase := bb * m * k
-		bBase := bb * k * n
-		cBase := bb * m * n
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
+			aBase := bb * m * k
+			bBase := bb * k * n
+			cBase := bb * m * n
 			for j := range n {
 				var sum float64
 				for l := range k {
@@ -283,18 +397,19 @@ func NaiveBatchedMatMulF64(bSize, m, n, k int, a, b, c []float64) {
 				c[cBase+i*n+j] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulU8 performs batched matrix multiplication for uint8 using direct loops
 // Assumes both A and B are batched with contiguous memory layout: A[bSize*m*k], B[bSize*k*n], C[bSize*m*n]
 func NaiveBatchedMatMulU8(bSize, m, n, k int, a, b, c []uint8) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		aBase := bb * m * k
-		bBase := bb * k * n
-		cBase := bb * m * n
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
+			aBase := bb * m * k
+			bBase := bb * k * n
+			cBase := bb * m * n
 			for j := range n {
 				var sum uint8
 				for l := range k {
@@ -303,18 +418,19 @@ func NaiveBatchedMatMulU8(bSize, m, n, k int, a, b, c []uint8) {
 				c[cBase+i*n+j] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulU32 performs batched matrix multiplication for uint32 using direct loops
 // Assumes both A and B are batched with contiguous memory layout: A[bSize*m*k], B[bSize*k*n], C[bSize*m*n]
 func NaiveBatchedMatMulU32(bSize, m, n, k int, a, b, c []uint32) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		aBase := bb * m * k
-		bBase := bb * k * n
-		cBase := bb * m * n
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
+			aBase := bb * m * k
+			bBase := bb * k * n
+			cBase := bb * m * n
 			for j := range n {
 				var sum uint32
 				for l := range k {
@@ -323,18 +439,19 @@ func NaiveBatchedMatMulU32(bSize, m, n, k int, a, b, c []uint32) {
 				c[cBase+i*n+j] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulI64 performs batched matrix multiplication for int64 using direct loops
 // Assumes both A and B are batched with contiguous memory layout: A[bSize*m*k], B[bSize*k*n], C[bSize*m*n]
 func NaiveBatchedMatMulI64(bSize, m, n, k int, a, b, c []int64) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		aBase := bb * m * k
-		bBase := bb * k * n
-		cBase := bb * m * n
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
+			aBase := bb * m * k
+			bBase := bb * k * n
+			cBase := bb * m * n
 			for j := range n {
 				var sum int64
 				for l := range k {
@@ -343,7 +460,7 @@ func NaiveBatchedMatMulI64(bSize, m, n, k int, a, b, c []int64) {
 				c[cBase+i*n+j] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulStrided performs batched matrix multiplication for any numeric type using direct loops with support for non-contiguous memory
@@ -351,8 +468,9 @@ func NaiveBatchedMatMulI64(bSize, m, n, k int, a, b, c []int64) {
 // Broadcasting over batch is supported if the batch_stride (strides[0]) is 0 for A or B.
 func NaiveBatchedMatMulStrided[T D](bSize, m, n, k int, a, b, c []T, aStrides, bStrides, cStrides []int) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
 			for j := range n {
 				var sum T
 				for l := range k {
@@ -364,7 +482,7 @@ func NaiveBatchedMatMulStrided[T D](bSize, m, n, k int, a, b, c []T, aStrides, b
 				c[cIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulStridedF32 performs batched matrix multiplication for float32 using direct loops with support for non-contiguous memory
@@ -372,8 +490,9 @@ func NaiveBatchedMatMulStrided[T D](bSize, m, n, k int, a, b, c []T, aStrides, b
 // Broadcasting over batch is supported if the batch_stride (strides[0]) is 0 for A or B.
 func NaiveBatchedMatMulStridedF32(bSize, m, n, k int, a, b, c []float32, aStrides, bStrides, cStrides []int) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
 			for j := range n {
 				var sum float32
 				for l := range k {
@@ -385,7 +504,7 @@ func NaiveBatchedMatMulStridedF32(bSize, m, n, k int, a, b, c []float32, aStride
 				c[cIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulStridedF64 performs batched matrix multiplication for float64 using direct loops with support for non-contiguous memory
@@ -393,8 +512,9 @@ func NaiveBatchedMatMulStridedF32(bSize, m, n, k int, a, b, c []float32, aStride
 // Broadcasting over batch is supported if the batch_stride (strides[0]) is 0 for A or B.
 func NaiveBatchedMatMulStridedF64(bSize, m, n, k int, a, b, c []float64, aStrides, bStrides, cStrides []int) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
 			for j := range n {
 				var sum float64
 				for l := range k {
@@ -406,7 +526,7 @@ func NaiveBatchedMatMulStridedF64(bSize, m, n, k int, a, b, c []float64, aStride
 				c[cIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulStridedU8 performs batched matrix multiplication for uint8 using direct loops with support for non-contiguous memory
@@ -414,8 +534,9 @@ func NaiveBatchedMatMulStridedF64(bSize, m, n, k int, a, b, c []float64, aStride
 // Broadcasting over batch is supported if the batch_stride (strides[0]) is 0 for A or B.
 func NaiveBatchedMatMulStridedU8(bSize, m, n, k int, a, b, c []uint8, aStrides, bStrides, cStrides []int) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
 			for j := range n {
 				var sum uint8
 				for l := range k {
@@ -427,7 +548,7 @@ func NaiveBatchedMatMulStridedU8(bSize, m, n, k int, a, b, c []uint8, aStrides,
 				c[cIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulStridedU32 performs batched matrix multiplication for uint32 using direct loops with support for non-contiguous memory
@@ -435,8 +556,9 @@ func NaiveBatchedMatMulStridedU8(bSize, m, n, k int, a, b, c []uint8, aStrides,
 // Broadcasting over batch is supported if the batch_stride (strides[0]) is 0 for A or B.
 func NaiveBatchedMatMulStridedU32(bSize, m, n, k int, a, b, c []uint32, aStrides, bStrides, cStrides []int) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
 			for j := range n {
 				var sum uint32
 				for l := range k {
@@ -448,7 +570,7 @@ func NaiveBatchedMatMulStridedU32(bSize, m, n, k int, a, b, c []uint32, aStrides
 				c[cIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveBatchedMatMulStridedI64 performs batched matrix multiplication for int64 using direct loops with support for non-contiguous memory
@@ -456,8 +578,9 @@ func NaiveBatchedMatMulStridedU32(bSize, m, n, k int, a, b, c []uint32, aStrides
 // Broadcasting over batch is supported if the batch_stride (strides[0]) is 0 for A or B.
 func NaiveBatchedMatMulStridedI64(bSize, m, n, k int, a, b, c []int64, aStrides, bStrides, cStrides []int) {
 	// C[bb,i,j] = sum(A[bb,i,l] * B[bb,l,j]) for l in [0,k)
-	for bb := range bSize {
-		for i := range m {
+	ParallelFor(bSize*m, rowGrain(bSize*m*n*k, bSize*m), func(start, end int) {
+		for r := start; r < end; r++ {
+			bb, i := r/m, r%m
 			for j := range n {
 				var sum int64
 				for l := range k {
@@ -469,7 +592,7 @@ func NaiveBatchedMatMulStridedI64(bSize, m, n, k int, a, b, c []int64, aStrides,
 				c[cIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // Im2colConv1dF32 performs 1D convolution for float32 using im2col + gemm with direct BLAS Gemm call
@@ -517,8 +640,9 @@ func Im2colConv2dF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, di
 // NaiveConv1d performs 1D convolution for any supported numeric type using direct loop
 func NaiveConv1d[T D](bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []T) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum T
 				for ci := range cIn {
@@ -532,14 +656,15 @@ func NaiveConv1d[T D](bSize, cIn, lIn, cOut, kSize int, stride, padding, dilatio
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dF32 performs 1D convolution for float32 using direct loop
 func NaiveConv1dF32(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []float32) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float32(0)
 				for ci := range cIn {
@@ -553,14 +678,15 @@ func NaiveConv1dF32(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dF64 performs 1D convolution for float64 using direct loop
 func NaiveConv1dF64(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []float64) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float64(0)
 				for ci := range cIn {
@@ -574,14 +700,15 @@ func NaiveConv1dF64(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dU8 performs 1D convolution for uint8 using direct loop
 func NaiveConv1dU8(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []uint8) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -600,14 +727,15 @@ func NaiveConv1dU8(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation i
 				dst[b*cOut*lOut+co*lOut+lo] = uint8(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dU32 performs 1D convolution for uint32 using direct loop
 func NaiveConv1dU32(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []uint32) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -626,14 +754,15 @@ func NaiveConv1dU32(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation
 				dst[b*cOut*lOut+co*lOut+lo] = uint32(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dI64 performs 1D convolution for int64 using direct loop
 func NaiveConv1dI64(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []int64) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -647,14 +776,15 @@ func NaiveConv1dI64(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dStrided performs 1D convolution for any supported numeric type using direct loop with support for non-contiguous memory
 func NaiveConv1dStrided[T D](bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []T, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum T
 				for ci := range cIn {
@@ -671,14 +801,15 @@ func NaiveConv1dStrided[T D](bSize, cIn, lIn, cOut, kSize int, stride, padding,
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dStridedF32 performs 1D convolution for float32 using direct loop with support for non-contiguous memory
 func NaiveConv1dStridedF32(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []float32, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float32(0)
 				for ci := range cIn {
@@ -695,14 +826,15 @@ func NaiveConv1dStridedF32(bSize, cIn, lIn, cOut, kSize int, stride, padding, di
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dStridedF64 performs 1D convolution for float64 using direct loop with support for non-contiguous memory
 func NaiveConv1dStridedF64(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []float64, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float64(0)
 				for ci := range cIn {
@@ -719,14 +851,15 @@ func NaiveConv1dStridedF64(bSize, cIn, lIn, cOut, kSize int, stride, padding, di
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dStridedU8 performs 1D convolution for uint8 using direct loop with support for non-contiguous memory
 func NaiveConv1dStridedU8(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []uint8, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -748,14 +881,15 @@ func NaiveConv1dStridedU8(bSize, cIn, lIn, cOut, kSize int, stride, padding, dil
 				dst[dstIdx] = uint8(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dStridedU32 performs 1D convolution for uint32 using direct loop with support for non-contiguous memory
 func NaiveConv1dStridedU32(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []uint32, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -777,14 +911,15 @@ func NaiveConv1dStridedU32(bSize, cIn, lIn, cOut, kSize int, stride, padding, di
 				dst[dstIdx] = uint32(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv1dStridedI64 performs 1D convolution for int64 using direct loop with support for non-contiguous memory
 func NaiveConv1dStridedI64(bSize, cIn, lIn, cOut, kSize int, stride, padding, dilation int, src, kernel, dst []int64, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn+2*padding-dilation*(kSize-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -801,15 +936,16 @@ func NaiveConv1dStridedI64(bSize, cIn, lIn, cOut, kSize int, stride, padding, di
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2d performs 2D convolution for any supported numeric type using direct loop
 func NaiveConv2d[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []T) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum T
@@ -828,15 +964,16 @@ func NaiveConv2d[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, d
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dF32 performs 2D convolution for float32 using direct loop
 func NaiveConv2dF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []float32) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float32(0)
@@ -855,15 +992,16 @@ func NaiveConv2dF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dil
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dF64 performs 2D convolution for float64 using direct loop
 func NaiveConv2dF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []float64) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float64(0)
@@ -882,15 +1020,16 @@ func NaiveConv2dF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dil
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dU8 performs 2D convolution for uint8 using direct loop
 func NaiveConv2dU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []uint8) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -914,15 +1053,16 @@ func NaiveConv2dU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dila
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dU32 performs 2D convolution for uint32 using direct loop
 func NaiveConv2dU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []uint32) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -946,15 +1086,16 @@ func NaiveConv2dU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dil
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dI64 performs 2D convolution for int64 using direct loop
 func NaiveConv2dI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []int64) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -973,15 +1114,16 @@ func NaiveConv2dI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dil
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dStrided performs 2D convolution for any supported numeric type using direct loop with support for non-contiguous memory
 func NaiveConv2dStrided[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []T, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum T
@@ -1003,15 +1145,16 @@ func NaiveConv2dStrided[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, pad
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dStridedF32 performs 2D convolution for float32 using direct loop with support for non-contiguous memory
 func NaiveConv2dStridedF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []float32, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float32(0)
@@ -1033,15 +1176,16 @@ func NaiveConv2dStridedF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, paddi
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dStridedF64 performs 2D convolution for float64 using direct loop with support for non-contiguous memory
 func NaiveConv2dStridedF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []float64, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float64(0)
@@ -1063,15 +1207,16 @@ func NaiveConv2dStridedF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, paddi
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dStridedU8 performs 2D convolution for uint8 using direct loop with support for non-contiguous memory
 func NaiveConv2dStridedU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []uint8, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1098,15 +1243,16 @@ func NaiveConv2dStridedU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, paddin
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dStridedU32 performs 2D convolution for uint32 using direct loop with support for non-contiguous memory
 func NaiveConv2dStridedU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []uint32, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1133,15 +1279,16 @@ func NaiveConv2dStridedU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, paddi
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConv2dStridedI64 performs 2D convolution for int64 using direct loop with support for non-contiguous memory
 func NaiveConv2dStridedI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, dilation int, src, kernel, dst []int64, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn+2*padding-dilation*(hK-1)-1)/stride + 1
 	wOut := (wIn+2*padding-dilation*(wK-1)-1)/stride + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1163,14 +1310,15 @@ func NaiveConv2dStridedI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, paddi
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1d performs 1D transpose convolution for any supported numeric type using direct loop
 func NaiveConvTranspose1d[T D](bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []T) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum T
 				for ci := range cIn {
@@ -1187,14 +1335,15 @@ func NaiveConvTranspose1d[T D](bSize, cIn, lIn, cOut, kSize int, stride, padding
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dF32 performs 1D transpose convolution for float32 using direct loop
 func NaiveConvTranspose1dF32(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []float32) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float32(0)
 				for ci := range cIn {
@@ -1211,14 +1360,15 @@ func NaiveConvTranspose1dF32(bSize, cIn, lIn, cOut, kSize int, stride, padding,
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dF64 performs 1D transpose convolution for float64 using direct loop
 func NaiveConvTranspose1dF64(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []float64) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float64(0)
 				for ci := range cIn {
@@ -1235,14 +1385,15 @@ func NaiveConvTranspose1dF64(bSize, cIn, lIn, cOut, kSize int, stride, padding,
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dU8 performs 1D transpose convolution for uint8 using direct loop
 func NaiveConvTranspose1dU8(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []uint8) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -1264,14 +1415,15 @@ func NaiveConvTranspose1dU8(bSize, cIn, lIn, cOut, kSize int, stride, padding, o
 				dst[b*cOut*lOut+co*lOut+lo] = uint8(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dU32 performs 1D transpose convolution for uint32 using direct loop
 func NaiveConvTranspose1dU32(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []uint32) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -1293,14 +1445,15 @@ func NaiveConvTranspose1dU32(bSize, cIn, lIn, cOut, kSize int, stride, padding,
 				dst[b*cOut*lOut+co*lOut+lo] = uint32(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dI64 performs 1D transpose convolution for int64 using direct loop
 func NaiveConvTranspose1dI64(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []int64) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -1317,14 +1470,15 @@ func NaiveConvTranspose1dI64(bSize, cIn, lIn, cOut, kSize int, stride, padding,
 				dst[b*cOut*lOut+co*lOut+lo] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dStrided performs 1D transpose convolution for any supported numeric type using direct loop with support for non-contiguous memory
 func NaiveConvTranspose1dStrided[T D](bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []T, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum T
 				for ci := range cIn {
@@ -1344,14 +1498,15 @@ func NaiveConvTranspose1dStrided[T D](bSize, cIn, lIn, cOut, kSize int, stride,
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dStridedF32 performs 1D transpose convolution for float32 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose1dStridedF32(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []float32, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float32(0)
 				for ci := range cIn {
@@ -1371,14 +1526,15 @@ func NaiveConvTranspose1dStridedF32(bSize, cIn, lIn, cOut, kSize int, stride, pa
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dStridedF64 performs 1D transpose convolution for float64 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose1dStridedF64(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []float64, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				sum := float64(0)
 				for ci := range cIn {
@@ -1398,14 +1554,15 @@ func NaiveConvTranspose1dStridedF64(bSize, cIn, lIn, cOut, kSize int, stride, pa
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dStridedU8 performs 1D transpose convolution for uint8 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose1dStridedU8(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []uint8, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -1430,14 +1587,15 @@ func NaiveConvTranspose1dStridedU8(bSize, cIn, lIn, cOut, kSize int, stride, pad
 				dst[dstIdx] = uint8(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dStridedU32 performs 1D transpose convolution for uint32 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose1dStridedU32(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []uint32, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -1462,14 +1620,15 @@ func NaiveConvTranspose1dStridedU32(bSize, cIn, lIn, cOut, kSize int, stride, pa
 				dst[dstIdx] = uint32(sum)
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose1dStridedI64 performs 1D transpose convolution for int64 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose1dStridedI64(bSize, cIn, lIn, cOut, kSize int, stride, padding, outPadding, dilation int, src, kernel, dst []int64, srcStrides, kernelStrides, dstStrides []int) {
 	lOut := (lIn-1)*stride + dilation*(kSize-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*kSize, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for lo := range lOut {
 				var sum int64
 				for ci := range cIn {
@@ -1489,15 +1648,16 @@ func NaiveConvTranspose1dStridedI64(bSize, cIn, lIn, cOut, kSize int, stride, pa
 				dst[dstIdx] = sum
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2d performs 2D transpose convolution for any supported numeric type using direct loop
 func NaiveConvTranspose2d[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []T) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum T
@@ -1520,15 +1680,16 @@ func NaiveConvTranspose2d[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, p
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dF32 performs 2D transpose convolution for float32 using direct loop
 func NaiveConvTranspose2dF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []float32) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float32(0)
@@ -1551,15 +1712,16 @@ func NaiveConvTranspose2dF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, pad
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dF64 performs 2D transpose convolution for float64 using direct loop
 func NaiveConvTranspose2dF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []float64) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float64(0)
@@ -1582,15 +1744,16 @@ func NaiveConvTranspose2dF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, pad
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dU8 performs 2D transpose convolution for uint8 using direct loop
 func NaiveConvTranspose2dU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []uint8) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1618,15 +1781,16 @@ func NaiveConvTranspose2dU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padd
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dU32 performs 2D transpose convolution for uint32 using direct loop
 func NaiveConvTranspose2dU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []uint32) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1654,15 +1818,16 @@ func NaiveConvTranspose2dU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, pad
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dI64 performs 2D transpose convolution for int64 using direct loop
 func NaiveConvTranspose2dI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []int64) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1685,15 +1850,16 @@ func NaiveConvTranspose2dI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, pad
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dStrided performs 2D transpose convolution for any supported numeric type using direct loop with support for non-contiguous memory
 func NaiveConvTranspose2dStrided[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []T, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum T
@@ -1719,15 +1885,16 @@ func NaiveConvTranspose2dStrided[T D](bSize, cIn, hIn, wIn, cOut, hK, wK int, st
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dStridedF32 performs 2D transpose convolution for float32 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose2dStridedF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []float32, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float32(0)
@@ -1753,15 +1920,16 @@ func NaiveConvTranspose2dStridedF32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stri
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dStridedF64 performs 2D transpose convolution for float64 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose2dStridedF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []float64, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float64(0)
@@ -1787,15 +1955,16 @@ func NaiveConvTranspose2dStridedF64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stri
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dStridedU8 performs 2D transpose convolution for uint8 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose2dStridedU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []uint8, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1826,15 +1995,16 @@ func NaiveConvTranspose2dStridedU8(bSize, cIn, hIn, wIn, cOut, hK, wK int, strid
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dStridedU32 performs 2D transpose convolution for uint32 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose2dStridedU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []uint32, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1865,15 +2035,16 @@ func NaiveConvTranspose2dStridedU32(bSize, cIn, hIn, wIn, cOut, hK, wK int, stri
 				}
 			}
 		}
-	}
+	})
 }
 
 // NaiveConvTranspose2dStridedI64 performs 2D transpose convolution for int64 using direct loop with support for non-contiguous memory
 func NaiveConvTranspose2dStridedI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stride, padding, outPadding, dilation int, src, kernel, dst []int64, srcStrides, kernelStrides, dstStrides []int) {
 	hOut := (hIn-1)*stride + dilation*(hK-1) + outPadding - 2*padding + 1
 	wOut := (wIn-1)*stride + dilation*(wK-1) + outPadding - 2*padding + 1
-	for b := range bSize {
-		for co := range cOut {
+	ParallelFor(bSize*cOut, rowGrain(len(dst)*cIn*hK*wK, bSize*cOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/cOut, r%cOut
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -1899,15 +2070,16 @@ func NaiveConvTranspose2dStridedI64(bSize, cIn, hIn, wIn, cOut, hK, wK int, stri
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2d performs 2D average pooling for any supported numeric type
 func AvgPool2d[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []T) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum T
@@ -1926,15 +2098,16 @@ func AvgPool2d[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst [
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dF32 performs 2D average pooling for float32
 func AvgPool2dF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float32) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float32(0)
@@ -1953,15 +2126,16 @@ func AvgPool2dF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []f
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dF64 performs 2D average pooling for float64
 func AvgPool2dF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float64) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float64(0)
@@ -1980,15 +2154,16 @@ func AvgPool2dF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []f
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dU8 performs 2D average pooling for uint8
 func AvgPool2dU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint8) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -2008,15 +2183,16 @@ func AvgPool2dU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []ui
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dU32 performs 2D average pooling for uint32
 func AvgPool2dU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint32) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -2036,15 +2212,16 @@ func AvgPool2dU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []u
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dI64 performs 2D average pooling for int64
 func AvgPool2dI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []int64) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -2063,15 +2240,16 @@ func AvgPool2dI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []i
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dStrided performs 2D average pooling for any supported numeric type with support for non-contiguous memory
 func AvgPool2dStrided[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []T, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum T
@@ -2092,15 +2270,16 @@ func AvgPool2dStrided[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dStridedF32 performs 2D average pooling for float32 with support for non-contiguous memory
 func AvgPool2dStridedF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float32, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float32(0)
@@ -2121,15 +2300,16 @@ func AvgPool2dStridedF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dStridedF64 performs 2D average pooling for float64 with support for non-contiguous memory
 func AvgPool2dStridedF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float64, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					sum := float64(0)
@@ -2150,15 +2330,16 @@ func AvgPool2dStridedF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dStridedU8 performs 2D average pooling for uint8 with support for non-contiguous memory
 func AvgPool2dStridedU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint8, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -2180,15 +2361,16 @@ func AvgPool2dStridedU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, d
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dStridedU32 performs 2D average pooling for uint32 with support for non-contiguous memory
 func AvgPool2dStridedU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint32, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -2210,15 +2392,16 @@ func AvgPool2dStridedU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // AvgPool2dStridedI64 performs 2D average pooling for int64 with support for non-contiguous memory
 func AvgPool2dStridedI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []int64, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var sum int64
@@ -2239,15 +2422,16 @@ func AvgPool2dStridedI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2d performs 2D max pooling for any supported numeric type
 func MaxPool2d[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []T) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal T
@@ -2271,15 +2455,16 @@ func MaxPool2d[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst [
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dF32 performs 2D max pooling for float32
 func MaxPool2dF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float32) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					maxVal := float32(math.Inf(-1))
@@ -2299,15 +2484,16 @@ func MaxPool2dF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []f
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dF64 performs 2D max pooling for float64
 func MaxPool2dF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float64) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					maxVal := float64(math.Inf(-1))
@@ -2327,15 +2513,16 @@ func MaxPool2dF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []f
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dU8 performs 2D max pooling for uint8
 func MaxPool2dU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint8) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal uint8
@@ -2359,15 +2546,16 @@ func MaxPool2dU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []ui
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dU32 performs 2D max pooling for uint32
 func MaxPool2dU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint32) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal uint32
@@ -2391,15 +2579,16 @@ func MaxPool2dU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []u
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dI64 performs 2D max pooling for int64
 func MaxPool2dI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []int64) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal int64
@@ -2423,15 +2612,16 @@ func MaxPool2dI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []i
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dStrided performs 2D max pooling for any supported numeric type with support for non-contiguous memory
 func MaxPool2dStrided[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []T, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal T
@@ -2457,15 +2647,16 @@ func MaxPool2dStrided[T D](bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dStridedF32 performs 2D max pooling for float32 with support for non-contiguous memory
 func MaxPool2dStridedF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float32, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					maxVal := float32(math.Inf(-1))
@@ -2487,15 +2678,16 @@ func MaxPool2dStridedF32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dStridedF64 performs 2D max pooling for float64 with support for non-contiguous memory
 func MaxPool2dStridedF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []float64, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					maxVal := float64(math.Inf(-1))
@@ -2517,15 +2709,16 @@ func MaxPool2dStridedF64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dStridedU8 performs 2D max pooling for uint8 with support for non-contiguous memory
 func MaxPool2dStridedU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint8, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal uint8
@@ -2551,15 +2744,16 @@ func MaxPool2dStridedU8(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, d
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dStridedU32 performs 2D max pooling for uint32 with support for non-contiguous memory
 func MaxPool2dStridedU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []uint32, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal uint32
@@ -2585,15 +2779,16 @@ func MaxPool2dStridedU32(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // MaxPool2dStridedI64 performs 2D max pooling for int64 with support for non-contiguous memory
 func MaxPool2dStridedI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src, dst []int64, srcStrides, dstStrides []int) {
 	hOut := (hIn-hK)/hStride + 1
 	wOut := (wIn-wK)/wStride + 1
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst)*hK*wK, bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					var maxVal int64
@@ -2619,13 +2814,14 @@ func MaxPool2dStridedI64(bSize, c, hIn, wIn, hK, wK, hStride, wStride int, src,
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2d performs 2D nearest neighbor upsampling for any supported numeric type
 func UpsampleNearest2d[T D](bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []T) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2640,13 +2836,14 @@ func UpsampleNearest2d[T D](bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale f
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dF32 performs 2D nearest neighbor upsampling for float32
 func UpsampleNearest2dF32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []float32) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2661,13 +2858,14 @@ func UpsampleNearest2dF32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale flo
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dF64 performs 2D nearest neighbor upsampling for float64
 func UpsampleNearest2dF64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []float64) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2682,13 +2880,14 @@ func UpsampleNearest2dF64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale flo
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dU8 performs 2D nearest neighbor upsampling for uint8
 func UpsampleNearest2dU8(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []uint8) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2703,13 +2902,14 @@ func UpsampleNearest2dU8(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale floa
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dU32 performs 2D nearest neighbor upsampling for uint32
 func UpsampleNearest2dU32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []uint32) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2724,13 +2924,14 @@ func UpsampleNearest2dU32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale flo
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dI64 performs 2D nearest neighbor upsampling for int64
 func UpsampleNearest2dI64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []int64) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2745,13 +2946,14 @@ func UpsampleNearest2dI64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale flo
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dStrided performs 2D nearest neighbor upsampling for any supported numeric type with support for non-contiguous memory
 func UpsampleNearest2dStrided[T D](bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []T, srcStrides, dstStrides []int) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2768,13 +2970,14 @@ func UpsampleNearest2dStrided[T D](bSize, c, hIn, wIn, hOut, wOut int, hScale, w
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dStridedF32 performs 2D nearest neighbor upsampling for float32 with support for non-contiguous memory
 func UpsampleNearest2dStridedF32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []float32, srcStrides, dstStrides []int) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2791,13 +2994,14 @@ func UpsampleNearest2dStridedF32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wSc
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dStridedF64 performs 2D nearest neighbor upsampling for float64 with support for non-contiguous memory
 func UpsampleNearest2dStridedF64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []float64, srcStrides, dstStrides []int) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2814,13 +3018,14 @@ func UpsampleNearest2dStridedF64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wSc
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dStridedU8 performs 2D nearest neighbor upsampling for uint8 with support for non-contiguous memory
 func UpsampleNearest2dStridedU8(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []uint8, srcStrides, dstStrides []int) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2837,13 +3042,14 @@ func UpsampleNearest2dStridedU8(bSize, c, hIn, wIn, hOut, wOut int, hScale, wSca
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dStridedU32 performs 2D nearest neighbor upsampling for uint32 with support for non-contiguous memory
 func UpsampleNearest2dStridedU32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []uint32, srcStrides, dstStrides []int) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2860,13 +3066,14 @@ func UpsampleNearest2dStridedU32(bSize, c, hIn, wIn, hOut, wOut int, hScale, wSc
 				}
 			}
 		}
-	}
+	})
 }
 
 // UpsampleNearest2dStridedI64 performs 2D nearest neighbor upsampling for int64 with support for non-contiguous memory
 func UpsampleNearest2dStridedI64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wScale float64, src, dst []int64, srcStrides, dstStrides []int) {
-	for b := range bSize {
-		for ch := range c {
+	ParallelFor(bSize*c, rowGrain(len(dst), bSize*c), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ch := r/c, r%c
 			for ho := range hOut {
 				for wo := range wOut {
 					hi := int(math.Floor((float64(ho) + 0.5) * float64(hIn) / float64(hOut)))
@@ -2883,13 +3090,14 @@ func UpsampleNearest2dStridedI64(bSize, c, hIn, wIn, hOut, wOut int, hScale, wSc
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col extracts columns for 2D convolution (im2col) for any supported numeric type
 func Im2col[T D](bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []T) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -2906,13 +3114,14 @@ func Im2col[T D](bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding,
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colF32 extracts columns for 2D convolution (im2col) for float32
 func Im2colF32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []float32) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -2929,13 +3138,14 @@ func Im2colF32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, di
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colF64 extracts columns for 2D convolution (im2col) for float64
 func Im2colF64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []float64) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -2952,13 +3162,14 @@ func Im2colF64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, di
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colU8 extracts columns for 2D convolution (im2col) for uint8
 func Im2colU8(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []uint8) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -2975,13 +3186,14 @@ func Im2colU8(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dil
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colU32 extracts columns for 2D convolution (im2col) for uint32
 func Im2colU32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []uint32) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -2998,13 +3210,14 @@ func Im2colU32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, di
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colI64 extracts columns for 2D convolution (im2col) for int64
 func Im2colI64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []int64) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -3021,13 +3234,14 @@ func Im2colI64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, di
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colStrided extracts columns for 2D convolution (im2col) for any supported numeric type with support for non-contiguous src
 func Im2colStrided[T D](bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []T, srcStrides []int) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -3046,13 +3260,14 @@ func Im2colStrided[T D](bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, pa
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colStridedF32 extracts columns for 2D convolution (im2col) for float32 with support for non-contiguous src
 func Im2colStridedF32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []float32, srcStrides []int) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -3071,13 +3286,14 @@ func Im2colStridedF32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padd
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colStridedF64 extracts columns for 2D convolution (im2col) for float64 with support for non-contiguous src
 func Im2colStridedF64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []float64, srcStrides []int) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -3096,13 +3312,14 @@ func Im2colStridedF64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padd
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colStridedU8 extracts columns for 2D convolution (im2col) for uint8 with support for non-contiguous src
 func Im2colStridedU8(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []uint8, srcStrides []int) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -3121,13 +3338,14 @@ func Im2colStridedU8(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, paddi
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colStridedU32 extracts columns for 2D convolution (im2col) for uint32 with support for non-contiguous src
 func Im2colStridedU32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []uint32, srcStrides []int) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -3146,13 +3364,14 @@ func Im2colStridedU32(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padd
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2colStridedI64 extracts columns for 2D convolution (im2col) for int64 with support for non-contiguous src
 func Im2colStridedI64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padding, dilation int, src, col []int64, srcStrides []int) {
-	for b := range bSize {
-		for ho := range hOut {
+	ParallelFor(bSize*hOut, rowGrain(len(col), bSize*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
 			for wo := range wOut {
 				for ci := range cIn {
 					for hk := range hK {
@@ -3171,13 +3390,14 @@ func Im2colStridedI64(bSize, cIn, hIn, wIn, hOut, wOut, hK, wK int, stride, padd
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1d performs im2col transformation for any supported numeric type
 func Im2col1d[T D](bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []T) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3189,13 +3409,14 @@ func Im2col1d[T D](bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation i
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dF32 extracts columns for 1D convolution (im2col) for float32
 func Im2col1dF32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []float32) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3207,13 +3428,14 @@ func Im2col1dF32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dF64 extracts columns for 1D convolution (im2col) for float64
 func Im2col1dF64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []float64) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3225,13 +3447,14 @@ func Im2col1dF64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dU8 extracts columns for 1D convolution (im2col) for uint8
 func Im2col1dU8(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []uint8) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3243,13 +3466,14 @@ func Im2col1dU8(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int,
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dU32 extracts columns for 1D convolution (im2col) for uint32
 func Im2col1dU32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []uint32) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3261,13 +3485,14 @@ func Im2col1dU32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dI64 extracts columns for 1D convolution (im2col) for int64
 func Im2col1dI64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []int64) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3279,13 +3504,14 @@ func Im2col1dI64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dStrided performs im2col transformation for any supported numeric type with support for non-contiguous src
 func Im2col1dStrided[T D](bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []T, srcStrides []int) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3299,13 +3525,14 @@ func Im2col1dStrided[T D](bSize, cIn, lIn, lOut, kSize int, stride, padding, dil
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dStridedF32 extracts columns for 1D convolution (im2col) for float32 with support for non-contiguous src
 func Im2col1dStridedF32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []float32, srcStrides []int) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3319,13 +3546,14 @@ func Im2col1dStridedF32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilat
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dStridedF64 performs 1D col2im for float64 with non-contiguous src
 func Im2col1dStridedF64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []float64, srcStrides []int) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3339,13 +3567,14 @@ func Im2col1dStridedF64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilat
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dStridedU8 extracts columns for 1D convolution (im2col) for uint8 with support for non-contiguous src
 func Im2col1dStridedU8(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []uint8, srcStrides []int) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3359,13 +3588,14 @@ func Im2col1dStridedU8(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilati
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dStridedU32 extracts columns for 1D convolution (im2col) for uint32 with support for non-contiguous src
 func Im2col1dStridedU32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []uint32, srcStrides []int) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3379,13 +3609,14 @@ func Im2col1dStridedU32(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilat
 				}
 			}
 		}
-	}
+	})
 }
 
 // Im2col1dStridedI64 extracts columns for 1D convolution (im2col) for int64 with support for non-contiguous src
 func Im2col1dStridedI64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilation int, src, col []int64, srcStrides []int) {
-	for b := range bSize {
-		for lo := range lOut {
+	ParallelFor(bSize*lOut, rowGrain(len(col), bSize*lOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, lo := r/lOut, r%lOut
 			for ci := range cIn {
 				for k := range kSize {
 					li := lo*stride + k*dilation - padding
@@ -3399,7 +3630,7 @@ func Im2col1dStridedI64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilat
 				}
 			}
 		}
-	}
+	})
 }
 
 // Col2im1d performs standard 1D col2im for any supported numeric type (e.g., for conv backward data)
@@ -3621,3 +3852,212 @@ func Col2im1dStridedI64(bSize, cIn, lIn, lOut, kSize int, stride, padding, dilat
 		}
 	}
 }
+
+// convPadIndex maps coordinate i of an axis of size n to the coordinate it
+// reads under mode, reporting false when the position is zero padding.
+func convPadIndex(i, n int, mode candy.PadMode) (int, bool) {
+	if i >= 0 && i < n {
+		return i, true
+	}
+	switch mode {
+	case candy.PadReflect:
+		if i < 0 {
+			i = -i
+		} else {
+			i = 2*(n-1) - i
+		}
+		return i, i >= 0 && i < n
+	case candy.PadReplicate:
+		return min(max(i, 0), n-1), true
+	case candy.PadCircular:
+		return (i%n + n) % n, true
+	default:
+		return 0, false
+	}
+}
+
+// convTaps returns, for every output position o and kernel tap k of one axis,
+// the input coordinate read at index o*size+k, or -1 for zero padding.
+func convTaps(in, out, size, stride, pad, dilation int, mode candy.PadMode) []int {
+	taps := make([]int, out*size)
+	for o := range out {
+		for k := range size {
+			i, ok := convPadIndex(o*stride+k*dilation-pad, in, mode)
+			if !ok {
+				i = -1
+			}
+			taps[o*size+k] = i
+		}
+	}
+	return taps
+}
+
+// Im2colGeneral extracts im2col columns of shape [B*outH*outW, InCh*KH*KW]
+// for any 2D convolution parameters, reading src through NCHW strides and
+// filling padding according to p.PadMode.
+func Im2colGeneral[T D](p *candy.Conv2DParams, src []T, srcStrides []int, col []T) {
+	hOut, wOut := p.OutH(), p.OutW()
+	ph, pw := p.Padding()
+	sh, sw := p.Strides()
+	dh, dw := p.Dilations()
+	hTaps := convTaps(p.InH, hOut, p.KH, sh, ph, dh, p.PadMode)
+	wTaps := convTaps(p.InW, wOut, p.KW, sw, pw, dw, p.PadMode)
+	k := p.InCh * p.KH * p.KW
+	ParallelFor(p.Batch*hOut, rowGrain(len(col), p.Batch*hOut), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho := r/hOut, r%hOut
+			for wo := range wOut {
+				row := col[(r*wOut+wo)*k : (r*wOut+wo+1)*k]
+				for ci := range p.InCh {
+					base := b*srcStrides[0] + ci*srcStrides[1]
+					for hk := range p.KH {
+						hi := hTaps[ho*p.KH+hk]
+						for wk := range p.KW {
+							wi := wTaps[wo*p.KW+wk]
+							if hi < 0 || wi < 0 {
+								row[(ci*p.KH+hk)*p.KW+wk] = 0
+							} else {
+								row[(ci*p.KH+hk)*p.KW+wk] = src[base+hi*srcStrides[2]+wi*srcStrides[3]]
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+// Col2imGeneral accumulates im2col columns back into a contiguous NCHW
+// image, the adjoint of Im2colGeneral. Padding positions that read the
+// input, as in reflect mode, add their gradient to the source element.
+func Col2imGeneral[T D](p *candy.Conv2DParams, col, im []T) {
+	hOut, wOut := p.OutH(), p.OutW()
+	ph, pw := p.Padding()
+	sh, sw := p.Strides()
+	dh, dw := p.Dilations()
+	hTaps := convTaps(p.InH, hOut, p.KH, sh, ph, dh, p.PadMode)
+	wTaps := convTaps(p.InW, wOut, p.KW, sw, pw, dw, p.PadMode)
+	k := p.InCh * p.KH * p.KW
+	ParallelFor(p.Batch*p.InCh, rowGrain(len(col), p.Batch*p.InCh), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ci := r/p.InCh, r%p.InCh
+			img := im[r*p.InH*p.InW : (r+1)*p.InH*p.InW]
+			for ho := range hOut {
+				for wo := range wOut {
+					row := col[((b*hOut+ho)*wOut+wo)*k:]
+					for hk := range p.KH {
+						hi := hTaps[ho*p.KH+hk]
+						if hi < 0 {
+							continue
+						}
+						for wk := range p.KW {
+							if wi := wTaps[wo*p.KW+wk]; wi >= 0 {
+								img[hi*p.InW+wi] += row[(ci*p.KH+hk)*p.KW+wk]
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+// gemmStrided computes C = op(A) * op(B) where op transposes when requested,
+// for row-major matrices with leading dimensions lda, ldb and ldc. Floats
+// use BLAS; other types fall back to direct loops.
+func gemmStrided[T D](transA, transB bool, m, n, k int, a []T, lda int, b []T, ldb int, c []T, ldc int) {
+	switch a := any(a).(type) {
+	case []float32:
+		blas32.Gemm(gemmTranspose(transA), gemmTranspose(transB), m, n, k, 1, a, lda, any(b).([]float32), ldb, 0, any(c).([]float32), ldc)
+		return
+	case []float64:
+		blas64.Gemm(gemmTranspose(transA), gemmTranspose(transB), m, n, k, 1, a, lda, any(b).([]float64), ldb, 0, any(c).([]float64), ldc)
+		return
+	}
+	aRow, aCol := lda, 1
+	if transA {
+		aRow, aCol = 1, lda
+	}
+	bRow, bCol := ldb, 1
+	if transB {
+		bRow, bCol = 1, ldb
+	}
+	ParallelFor(m, rowGrain(m*n*k, m), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := range n {
+				var sum T
+				for l := range k {
+					sum += a[i*aRow+l*aCol] * b[l*bRow+j*bCol]
+				}
+				c[i*ldc+j] = sum
+			}
+		}
+	})
+}
+
+// packConvKernel copies a strided [OutCh, InCh/Groups, KH, KW] kernel into a
+// contiguous buffer.
+func packConvKernel[T D](p *candy.Conv2DParams, kernel []T, kernelStrides []int) []T {
+	cg := p.InCh / p.NumGroups()
+	out := make([]T, p.OutCh*cg*p.KH*p.KW)
+	i := 0
+	for o := range p.OutCh {
+		for c := range cg {
+			for h := range p.KH {
+				for w := range p.KW {
+					out[i] = kernel[o*kernelStrides[0]+c*kernelStrides[1]+h*kernelStrides[2]+w*kernelStrides[3]]
+					i++
+				}
+			}
+		}
+	}
+	return out
+}
+
+// GroupedConv2d performs 2D convolution with per-axis stride, padding and
+// dilation, channel groups and any padding mode, using im2col and one gemm
+// per group. src and kernel are read through their NCHW and
+// [OutCh, InCh/Groups, KH, KW] strides; dst receives the output in NHWC order.
+func GroupedConv2d[T D](p *candy.Conv2DParams, src []T, srcStrides []int, kernel []T, kernelStrides []int, dst []T) {
+	hOut, wOut := p.OutH(), p.OutW()
+	groups := p.NumGroups()
+	k := p.InCh * p.KH * p.KW
+	kg, og := k/groups, p.OutCh/groups
+	col := make([]T, p.Batch*hOut*wOut*k)
+	Im2colGeneral(p, src, srcStrides, col)
+	w := packConvKernel(p, kernel, kernelStrides)
+	m := p.Batch * hOut * wOut
+	for g := range groups {
+		gemmStrided(false, true, m, og, kg, col[g*kg:], k, w[g*og*kg:], kg, dst[g*og:], p.OutCh)
+	}
+}
+
+// GroupedConv2dBackward computes the gradients of GroupedConv2d from grad,
+// read through NCHW strides. dx receives the contiguous NCHW input gradient
+// and dw the contiguous [OutCh, InCh/Groups, KH, KW] kernel gradient.
+func GroupedConv2dBackward[T D](p *candy.Conv2DParams, src []T, srcStrides []int, kernel []T, kernelStrides []int, grad []T, gradStrides []int, dx, dw []T) {
+	hOut, wOut := p.OutH(), p.OutW()
+	groups := p.NumGroups()
+	k := p.InCh * p.KH * p.KW
+	kg, og := k/groups, p.OutCh/groups
+	m := p.Batch * hOut * wOut
+	// Gather the output gradient as an [m, OutCh] matrix, matching dst of the forward pass.
+	g := make([]T, m*p.OutCh)
+	ParallelFor(m, rowGrain(len(g), m), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ho, wo := r/(hOut*wOut), r/wOut%hOut, r%wOut
+			for o := range p.OutCh {
+				g[r*p.OutCh+o] = grad[b*gradStrides[0]+o*gradStrides[1]+ho*gradStrides[2]+wo*gradStrides[3]]
+			}
+		}
+	})
+	col := make([]T, m*k)
+	Im2colGeneral(p, src, srcStrides, col)
+	w := packConvKernel(p, kernel, kernelStrides)
+	dcol := make([]T, m*k)
+	for gi := range groups {
+		gemmStrided(true, false, og, kg, m, g[gi*og:], p.OutCh, col[gi*kg:], k, dw[gi*og*kg:], kg)
+		gemmStrided(false, false, m, kg, og, g[gi*og:], p.OutCh, w[gi*og*kg:], kg, dcol[gi*kg:], k)
+	}
+	Col2imGeneral(p, dcol, dx)
+}
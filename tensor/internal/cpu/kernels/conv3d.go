@@ -0,0 +1,444 @@
+package kernels
+
+import (
+	"math"
+
+	"github.com/gocnn/candy"
+)
+
+// Vol2col extracts vol2col columns of shape [B*outD*outH*outW, InCh*KD*KH*KW]
+// for 3D convolution, reading src through NCDHW strides. Padding reads zero.
+func Vol2col[T D](p *candy.Conv3DParams, src []T, srcStrides []int, col []T) {
+	dOut, hOut, wOut := p.OutD(), p.OutH(), p.OutW()
+	pd, ph, pw := p.Padding()
+	sd, sh, sw := p.Strides()
+	dd, dh, dw := p.Dilations()
+	dTaps := convTaps(p.InD, dOut, p.KD, sd, pd, dd, candy.PadZeros)
+	hTaps := convTaps(p.InH, hOut, p.KH, sh, ph, dh, candy.PadZeros)
+	wTaps := convTaps(p.InW, wOut, p.KW, sw, pw, dw, candy.PadZeros)
+	kVol := p.KD * p.KH * p.KW
+	k := p.InCh * kVol
+	rows := p.Batch * dOut * hOut
+	ParallelFor(rows, rowGrain(len(col), rows), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, do, ho := r/(dOut*hOut), r/hOut%dOut, r%hOut
+			for wo := range wOut {
+				row := col[(r*wOut+wo)*k : (r*wOut+wo+1)*k]
+				for ci := range p.InCh {
+					base := b*srcStrides[0] + ci*srcStrides[1]
+					i := ci * kVol
+					for kd := range p.KD {
+						di := dTaps[do*p.KD+kd]
+						for kh := range p.KH {
+							hi := hTaps[ho*p.KH+kh]
+							for kw := range p.KW {
+								wi := wTaps[wo*p.KW+kw]
+								if di < 0 || hi < 0 || wi < 0 {
+									row[i] = 0
+								} else {
+									row[i] = src[base+di*srcStrides[2]+hi*srcStrides[3]+wi*srcStrides[4]]
+								}
+								i++
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+// Col2vol accumulates vol2col columns back into a contiguous NCDHW volume,
+// the adjoint of Vol2col.
+func Col2vol[T D](p *candy.Conv3DParams, col, vol []T) {
+	dOut, hOut, wOut := p.OutD(), p.OutH(), p.OutW()
+	pd, ph, pw := p.Padding()
+	sd, sh, sw := p.Strides()
+	dd, dh, dw := p.Dilations()
+	dTaps := convTaps(p.InD, dOut, p.KD, sd, pd, dd, candy.PadZeros)
+	hTaps := convTaps(p.InH, hOut, p.KH, sh, ph, dh, candy.PadZeros)
+	wTaps := convTaps(p.InW, wOut, p.KW, sw, pw, dw, candy.PadZeros)
+	kVol := p.KD * p.KH * p.KW
+	k := p.InCh * kVol
+	inVol := p.InD * p.InH * p.InW
+	ParallelFor(p.Batch*p.InCh, rowGrain(len(col), p.Batch*p.InCh), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, ci := r/p.InCh, r%p.InCh
+			v := vol[r*inVol : (r+1)*inVol]
+			for do := range dOut {
+				for ho := range hOut {
+					for wo := range wOut {
+						row := col[(((b*dOut+do)*hOut+ho)*wOut+wo)*k+ci*kVol:]
+						i := 0
+						for kd := range p.KD {
+							di := dTaps[do*p.KD+kd]
+							for kh := range p.KH {
+								hi := hTaps[ho*p.KH+kh]
+								for kw := range p.KW {
+									if wi := wTaps[wo*p.KW+kw]; di >= 0 && hi >= 0 && wi >= 0 {
+										v[(di*p.InH+hi)*p.InW+wi] += row[i]
+									}
+									i++
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+// packConv3dKernel copies a strided [OutCh, InCh/Groups, KD, KH, KW] kernel
+// into a contiguous buffer.
+func packConv3dKernel[T D](p *candy.Conv3DParams, kernel []T, kernelStrides []int) []T {
+	cg := p.InCh / p.NumGroups()
+	out := make([]T, p.OutCh*cg*p.KD*p.KH*p.KW)
+	i := 0
+	for o := range p.OutCh {
+		for c := range cg {
+			for d := range p.KD {
+				for h := range p.KH {
+					for w := range p.KW {
+						out[i] = kernel[o*kernelStrides[0]+c*kernelStrides[1]+d*kernelStrides[2]+h*kernelStrides[3]+w*kernelStrides[4]]
+						i++
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Conv3d performs 3D convolution with per-axis stride, zero padding and
+// dilation and channel groups, using vol2col and one gemm per group. src and
+// kernel are read through their NCDHW and [OutCh, InCh/Groups, KD, KH, KW]
+// strides; dst receives the output in NDHWC order.
+func Conv3d[T D](p *candy.Conv3DParams, src []T, srcStrides []int, kernel []T, kernelStrides []int, dst []T) {
+	groups := p.NumGroups()
+	k := p.InCh * p.KD * p.KH * p.KW
+	kg, og := k/groups, p.OutCh/groups
+	m := p.Batch * p.OutD() * p.OutH() * p.OutW()
+	col := make([]T, m*k)
+	Vol2col(p, src, srcStrides, col)
+	w := packConv3dKernel(p, kernel, kernelStrides)
+	for g := range groups {
+		gemmStrided(false, true, m, og, kg, col[g*kg:], k, w[g*og*kg:], kg, dst[g*og:], p.OutCh)
+	}
+}
+
+// Conv3dBackward computes the gradients of Conv3d from grad, read through
+// NCDHW strides. dx receives the contiguous NCDHW input gradient and dw the
+// contiguous [OutCh, InCh/Groups, KD, KH, KW] kernel gradient; either may be
+// nil to skip it.
+func Conv3dBackward[T D](p *candy.Conv3DParams, src []T, srcStrides []int, kernel []T, kernelStrides []int, grad []T, gradStrides []int, dx, dw []T) {
+	dOut, hOut, wOut := p.OutD(), p.OutH(), p.OutW()
+	groups := p.NumGroups()
+	k := p.InCh * p.KD * p.KH * p.KW
+	kg, og := k/groups, p.OutCh/groups
+	m := p.Batch * dOut * hOut * wOut
+	g := gatherChannelsLast(grad, gradStrides, p.Batch, p.OutCh, dOut, hOut, wOut)
+	if dw != nil {
+		col := make([]T, m*k)
+		Vol2col(p, src, srcStrides, col)
+		for gi := range groups {
+			gemmStrided(true, false, og, kg, m, g[gi*og:], p.OutCh, col[gi*kg:], k, dw[gi*og*kg:], kg)
+		}
+	}
+	if dx != nil {
+		w := packConv3dKernel(p, kernel, kernelStrides)
+		dcol := make([]T, m*k)
+		for gi := range groups {
+			gemmStrided(false, false, m, kg, og, g[gi*og:], p.OutCh, w[gi*og*kg:], kg, dcol[gi*kg:], k)
+		}
+		Col2vol(p, dcol, dx)
+	}
+}
+
+// ConvTranspose3d performs 3D transposed convolution as the input gradient
+// of the matching Conv3d: src and kernel are read through their NCDHW and
+// [InCh, OutCh, KD, KH, KW] strides and dst receives contiguous NCDHW output.
+func ConvTranspose3d[T D](p *candy.ConvT3DParams, src []T, srcStrides []int, kernel []T, kernelStrides []int, dst []T) {
+	cp := p.Conv3D()
+	Conv3dBackward(&cp, nil, nil, kernel, kernelStrides, src, srcStrides, dst, nil)
+}
+
+// gatherChannelsLast copies a strided NCDHW tensor into an [N*D*H*W, C]
+// matrix.
+func gatherChannelsLast[T D](src []T, strides []int, n, c, d, h, w int) []T {
+	m := n * d * h * w
+	out := make([]T, m*c)
+	ParallelFor(m, rowGrain(len(out), m), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, di, hi, wi := r/(d*h*w), r/(h*w)%d, r/w%h, r%w
+			base := b*strides[0] + di*strides[2] + hi*strides[3] + wi*strides[4]
+			for ci := range c {
+				out[r*c+ci] = src[base+ci*strides[1]]
+			}
+		}
+	})
+	return out
+}
+
+// NaiveConv3d performs 3D convolution using direct loops, reading src and
+// kernel through their strides and writing contiguous NCDHW output.
+func NaiveConv3d[T D](p *candy.Conv3DParams, src []T, srcStrides []int, kernel []T, kernelStrides []int, dst []T) {
+	dOut, hOut, wOut := p.OutD(), p.OutH(), p.OutW()
+	pd, ph, pw := p.Padding()
+	sd, sh, sw := p.Strides()
+	dd, dh, dw := p.Dilations()
+	cg, og := p.InCh/p.NumGroups(), p.OutCh/p.NumGroups()
+	ParallelFor(p.Batch*p.OutCh, rowGrain(len(dst)*cg*p.KD*p.KH*p.KW, p.Batch*p.OutCh), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/p.OutCh, r%p.OutCh
+			for do := range dOut {
+				for ho := range hOut {
+					for wo := range wOut {
+						var sum T
+						for c := range cg {
+							ci := co/og*cg + c
+							for kd := range p.KD {
+								di := do*sd + kd*dd - pd
+								if di < 0 || di >= p.InD {
+									continue
+								}
+								for kh := range p.KH {
+									hi := ho*sh + kh*dh - ph
+									if hi < 0 || hi >= p.InH {
+										continue
+									}
+									for kw := range p.KW {
+										wi := wo*sw + kw*dw - pw
+										if wi < 0 || wi >= p.InW {
+											continue
+										}
+										srcIdx := b*srcStrides[0] + ci*srcStrides[1] + di*srcStrides[2] + hi*srcStrides[3] + wi*srcStrides[4]
+										kernelIdx := co*kernelStrides[0] + c*kernelStrides[1] + kd*kernelStrides[2] + kh*kernelStrides[3] + kw*kernelStrides[4]
+										sum += src[srcIdx] * kernel[kernelIdx]
+									}
+								}
+							}
+						}
+						dst[(((r*dOut)+do)*hOut+ho)*wOut+wo] = sum
+					}
+				}
+			}
+		}
+	})
+}
+
+// NaiveConvTranspose3d performs 3D transposed convolution using direct
+// loops, reading src and kernel through their strides and writing contiguous
+// NCDHW output.
+func NaiveConvTranspose3d[T D](p *candy.ConvT3DParams, src []T, srcStrides []int, kernel []T, kernelStrides []int, dst []T) {
+	dOut, hOut, wOut := p.OutD(), p.OutH(), p.OutW()
+	s, pad, dil := p.Stride, p.Pad, p.Dilate
+	ParallelFor(p.Batch*p.OutCh, rowGrain(len(dst)*p.InCh*p.KD*p.KH*p.KW, p.Batch*p.OutCh), func(start, end int) {
+		for r := start; r < end; r++ {
+			b, co := r/p.OutCh, r%p.OutCh
+			for do := range dOut {
+				for ho := range hOut {
+					for wo := range wOut {
+						var sum T
+						for ci := range p.InCh {
+							for kd := range p.KD {
+								dStride := do + pad - kd*dil
+								if dStride%s != 0 || dStride < 0 || dStride/s >= p.InD {
+									continue
+								}
+								for kh := range p.KH {
+									hStride := ho + pad - kh*dil
+									if hStride%s != 0 || hStride < 0 || hStride/s >= p.InH {
+										continue
+									}
+									for kw := range p.KW {
+										wStride := wo + pad - kw*dil
+										if wStride%s != 0 || wStride < 0 || wStride/s >= p.InW {
+											continue
+										}
+										srcIdx := b*srcStrides[0] + ci*srcStrides[1] + dStride/s*srcStrides[2] + hStride/s*srcStrides[3] + wStride/s*srcStrides[4]
+										kernelIdx := ci*kernelStrides[0] + co*kernelStrides[1] + kd*kernelStrides[2] + kh*kernelStrides[3] + kw*kernelStrides[4]
+										sum += src[srcIdx] * kernel[kernelIdx]
+									}
+								}
+							}
+						}
+						dst[(((r*dOut)+do)*hOut+ho)*wOut+wo] = sum
+					}
+				}
+			}
+		}
+	})
+}
+
+// pool3dWindows calls fn for every output position of a 3D pooling over
+// a strided NCDHW src, passing the (b, c) row, the contiguous output index
+// and the source indices of the window.
+func pool3dWindows(bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW int, srcStrides []int, fn func(out int, window []int)) {
+	dOut, hOut, wOut := (dIn-kD)/sD+1, (hIn-kH)/sH+1, (wIn-kW)/sW+1
+	rows := bSize * c
+	ParallelFor(rows, rowGrain(rows*dOut*hOut*wOut*kD*kH*kW, rows), func(start, end int) {
+		window := make([]int, kD*kH*kW)
+		for r := start; r < end; r++ {
+			base := r/c*srcStrides[0] + r%c*srcStrides[1]
+			for do := range dOut {
+				for ho := range hOut {
+					for wo := range wOut {
+						i := 0
+						for kd := range kD {
+							for kh := range kH {
+								for kw := range kW {
+									window[i] = base + (do*sD+kd)*srcStrides[2] + (ho*sH+kh)*srcStrides[3] + (wo*sW+kw)*srcStrides[4]
+									i++
+								}
+							}
+						}
+						fn(((r*dOut+do)*hOut+ho)*wOut+wo, window)
+					}
+				}
+			}
+		}
+	})
+}
+
+// AvgPool3d performs 3D average pooling without padding, reading src through
+// NCDHW strides and writing contiguous output.
+func AvgPool3d[T D](bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW int, src []T, srcStrides []int, dst []T) {
+	n := float64(kD * kH * kW)
+	pool3dWindows(bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW, srcStrides, func(out int, window []int) {
+		var sum float64
+		for _, i := range window {
+			sum += float64(src[i])
+		}
+		dst[out] = T(sum / n)
+	})
+}
+
+// AvgPool3dBackward accumulates grad, read through NCDHW strides, evenly
+// over each pooling window of the contiguous input gradient dx.
+func AvgPool3dBackward[T D](bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW int, grad []T, gradStrides []int, dx []T) {
+	dOut, hOut, wOut := (dIn-kD)/sD+1, (hIn-kH)/sH+1, (wIn-kW)/sW+1
+	g := make([]T, bSize*c*dOut*hOut*wOut)
+	gatherStrided(grad, gradStrides, []int{bSize, c, dOut, hOut, wOut}, g)
+	n := float64(kD * kH * kW)
+	// Rows own disjoint (b, c) slices of dx, so windows may overlap safely.
+	pool3dWindows(bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW, contiguousStrides(bSize, c, dIn, hIn, wIn), func(out int, window []int) {
+		v := T(float64(g[out]) / n)
+		for _, i := range window {
+			dx[i] += v
+		}
+	})
+}
+
+// MaxPool3d performs 3D max pooling without padding, reading src through
+// NCDHW strides and writing contiguous output.
+func MaxPool3d[T D](bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW int, src []T, srcStrides []int, dst []T) {
+	pool3dWindows(bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW, srcStrides, func(out int, window []int) {
+		m := src[window[0]]
+		for _, i := range window[1:] {
+			m = max(m, src[i])
+		}
+		dst[out] = m
+	})
+}
+
+// MaxPool3dBackward adds grad, read through NCDHW strides, to the first
+// maximum of each pooling window of src in the contiguous input gradient dx.
+func MaxPool3dBackward[T D](bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW int, src []T, srcStrides []int, grad []T, gradStrides []int, dx []T) {
+	dOut, hOut, wOut := (dIn-kD)/sD+1, (hIn-kH)/sH+1, (wIn-kW)/sW+1
+	g := make([]T, bSize*c*dOut*hOut*wOut)
+	gatherStrided(grad, gradStrides, []int{bSize, c, dOut, hOut, wOut}, g)
+	srcS, dxS := srcStrides, contiguousStrides(bSize, c, dIn, hIn, wIn)
+	pool3dWindows(bSize, c, dIn, hIn, wIn, kD, kH, kW, sD, sH, sW, dxS, func(out int, window []int) {
+		best := 0
+		bestVal := src[stridedFrom(window[0], dxS, srcS)]
+		for j, i := range window[1:] {
+			if v := src[stridedFrom(i, dxS, srcS)]; v > bestVal {
+				best, bestVal = j+1, v
+			}
+		}
+		dx[window[best]] += g[out]
+	})
+}
+
+// UpsampleNearest3d performs 3D nearest neighbor upsampling, reading src
+// through NCDHW strides and writing contiguous output. Source coordinates
+// follow UpsampleNearest2d.
+func UpsampleNearest3d[T D](bSize, c, dIn, hIn, wIn, dOut, hOut, wOut int, src []T, srcStrides []int, dst []T) {
+	dIdx, hIdx, wIdx := nearestIndex(dIn, dOut), nearestIndex(hIn, hOut), nearestIndex(wIn, wOut)
+	rows := bSize * c
+	ParallelFor(rows, rowGrain(len(dst), rows), func(start, end int) {
+		for r := start; r < end; r++ {
+			base := r/c*srcStrides[0] + r%c*srcStrides[1]
+			out := dst[r*dOut*hOut*wOut:]
+			for do, di := range dIdx {
+				for ho, hi := range hIdx {
+					for wo, wi := range wIdx {
+						out[(do*hOut+ho)*wOut+wo] = src[base+di*srcStrides[2]+hi*srcStrides[3]+wi*srcStrides[4]]
+					}
+				}
+			}
+		}
+	})
+}
+
+// UpsampleNearest3dBackward sums grad, read through NCDHW strides, into the
+// source elements of the contiguous input gradient dx.
+func UpsampleNearest3dBackward[T D](bSize, c, dIn, hIn, wIn, dOut, hOut, wOut int, grad []T, gradStrides []int, dx []T) {
+	dIdx, hIdx, wIdx := nearestIndex(dIn, dOut), nearestIndex(hIn, hOut), nearestIndex(wIn, wOut)
+	rows := bSize * c
+	ParallelFor(rows, rowGrain(rows*dOut*hOut*wOut, rows), func(start, end int) {
+		for r := start; r < end; r++ {
+			base := r/c*gradStrides[0] + r%c*gradStrides[1]
+			v := dx[r*dIn*hIn*wIn:]
+			for do, di := range dIdx {
+				for ho, hi := range hIdx {
+					for wo, wi := range wIdx {
+						v[(di*hIn+hi)*wIn+wi] += grad[base+do*gradStrides[2]+ho*gradStrides[3]+wo*gradStrides[4]]
+					}
+				}
+			}
+		}
+	})
+}
+
+// nearestIndex returns the source coordinate of every output coordinate when
+// resizing an axis from in to out with nearest neighbor sampling.
+func nearestIndex(in, out int) []int {
+	idx := make([]int, out)
+	for o := range idx {
+		idx[o] = min(int(math.Floor((float64(o)+0.5)*float64(in)/float64(out))), in-1)
+	}
+	return idx
+}
+
+// contiguousStrides returns the row-major strides of dims.
+func contiguousStrides(dims ...int) []int {
+	strides := make([]int, len(dims))
+	s := 1
+	for i := len(dims) - 1; i >= 0; i-- {
+		strides[i] = s
+		s *= dims[i]
+	}
+	return strides
+}
+
+// stridedFrom converts offset i under strides from into the offset of the
+// same element under strides to.
+func stridedFrom(i int, from, to []int) int {
+	j := 0
+	for d, s := range from {
+		j += i / s * to[d]
+		i %= s
+	}
+	return j
+}
+
+// gatherStrided copies the elements of src with the given dims and strides
+// into dst in row-major order.
+func gatherStrided[T D](src []T, strides, dims []int, dst []T) {
+	cs := contiguousStrides(dims...)
+	for i := range dst {
+		dst[i] = src[stridedFrom(i, cs, strides)]
+	}
+}
@@ -0,0 +1,182 @@
+package kernels_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
+)
+
+// ramp returns n values cycling through [-m/2, m/2).
+func ramp(n, m int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = float64(i%m) - float64(m/2)
+	}
+	return out
+}
+
+func TestConv3d(t *testing.T) {
+	tests := []struct {
+		name string
+		p    candy.Conv3DParams
+	}{
+		{"uniform", candy.Conv3DParams{Batch: 2, InCh: 2, OutCh: 3, InD: 4, InH: 5, InW: 4, KD: 3, KH: 3, KW: 3, Pad: 1, Stride: 1, Dilate: 1}},
+		{"per-axis", candy.Conv3DParams{Batch: 1, InCh: 2, OutCh: 2, InD: 5, InH: 4, InW: 6, KD: 1, KH: 2, KW: 3, PadD: 1, PadW: 2, StrideD: 2, StrideH: 1, StrideW: 2, DilateD: 1, DilateH: 2, DilateW: 1}},
+		{"groups", candy.Conv3DParams{Batch: 1, InCh: 4, OutCh: 6, InD: 3, InH: 4, InW: 4, KD: 2, KH: 3, KW: 2, Pad: 1, Stride: 1, Dilate: 1, Groups: 2}},
+		{"depthwise", candy.Conv3DParams{Batch: 2, InCh: 3, OutCh: 3, InD: 4, InH: 4, InW: 5, KD: 3, KH: 3, KW: 3, Pad: 1, Stride: 2, Dilate: 1, Groups: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.p
+			src := ramp(p.Batch*p.InCh*p.InD*p.InH*p.InW, 7)
+			kernel := ramp(p.OutCh*p.InCh/p.NumGroups()*p.KD*p.KH*p.KW, 5)
+			srcStrides := []int{p.InCh * p.InD * p.InH * p.InW, p.InD * p.InH * p.InW, p.InH * p.InW, p.InW, 1}
+			kk := len(kernel) / p.OutCh
+			kernelStrides := []int{kk, p.KD * p.KH * p.KW, p.KH * p.KW, p.KW, 1}
+			spatial := p.OutD() * p.OutH() * p.OutW()
+			want := make([]float64, p.Batch*p.OutCh*spatial)
+			kernels.NaiveConv3d(&p, src, srcStrides, kernel, kernelStrides, want)
+			dst := make([]float64, len(want))
+			kernels.Conv3d(&p, src, srcStrides, kernel, kernelStrides, dst)
+			for b := range p.Batch {
+				for o := range p.OutCh {
+					for i := range spatial {
+						got := dst[(b*spatial+i)*p.OutCh+o]
+						if w := want[(b*p.OutCh+o)*spatial+i]; math.Abs(got-w) > 1e-9 {
+							t.Fatalf("dst[%d,%d,%d] = %v, want %v", b, o, i, got, w)
+						}
+					}
+				}
+			}
+
+			// The backward pass is the adjoint: <conv(x), g> = <x, dx> and <w, dw>.
+			grad := ramp(len(want), 3)
+			dx := make([]float64, len(src))
+			dw := make([]float64, len(kernel))
+			kernels.Conv3dBackward(&p, src, srcStrides, kernel, kernelStrides,
+				grad, []int{p.OutCh * spatial, spatial, p.OutH() * p.OutW(), p.OutW(), 1}, dx, dw)
+			var lhs, rx, rw float64
+			for i := range want {
+				lhs += want[i] * grad[i]
+			}
+			for i := range src {
+				rx += src[i] * dx[i]
+			}
+			for i := range kernel {
+				rw += kernel[i] * dw[i]
+			}
+			if math.Abs(lhs-rx) > 1e-9 || math.Abs(lhs-rw) > 1e-9 {
+				t.Fatalf("adjoint mismatch: <y,g>=%v <x,dx>=%v <w,dw>=%v", lhs, rx, rw)
+			}
+		})
+	}
+}
+
+func TestConvTranspose3d(t *testing.T) {
+	tests := []struct {
+		name string
+		p    candy.ConvT3DParams
+	}{
+		{"unit", candy.ConvT3DParams{Batch: 1, InCh: 2, OutCh: 3, InD: 3, InH: 3, InW: 4, KD: 2, KH: 2, KW: 2, Stride: 1, Dilate: 1}},
+		{"strided", candy.ConvT3DParams{Batch: 2, InCh: 3, OutCh: 2, InD: 2, InH: 3, InW: 3, KD: 3, KH: 3, KW: 3, Pad: 1, OutPad: 1, Stride: 2, Dilate: 1}},
+		{"dilated", candy.ConvT3DParams{Batch: 1, InCh: 2, OutCh: 2, InD: 3, InH: 2, InW: 3, KD: 2, KH: 3, KW: 2, Pad: 1, Stride: 1, Dilate: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.p
+			src := ramp(p.Batch*p.InCh*p.InD*p.InH*p.InW, 7)
+			kernel := ramp(p.InCh*p.OutCh*p.KD*p.KH*p.KW, 5)
+			// Read src through a (b, c) transposed view to cover strided input.
+			sp := p.InD * p.InH * p.InW
+			srcT := make([]float64, len(src))
+			for b := range p.Batch {
+				for c := range p.InCh {
+					copy(srcT[(c*p.Batch+b)*sp:], src[(b*p.InCh+c)*sp:(b*p.InCh+c+1)*sp])
+				}
+			}
+			srcStrides := []int{sp, p.Batch * sp, p.InH * p.InW, p.InW, 1}
+			kernelStrides := []int{p.OutCh * p.KD * p.KH * p.KW, p.KD * p.KH * p.KW, p.KH * p.KW, p.KW, 1}
+			want := make([]float64, p.Batch*p.OutCh*p.OutD()*p.OutH()*p.OutW())
+			kernels.NaiveConvTranspose3d(&p, srcT, srcStrides, kernel, kernelStrides, want)
+			dst := make([]float64, len(want))
+			kernels.ConvTranspose3d(&p, srcT, srcStrides, kernel, kernelStrides, dst)
+			for i := range want {
+				if math.Abs(dst[i]-want[i]) > 1e-9 {
+					t.Fatalf("dst[%d] = %v, want %v", i, dst[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPool3d(t *testing.T) {
+	// One 1x1x2x2x4 volume: depth slices [0..7] and [8..15].
+	src := ramp(16, 16)
+	for i := range src {
+		src[i] += 8
+	}
+	src[5] = 20 // Move the first window's maximum off its last element.
+	strides := []int{16, 16, 8, 4, 1}
+
+	avg := make([]float64, 2)
+	kernels.AvgPool3d(1, 1, 2, 2, 4, 2, 2, 2, 2, 2, 2, src, strides, avg)
+	if want := []float64{(0 + 1 + 4 + 20 + 8 + 9 + 12 + 13) / 8.0, (2 + 3 + 6 + 7 + 10 + 11 + 14 + 15) / 8.0}; avg[0] != want[0] || avg[1] != want[1] {
+		t.Errorf("AvgPool3d = %v, want %v", avg, want)
+	}
+	maxv := make([]float64, 2)
+	kernels.MaxPool3d(1, 1, 2, 2, 4, 2, 2, 2, 2, 2, 2, src, strides, maxv)
+	if maxv[0] != 20 || maxv[1] != 15 {
+		t.Errorf("MaxPool3d = %v, want [20 15]", maxv)
+	}
+	dx := make([]float64, 16)
+	kernels.MaxPool3dBackward(1, 1, 2, 2, 4, 2, 2, 2, 2, 2, 2, src, strides, []float64{1, 2}, []int{2, 2, 2, 2, 1}, dx)
+	for i, v := range dx {
+		want := 0.0
+		switch i {
+		case 5:
+			want = 1
+		case 15:
+			want = 2
+		}
+		if v != want {
+			t.Fatalf("MaxPool3dBackward dx[%d] = %v, want %v", i, v, want)
+		}
+	}
+	dx = make([]float64, 16)
+	kernels.AvgPool3dBackward(1, 1, 2, 2, 4, 2, 2, 2, 2, 2, 2, []float64{8, 16}, []int{2, 2, 2, 2, 1}, dx)
+	for i, v := range dx {
+		if want := []float64{1, 2}[i%4/2]; v != want {
+			t.Fatalf("AvgPool3dBackward dx[%d] = %v, want %v", i, v, want)
+		}
+	}
+}
+
+func TestUpsampleNearest3d(t *testing.T) {
+	src := []float64{1, 2, 3, 4, 5, 6, 7, 8} // 1x1x2x2x2
+	strides := []int{8, 8, 4, 2, 1}
+	dst := make([]float64, 4*2*6)
+	kernels.UpsampleNearest3d(1, 1, 2, 2, 2, 4, 2, 6, src, strides, dst)
+	for d := range 4 {
+		for h := range 2 {
+			for w := range 6 {
+				want := src[d/2*4+h*2+w/3]
+				if got := dst[(d*2+h)*6+w]; got != want {
+					t.Fatalf("dst[%d,%d,%d] = %v, want %v", d, h, w, got, want)
+				}
+			}
+		}
+	}
+	grad := make([]float64, len(dst))
+	for i := range grad {
+		grad[i] = 1
+	}
+	dx := make([]float64, 8)
+	kernels.UpsampleNearest3dBackward(1, 1, 2, 2, 2, 4, 2, 6, grad, []int{48, 48, 12, 6, 1}, dx)
+	for i, v := range dx {
+		if v != 6 {
+			t.Fatalf("dx[%d] = %v, want 6", i, v)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/gocnn/candy"
 	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
 )
 
@@ -4988,3 +4989,120 @@ func TestCol2im1dStridedF64(t *testing.T) {
 		})
 	}
 }
+
+// refConv2d is a direct-loop reference for GroupedConv2d, writing NCHW output.
+func refConv2d(p *candy.Conv2DParams, src, kernel []float64) []float64 {
+	ph, pw := p.Padding()
+	sh, sw := p.Strides()
+	dh, dw := p.Dilations()
+	hOut, wOut := p.OutH(), p.OutW()
+	cg, og := p.InCh/p.NumGroups(), p.OutCh/p.NumGroups()
+	at := func(i, n int) (int, bool) {
+		if i >= 0 && i < n {
+			return i, true
+		}
+		switch p.PadMode {
+		case candy.PadReflect:
+			if i < 0 {
+				return -i, true
+			}
+			return 2*(n-1) - i, true
+		case candy.PadReplicate:
+			return min(max(i, 0), n-1), true
+		case candy.PadCircular:
+			return (i + n) % n, true
+		}
+		return 0, false
+	}
+	dst := make([]float64, p.Batch*p.OutCh*hOut*wOut)
+	for b := range p.Batch {
+		for o := range p.OutCh {
+			for ho := range hOut {
+				for wo := range wOut {
+					var sum float64
+					for c := range cg {
+						ci := o/og*cg + c
+						for kh := range p.KH {
+							for kw := range p.KW {
+								hi, okh := at(ho*sh+kh*dh-ph, p.InH)
+								wi, okw := at(wo*sw+kw*dw-pw, p.InW)
+								if okh && okw {
+									sum += src[((b*p.InCh+ci)*p.InH+hi)*p.InW+wi] * kernel[((o*cg+c)*p.KH+kh)*p.KW+kw]
+								}
+							}
+						}
+					}
+					dst[((b*p.OutCh+o)*hOut+ho)*wOut+wo] = sum
+				}
+			}
+		}
+	}
+	return dst
+}
+
+func TestGroupedConv2d(t *testing.T) {
+	tests := []struct {
+		name string
+		p    candy.Conv2DParams
+	}{
+		{"uniform", candy.Conv2DParams{Batch: 2, InCh: 2, OutCh: 3, InH: 5, InW: 6, KH: 3, KW: 3, Pad: 1, Stride: 1, Dilate: 1}},
+		{"per-axis", candy.Conv2DParams{Batch: 1, InCh: 2, OutCh: 2, InH: 7, InW: 6, KH: 1, KW: 3, PadH: 2, PadW: 1, StrideH: 2, StrideW: 1, DilateH: 1, DilateW: 2}},
+		{"depthwise", candy.Conv2DParams{Batch: 2, InCh: 4, OutCh: 4, InH: 5, InW: 5, KH: 3, KW: 3, Pad: 1, Stride: 2, Dilate: 1, Groups: 4}},
+		{"groups", candy.Conv2DParams{Batch: 1, InCh: 4, OutCh: 6, InH: 4, InW: 5, KH: 2, KW: 3, Pad: 1, Stride: 1, Dilate: 1, Groups: 2}},
+		{"reflect", candy.Conv2DParams{Batch: 1, InCh: 2, OutCh: 2, InH: 4, InW: 5, KH: 3, KW: 3, Pad: 2, Stride: 1, Dilate: 1, PadMode: candy.PadReflect}},
+		{"replicate", candy.Conv2DParams{Batch: 1, InCh: 1, OutCh: 2, InH: 3, InW: 4, KH: 3, KW: 2, Pad: 2, Stride: 1, Dilate: 1, PadMode: candy.PadReplicate}},
+		{"circular", candy.Conv2DParams{Batch: 1, InCh: 2, OutCh: 2, InH: 4, InW: 3, KH: 3, KW: 3, Pad: 1, Stride: 1, Dilate: 2, PadMode: candy.PadCircular}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.p
+			src := make([]float64, p.Batch*p.InCh*p.InH*p.InW)
+			for i := range src {
+				src[i] = float64(i%7) - 3
+			}
+			kernel := make([]float64, p.OutCh*p.InCh/p.NumGroups()*p.KH*p.KW)
+			for i := range kernel {
+				kernel[i] = float64(i%5) - 2
+			}
+			want := refConv2d(&p, src, kernel)
+			hOut, wOut := p.OutH(), p.OutW()
+			dst := make([]float64, len(want))
+			kernels.GroupedConv2d(&p, src, []int{p.InCh * p.InH * p.InW, p.InH * p.InW, p.InW, 1},
+				kernel, []int{len(kernel) / p.OutCh, p.KH * p.KW, p.KW, 1}, dst)
+			for b := range p.Batch {
+				for o := range p.OutCh {
+					for i := range hOut * wOut {
+						got := dst[(b*hOut*wOut+i)*p.OutCh+o]
+						if w := want[(b*p.OutCh+o)*hOut*wOut+i]; math.Abs(got-w) > 1e-9 {
+							t.Fatalf("dst[%d,%d,%d] = %v, want %v", b, o, i, got, w)
+						}
+					}
+				}
+			}
+
+			// The backward pass is the adjoint: <conv(x), g> = <x, dx> and <w, dw>.
+			grad := make([]float64, len(want))
+			for i := range grad {
+				grad[i] = float64(i%3) - 1
+			}
+			dx := make([]float64, len(src))
+			dw := make([]float64, len(kernel))
+			kernels.GroupedConv2dBackward(&p, src, []int{p.InCh * p.InH * p.InW, p.InH * p.InW, p.InW, 1},
+				kernel, []int{len(kernel) / p.OutCh, p.KH * p.KW, p.KW, 1},
+				grad, []int{p.OutCh * hOut * wOut, hOut * wOut, wOut, 1}, dx, dw)
+			var lhs, rx, rw float64
+			for i := range want {
+				lhs += want[i] * grad[i]
+			}
+			for i := range src {
+				rx += src[i] * dx[i]
+			}
+			for i := range kernel {
+				rw += kernel[i] * dw[i]
+			}
+			if math.Abs(lhs-rx) > 1e-9 || math.Abs(lhs-rw) > 1e-9 {
+				t.Fatalf("adjoint mismatch: <y,g>=%v <x,dx>=%v <w,dw>=%v", lhs, rx, rw)
+			}
+		})
+	}
+}
@@ -5,7 +5,7 @@ import "github.com/gocnn/candy"
 type D interface {
 	candy.D
 
-	~float32 | ~float64 | ~uint8 | ~uint32 | ~int64
+	~float32 | ~float64 | ~uint8 | ~uint32 | ~int64 | candy.Float16 | candy.BFloat16
 }
 
 type I interface {
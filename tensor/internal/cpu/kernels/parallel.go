@@ -0,0 +1,92 @@
+package kernels
+
+import (
+	"sync"
+
+	"github.com/gocnn/candy"
+)
+
+// grainSize is the minimum number of elements handled by one parallel block.
+// Ops smaller than two blocks run serially on the calling goroutine.
+const grainSize = 1 << 14
+
+var (
+	poolMu  sync.Mutex
+	workers int
+	tasks   = make(chan func())
+)
+
+// ensureWorkers grows the shared worker pool to at least n goroutines.
+func ensureWorkers(n int) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	for ; workers < n; workers++ {
+		go func() {
+			for task := range tasks {
+				task()
+			}
+		}()
+	}
+}
+
+// ParallelFor partitions [0, n) into at most candy.NumThreads blocks of at
+// least grain items and runs fn on each block, using the shared worker pool.
+// Blocks must write disjoint outputs. When no worker is idle a block runs on
+// the calling goroutine, so nested calls cannot deadlock. A panic in any block
+// is re-raised on the caller.
+func ParallelFor(n, grain int, fn func(start, end int)) {
+	blocks := min(candy.NumThreads(), n/max(grain, 1))
+	if blocks <= 1 {
+		fn(0, n)
+		return
+	}
+	ensureWorkers(blocks - 1)
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		perr any
+	)
+	size := (n + blocks - 1) / blocks
+	for start := size; start < n; start += size {
+		end := min(start+size, n)
+		wg.Add(1)
+		task := func() {
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { perr = r })
+				}
+				wg.Done()
+			}()
+			fn(start, end)
+		}
+		select {
+		case tasks <- task:
+		default:
+			task()
+		}
+	}
+	wg.Add(1)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				once.Do(func() { perr = r })
+			}
+			wg.Done()
+		}()
+		fn(0, size)
+	}()
+	wg.Wait()
+	if perr != nil {
+		panic(perr)
+	}
+}
+
+// rowGrain returns the minimum number of rows per block so that a block
+// covers about grainSize of the numel elements spread across rows.
+func rowGrain(numel, rows int) int {
+	if numel <= 0 {
+		return 1
+	}
+	return max(1, grainSize*rows/numel)
+}
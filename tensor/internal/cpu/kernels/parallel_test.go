@@ -0,0 +1,74 @@
+package kernels_test
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
+)
+
+func TestParallelFor(t *testing.T) {
+	defer candy.SetNumThreads(0)
+	for _, threads := range []int{1, 3, 8} {
+		candy.SetNumThreads(threads)
+		for _, n := range []int{0, 1, 100, 1 << 16, 1<<16 + 7} {
+			var total atomic.Int64
+			seen := make([]int32, n)
+			kernels.ParallelFor(n, 1000, func(start, end int) {
+				for i := start; i < end; i++ {
+					seen[i]++
+				}
+				total.Add(int64(end - start))
+			})
+			if int(total.Load()) != n || slices.ContainsFunc(seen, func(v int32) bool { return v != 1 }) {
+				t.Fatalf("threads=%d n=%d: blocks do not cover the range exactly once", threads, n)
+			}
+		}
+	}
+}
+
+func TestParallelForPanic(t *testing.T) {
+	defer candy.SetNumThreads(0)
+	candy.SetNumThreads(4)
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic to reach the caller")
+		}
+	}()
+	kernels.ParallelFor(1<<16, 1, func(start, end int) {
+		if start > 0 {
+			panic("boom")
+		}
+	})
+}
+
+func TestParallelMatchesSerial(t *testing.T) {
+	defer candy.SetNumThreads(0)
+	const rows, cols = 300, 257
+	x := make([]float64, rows*cols)
+	for i := range x {
+		x[i] = float64(i%97) / 13
+	}
+	dims := []int{cols, rows}
+	strides := []int{1, cols}
+	run := func(threads int) [][]float64 {
+		candy.SetNumThreads(threads)
+		exp := make([]float64, len(x))
+		kernels.UExpStrided(len(x), 2, dims, strides, x, exp)
+		sum := make([]float64, cols)
+		kernels.FastSumStrided(len(x), 2, dims, strides, x, sum)
+		soft := make([]float64, len(x))
+		kernels.FastSoftmax(len(x), 2, []int{rows, cols}, x, soft)
+		mm := make([]float64, 2*rows*rows)
+		kernels.NaiveBatchedMatMul(2, rows, rows, cols/2, x, x, mm)
+		return [][]float64{exp, sum, soft, mm}
+	}
+	serial, parallel := run(1), run(8)
+	for i := range serial {
+		if !slices.Equal(serial[i], parallel[i]) {
+			t.Fatalf("output %d differs between serial and parallel runs", i)
+		}
+	}
+}
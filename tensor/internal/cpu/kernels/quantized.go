@@ -1,7 +1,19 @@
 package kernels
 
 import (
+	"encoding/binary"
 	"math"
+	"unsafe"
+
+	"github.com/gocnn/candy"
+)
+
+const (
+	QK  = 32  // values per q4_0, q4_1, q5_0, q5_1 and q8_0 block
+	QKK = 256 // values per k-quant super-block
+
+	// groupMaxEps is the magnitude below which a group quantizes to zero.
+	groupMaxEps = 1e-15
 )
 
 // BlockQ4_0 represents a q4_0 block
@@ -81,25 +93,135 @@ type BlockQ8K struct {
 	Bsums [16]int16 // sum of quants in groups of 16
 }
 
+// BlocksFromBytes reinterprets packed little-endian block data as a slice of
+// blocks without copying. The data is copied into a fresh slice when it is not
+// aligned for B. Trailing bytes that do not form a whole block are ignored.
+func BlocksFromBytes[B any](data []byte) []B {
+	var zero B
+	n := len(data) / int(unsafe.Sizeof(zero))
+	if n == 0 {
+		return nil
+	}
+	if uintptr(unsafe.Pointer(&data[0]))%unsafe.Alignof(zero) != 0 {
+		out := make([]B, n)
+		copy(BytesFromBlocks(out), data)
+		return out
+	}
+	return unsafe.Slice((*B)(unsafe.Pointer(&data[0])), n)
+}
+
+// BytesFromBlocks returns the packed byte view of blocks without copying.
+func BytesFromBlocks[B any](blocks []B) []byte {
+	if len(blocks) == 0 {
+		return nil
+	}
+	var zero B
+	return unsafe.Slice((*byte)(unsafe.Pointer(&blocks[0])), len(blocks)*int(unsafe.Sizeof(zero)))
+}
+
+// DequantizeBlocksF32 dequantizes contiguous blocks of n values each into y
+// using the per-block routine fn, in parallel over blocks.
+func DequantizeBlocksF32[B any](blocks []B, n int, y []float32, fn func(*B, []float32)) {
+	ParallelFor(len(blocks), max(grainSize/n, 1), func(start, end int) {
+		for i := start; i < end; i++ {
+			fn(&blocks[i], y[i*n:(i+1)*n])
+		}
+	})
+}
+
+// nearestInt rounds to the nearest integer, ties to even.
+func nearestInt(x float32) int {
+	return int(math.RoundToEven(float64(x)))
+}
+
+// clampInt limits v to [lo, hi].
+func clampInt(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
+
+// inverse returns 1/d, or 0 when d is 0.
+func inverse(d float32) float32 {
+	if d == 0 {
+		return 0
+	}
+	return 1 / d
+}
+
+// absMax returns the value of x with the largest magnitude and that magnitude.
+func absMax(x []float32) (float32, float32) {
+	var maxV, amax float32
+	for _, v := range x {
+		if a := float32(math.Abs(float64(v))); a > amax {
+			amax, maxV = a, v
+		}
+	}
+	return maxV, amax
+}
+
+// minMax returns the smallest and largest values of x.
+func minMax(x []float32) (float32, float32) {
+	lo, hi := float32(math.Inf(1)), float32(math.Inf(-1))
+	for _, v := range x {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return lo, hi
+}
+
+// fp32ToFP16 converts a float32 to its IEEE 754 half-precision bits.
+func fp32ToFP16(f float32) uint16 {
+	return uint16(candy.NewFloat16(f))
+}
+
+// dequantizeF64 dequantizes one block of n values through the float32 routine fn.
+func dequantizeF64[B any](b *B, y []float64, n int, fn func(*B, []float32)) {
+	var buf [QKK]float32
+	fn(b, buf[:n])
+	for i, v := range buf[:n] {
+		y[i] = float64(v)
+	}
+}
+
+// QuantizeBlockQ4_0F32 quantizes 32 float32 values to a q4_0 block
+func QuantizeBlockQ4_0F32(x []float32, b *BlockQ4_0) {
+	maxV, _ := absMax(x[:QK])
+	d := maxV / -8
+	id := inverse(d)
+	b.D = fp32ToFP16(d)
+	for j := range QK / 2 {
+		xi0 := min(15, int8(x[j]*id+8.5))
+		xi1 := min(15, int8(x[j+QK/2]*id+8.5))
+		b.Qs[j] = byte(xi0) | byte(xi1)<<4
+	}
+}
+
 // DequantizeBlockQ4_0F32 dequantizes a q4_0 block to float32 (contiguous)
 func DequantizeBlockQ4_0F32(b *BlockQ4_0, y []float32) {
 	d := fp16ToFloat32(b.D)
-	for i := 0; i < 32; i += 2 {
-		q1 := int(b.Qs[i/2]) & 0xF
-		q2 := int(b.Qs[i/2]) >> 4
-		y[i] = d * (float32(q1) - 8)
-		y[i+1] = d * (float32(q2) - 8)
+	for j := range QK / 2 {
+		y[j] = d * (float32(b.Qs[j]&0xF) - 8)
+		y[j+QK/2] = d * (float32(b.Qs[j]>>4) - 8)
 	}
 }
 
 // DequantizeBlockQ4_0F64 dequantizes a q4_0 block to float64 (contiguous)
 func DequantizeBlockQ4_0F64(b *BlockQ4_0, y []float64) {
 	d := float64(fp16ToFloat32(b.D))
-	for i := 0; i < 32; i += 2 {
-		q1 := int(b.Qs[i/2]) & 0xF
-		q2 := int(b.Qs[i/2]) >> 4
-		y[i] = d * (float64(q1) - 8)
-		y[i+1] = d * (float64(q2) - 8)
+	for j := range QK / 2 {
+		y[j] = d * (float64(b.Qs[j]&0xF) - 8)
+		y[j+QK/2] = d * (float64(b.Qs[j]>>4) - 8)
+	}
+}
+
+// QuantizeBlockQ4_1F32 quantizes 32 float32 values to a q4_1 block
+func QuantizeBlockQ4_1F32(x []float32, b *BlockQ4_1) {
+	lo, hi := minMax(x[:QK])
+	d := (hi - lo) / 15
+	id := inverse(d)
+	b.DM = [2]uint16{fp32ToFP16(d), fp32ToFP16(lo)}
+	for j := range QK / 2 {
+		xi0 := min(15, int8((x[j]-lo)*id+0.5))
+		xi1 := min(15, int8((x[j+QK/2]-lo)*id+0.5))
+		b.Qs[j] = byte(xi0) | byte(xi1)<<4
 	}
 }
 
@@ -107,11 +229,9 @@ func DequantizeBlockQ4_0F64(b *BlockQ4_0, y []float64) {
 func DequantizeBlockQ4_1F32(b *BlockQ4_1, y []float32) {
 	d := fp16ToFloat32(b.DM[0])
 	m := fp16ToFloat32(b.DM[1])
-	for i := 0; i < 32; i += 2 {
-		q1 := int(b.Qs[i/2]) & 0xF
-		q2 := int(b.Qs[i/2]) >> 4
-		y[i] = d*float32(q1) + m
-		y[i+1] = d*float32(q2) + m
+	for j := range QK / 2 {
+		y[j] = d*float32(b.Qs[j]&0xF) + m
+		y[j+QK/2] = d*float32(b.Qs[j]>>4) + m
 	}
 }
 
@@ -119,18 +239,96 @@ func DequantizeBlockQ4_1F32(b *BlockQ4_1, y []float32) {
 func DequantizeBlockQ4_1F64(b *BlockQ4_1, y []float64) {
 	d := float64(fp16ToFloat32(b.DM[0]))
 	m := float64(fp16ToFloat32(b.DM[1]))
-	for i := 0; i < 32; i += 2 {
-		q1 := int(b.Qs[i/2]) & 0xF
-		q2 := int(b.Qs[i/2]) >> 4
-		y[i] = d*float64(q1) + m
-		y[i+1] = d*float64(q2) + m
+	for j := range QK / 2 {
+		y[j] = d*float64(b.Qs[j]&0xF) + m
+		y[j+QK/2] = d*float64(b.Qs[j]>>4) + m
+	}
+}
+
+// QuantizeBlockQ5_0F32 quantizes 32 float32 values to a q5_0 block
+func QuantizeBlockQ5_0F32(x []float32, b *BlockQ5_0) {
+	maxV, _ := absMax(x[:QK])
+	d := maxV / -16
+	id := inverse(d)
+	b.D = fp32ToFP16(d)
+	var qh uint32
+	for j := range QK / 2 {
+		xi0 := uint32(min(31, int8(x[j]*id+16.5)))
+		xi1 := uint32(min(31, int8(x[j+QK/2]*id+16.5)))
+		b.Qs[j] = byte(xi0&0xF | (xi1&0xF)<<4)
+		qh |= (xi0 & 0x10) >> 4 << j
+		qh |= (xi1 & 0x10) >> 4 << (j + QK/2)
+	}
+	binary.LittleEndian.PutUint32(b.Qh[:], qh)
+}
+
+// DequantizeBlockQ5_0F32 dequantizes a q5_0 block to float32 (contiguous)
+func DequantizeBlockQ5_0F32(b *BlockQ5_0, y []float32) {
+	d := fp16ToFloat32(b.D)
+	qh := binary.LittleEndian.Uint32(b.Qh[:])
+	for j := range QK / 2 {
+		xh0 := byte(qh>>j<<4) & 0x10
+		xh1 := byte(qh>>(j+12)) & 0x10
+		y[j] = d * float32(int(b.Qs[j]&0xF|xh0)-16)
+		y[j+QK/2] = d * float32(int(b.Qs[j]>>4|xh1)-16)
+	}
+}
+
+// DequantizeBlockQ5_0F64 dequantizes a q5_0 block to float64 (contiguous)
+func DequantizeBlockQ5_0F64(b *BlockQ5_0, y []float64) {
+	dequantizeF64(b, y, QK, DequantizeBlockQ5_0F32)
+}
+
+// QuantizeBlockQ5_1F32 quantizes 32 float32 values to a q5_1 block
+func QuantizeBlockQ5_1F32(x []float32, b *BlockQ5_1) {
+	lo, hi := minMax(x[:QK])
+	d := (hi - lo) / 31
+	id := inverse(d)
+	b.DM = [2]uint16{fp32ToFP16(d), fp32ToFP16(lo)}
+	var qh uint32
+	for j := range QK / 2 {
+		xi0 := uint32(uint8((x[j]-lo)*id + 0.5))
+		xi1 := uint32(uint8((x[j+QK/2]-lo)*id + 0.5))
+		b.Qs[j] = byte(xi0&0xF | (xi1&0xF)<<4)
+		qh |= (xi0 & 0x10) >> 4 << j
+		qh |= (xi1 & 0x10) >> 4 << (j + QK/2)
+	}
+	binary.LittleEndian.PutUint32(b.Qh[:], qh)
+}
+
+// DequantizeBlockQ5_1F32 dequantizes a q5_1 block to float32 (contiguous)
+func DequantizeBlockQ5_1F32(b *BlockQ5_1, y []float32) {
+	d := fp16ToFloat32(b.DM[0])
+	m := fp16ToFloat32(b.DM[1])
+	qh := binary.LittleEndian.Uint32(b.Qh[:])
+	for j := range QK / 2 {
+		xh0 := byte(qh>>j<<4) & 0x10
+		xh1 := byte(qh>>(j+12)) & 0x10
+		y[j] = d*float32(b.Qs[j]&0xF|xh0) + m
+		y[j+QK/2] = d*float32(b.Qs[j]>>4|xh1) + m
+	}
+}
+
+// DequantizeBlockQ5_1F64 dequantizes a q5_1 block to float64 (contiguous)
+func DequantizeBlockQ5_1F64(b *BlockQ5_1, y []float64) {
+	dequantizeF64(b, y, QK, DequantizeBlockQ5_1F32)
+}
+
+// QuantizeBlockQ8_0F32 quantizes 32 float32 values to a q8_0 block
+func QuantizeBlockQ8_0F32(x []float32, b *BlockQ8_0) {
+	_, amax := absMax(x[:QK])
+	d := amax / 127
+	id := inverse(d)
+	b.D = fp32ToFP16(d)
+	for j := range QK {
+		b.Qs[j] = int8(math.Round(float64(x[j] * id)))
 	}
 }
 
 // DequantizeBlockQ8_0F32 dequantizes a q8_0 block to float32 (contiguous)
 func DequantizeBlockQ8_0F32(b *BlockQ8_0, y []float32) {
 	d := fp16ToFloat32(b.D)
-	for i := range 32 {
+	for i := range QK {
 		y[i] = d * float32(b.Qs[i])
 	}
 }
@@ -138,11 +336,615 @@ func DequantizeBlockQ8_0F32(b *BlockQ8_0, y []float32) {
 // DequantizeBlockQ8_0F64 dequantizes a q8_0 block to float64 (contiguous)
 func DequantizeBlockQ8_0F64(b *BlockQ8_0, y []float64) {
 	d := float64(fp16ToFloat32(b.D))
-	for i := range 32 {
+	for i := range QK {
+		y[i] = d * float64(b.Qs[i])
+	}
+}
+
+// makeQxQuants picks a symmetric scale for x with levels in [-nmax, nmax),
+// searching around the max-based scale to minimise the x²-weighted error.
+// L receives the levels offset by nmax.
+func makeQxQuants(nmax int, x []float32, L []uint8) float32 {
+	maxV, amax := absMax(x)
+	if amax < groupMaxEps {
+		clear(L[:len(x)])
+		return 0
+	}
+	fit := func(iscale float32) (float32, float32) {
+		var sumlx, suml2 float32
+		for _, v := range x {
+			l := float32(clampInt(nearestInt(iscale*v), -nmax, nmax-1))
+			w := v * v
+			sumlx += w * v * l
+			suml2 += w * l * l
+		}
+		return sumlx, suml2
+	}
+	assign := func(iscale float32) {
+		for i, v := range x {
+			L[i] = uint8(nmax + clampInt(nearestInt(iscale*v), -nmax, nmax-1))
+		}
+	}
+	iscale := -float32(nmax) / maxV
+	assign(iscale)
+	sumlx, suml2 := fit(iscale)
+	var scale float32
+	if suml2 != 0 {
+		scale = sumlx / suml2
+	}
+	best := scale * sumlx
+	for is := -9; is <= 9; is++ {
+		if is == 0 {
+			continue
+		}
+		iscale = -(float32(nmax) + 0.1*float32(is)) / maxV
+		sumlx, suml2 = fit(iscale)
+		if suml2 > 0 && sumlx*sumlx > best*suml2 {
+			assign(iscale)
+			scale = sumlx / suml2
+			best = scale * sumlx
+		}
+	}
+	return scale
+}
+
+// makeQ3Quants picks a symmetric scale for x with levels in [-nmax, nmax),
+// refining individual levels to minimise the x²-weighted error. L receives
+// the levels offset by nmax.
+func makeQ3Quants(nmax int, x []float32, L []int8) float32 {
+	maxV, amax := absMax(x)
+	if amax < groupMaxEps {
+		clear(L[:len(x)])
+		return 0
+	}
+	iscale := -float32(nmax) / maxV
+	var sumlx, suml2 float32
+	for i, v := range x {
+		l := clampInt(nearestInt(iscale*v), -nmax, nmax-1)
+		L[i] = int8(l)
+		w := v * v
+		sumlx += w * v * float32(l)
+		suml2 += w * float32(l*l)
+	}
+	for range 5 {
+		changed := false
+		for i, v := range x {
+			w := v * v
+			li := float32(L[i])
+			slx := sumlx - w*v*li
+			if slx <= 0 {
+				continue
+			}
+			sl2 := suml2 - w*li*li
+			nl := clampInt(nearestInt(v*sl2/slx), -nmax, nmax-1)
+			if nl == int(L[i]) {
+				continue
+			}
+			slx += w * v * float32(nl)
+			sl2 += w * float32(nl*nl)
+			if sl2 > 0 && slx*slx*suml2 > sumlx*sumlx*sl2 {
+				L[i] = int8(nl)
+				sumlx, suml2 = slx, sl2
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	for i := range x {
+		L[i] += int8(nmax)
+	}
+	return sumlx / suml2
+}
+
+// makeQkx2Quants fits an asymmetric scale and non-positive offset for x with
+// levels in [0, nmax], minimising the weighted absolute (useMad) or squared
+// error over nstep candidate scales. It returns the scale and the negated offset.
+func makeQkx2Quants(nmax int, x, weights []float32, L, aux []uint8, rmin, rdelta float32, nstep int, useMad bool) (float32, float32) {
+	lo, hi := minMax(x)
+	lo = min(lo, 0)
+	if hi == lo {
+		clear(L[:len(x)])
+		return 0, -lo
+	}
+	var sumW, sumX float32
+	for i, v := range x {
+		sumW += weights[i]
+		sumX += weights[i] * v
+	}
+	errorOf := func(levels []uint8, scale, offset float32) float32 {
+		var e float32
+		for i, v := range x {
+			diff := scale*float32(levels[i]) + offset - v
+			if useMad {
+				diff = float32(math.Abs(float64(diff)))
+			} else {
+				diff *= diff
+			}
+			e += weights[i] * diff
+		}
+		return e
+	}
+	iscale := float32(nmax) / (hi - lo)
+	scale := 1 / iscale
+	for i, v := range x {
+		L[i] = uint8(clampInt(nearestInt(iscale*(v-lo)), 0, nmax))
+	}
+	best := errorOf(L, scale, lo)
+	for is := 0; is <= nstep; is++ {
+		iscale = (rmin + rdelta*float32(is) + float32(nmax)) / (hi - lo)
+		var sumL, sumL2, sumXL float32
+		for i, v := range x {
+			l := uint8(clampInt(nearestInt(iscale*(v-lo)), 0, nmax))
+			aux[i] = l
+			w := weights[i]
+			sumL += w * float32(l)
+			sumL2 += w * float32(l) * float32(l)
+			sumXL += w * float32(l) * v
+		}
+		D := sumW*sumL2 - sumL*sumL
+		if D <= 0 {
+			continue
+		}
+		thisScale := (sumW*sumXL - sumX*sumL) / D
+		thisMin := (sumL2*sumX - sumL*sumXL) / D
+		if thisMin > 0 {
+			thisMin = 0
+			thisScale = sumXL / sumL2
+		}
+		if cur := errorOf(aux, thisScale, thisMin); cur < best {
+			copy(L, aux[:len(x)])
+			best, scale, lo = cur, thisScale, thisMin
+		}
+	}
+	return scale, -lo
+}
+
+// packLevels2 packs 256 2-bit levels into 64 bytes, four 32-value rows per
+// 128-value half.
+func packLevels2(L []uint8, qs []byte) {
+	for j := 0; j < QKK; j += 128 {
+		for l := range 32 {
+			qs[j/4+l] = L[j+l] | L[j+l+32]<<2 | L[j+l+64]<<4 | L[j+l+96]<<6
+		}
+	}
+}
+
+// QuantizeBlockQ2KF32 quantizes 256 float32 values to a q2_K block
+func QuantizeBlockQ2KF32(x []float32, b *BlockQ2K) {
+	var L, aux [16]uint8
+	var levels [QKK]uint8
+	var weights [16]float32
+	var scales, mins [QKK / 16]float32
+	var maxScale, maxMin float32
+	for j := range QKK / 16 {
+		xs := x[16*j : 16*j+16]
+		for l, v := range xs {
+			weights[l] = float32(math.Abs(float64(v)))
+		}
+		scales[j], mins[j] = makeQkx2Quants(3, xs, weights[:], L[:], aux[:], -0.5, 0.1, 15, true)
+		copy(levels[16*j:], L[:])
+		maxScale, maxMin = max(maxScale, scales[j]), max(maxMin, mins[j])
+	}
+	b.Scales = [16]byte{}
+	b.DM = [2]uint16{}
+	if maxScale > 0 {
+		iscale := 15 / maxScale
+		for j, s := range scales {
+			b.Scales[j] = byte(nearestInt(iscale * s))
+		}
+		b.DM[0] = fp32ToFP16(maxScale / 15)
+	}
+	if maxMin > 0 {
+		iscale := 15 / maxMin
+		for j, m := range mins {
+			b.Scales[j] |= byte(nearestInt(iscale*m)) << 4
+		}
+		b.DM[1] = fp32ToFP16(maxMin / 15)
+	}
+	d, dmin := fp16ToFloat32(b.DM[0]), fp16ToFloat32(b.DM[1])
+	for j := range QKK / 16 {
+		dl := d * float32(b.Scales[j]&0xF)
+		if dl == 0 {
+			continue
+		}
+		ml := dmin * float32(b.Scales[j]>>4)
+		for ii := range 16 {
+			levels[16*j+ii] = uint8(clampInt(nearestInt((x[16*j+ii]+ml)/dl), 0, 3))
+		}
+	}
+	packLevels2(levels[:], b.Qs[:])
+}
+
+// DequantizeBlockQ2KF32 dequantizes a q2_K block to float32 (contiguous)
+func DequantizeBlockQ2KF32(b *BlockQ2K, y []float32) {
+	d, dmin := fp16ToFloat32(b.DM[0]), fp16ToFloat32(b.DM[1])
+	for n := 0; n < QKK; n += 128 {
+		q := b.Qs[n/4 : n/4+32]
+		for j := range 4 {
+			for h := range 2 {
+				sc := b.Scales[n/16+2*j+h]
+				dl, ml := d*float32(sc&0xF), dmin*float32(sc>>4)
+				for l := 16 * h; l < 16*h+16; l++ {
+					y[n+32*j+l] = dl*float32(q[l]>>(2*j)&3) - ml
+				}
+			}
+		}
+	}
+}
+
+// DequantizeBlockQ2KF64 dequantizes a q2_K block to float64 (contiguous)
+func DequantizeBlockQ2KF64(b *BlockQ2K, y []float64) {
+	dequantizeF64(b, y, QKK, DequantizeBlockQ2KF32)
+}
+
+// q3kScale returns the signed 6-bit scale of sub-block j of a q3_K block.
+func q3kScale(s *[12]byte, j int) int {
+	sc := s[j%8] & 0xF
+	if j >= 8 {
+		sc = s[j-8] >> 4
+	}
+	return int(sc|(s[8+j%4]>>(2*(j/4))&3)<<4) - 32
+}
+
+// QuantizeBlockQ3KF32 quantizes 256 float32 values to a q3_K block
+func QuantizeBlockQ3KF32(x []float32, b *BlockQ3K) {
+	var L [QKK]int8
+	var scales [QKK / 16]float32
+	var maxScale, amax float32
+	for j := range QKK / 16 {
+		scales[j] = makeQ3Quants(4, x[16*j:16*j+16], L[16*j:])
+		if a := float32(math.Abs(float64(scales[j]))); a > amax {
+			amax, maxScale = a, scales[j]
+		}
+	}
+	b.Scales = [12]byte{}
+	b.D = 0
+	if maxScale != 0 {
+		iscale := -32 / maxScale
+		for j, s := range scales {
+			l := byte(clampInt(nearestInt(iscale*s), -32, 31) + 32)
+			if j < 8 {
+				b.Scales[j] = l & 0xF
+			} else {
+				b.Scales[j-8] |= (l & 0xF) << 4
+			}
+			b.Scales[j%4+8] |= l >> 4 << (2 * (j / 4))
+		}
+		b.D = fp32ToFP16(1 / iscale)
+	}
+	d := fp16ToFloat32(b.D)
+	for j := range QKK / 16 {
+		dl := d * float32(q3kScale(&b.Scales, j))
+		if dl == 0 {
+			continue
+		}
+		for ii := range 16 {
+			L[16*j+ii] = int8(clampInt(nearestInt(x[16*j+ii]/dl), -4, 3) + 4)
+		}
+	}
+	var levels [QKK]uint8
+	b.Hmask = [32]byte{}
+	for j, l := range L {
+		if l > 3 {
+			b.Hmask[j%32] |= 1 << (j / 32)
+			l -= 4
+		}
+		levels[j] = uint8(l)
+	}
+	packLevels2(levels[:], b.Qs[:])
+}
+
+// DequantizeBlockQ3KF32 dequantizes a q3_K block to float32 (contiguous)
+func DequantizeBlockQ3KF32(b *BlockQ3K, y []float32) {
+	d := fp16ToFloat32(b.D)
+	for n := 0; n < QKK; n += 128 {
+		q := b.Qs[n/4 : n/4+32]
+		for j := range 4 {
+			mask := byte(1) << (n/32 + j)
+			for h := range 2 {
+				dl := d * float32(q3kScale(&b.Scales, n/16+2*j+h))
+				for l := 16 * h; l < 16*h+16; l++ {
+					v := int(q[l] >> (2 * j) & 3)
+					if b.Hmask[l]&mask == 0 {
+						v -= 4
+					}
+					y[n+32*j+l] = dl * float32(v)
+				}
+			}
+		}
+	}
+}
+
+// DequantizeBlockQ3KF64 dequantizes a q3_K block to float64 (contiguous)
+func DequantizeBlockQ3KF64(b *BlockQ3K, y []float64) {
+	dequantizeF64(b, y, QKK, DequantizeBlockQ3KF32)
+}
+
+// scaleMinK4 returns the 6-bit scale and min of sub-block j of a q4_K or q5_K block.
+func scaleMinK4(j int, q *[12]byte) (float32, float32) {
+	if j < 4 {
+		return float32(q[j] & 63), float32(q[j+4] & 63)
+	}
+	return float32(q[j+4]&0xF | q[j-4]>>6<<4), float32(q[j+4]>>4 | q[j]>>6<<4)
+}
+
+// quantizeKx4 fits the eight 32-value sub-blocks of a q4_K or q5_K block with
+// levels in [0, nmax], packing the 6-bit scales and mins into scales and
+// storing the super-block scales in dm. It returns the final levels.
+func quantizeKx4(x []float32, nmax int, rmin float32, nstep int, scales *[12]byte, dm *[2]uint16) [QKK]uint8 {
+	var levels [QKK]uint8
+	var aux [32]uint8
+	var weights [32]float32
+	var subScales, subMins [QKK / 32]float32
+	var maxScale, maxMin float32
+	for j := range QKK / 32 {
+		xs := x[32*j : 32*j+32]
+		var sumX2 float32
+		for _, v := range xs {
+			sumX2 += v * v
+		}
+		avX := float32(math.Sqrt(float64(sumX2 / 32)))
+		for l, v := range xs {
+			weights[l] = avX + float32(math.Abs(float64(v)))
+		}
+		subScales[j], subMins[j] = makeQkx2Quants(nmax, xs, weights[:], levels[32*j:], aux[:], rmin, 0.1, nstep, false)
+		maxScale, maxMin = max(maxScale, subScales[j]), max(maxMin, subMins[j])
+	}
+	var invScale, invMin float32
+	if maxScale > 0 {
+		invScale = 63 / maxScale
+	}
+	if maxMin > 0 {
+		invMin = 63 / maxMin
+	}
+	*scales = [12]byte{}
+	for j := range QKK / 32 {
+		ls := byte(min(63, nearestInt(invScale*subScales[j])))
+		lm := byte(min(63, nearestInt(invMin*subMins[j])))
+		if j < 4 {
+			scales[j] = ls
+			scales[j+4] = lm
+		} else {
+			scales[j+4] = ls&0xF | (lm&0xF)<<4
+			scales[j-4] |= ls >> 4 << 6
+			scales[j] |= lm >> 4 << 6
+		}
+	}
+	dm[0], dm[1] = fp32ToFP16(maxScale/63), fp32ToFP16(maxMin/63)
+	d, dmin := fp16ToFloat32(dm[0]), fp16ToFloat32(dm[1])
+	for j := range QKK / 32 {
+		sc, m := scaleMinK4(j, scales)
+		dl := d * sc
+		if dl == 0 {
+			continue
+		}
+		ml := dmin * m
+		for ii := range 32 {
+			levels[32*j+ii] = uint8(clampInt(nearestInt((x[32*j+ii]+ml)/dl), 0, nmax))
+		}
+	}
+	return levels
+}
+
+// QuantizeBlockQ4KF32 quantizes 256 float32 values to a q4_K block
+func QuantizeBlockQ4KF32(x []float32, b *BlockQ4K) {
+	L := quantizeKx4(x[:QKK], 15, -1, 20, &b.Scales, &b.DM)
+	for j := 0; j < QKK; j += 64 {
+		for l := range 32 {
+			b.Qs[j/2+l] = L[j+l] | L[j+l+32]<<4
+		}
+	}
+}
+
+// DequantizeBlockQ4KF32 dequantizes a q4_K block to float32 (contiguous)
+func DequantizeBlockQ4KF32(b *BlockQ4K, y []float32) {
+	d, dmin := fp16ToFloat32(b.DM[0]), fp16ToFloat32(b.DM[1])
+	for j := 0; j < QKK; j += 64 {
+		sc1, m1 := scaleMinK4(j/32, &b.Scales)
+		sc2, m2 := scaleMinK4(j/32+1, &b.Scales)
+		d1, min1, d2, min2 := d*sc1, dmin*m1, d*sc2, dmin*m2
+		q := b.Qs[j/2 : j/2+32]
+		for l := range 32 {
+			y[j+l] = d1*float32(q[l]&0xF) - min1
+			y[j+32+l] = d2*float32(q[l]>>4) - min2
+		}
+	}
+}
+
+// DequantizeBlockQ4KF64 dequantizes a q4_K block to float64 (contiguous)
+func DequantizeBlockQ4KF64(b *BlockQ4K, y []float64) {
+	dequantizeF64(b, y, QKK, DequantizeBlockQ4KF32)
+}
+
+// QuantizeBlockQ5KF32 quantizes 256 float32 values to a q5_K block
+func QuantizeBlockQ5KF32(x []float32, b *BlockQ5K) {
+	L := quantizeKx4(x[:QKK], 31, -0.5, 15, &b.Scales, &b.DM)
+	b.Qh = [32]byte{}
+	for n := 0; n < QKK; n += 64 {
+		m1, m2 := byte(1)<<(n/32), byte(2)<<(n/32)
+		for j := range 32 {
+			l1, l2 := L[n+j], L[n+j+32]
+			if l1 > 15 {
+				l1 -= 16
+				b.Qh[j] |= m1
+			}
+			if l2 > 15 {
+				l2 -= 16
+				b.Qh[j] |= m2
+			}
+			b.Qs[n/2+j] = l1 | l2<<4
+		}
+	}
+}
+
+// DequantizeBlockQ5KF32 dequantizes a q5_K block to float32 (contiguous)
+func DequantizeBlockQ5KF32(b *BlockQ5K, y []float32) {
+	d, dmin := fp16ToFloat32(b.DM[0]), fp16ToFloat32(b.DM[1])
+	for j := 0; j < QKK; j += 64 {
+		sc1, m1 := scaleMinK4(j/32, &b.Scales)
+		sc2, m2 := scaleMinK4(j/32+1, &b.Scales)
+		d1, min1, d2, min2 := d*sc1, dmin*m1, d*sc2, dmin*m2
+		u1, u2 := byte(1)<<(j/32), byte(2)<<(j/32)
+		ql := b.Qs[j/2 : j/2+32]
+		for l := range 32 {
+			q1, q2 := ql[l]&0xF, ql[l]>>4
+			if b.Qh[l]&u1 != 0 {
+				q1 += 16
+			}
+			if b.Qh[l]&u2 != 0 {
+				q2 += 16
+			}
+			y[j+l] = d1*float32(q1) - min1
+			y[j+32+l] = d2*float32(q2) - min2
+		}
+	}
+}
+
+// DequantizeBlockQ5KF64 dequantizes a q5_K block to float64 (contiguous)
+func DequantizeBlockQ5KF64(b *BlockQ5K, y []float64) {
+	dequantizeF64(b, y, QKK, DequantizeBlockQ5KF32)
+}
+
+// QuantizeBlockQ6KF32 quantizes 256 float32 values to a q6_K block
+func QuantizeBlockQ6KF32(x []float32, b *BlockQ6K) {
+	var L [QKK]uint8
+	var scales [QKK / 16]float32
+	var maxScale, maxAbs float32
+	for ib := range QKK / 16 {
+		scales[ib] = makeQxQuants(32, x[16*ib:16*ib+16], L[16*ib:])
+		if a := float32(math.Abs(float64(scales[ib]))); a > maxAbs {
+			maxAbs, maxScale = a, scales[ib]
+		}
+	}
+	if maxAbs == 0 {
+		*b = BlockQ6K{}
+		return
+	}
+	iscale := -128 / maxScale
+	b.D = fp32ToFP16(1 / iscale)
+	for ib, s := range scales {
+		b.Scales[ib] = int8(min(127, nearestInt(iscale*s)))
+	}
+	d := fp16ToFloat32(b.D)
+	for j := range QKK / 16 {
+		dl := d * float32(b.Scales[j])
+		if dl == 0 {
+			continue
+		}
+		for ii := range 16 {
+			L[16*j+ii] = uint8(clampInt(nearestInt(x[16*j+ii]/dl), -32, 31) + 32)
+		}
+	}
+	for j := 0; j < QKK; j += 128 {
+		ql, qh := b.Ql[j/2:j/2+64], b.Qh[j/4:j/4+32]
+		for l := range 32 {
+			ql[l] = L[j+l]&0xF | (L[j+l+64]&0xF)<<4
+			ql[l+32] = L[j+l+32]&0xF | (L[j+l+96]&0xF)<<4
+			qh[l] = L[j+l]>>4 | L[j+l+32]>>4<<2 | L[j+l+64]>>4<<4 | L[j+l+96]>>4<<6
+		}
+	}
+}
+
+// DequantizeBlockQ6KF32 dequantizes a q6_K block to float32 (contiguous)
+func DequantizeBlockQ6KF32(b *BlockQ6K, y []float32) {
+	d := fp16ToFloat32(b.D)
+	for n := 0; n < QKK; n += 128 {
+		ql, qh, sc := b.Ql[n/2:n/2+64], b.Qh[n/4:n/4+32], b.Scales[n/16:n/16+8]
+		for l := range 32 {
+			is := l / 16
+			q1 := int(ql[l]&0xF|(qh[l]&3)<<4) - 32
+			q2 := int(ql[l+32]&0xF|(qh[l]>>2&3)<<4) - 32
+			q3 := int(ql[l]>>4|(qh[l]>>4&3)<<4) - 32
+			q4 := int(ql[l+32]>>4|(qh[l]>>6&3)<<4) - 32
+			y[n+l] = d * float32(sc[is]) * float32(q1)
+			y[n+l+32] = d * float32(sc[is+2]) * float32(q2)
+			y[n+l+64] = d * float32(sc[is+4]) * float32(q3)
+			y[n+l+96] = d * float32(sc[is+6]) * float32(q4)
+		}
+	}
+}
+
+// DequantizeBlockQ6KF64 dequantizes a q6_K block to float64 (contiguous)
+func DequantizeBlockQ6KF64(b *BlockQ6K, y []float64) {
+	dequantizeF64(b, y, QKK, DequantizeBlockQ6KF32)
+}
+
+// QuantizeBlockQ8KF32 quantizes 256 float32 values to a q8_K block
+func QuantizeBlockQ8KF32(x []float32, b *BlockQ8K) {
+	maxV, amax := absMax(x[:QKK])
+	*b = BlockQ8K{}
+	if amax == 0 {
+		return
+	}
+	iscale := -128 / maxV
+	for j := range QKK {
+		b.Qs[j] = int8(min(127, nearestInt(iscale*x[j])))
+	}
+	for j := range QKK / 16 {
+		var sum int16
+		for _, q := range b.Qs[16*j : 16*j+16] {
+			sum += int16(q)
+		}
+		b.Bsums[j] = sum
+	}
+	b.D = 1 / iscale
+}
+
+// DequantizeBlockQ8KF32 dequantizes a q8_K block to float32 (contiguous)
+func DequantizeBlockQ8KF32(b *BlockQ8K, y []float32) {
+	for i := range QKK {
+		y[i] = b.D * float32(b.Qs[i])
+	}
+}
+
+// DequantizeBlockQ8KF64 dequantizes a q8_K block to float64 (contiguous)
+func DequantizeBlockQ8KF64(b *BlockQ8K, y []float64) {
+	d := float64(b.D)
+	for i := range QKK {
 		y[i] = d * float64(b.Qs[i])
 	}
 }
 
+// QuantizeBlocksF32 quantizes x into blocks of n values each using the
+// per-block routine fn, in parallel over blocks.
+func QuantizeBlocksF32[B any](x []float32, n int, blocks []B, fn func([]float32, *B)) {
+	ParallelFor(len(blocks), max(grainSize/n, 1), func(start, end int) {
+		for i := start; i < end; i++ {
+			fn(x[i*n:(i+1)*n], &blocks[i])
+		}
+	})
+}
+
+// QMatMulF32 computes dst = x · wᵀ for x of shape (m, k) and a quantized
+// weight w of shape (n, k) stored row-major as k/bs blocks per row. Each
+// weight block is dequantized once into a scratch buffer and applied to every
+// row of x, so the full weight matrix is never materialized.
+func QMatMulF32[B any](m, n, k, bs int, x []float32, w []B, dst []float32, deq func(*B, []float32)) {
+	nb := k / bs
+	ParallelFor(n, max(grainSize/max(m*k, 1), 1), func(start, end int) {
+		buf := make([]float32, bs)
+		for j := start; j < end; j++ {
+			for i := range m {
+				dst[i*n+j] = 0
+			}
+			for blk := range nb {
+				deq(&w[j*nb+blk], buf)
+				off := blk * bs
+				for i := range m {
+					xi := x[i*k+off : i*k+off+bs]
+					var sum float32
+					for l, v := range buf {
+						sum += v * xi[l]
+					}
+					dst[i*n+j] += sum
+				}
+			}
+		}
+	})
+}
+
 // fp16ToFloat32 converts an IEEE 754 half-precision (FP16) float to single-precision (float32).
 // FP16 format: 1 sign bit, 5 exponent bits (bias 15), 10 mantissa bits.
 // float32 format: 1 sign bit, 8 exponent bits (bias 127), 23 mantissa bits.
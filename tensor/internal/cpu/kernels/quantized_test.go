@@ -0,0 +1,241 @@
+package kernels_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"unsafe"
+
+	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
+)
+
+// relRMSE returns the root-mean-square error of got relative to the RMS of want.
+func relRMSE(got, want []float32) float64 {
+	var se, ss float64
+	for i := range want {
+		d := float64(got[i] - want[i])
+		se += d * d
+		ss += float64(want[i]) * float64(want[i])
+	}
+	return math.Sqrt(se / ss)
+}
+
+func roundTrip[B any](x []float32, n int, q func([]float32, *B), dq func(*B, []float32)) []float32 {
+	blocks := make([]B, len(x)/n)
+	kernels.QuantizeBlocksF32(x, n, blocks, q)
+	y := make([]float32, len(x))
+	kernels.DequantizeBlocksF32(blocks, n, y, dq)
+	return y
+}
+
+func TestQuantizeRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	x := make([]float32, 4*kernels.QKK)
+	for i := range x {
+		x[i] = float32(r.NormFloat64())
+	}
+	tests := []struct {
+		name string
+		tol  float64
+		got  []float32
+	}{
+		{"q4_0", 0.12, roundTrip(x, kernels.QK, kernels.QuantizeBlockQ4_0F32, kernels.DequantizeBlockQ4_0F32)},
+		{"q4_1", 0.12, roundTrip(x, kernels.QK, kernels.QuantizeBlockQ4_1F32, kernels.DequantizeBlockQ4_1F32)},
+		{"q5_0", 0.06, roundTrip(x, kernels.QK, kernels.QuantizeBlockQ5_0F32, kernels.DequantizeBlockQ5_0F32)},
+		{"q5_1", 0.06, roundTrip(x, kernels.QK, kernels.QuantizeBlockQ5_1F32, kernels.DequantizeBlockQ5_1F32)},
+		{"q8_0", 0.01, roundTrip(x, kernels.QK, kernels.QuantizeBlockQ8_0F32, kernels.DequantizeBlockQ8_0F32)},
+		{"q2_K", 0.4, roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ2KF32, kernels.DequantizeBlockQ2KF32)},
+		{"q3_K", 0.2, roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ3KF32, kernels.DequantizeBlockQ3KF32)},
+		{"q4_K", 0.12, roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ4KF32, kernels.DequantizeBlockQ4KF32)},
+		{"q5_K", 0.06, roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ5KF32, kernels.DequantizeBlockQ5KF32)},
+		{"q6_K", 0.03, roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ6KF32, kernels.DequantizeBlockQ6KF32)},
+		{"q8_K", 0.01, roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ8KF32, kernels.DequantizeBlockQ8KF32)},
+	}
+	for _, tt := range tests {
+		if e := relRMSE(tt.got, x); e > tt.tol {
+			t.Errorf("%s: relative rmse %.4f exceeds %.4f", tt.name, e, tt.tol)
+		}
+	}
+}
+
+func TestQuantizeZeros(t *testing.T) {
+	x := make([]float32, kernels.QKK)
+	for name, y := range map[string][]float32{
+		"q4_0": roundTrip(x, kernels.QK, kernels.QuantizeBlockQ4_0F32, kernels.DequantizeBlockQ4_0F32),
+		"q2_K": roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ2KF32, kernels.DequantizeBlockQ2KF32),
+		"q3_K": roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ3KF32, kernels.DequantizeBlockQ3KF32),
+		"q4_K": roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ4KF32, kernels.DequantizeBlockQ4KF32),
+		"q6_K": roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ6KF32, kernels.DequantizeBlockQ6KF32),
+		"q8_K": roundTrip(x, kernels.QKK, kernels.QuantizeBlockQ8KF32, kernels.DequantizeBlockQ8KF32),
+	} {
+		for i, v := range y {
+			if v != 0 {
+				t.Fatalf("%s: y[%d] = %v, want 0", name, i, v)
+			}
+		}
+	}
+}
+
+func TestDequantizeQ4_0Layout(t *testing.T) {
+	// ggml packs element j in the low nibble and element j+16 in the high nibble.
+	b := kernels.BlockQ4_0{D: 0x3c00}
+	b.Qs[0] = 0x21
+	y := make([]float32, kernels.QK)
+	kernels.DequantizeBlockQ4_0F32(&b, y)
+	if y[0] != -7 || y[16] != -6 || y[1] != -8 {
+		t.Fatalf("got y[0]=%v y[1]=%v y[16]=%v", y[0], y[1], y[16])
+	}
+}
+
+// kQuantGolden is one block produced by ggml's quantize_row_*_K_ref for an
+// input of goldenInputs, with the SHA-256 of the little-endian float32
+// values ggml's dequantize_row_*_K decodes from it.
+type kQuantGolden struct {
+	input  string
+	block  string // hex
+	digest string // hex
+}
+
+// goldenInputs returns the inputs the ggml golden blocks were made from,
+// built from exact binary fractions so C and Go agree on every value.
+func goldenInputs() map[string][]float32 {
+	mixed, skewed := make([]float32, kernels.QKK), make([]float32, kernels.QKK)
+	for i := range kernels.QKK {
+		mixed[i] = float32((i*37)%101-50) / 16 * (1 + float32(i%7)/8)
+		skewed[i] = float32((i*53)%64)/8 - 1
+	}
+	skewed[100] = 25 // A single outlier stretches one sub-block's scale.
+	return map[string][]float32{"mixed": mixed, "skewed": skewed}
+}
+
+// checkKQuantGolden quantizes each golden input and compares the block with
+// ggml's bytes, then dequantizes ggml's block and compares the digest.
+func checkKQuantGolden[B comparable](t *testing.T, name string, golden []kQuantGolden, q func([]float32, *B), dq func(*B, []float32)) {
+	t.Helper()
+	inputs := goldenInputs()
+	for _, g := range golden {
+		raw, err := hex.DecodeString(g.block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := kernels.BlocksFromBytes[B](raw)
+		if len(want) != 1 || len(raw) != int(unsafe.Sizeof(want[0])) {
+			t.Fatalf("%s %s: golden block has %d bytes", name, g.input, len(raw))
+		}
+		var b B
+		q(inputs[g.input], &b)
+		if b != want[0] {
+			t.Errorf("%s %s: quantized block differs from ggml", name, g.input)
+		}
+		y := make([]float32, kernels.QKK)
+		dq(&want[0], y)
+		var buf []byte
+		for _, v := range y {
+			buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+		}
+		if got := sha256.Sum256(buf); hex.EncodeToString(got[:]) != g.digest {
+			t.Errorf("%s %s: dequantized values differ from ggml", name, g.input)
+		}
+	}
+}
+
+func TestKQuantGolden(t *testing.T) {
+	checkKQuantGolden(t, "q2_K", []kQuantGolden{
+		{"mixed",
+			"aa8beebb9bdfcd9aadedbb7afebd8adf18a18b68b60b6c861b618658b5db6db61a6c861b60d61ca1da6cb51b6dc65fa5" +
+				"1a6d862b61d62cb1da6c852b61d62cb58768a2176d825b618658a2d66db21b6d0e33d535",
+			"1bb969cb1ef2052de87252b4b4b405a5451edfeaf421e56ea376fc5161e4e74e"},
+		{"skewed",
+			"040303030403ff0304030303040303034866225ddd487766225d5d487726225ddd88773322ddcc88772222dd88887722" +
+				"886622dddd88776622dddd88776622dddd88773322ddcc88772222dd88887722f7382b33",
+			"26590297d0117428fd247d9732564246e2dbcc86f1e657bcae4cd89fa841e4e4"},
+	}, kernels.QuantizeBlockQ2KF32, kernels.DequantizeBlockQ2KF32)
+	checkKQuantGolden(t, "q3_K", []kQuantGolden{
+		{"mixed",
+			"a6f80fe6d927f25d27f81da6d80ff2d974813ed48b7ed5ab7ec13b74813ed48b4927b2c94823fdb81723bd844be3be98" +
+				"5efab75c3ee7476ce2fb881cf1f78929f51e9fc81523cef4625e88f4112f8b297279e8de72f99f2fb688ec2272c4df66" +
+				"d73b62ab09cf85f73ccfc0f37fa9",
+			"437de1516cdc5232c3f871454265f60944c72f1209fa9512750da9095fe45552"},
+		{"skewed",
+			"5d08aa0000550808aa0000550808aa0000550000aa00555500aaaa00555500aa191d22e237c8081d22f3f7c80c1d22f3" +
+				"7788ccdd22334488cc1122334499cc1199dd2222778888dd22337788ccdd22337788ccdd22334488cc1122334499cc11" +
+				"7788778877887088555551553f32",
+			"324d4aad3ae875d810e2d1199e1edd7d0f13c8bc7d2e4a8fdb7720be0c4f58ac"},
+	}, kernels.QuantizeBlockQ3KF32, kernels.DequantizeBlockQ3KF32)
+	checkKQuantGolden(t, "q4_K", []kQuantGolden{
+		{"mixed",
+			"a521a92df8f9fff1f2fbf4f08e56fe5fc2268ac2579de258bc057ab0468ce347acd369bf2479d2269bd258aef569cd45" +
+				"269ad357ad0368c2058ac1469cf447bc2479c02589f3279be358be0579e2468bacd469bf2579d3278bd358ae0569c246" +
+				"8bc2479bf448ad257ac0278af3579de4366ba2377bd5398dc55aa0176bc4487dc4499c065a92377cb2388ce5599f266b",
+			"f5f8c677b84149d8ef8829a315ce313b2beaa5ba54250690421b1d7d220d8923"},
+		{"skewed",
+			"d1263a245553557fffa8ff8df583f583705c29f7c5927e4b19e7c4926d4b19e6b4816d3b08d6b3815d3a08d5a3705c2a" +
+				"201c0947f5322e1b094734222d1b094634211d1b084633211d1a084533201c0a705c29f7c5927e4b19e7c4926d4b19e6" +
+				"b4816d3b08d6b3815d3a08d5a3705c2a705c29f7c5927e4b19e7c4926d4b19e6b4816d3b08d6b3815d3a08d5a3705c2a",
+			"f49759706b1481405cd7dfc118aeaca40b069faa56a0b46445084fa88eeca9d4"},
+	}, kernels.QuantizeBlockQ4KF32, kernels.DequantizeBlockQ4KF32)
+	checkKQuantGolden(t, "q5_K", []kQuantGolden{
+		{"mixed",
+			"281d6a2dfafffff6f4fdf7f39f6aff9f326c9936cc9366c9b36499324c9b66cc9366c9336c99324c9b66cdb366d9336c" +
+				"743cf5739e2bc4a15a0ae4607c09b77f3997c25f49e3a44d1894a04efab28c8b4e1796a04c07b2940cf5937e2bf9807a" +
+				"5ad4804c04e75f29c7b26f1cd3c48d1849a9c36f3ae4b64e18a6a14e0bb3937cf7937f26f9913c5ad5804e06e7a02bc7" +
+				"7ce7736f07c9722caac5503ee6b7901ba7a3490cb5447ef9947129fac43f4bd8",
+			"f669655749eb11790f5f21c85bd5feb52c958c3c6ee79d68f6ebc52e4319eaf9"},
+		{"skewed",
+			"c22269235353537ffff6fff7f363f363005555a2aaa2555555a2a2a2555555a2a2a2555555a2a2a2555555a2a2a25555" +
+				"f0aa45ff9a44ef9934ee8933de8823dd7822cd7712cc6711bc6601bb5600ab55503a159ffa644f39149e79634e28139d" +
+				"78524d27028c77513c26018b66503b15f0aa45ff9a44ef9934ee8933de8823dd7822cd7712cc6711bc6601bb5600ab55" +
+				"f0aa45ff9a44ef9934ee8933de8823dd7822cd7712cc6711bc6601bb5600ab55",
+			"18e0f822cd31819ecf65261df34fdbdfa3673304e80724fc34dfc61a8de4eb2e"},
+	}, kernels.QuantizeBlockQ5KF32, kernels.DequantizeBlockQ5KF32)
+	checkKQuantGolden(t, "q6_K", []kQuantGolden{
+		{"mixed",
+			"3aea4dd81e2899937605ba1149d5c060ad6fca606cf805d4ef951e637a4a36f88d373d4c831376240af11cfb4eef14ef" +
+				"a9bc09860bc5ab5b9f6dde19b09b0f228dbd79c1b93722424f821cb3a8e9f7266fa8cfae64d479c5dc01ddfdaf01c700" +
+				"5945e96ce71d47a8528414d93048f3dfb1bd71eb706f0807d50fa81f738b6b462881f658853b5cf62b51f62881e75d82" +
+				"2452f9249ee9238ee453892452f9608ed8ae05d86215ef7218af45d8a2059f723a904de6917ae78d3ae04d26904ae791" +
+				"986d8668637b9c9f778c976880655f76a195",
+			"7aeff92e1d618a9ab8903599ec289170eb445d97195a4db6ae373b796c8f122e"},
+		{"skewed",
+			"5566cc3399ff0077dd44aa112288ee55bb220077ee55cc331188ff66dd4433aac2e90f7107bdc3ea008298bed5fb0183" +
+				"66dd44cc331188ff66dd4422990077ee5566cc3399ff0077dd44aa112288ee55bb220077ee55cc331188ff66dd4433aa" +
+				"2299ff1177dd33aa002288ee55bb113366dd44cc331188ff66dd4422990077ee66448451115144448851516244448851" +
+				"112244448811112244448811116644446644441111114444881111224444881111224444881111224444881111664444" +
+				"dde0dce0dde080e0dde0dce0dde0dce0401e",
+			"2b4701d07c2918e1fb780495e7909c670f5380e10685fb8667b8adf79648d41e"},
+	}, kernels.QuantizeBlockQ6KF32, kernels.DequantizeBlockQ6KF32)
+}
+
+func TestQMatMulF32(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	m, n, k := 3, 5, 2*kernels.QKK
+	x := make([]float32, m*k)
+	w := make([]float32, n*k)
+	for i := range x {
+		x[i] = float32(r.NormFloat64())
+	}
+	for i := range w {
+		w[i] = float32(r.NormFloat64())
+	}
+	blocks := make([]kernels.BlockQ4K, n*k/kernels.QKK)
+	kernels.QuantizeBlocksF32(w, kernels.QKK, blocks, kernels.QuantizeBlockQ4KF32)
+	wq := make([]float32, n*k)
+	kernels.DequantizeBlocksF32(blocks, kernels.QKK, wq, kernels.DequantizeBlockQ4KF32)
+
+	got := make([]float32, m*n)
+	kernels.QMatMulF32(m, n, k, kernels.QKK, x, blocks, got, kernels.DequantizeBlockQ4KF32)
+	for i := range m {
+		for j := range n {
+			var want float64
+			for l := range k {
+				want += float64(x[i*k+l]) * float64(wq[j*k+l])
+			}
+			if math.Abs(float64(got[i*n+j])-want) > 1e-3 {
+				t.Fatalf("dst[%d,%d] = %v, want %v", i, j, got[i*n+j], want)
+			}
+		}
+	}
+}
@@ -8,85 +8,97 @@ import (
 // FastSum computes the sum over the last dimension for type T
 func FastSum[T D](numel, ndims int, dims []int, src, dst []T) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		var sum T
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			var sum T
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[j]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumF32 computes the sum over the last dimension for float32
 func FastSumF32(numel, ndims int, dims []int, src, dst []float32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := float32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := float32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[j]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumF64 computes the sum over the last dimension for float64
 func FastSumF64(numel, ndims int, dims []int, src, dst []float64) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := float64(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := float64(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[j]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumU8 computes the sum over the last dimension for uint8
 func FastSumU8(numel, ndims int, dims []int, src, dst []uint8) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := uint8(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := uint8(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[j]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumU32 computes the sum over the last dimension for uint32
 func FastSumU32(numel, ndims int, dims []int, src, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[j]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumI64 computes the sum over the last dimension for int64
 func FastSumI64(numel, ndims int, dims []int, src, dst []int64) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := int64(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := int64(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[j]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumStrided computes the sum over the last dimension for type T with strided memory
@@ -96,15 +108,17 @@ func FastSumStrided[T D](numel, ndims int, dims, strides []int, src, dst []T) {
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		var sum T
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[GetStridedIndex(j, ndims, dims, strides)]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			var sum T
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[GetStridedIndex(j, ndims, dims, strides)]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumStridedF32 computes the sum over the last dimension for float32 with strided memory
@@ -114,15 +128,17 @@ func FastSumStridedF32(numel, ndims int, dims, strides []int, src, dst []float32
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := float32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[GetStridedIndex(j, ndims, dims, strides)]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := float32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[GetStridedIndex(j, ndims, dims, strides)]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumStridedF64 computes the sum over the last dimension for float64 with strided memory
@@ -132,15 +148,17 @@ func FastSumStridedF64(numel, ndims int, dims, strides []int, src, dst []float64
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := float64(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[GetStridedIndex(j, ndims, dims, strides)]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := float64(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[GetStridedIndex(j, ndims, dims, strides)]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumStridedU8 computes the sum over the last dimension for uint8 with strided memory
@@ -150,15 +168,17 @@ func FastSumStridedU8(numel, ndims int, dims, strides []int, src, dst []uint8) {
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := uint8(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[GetStridedIndex(j, ndims, dims, strides)]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := uint8(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[GetStridedIndex(j, ndims, dims, strides)]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumStridedU32 computes the sum over the last dimension for uint32 with strided memory
@@ -168,15 +188,17 @@ func FastSumStridedU32(numel, ndims int, dims, strides []int, src, dst []uint32)
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[GetStridedIndex(j, ndims, dims, strides)]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[GetStridedIndex(j, ndims, dims, strides)]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastSumStridedI64 computes the sum over the last dimension for int64 with strided memory
@@ -186,111 +208,125 @@ func FastSumStridedI64(numel, ndims int, dims, strides []int, src, dst []int64)
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		sum := int64(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			sum += src[GetStridedIndex(j, ndims, dims, strides)]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			sum := int64(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				sum += src[GetStridedIndex(j, ndims, dims, strides)]
+			}
+			dst[i] = sum
 		}
-		dst[i] = sum
-	}
+	})
 }
 
 // FastMin computes the minimum over the last dimension for type T
 func FastMin[T D](numel, ndims int, dims []int, src, dst []T) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		startIdx := i * dims[ndims-1]
-		minVal := src[startIdx] // init minVal to first element
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			startIdx := i * dims[ndims-1]
+			minVal := src[startIdx] // init minVal to first element
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinF32 computes the minimum over the last dimension for float32
 func FastMinF32(numel, ndims int, dims []int, src, dst []float32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float32(math.MaxFloat32)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float32(math.MaxFloat32)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinF64 computes the minimum over the last dimension for float64
 func FastMinF64(numel, ndims int, dims []int, src, dst []float64) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float64(math.MaxFloat64)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float64(math.MaxFloat64)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinU8 computes the minimum over the last dimension for uint8
 func FastMinU8(numel, ndims int, dims []int, src, dst []uint8) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint8(math.MaxUint8)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint8(math.MaxUint8)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinU32 computes the minimum over the last dimension for uint32
 func FastMinU32(numel, ndims int, dims []int, src, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint32(math.MaxUint32)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint32(math.MaxUint32)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinI64 computes the minimum over the last dimension for int64
 func FastMinI64(numel, ndims int, dims []int, src, dst []int64) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := int64(math.MaxInt64)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := int64(math.MaxInt64)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinStrided computes the minimum over the last dimension for type T with strided memory
@@ -300,18 +336,20 @@ func FastMinStrided[T D](numel, ndims int, dims, strides []int, src, dst []T) {
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		startIdx := i * dims[ndims-1]
-		minVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			startIdx := i * dims[ndims-1]
+			minVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinStridedF32 computes the minimum over the last dimension for float32 with strided memory
@@ -321,18 +359,20 @@ func FastMinStridedF32(numel, ndims int, dims, strides []int, src, dst []float32
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float32(math.MaxFloat32)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float32(math.MaxFloat32)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinStridedF64 computes the minimum over the last dimension for float64 with strided memory
@@ -342,18 +382,20 @@ func FastMinStridedF64(numel, ndims int, dims, strides []int, src, dst []float64
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float64(math.MaxFloat64)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float64(math.MaxFloat64)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinStridedU8 computes the minimum over the last dimension for uint8 with strided memory
@@ -363,18 +405,20 @@ func FastMinStridedU8(numel, ndims int, dims, strides []int, src, dst []uint8) {
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint8(math.MaxUint8)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint8(math.MaxUint8)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinStridedU32 computes the minimum over the last dimension for uint32 with strided memory
@@ -384,18 +428,20 @@ func FastMinStridedU32(numel, ndims int, dims, strides []int, src, dst []uint32)
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint32(math.MaxUint32)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint32(math.MaxUint32)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMinStridedI64 computes the minimum over the last dimension for int64 with strided memory
@@ -405,114 +451,128 @@ func FastMinStridedI64(numel, ndims int, dims, strides []int, src, dst []int64)
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := int64(math.MaxInt64)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := int64(math.MaxInt64)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // FastMax computes the maximum over the last dimension for type T
 func FastMax[T D](numel, ndims int, dims []int, src, dst []T) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		startIdx := i * dims[ndims-1]
-		maxVal := src[startIdx]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			startIdx := i * dims[ndims-1]
+			maxVal := src[startIdx]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxF32 computes the maximum over the last dimension for float32
 func FastMaxF32(numel, ndims int, dims []int, src, dst []float32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float32(-math.MaxFloat32)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float32(-math.MaxFloat32)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxF64 computes the maximum over the last dimension for float64
 func FastMaxF64(numel, ndims int, dims []int, src, dst []float64) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float64(-math.MaxFloat64)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float64(-math.MaxFloat64)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxU8 computes the maximum over the last dimension for uint8
 func FastMaxU8(numel, ndims int, dims []int, src, dst []uint8) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := uint8(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := uint8(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxU32 computes the maximum over the last dimension for uint32
 func FastMaxU32(numel, ndims int, dims []int, src, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxI64 computes the maximum over the last dimension for int64
 func FastMaxI64(numel, ndims int, dims []int, src, dst []int64) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := int64(math.MinInt64)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := int64(math.MinInt64)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxStrided computes the maximum over the last dimension for type T with strided memory
@@ -522,18 +582,20 @@ func FastMaxStrided[T D](numel, ndims int, dims, strides []int, src, dst []T) {
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		startIdx := i * dims[ndims-1]
-		maxVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			startIdx := i * dims[ndims-1]
+			maxVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxStridedF32 computes the maximum over the last dimension for float32 with strided memory
@@ -543,18 +605,20 @@ func FastMaxStridedF32(numel, ndims int, dims, strides []int, src, dst []float32
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float32(-math.MaxFloat32)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float32(-math.MaxFloat32)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastMaxStridedF64 computes the maximum over the last dimension for float64 with strided memory
@@ -564,126 +628,140 @@ func FastMaxStridedF64(numel, ndims int, dims, strides []int, src, dst []float64
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float64(-math.MaxFloat64)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float64(-math.MaxFloat64)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // FastArgmin computes the index of the minimum over the last dimension for type T
 func FastArgmin[T D](numel, ndims int, dims []int, src []T, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		minVal := src[startIdx]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			minVal := src[startIdx]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminF32 computes the index of the minimum over the last dimension for float32
 func FastArgminF32(numel, ndims int, dims []int, src []float32, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float32(math.MaxFloat32)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float32(math.MaxFloat32)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminF64 computes the index of the minimum over the last dimension for float64
 func FastArgminF64(numel, ndims int, dims []int, src []float64, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float64(math.MaxFloat64)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float64(math.MaxFloat64)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminU8 computes the index of the minimum over the last dimension for uint8
 func FastArgminU8(numel, ndims int, dims []int, src []uint8, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint8(math.MaxUint8)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint8(math.MaxUint8)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminU32 computes the index of the minimum over the last dimension for uint32
 func FastArgminU32(numel, ndims int, dims []int, src []uint32, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint32(math.MaxUint32)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint32(math.MaxUint32)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminI64 computes the index of the minimum over the last dimension for int64
 func FastArgminI64(numel, ndims int, dims []int, src []int64, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := int64(math.MaxInt64)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] < minVal {
-				minVal = src[j]
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := int64(math.MaxInt64)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] < minVal {
+					minVal = src[j]
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminStrided computes the index of the minimum over the last dimension for type T with strided memory
@@ -693,20 +771,22 @@ func FastArgminStrided[T D](numel, ndims int, dims, strides []int, src []T, dst
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		minVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			minVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminStridedF32 computes the index of the minimum over the last dimension for float32 with strided memory
@@ -716,20 +796,22 @@ func FastArgminStridedF32(numel, ndims int, dims, strides []int, src []float32,
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float32(math.MaxFloat32)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float32(math.MaxFloat32)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminStridedF64 computes the index of the minimum over the last dimension for float64 with strided memory
@@ -739,20 +821,22 @@ func FastArgminStridedF64(numel, ndims int, dims, strides []int, src []float64,
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := float64(math.MaxFloat64)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := float64(math.MaxFloat64)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminStridedU8 computes the index of the minimum over the last dimension for uint8 with strided memory
@@ -762,20 +846,22 @@ func FastArgminStridedU8(numel, ndims int, dims, strides []int, src []uint8, dst
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint8(math.MaxUint8)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint8(math.MaxUint8)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminStridedU32 computes the index of the minimum over the last dimension for uint32 with strided memory
@@ -785,20 +871,22 @@ func FastArgminStridedU32(numel, ndims int, dims, strides []int, src []uint32, d
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := uint32(math.MaxUint32)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := uint32(math.MaxUint32)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgminStridedI64 computes the index of the minimum over the last dimension for int64 with strided memory
@@ -808,128 +896,142 @@ func FastArgminStridedI64(numel, ndims int, dims, strides []int, src []int64, ds
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		minVal := int64(math.MaxInt64)
-		minIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			minVal := int64(math.MaxInt64)
+			minIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // FastArgmax computes the index of the maximum over the last dimension for type T
 func FastArgmax[T D](numel, ndims int, dims []int, src []T, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		maxVal := src[startIdx]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			maxVal := src[startIdx]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxF32 computes the index of the maximum over the last dimension for float32
 func FastArgmaxF32(numel, ndims int, dims []int, src []float32, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float32(-math.MaxFloat32)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float32(-math.MaxFloat32)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxF64 computes the index of the maximum over the last dimension for float64
 func FastArgmaxF64(numel, ndims int, dims []int, src []float64, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float64(-math.MaxFloat64)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float64(-math.MaxFloat64)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxU8 computes the index of the maximum over the last dimension for uint8
 func FastArgmaxU8(numel, ndims int, dims []int, src []uint8, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := uint8(0)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := uint8(0)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxU32 computes the index of the maximum over the last dimension for uint32
 func FastArgmaxU32(numel, ndims int, dims []int, src []uint32, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := uint32(0)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := uint32(0)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxI64 computes the index of the maximum over the last dimension for int64
 func FastArgmaxI64(numel, ndims int, dims []int, src []int64, dst []uint32) {
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := int64(math.MinInt64)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			if src[j] > maxVal {
-				maxVal = src[j]
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := int64(math.MinInt64)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				if src[j] > maxVal {
+					maxVal = src[j]
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxStrided computes the index of the maximum over the last dimension for type T with strided memory
@@ -939,20 +1041,22 @@ func FastArgmaxStrided[T D](numel, ndims int, dims, strides []int, src []T, dst
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		maxVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx + 1; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			maxVal := src[GetStridedIndex(startIdx, ndims, dims, strides)]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx + 1; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxStridedF32 computes the index of the maximum over the last dimension for float32 with strided memory
@@ -962,20 +1066,22 @@ func FastArgmaxStridedF32(numel, ndims int, dims, strides []int, src []float32,
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float32(-math.MaxFloat32)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float32(-math.MaxFloat32)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxStridedF64 computes the index of the maximum over the last dimension for float64 with strided memory
@@ -985,20 +1091,22 @@ func FastArgmaxStridedF64(numel, ndims int, dims, strides []int, src []float64,
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := float64(-math.MaxFloat64)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := float64(-math.MaxFloat64)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxStridedU8 computes the index of the maximum over the last dimension for uint8 with strided memory
@@ -1008,20 +1116,22 @@ func FastArgmaxStridedU8(numel, ndims int, dims, strides []int, src []uint8, dst
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := uint8(0)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := uint8(0)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxStridedU32 computes the index of the maximum over the last dimension for uint32 with strided memory
@@ -1031,20 +1141,22 @@ func FastArgmaxStridedU32(numel, ndims int, dims, strides []int, src []uint32, d
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := uint32(0)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := uint32(0)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // FastArgmaxStridedI64 computes the index of the maximum over the last dimension for int64 with strided memory
@@ -1054,20 +1166,22 @@ func FastArgmaxStridedI64(numel, ndims int, dims, strides []int, src []int64, ds
 		return
 	}
 	dstSize := numel / dims[ndims-1]
-	for i := range dstSize {
-		maxVal := int64(math.MinInt64)
-		maxIdx := uint32(0)
-		startIdx := i * dims[ndims-1]
-		stopIdx := min(startIdx+dims[ndims-1], numel)
-		for j := startIdx; j < stopIdx; j++ {
-			val := src[GetStridedIndex(j, ndims, dims, strides)]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j % dims[ndims-1])
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			maxVal := int64(math.MinInt64)
+			maxIdx := uint32(0)
+			startIdx := i * dims[ndims-1]
+			stopIdx := min(startIdx+dims[ndims-1], numel)
+			for j := startIdx; j < stopIdx; j++ {
+				val := src[GetStridedIndex(j, ndims, dims, strides)]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j % dims[ndims-1])
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // Sum performs sum reduction over specified dimension indices for type T
@@ -1340,19 +1454,21 @@ func Min[T D](numel, ndims int, dims []int, dim int, src, dst []T) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := src[baseIdx] // init to first element
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := src[baseIdx] // init to first element
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinF32 computes the minimum over the specified dimension for float32
@@ -1367,19 +1483,21 @@ func MinF32(numel, ndims int, dims []int, dim int, src, dst []float32) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := float32(math.MaxFloat32)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := float32(math.MaxFloat32)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinF64 computes the minimum over the specified dimension for float64
@@ -1394,19 +1512,21 @@ func MinF64(numel, ndims int, dims []int, dim int, src, dst []float64) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := math.MaxFloat64
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := math.MaxFloat64
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinU8 computes the minimum over the specified dimension for uint8
@@ -1421,19 +1541,21 @@ func MinU8(numel, ndims int, dims []int, dim int, src, dst []uint8) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint8(math.MaxUint8)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint8(math.MaxUint8)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinU32 computes the minimum over the specified dimension for uint32
@@ -1448,19 +1570,21 @@ func MinU32(numel, ndims int, dims []int, dim int, src, dst []uint32) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint32(math.MaxUint32)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint32(math.MaxUint32)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinI64 computes the minimum over the specified dimension for int64
@@ -1475,19 +1599,21 @@ func MinI64(numel, ndims int, dims []int, dim int, src, dst []int64) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := int64(math.MaxInt64)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := int64(math.MaxInt64)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinStrided computes the minimum over the specified dimension for type T with strided memory
@@ -1506,21 +1632,23 @@ func MinStrided[T D](numel, ndims int, dims, strides []int, dim int, src, dst []
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
-		minVal := src[stridedFirst] // init to first element
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			if src[stridedI] < minVal {
-				minVal = src[stridedI]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
+			minVal := src[stridedFirst] // init to first element
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				if src[stridedI] < minVal {
+					minVal = src[stridedI]
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinStridedF32 computes the minimum over the specified dimension for float32 with strided memory
@@ -1539,21 +1667,23 @@ func MinStridedF32(numel, ndims int, dims, strides []int, dim int, src, dst []fl
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := float32(math.MaxFloat32)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := float32(math.MaxFloat32)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinStridedF64 computes the minimum over the specified dimension for float64 with strided memory
@@ -1572,21 +1702,23 @@ func MinStridedF64(numel, ndims int, dims, strides []int, dim int, src, dst []fl
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := math.MaxFloat64
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := math.MaxFloat64
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinStridedU8 computes the minimum over the specified dimension for uint8 with strided memory
@@ -1605,21 +1737,23 @@ func MinStridedU8(numel, ndims int, dims, strides []int, dim int, src, dst []uin
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint8(math.MaxUint8)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint8(math.MaxUint8)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinStridedU32 computes the minimum over the specified dimension for uint32 with strided memory
@@ -1638,21 +1772,23 @@ func MinStridedU32(numel, ndims int, dims, strides []int, dim int, src, dst []ui
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint32(math.MaxUint32)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint32(math.MaxUint32)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // MinStridedI64 computes the minimum over the specified dimension for int64 with strided memory
@@ -1671,21 +1807,23 @@ func MinStridedI64(numel, ndims int, dims, strides []int, dim int, src, dst []in
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := int64(math.MaxInt64)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := int64(math.MaxInt64)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+				}
 			}
+			dst[i] = minVal
 		}
-		dst[i] = minVal
-	}
+	})
 }
 
 // Max computes the maximum over the specified dimension for type T
@@ -1700,19 +1838,21 @@ func Max[T D](numel, ndims int, dims []int, dim int, src, dst []T) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := src[baseIdx] // init to first element
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := src[baseIdx] // init to first element
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxF32 computes the maximum over the specified dimension for float32
@@ -1727,19 +1867,21 @@ func MaxF32(numel, ndims int, dims []int, dim int, src, dst []float32) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := float32(-math.MaxFloat32)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := float32(-math.MaxFloat32)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxF64 computes the maximum over the specified dimension for float64
@@ -1754,19 +1896,21 @@ func MaxF64(numel, ndims int, dims []int, dim int, src, dst []float64) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := -math.MaxFloat64
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := -math.MaxFloat64
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxU8 computes the maximum over the specified dimension for uint8
@@ -1781,19 +1925,21 @@ func MaxU8(numel, ndims int, dims []int, dim int, src, dst []uint8) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint8(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint8(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxU32 computes the maximum over the specified dimension for uint32
@@ -1808,19 +1954,21 @@ func MaxU32(numel, ndims int, dims []int, dim int, src, dst []uint32) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxI64 computes the maximum over the specified dimension for int64
@@ -1835,19 +1983,21 @@ func MaxI64(numel, ndims int, dims []int, dim int, src, dst []int64) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := int64(math.MinInt64)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := int64(math.MinInt64)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxStrided computes the maximum over the specified dimension for type T with strided memory
@@ -1866,21 +2016,23 @@ func MaxStrided[T D](numel, ndims int, dims, strides []int, dim int, src, dst []
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
-		maxVal := src[stridedFirst] // init to first element
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			if src[stridedI] > maxVal {
-				maxVal = src[stridedI]
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
+			maxVal := src[stridedFirst] // init to first element
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				if src[stridedI] > maxVal {
+					maxVal = src[stridedI]
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxStridedF32 computes the maximum over the specified dimension for float32 with strided memory
@@ -1899,21 +2051,23 @@ func MaxStridedF32(numel, ndims int, dims, strides []int, dim int, src, dst []fl
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := float32(-math.MaxFloat32)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := float32(-math.MaxFloat32)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxStridedF64 computes the maximum over the specified dimension for float64 with strided memory
@@ -1932,21 +2086,23 @@ func MaxStridedF64(numel, ndims int, dims, strides []int, dim int, src, dst []fl
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := -math.MaxFloat64
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := -math.MaxFloat64
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxStridedU8 computes the maximum over the specified dimension for uint8 with strided memory
@@ -1965,21 +2121,23 @@ func MaxStridedU8(numel, ndims int, dims, strides []int, dim int, src, dst []uin
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint8(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint8(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxStridedU32 computes the maximum over the specified dimension for uint32 with strided memory
@@ -1998,21 +2156,23 @@ func MaxStridedU32(numel, ndims int, dims, strides []int, dim int, src, dst []ui
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // MaxStridedI64 computes the maximum over the specified dimension for int64 with strided memory
@@ -2031,21 +2191,23 @@ func MaxStridedI64(numel, ndims int, dims, strides []int, dim int, src, dst []in
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := int64(math.MinInt64)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := int64(math.MinInt64)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+				}
 			}
+			dst[i] = maxVal
 		}
-		dst[i] = maxVal
-	}
+	})
 }
 
 // Argmin computes the index of the minimum over the specified dimension for type T
@@ -2060,21 +2222,23 @@ func Argmin[T D](numel, ndims int, dims []int, dim int, src []T, dst []uint32) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minIdx := uint32(0)
-		minVal := src[baseIdx]
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
-				minIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minIdx := uint32(0)
+			minVal := src[baseIdx]
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+					minIdx = uint32(j)
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // ArgminF32 computes the index of the minimum over the specified dimension for float32
@@ -2089,21 +2253,23 @@ func ArgminF32(numel, ndims int, dims []int, dim int, src []float32, dst []uint3
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := float32(math.MaxFloat32)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
-				minIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := float32(math.MaxFloat32)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+					minIdx = uint32(j)
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // ArgminF64 computes the index of the minimum over the specified dimension for float64
@@ -2118,21 +2284,23 @@ func ArgminF64(numel, ndims int, dims []int, dim int, src []float64, dst []uint3
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := math.MaxFloat64
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
-				minIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := math.MaxFloat64
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+					minIdx = uint32(j)
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // ArgminU8 computes the index of the minimum over the specified dimension for uint8
@@ -2147,21 +2315,23 @@ func ArgminU8(numel, ndims int, dims []int, dim int, src []uint8, dst []uint32)
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint8(math.MaxUint8)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
-				minIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint8(math.MaxUint8)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+					minIdx = uint32(j)
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // ArgminU32 computes the index of the minimum over the specified dimension for uint32
@@ -2176,21 +2346,23 @@ func ArgminU32(numel, ndims int, dims []int, dim int, src []uint32, dst []uint32
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint32(math.MaxUint32)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
-				minIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint32(math.MaxUint32)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+					minIdx = uint32(j)
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // ArgminI64 computes the index of the minimum over the specified dimension for int64
@@ -2205,21 +2377,23 @@ func ArgminI64(numel, ndims int, dims []int, dim int, src []int64, dst []uint32)
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := int64(math.MaxInt64)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] < minVal {
-				minVal = src[idx]
-				minIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := int64(math.MaxInt64)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] < minVal {
+					minVal = src[idx]
+					minIdx = uint32(j)
+				}
 			}
+			dst[i] = minIdx
 		}
-		dst[i] = minIdx
-	}
+	})
 }
 
 // ArgminStrided computes the index of the minimum over the specified dimension for type T with strided memory
@@ -2238,24 +2412,26 @@ func ArgminStrided[T D](numel, ndims int, dims, strides []int, dim int, src []T,
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
-		minVal := src[stridedFirst]
-		minIdx := uint32(0)
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j)
-			}
-		}
-		dst[i] = minIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
+			minVal := src[stridedFirst]
+			minIdx := uint32(0)
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j)
+				}
+			}
+			dst[i] = minIdx
+		}
+	})
 }
 
 // ArgminStridedF32 computes the index of the minimum over the specified dimension for float32 with strided memory
@@ -2274,23 +2450,25 @@ func ArgminStridedF32(numel, ndims int, dims, strides []int, dim int, src []floa
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := float32(math.MaxFloat32)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j)
-			}
-		}
-		dst[i] = minIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := float32(math.MaxFloat32)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j)
+				}
+			}
+			dst[i] = minIdx
+		}
+	})
 }
 
 // ArgminStridedF64 computes the index of the minimum over the specified dimension for float64 with strided memory
@@ -2309,23 +2487,25 @@ func ArgminStridedF64(numel, ndims int, dims, strides []int, dim int, src []floa
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := math.MaxFloat64
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j)
-			}
-		}
-		dst[i] = minIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := math.MaxFloat64
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j)
+				}
+			}
+			dst[i] = minIdx
+		}
+	})
 }
 
 // ArgminStridedU8 computes the index of the minimum over the specified dimension for uint8 with strided memory
@@ -2344,23 +2524,25 @@ func ArgminStridedU8(numel, ndims int, dims, strides []int, dim int, src []uint8
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint8(math.MaxUint8)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j)
-			}
-		}
-		dst[i] = minIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint8(math.MaxUint8)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j)
+				}
+			}
+			dst[i] = minIdx
+		}
+	})
 }
 
 // ArgminStridedU32 computes the index of the minimum over the specified dimension for uint32 with strided memory
@@ -2379,23 +2561,25 @@ func ArgminStridedU32(numel, ndims int, dims, strides []int, dim int, src []uint
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := uint32(math.MaxUint32)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j)
-			}
-		}
-		dst[i] = minIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := uint32(math.MaxUint32)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j)
+				}
+			}
+			dst[i] = minIdx
+		}
+	})
 }
 
 // ArgminStridedI64 computes the index of the minimum over the specified dimension for int64 with strided memory
@@ -2414,23 +2598,25 @@ func ArgminStridedI64(numel, ndims int, dims, strides []int, dim int, src []int6
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		minVal := int64(math.MaxInt64)
-		minIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val < minVal {
-				minVal = val
-				minIdx = uint32(j)
-			}
-		}
-		dst[i] = minIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			minVal := int64(math.MaxInt64)
+			minIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val < minVal {
+					minVal = val
+					minIdx = uint32(j)
+				}
+			}
+			dst[i] = minIdx
+		}
+	})
 }
 
 // Argmax computes the index of the maximum over the specified dimension for type T
@@ -2445,21 +2631,23 @@ func Argmax[T D](numel, ndims int, dims []int, dim int, src []T, dst []uint32) {
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxIdx := uint32(0)
-		maxVal := src[baseIdx]
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
-				maxIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxIdx := uint32(0)
+			maxVal := src[baseIdx]
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+					maxIdx = uint32(j)
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // ArgmaxF32 computes the index of the maximum over the specified dimension for float32
@@ -2474,21 +2662,23 @@ func ArgmaxF32(numel, ndims int, dims []int, dim int, src []float32, dst []uint3
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := float32(-math.MaxFloat32)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
-				maxIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := float32(-math.MaxFloat32)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+					maxIdx = uint32(j)
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // ArgmaxF64 computes the index of the maximum over the specified dimension for float64
@@ -2503,21 +2693,23 @@ func ArgmaxF64(numel, ndims int, dims []int, dim int, src []float64, dst []uint3
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := -math.MaxFloat64
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
-				maxIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := -math.MaxFloat64
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+					maxIdx = uint32(j)
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // ArgmaxU8 computes the index of the maximum over the specified dimension for uint8
@@ -2532,21 +2724,23 @@ func ArgmaxU8(numel, ndims int, dims []int, dim int, src []uint8, dst []uint32)
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint8(0)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
-				maxIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint8(0)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+					maxIdx = uint32(j)
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // ArgmaxU32 computes the index of the maximum over the specified dimension for uint32
@@ -2561,21 +2755,23 @@ func ArgmaxU32(numel, ndims int, dims []int, dim int, src []uint32, dst []uint32
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint32(0)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
-				maxIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint32(0)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+					maxIdx = uint32(j)
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // ArgmaxI64 computes the index of the maximum over the specified dimension for int64
@@ -2590,21 +2786,23 @@ func ArgmaxI64(numel, ndims int, dims []int, dim int, src []int64, dst []uint32)
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := int64(math.MinInt64)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			if src[idx] > maxVal {
-				maxVal = src[idx]
-				maxIdx = uint32(j)
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := int64(math.MinInt64)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				if src[idx] > maxVal {
+					maxVal = src[idx]
+					maxIdx = uint32(j)
+				}
 			}
+			dst[i] = maxIdx
 		}
-		dst[i] = maxIdx
-	}
+	})
 }
 
 // ArgmaxStrided computes the index of the maximum over the specified dimension for type T with strided memory
@@ -2623,24 +2821,26 @@ func ArgmaxStrided[T D](numel, ndims int, dims, strides []int, dim int, src []T,
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
-		maxVal := src[stridedFirst]
-		maxIdx := uint32(0)
-		for j := 1; j < reduceSize; j++ {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j)
-			}
-		}
-		dst[i] = maxIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			stridedFirst := GetStridedIndex(baseIdx, ndims, dims, strides)
+			maxVal := src[stridedFirst]
+			maxIdx := uint32(0)
+			for j := 1; j < reduceSize; j++ {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j)
+				}
+			}
+			dst[i] = maxIdx
+		}
+	})
 }
 
 // ArgmaxStridedF32 computes the index of the maximum over the specified dimension for float32 with strided memory
@@ -2659,23 +2859,25 @@ func ArgmaxStridedF32(numel, ndims int, dims, strides []int, dim int, src []floa
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := float32(-math.MaxFloat32)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j)
-			}
-		}
-		dst[i] = maxIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := float32(-math.MaxFloat32)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j)
+				}
+			}
+			dst[i] = maxIdx
+		}
+	})
 }
 
 // ArgmaxStridedF64 computes the index of the maximum over the specified dimension for float64 with strided memory
@@ -2694,23 +2896,25 @@ func ArgmaxStridedF64(numel, ndims int, dims, strides []int, dim int, src []floa
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := -math.MaxFloat64
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j)
-			}
-		}
-		dst[i] = maxIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := -math.MaxFloat64
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j)
+				}
+			}
+			dst[i] = maxIdx
+		}
+	})
 }
 
 // ArgmaxStridedU8 computes the index of the maximum over the specified dimension for uint8 with strided memory
@@ -2729,23 +2933,25 @@ func ArgmaxStridedU8(numel, ndims int, dims, strides []int, dim int, src []uint8
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint8(0)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j)
-			}
-		}
-		dst[i] = maxIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint8(0)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j)
+				}
+			}
+			dst[i] = maxIdx
+		}
+	})
 }
 
 // ArgmaxStridedU32 computes the index of the maximum over the last dimension for uint32 with strided memory
@@ -2764,23 +2970,25 @@ func ArgmaxStridedU32(numel, ndims int, dims, strides []int, dim int, src []uint
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := uint32(0)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j)
-			}
-		}
-		dst[i] = maxIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := uint32(0)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j)
+				}
+			}
+			dst[i] = maxIdx
+		}
+	})
 }
 
 // ArgmaxStridedI64 computes the index of the maximum over the specified dimension for int64 with strided memory
@@ -2799,23 +3007,25 @@ func ArgmaxStridedI64(numel, ndims int, dims, strides []int, dim int, src []int6
 		suffix *= dims[i]
 	}
 	dstSize := numel / reduceSize
-	for i := range dstSize {
-		outer := i / suffix
-		inner := i % suffix
-		baseIdx := outer*(reduceSize*suffix) + inner
-		maxVal := int64(math.MinInt64)
-		maxIdx := uint32(0)
-		for j := range reduceSize {
-			idx := baseIdx + j*suffix
-			stridedI := GetStridedIndex(idx, ndims, dims, strides)
-			val := src[stridedI]
-			if val > maxVal {
-				maxVal = val
-				maxIdx = uint32(j)
-			}
-		}
-		dst[i] = maxIdx
-	}
+	ParallelFor(dstSize, rowGrain(numel, dstSize), func(start, end int) {
+		for i := start; i < end; i++ {
+			outer := i / suffix
+			inner := i % suffix
+			baseIdx := outer*(reduceSize*suffix) + inner
+			maxVal := int64(math.MinInt64)
+			maxIdx := uint32(0)
+			for j := range reduceSize {
+				idx := baseIdx + j*suffix
+				stridedI := GetStridedIndex(idx, ndims, dims, strides)
+				val := src[stridedI]
+				if val > maxVal {
+					maxVal = val
+					maxIdx = uint32(j)
+				}
+			}
+			dst[i] = maxIdx
+		}
+	})
 }
 
 // FastSoftmax performs softmax along the last dimension for type T (contiguous memory)
@@ -2828,81 +3038,87 @@ func FastSoftmax[T D](numel, ndims int, dims []int, src, dst []T) {
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		maxVal := src[row*ncols]
-		for col := range ncols {
-			i := row*ncols + col
-			if src[i] > maxVal {
-				maxVal = src[i]
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			maxVal := src[row*ncols]
+			for col := range ncols {
+				i := row*ncols + col
+				if src[i] > maxVal {
+					maxVal = src[i]
+				}
+			}
+			var sum T
+			for col := range ncols {
+				i := row*ncols + col
+				val := T(math.Exp(float64(src[i] - maxVal)))
+				dst[i] = val
+				sum += val
+			}
+			invSum := T(1) / sum
+			for col := range ncols {
+				i := row*ncols + col
+				dst[i] *= invSum
 			}
 		}
-		var sum T
-		for col := range ncols {
-			i := row*ncols + col
-			val := T(math.Exp(float64(src[i] - maxVal)))
-			dst[i] = val
-			sum += val
-		}
-		invSum := T(1) / sum
-		for col := range ncols {
-			i := row*ncols + col
-			dst[i] *= invSum
-		}
-	}
+	})
 }
 
 // FastSoftmaxF32 performs softmax along the last dimension for float32 (contiguous memory)
 func FastSoftmaxF32(numel int, ndims int, dims []int, src, dst []float32) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		maxVal := float32(-math.MaxFloat32)
-		for col := range ncols {
-			i := row*ncols + col
-			if src[i] > maxVal {
-				maxVal = src[i]
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			maxVal := float32(-math.MaxFloat32)
+			for col := range ncols {
+				i := row*ncols + col
+				if src[i] > maxVal {
+					maxVal = src[i]
+				}
+			}
+			sum := float32(0)
+			for col := range ncols {
+				i := row*ncols + col
+				val := float32(math.Exp(float64(src[i] - maxVal)))
+				dst[i] = val
+				sum += val
+			}
+			invSum := 1 / sum
+			for col := range ncols {
+				i := row*ncols + col
+				dst[i] *= invSum
 			}
 		}
-		sum := float32(0)
-		for col := range ncols {
-			i := row*ncols + col
-			val := float32(math.Exp(float64(src[i] - maxVal)))
-			dst[i] = val
-			sum += val
-		}
-		invSum := 1 / sum
-		for col := range ncols {
-			i := row*ncols + col
-			dst[i] *= invSum
-		}
-	}
+	})
 }
 
 // FastSoftmaxF64 performs softmax along the last dimension for float64 (contiguous memory)
 func FastSoftmaxF64(numel int, ndims int, dims []int, src, dst []float64) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		maxVal := -math.MaxFloat64
-		for col := range ncols {
-			i := row*ncols + col
-			if src[i] > maxVal {
-				maxVal = src[i]
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			maxVal := -math.MaxFloat64
+			for col := range ncols {
+				i := row*ncols + col
+				if src[i] > maxVal {
+					maxVal = src[i]
+				}
+			}
+			sum := 0.0
+			for col := range ncols {
+				i := row*ncols + col
+				val := math.Exp(src[i] - maxVal)
+				dst[i] = val
+				sum += val
+			}
+			invSum := 1 / sum
+			for col := range ncols {
+				i := row*ncols + col
+				dst[i] *= invSum
 			}
 		}
-		sum := 0.0
-		for col := range ncols {
-			i := row*ncols + col
-			val := math.Exp(src[i] - maxVal)
-			dst[i] = val
-			sum += val
-		}
-		invSum := 1 / sum
-		for col := range ncols {
-			i := row*ncols + col
-			dst[i] *= invSum
-		}
-	}
+	})
 }
 
 // FastSoftmaxU8 performs softmax along the last dimension for uint8 (contiguous memory)
@@ -2934,30 +3150,32 @@ func FastSoftmaxStrided[T D](numel, ndims int, dims, strides []int, src, dst []T
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		maxVal := src[GetStridedIndex(row*ncols, ndims, dims, strides)]
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			if src[stridedI] > maxVal {
-				maxVal = src[stridedI]
-			}
-		}
-		var sum T
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			val := T(math.Exp(float64(src[stridedI] - maxVal)))
-			dst[stridedI] = val
-			sum += val
-		}
-		invSum := T(1) / sum
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			dst[stridedI] *= invSum
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			maxVal := src[GetStridedIndex(row*ncols, ndims, dims, strides)]
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				if src[stridedI] > maxVal {
+					maxVal = src[stridedI]
+				}
+			}
+			var sum T
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				val := T(math.Exp(float64(src[stridedI] - maxVal)))
+				dst[stridedI] = val
+				sum += val
+			}
+			invSum := T(1) / sum
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				dst[stridedI] *= invSum
+			}
+		}
+	})
 }
 
 // FastSoftmaxStridedF32 performs strided softmax along the last dimension for float32
@@ -2968,30 +3186,32 @@ func FastSoftmaxStridedF32(numel int, ndims int, dims, strides []int, src, dst [
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		maxVal := float32(-math.MaxFloat32)
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			if src[stridedI] > maxVal {
-				maxVal = src[stridedI]
-			}
-		}
-		sum := float32(0)
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			val := float32(math.Exp(float64(src[stridedI] - maxVal)))
-			dst[stridedI] = val
-			sum += val
-		}
-		invSum := 1 / sum
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			dst[stridedI] *= invSum
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			maxVal := float32(-math.MaxFloat32)
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				if src[stridedI] > maxVal {
+					maxVal = src[stridedI]
+				}
+			}
+			sum := float32(0)
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				val := float32(math.Exp(float64(src[stridedI] - maxVal)))
+				dst[stridedI] = val
+				sum += val
+			}
+			invSum := 1 / sum
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				dst[stridedI] *= invSum
+			}
+		}
+	})
 }
 
 // FastSoftmaxStridedF64 performs strided softmax along the last dimension for float64
@@ -3002,30 +3222,32 @@ func FastSoftmaxStridedF64(numel int, ndims int, dims, strides []int, src, dst [
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		maxVal := -math.MaxFloat64
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			if src[stridedI] > maxVal {
-				maxVal = src[stridedI]
-			}
-		}
-		sum := 0.0
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			val := math.Exp(src[stridedI] - maxVal)
-			dst[stridedI] = val
-			sum += val
-		}
-		invSum := 1 / sum
-		for col := range ncols {
-			logicalI := row*ncols + col
-			stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
-			dst[stridedI] *= invSum
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			maxVal := -math.MaxFloat64
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				if src[stridedI] > maxVal {
+					maxVal = src[stridedI]
+				}
+			}
+			sum := 0.0
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				val := math.Exp(src[stridedI] - maxVal)
+				dst[stridedI] = val
+				sum += val
+			}
+			invSum := 1 / sum
+			for col := range ncols {
+				logicalI := row*ncols + col
+				stridedI := GetStridedIndex(logicalI, ndims, dims, strides)
+				dst[stridedI] *= invSum
+			}
+		}
+	})
 }
 
 // FastSoftmaxStridedU8 performs strided softmax along the last dimension for uint8
@@ -3047,150 +3269,162 @@ func FastSoftmaxStridedI64(numel, ndims int, dims, strides []int, src, dst []int
 func FastRmsNorm[T D](numel, ndims int, dims []int, eps T, alpha, x, dst []T) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum T
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := x[idx]
-			sum += xi * xi
-		}
-		mean := sum / T(ncols)
-		scale := T(1) / T(math.Sqrt(float64(mean+eps)))
-		for col := range ncols {
-			idx := row*ncols + col
-			if alpha != nil {
-				dst[idx] = scale * x[idx] * alpha[col]
-			} else {
-				dst[idx] = scale * x[idx]
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum T
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := x[idx]
+				sum += xi * xi
+			}
+			mean := sum / T(ncols)
+			scale := T(1) / T(math.Sqrt(float64(mean+eps)))
+			for col := range ncols {
+				idx := row*ncols + col
+				if alpha != nil {
+					dst[idx] = scale * x[idx] * alpha[col]
+				} else {
+					dst[idx] = scale * x[idx]
+				}
 			}
 		}
-	}
+	})
 }
 
 // FastRmsNormF32 performs RMS normalization along the last dimension for float32 (contiguous memory)
 func FastRmsNormF32(numel int, ndims int, dims []int, eps float32, alpha, x, dst []float32) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float32
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := x[idx]
-			sum += xi * xi
-		}
-		mean := sum / float32(ncols)
-		scale := 1 / float32(math.Sqrt(float64(mean+eps)))
-		for col := range ncols {
-			idx := row*ncols + col
-			if alpha != nil {
-				dst[idx] = scale * x[idx] * alpha[col]
-			} else {
-				dst[idx] = scale * x[idx]
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float32
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := x[idx]
+				sum += xi * xi
+			}
+			mean := sum / float32(ncols)
+			scale := 1 / float32(math.Sqrt(float64(mean+eps)))
+			for col := range ncols {
+				idx := row*ncols + col
+				if alpha != nil {
+					dst[idx] = scale * x[idx] * alpha[col]
+				} else {
+					dst[idx] = scale * x[idx]
+				}
 			}
 		}
-	}
+	})
 }
 
 // FastRmsNormF64 performs RMS normalization along the last dimension for float64 (contiguous memory)
 func FastRmsNormF64(numel int, ndims int, dims []int, eps float64, alpha, x, dst []float64) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := x[idx]
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			if alpha != nil {
-				dst[idx] = scale * x[idx] * alpha[col]
-			} else {
-				dst[idx] = scale * x[idx]
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := x[idx]
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				if alpha != nil {
+					dst[idx] = scale * x[idx] * alpha[col]
+				} else {
+					dst[idx] = scale * x[idx]
+				}
 			}
 		}
-	}
+	})
 }
 
 // FastRmsNormU8 performs RMS normalization along the last dimension for uint8 (contiguous memory)
 func FastRmsNormU8(numel, ndims int, dims []int, eps float64, alpha, x, dst []uint8) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := float64(x[idx])
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			var result float64
-			if alpha != nil {
-				result = scale * float64(x[idx]) * float64(alpha[col])
-			} else {
-				result = scale * float64(x[idx])
-			}
-			dst[idx] = uint8(result * 255) // Scale to uint8 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := float64(x[idx])
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				var result float64
+				if alpha != nil {
+					result = scale * float64(x[idx]) * float64(alpha[col])
+				} else {
+					result = scale * float64(x[idx])
+				}
+				dst[idx] = uint8(result * 255) // Scale to uint8 range
+			}
+		}
+	})
 }
 
 // FastRmsNormU32 performs RMS normalization along the last dimension for uint32 (contiguous memory)
 func FastRmsNormU32(numel, ndims int, dims []int, eps float64, alpha, x, dst []uint32) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := float64(x[idx])
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			var result float64
-			if alpha != nil {
-				result = scale * float64(x[idx]) * float64(alpha[col])
-			} else {
-				result = scale * float64(x[idx])
-			}
-			dst[idx] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := float64(x[idx])
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				var result float64
+				if alpha != nil {
+					result = scale * float64(x[idx]) * float64(alpha[col])
+				} else {
+					result = scale * float64(x[idx])
+				}
+				dst[idx] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
+			}
+		}
+	})
 }
 
 // FastRmsNormI64 performs RMS normalization along the last dimension for int64 (contiguous memory)
 func FastRmsNormI64(numel, ndims int, dims []int, eps float64, alpha, x, dst []int64) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := float64(x[idx])
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			var result float64
-			if alpha != nil {
-				result = scale * float64(x[idx]) * float64(alpha[col])
-			} else {
-				result = scale * float64(x[idx])
-			}
-			dst[idx] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := float64(x[idx])
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				var result float64
+				if alpha != nil {
+					result = scale * float64(x[idx]) * float64(alpha[col])
+				} else {
+					result = scale * float64(x[idx])
+				}
+				dst[idx] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
+			}
+		}
+	})
 }
 
 // FastRmsNormStrided performs strided RMS normalization along the last dimension for type T
@@ -3201,26 +3435,28 @@ func FastRmsNormStrided[T D](numel, ndims int, dims, strides []int, eps T, alpha
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum T
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := x[stridedI]
-			sum += xi * xi
-		}
-		mean := sum / T(ncols)
-		scale := T(1) / T(math.Sqrt(float64(mean+eps)))
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			if alpha != nil {
-				dst[stridedI] = scale * x[stridedI] * alpha[col]
-			} else {
-				dst[stridedI] = scale * x[stridedI]
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum T
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := x[stridedI]
+				sum += xi * xi
+			}
+			mean := sum / T(ncols)
+			scale := T(1) / T(math.Sqrt(float64(mean+eps)))
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				if alpha != nil {
+					dst[stridedI] = scale * x[stridedI] * alpha[col]
+				} else {
+					dst[stridedI] = scale * x[stridedI]
+				}
+			}
+		}
+	})
 }
 
 // FastRmsNormStridedF32 performs strided RMS normalization along the last dimension for float32
@@ -3231,26 +3467,28 @@ func FastRmsNormStridedF32(numel int, ndims int, dims, strides []int, eps float3
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float32
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := x[stridedI]
-			sum += xi * xi
-		}
-		mean := sum / float32(ncols)
-		scale := 1 / float32(math.Sqrt(float64(mean+eps)))
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			if alpha != nil {
-				dst[stridedI] = scale * x[stridedI] * alpha[col]
-			} else {
-				dst[stridedI] = scale * x[stridedI]
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float32
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := x[stridedI]
+				sum += xi * xi
+			}
+			mean := sum / float32(ncols)
+			scale := 1 / float32(math.Sqrt(float64(mean+eps)))
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				if alpha != nil {
+					dst[stridedI] = scale * x[stridedI] * alpha[col]
+				} else {
+					dst[stridedI] = scale * x[stridedI]
+				}
+			}
+		}
+	})
 }
 
 // FastRmsNormStridedF64 performs strided RMS normalization along the last dimension for float64
@@ -3261,26 +3499,28 @@ func FastRmsNormStridedF64(numel int, ndims int, dims, strides []int, eps float6
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := x[stridedI]
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			if alpha != nil {
-				dst[stridedI] = scale * x[stridedI] * alpha[col]
-			} else {
-				dst[stridedI] = scale * x[stridedI]
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := x[stridedI]
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				if alpha != nil {
+					dst[stridedI] = scale * x[stridedI] * alpha[col]
+				} else {
+					dst[stridedI] = scale * x[stridedI]
+				}
+			}
+		}
+	})
 }
 
 // FastRmsNormStridedU8 performs strided RMS normalization along the last dimension for uint8
@@ -3291,28 +3531,30 @@ func FastRmsNormStridedU8(numel, ndims int, dims, strides []int, eps float64, al
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := float64(x[stridedI])
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			var result float64
-			if alpha != nil {
-				result = scale * float64(x[stridedI]) * float64(alpha[col])
-			} else {
-				result = scale * float64(x[stridedI])
-			}
-			dst[stridedI] = uint8(result * 255) // Scale to uint8 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := float64(x[stridedI])
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				var result float64
+				if alpha != nil {
+					result = scale * float64(x[stridedI]) * float64(alpha[col])
+				} else {
+					result = scale * float64(x[stridedI])
+				}
+				dst[stridedI] = uint8(result * 255) // Scale to uint8 range
+			}
+		}
+	})
 }
 
 // FastRmsNormStridedU32 performs strided RMS normalization along the last dimension for uint32
@@ -3323,28 +3565,30 @@ func FastRmsNormStridedU32(numel, ndims int, dims, strides []int, eps float64, a
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := float64(x[stridedI])
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			var result float64
-			if alpha != nil {
-				result = scale * float64(x[stridedI]) * float64(alpha[col])
-			} else {
-				result = scale * float64(x[stridedI])
-			}
-			dst[stridedI] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := float64(x[stridedI])
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				var result float64
+				if alpha != nil {
+					result = scale * float64(x[stridedI]) * float64(alpha[col])
+				} else {
+					result = scale * float64(x[stridedI])
+				}
+				dst[stridedI] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
+			}
+		}
+	})
 }
 
 // FastRmsNormStridedI64 performs strided RMS normalization along the last dimension for int64
@@ -3355,220 +3599,234 @@ func FastRmsNormStridedI64(numel, ndims int, dims, strides []int, eps float64, a
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := float64(x[stridedI])
-			sum += xi * xi
-		}
-		mean := sum / float64(ncols)
-		scale := 1 / math.Sqrt(mean+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			var result float64
-			if alpha != nil {
-				result = scale * float64(x[stridedI]) * float64(alpha[col])
-			} else {
-				result = scale * float64(x[stridedI])
-			}
-			dst[stridedI] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := float64(x[stridedI])
+				sum += xi * xi
+			}
+			mean := sum / float64(ncols)
+			scale := 1 / math.Sqrt(mean+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				var result float64
+				if alpha != nil {
+					result = scale * float64(x[stridedI]) * float64(alpha[col])
+				} else {
+					result = scale * float64(x[stridedI])
+				}
+				dst[stridedI] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
+			}
+		}
+	})
 }
 
 // FastLayerNorm performs Layer normalization along the last dimension for type T (contiguous memory)
 func FastLayerNorm[T D](numel, ndims int, dims []int, eps T, alpha, beta, x, dst []T) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq T
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := x[idx]
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / T(ncols)
-		variance := sumSq/T(ncols) - mean*mean
-		scale := T(1) / T(math.Sqrt(float64(variance+eps)))
-		for col := range ncols {
-			idx := row*ncols + col
-			lhs := (x[idx] - mean) * scale
-			if alpha != nil && beta != nil {
-				dst[idx] = lhs*alpha[col] + beta[col]
-			} else if alpha != nil {
-				dst[idx] = lhs * alpha[col]
-			} else if beta != nil {
-				dst[idx] = lhs + beta[col]
-			} else {
-				dst[idx] = lhs
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq T
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := x[idx]
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / T(ncols)
+			variance := sumSq/T(ncols) - mean*mean
+			scale := T(1) / T(math.Sqrt(float64(variance+eps)))
+			for col := range ncols {
+				idx := row*ncols + col
+				lhs := (x[idx] - mean) * scale
+				if alpha != nil && beta != nil {
+					dst[idx] = lhs*alpha[col] + beta[col]
+				} else if alpha != nil {
+					dst[idx] = lhs * alpha[col]
+				} else if beta != nil {
+					dst[idx] = lhs + beta[col]
+				} else {
+					dst[idx] = lhs
+				}
+			}
+		}
+	})
 }
 
 // FastLayerNormF32 performs Layer normalization along the last dimension for float32 (contiguous memory)
 func FastLayerNormF32(numel int, ndims int, dims []int, eps float32, alpha, beta, x, dst []float32) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float32
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := x[idx]
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float32(ncols)
-		variance := sumSq/float32(ncols) - mean*mean
-		scale := 1 / float32(math.Sqrt(float64(variance+eps)))
-		for col := range ncols {
-			idx := row*ncols + col
-			lhs := (x[idx] - mean) * scale
-			if alpha != nil && beta != nil {
-				dst[idx] = lhs*alpha[col] + beta[col]
-			} else if alpha != nil {
-				dst[idx] = lhs * alpha[col]
-			} else if beta != nil {
-				dst[idx] = lhs + beta[col]
-			} else {
-				dst[idx] = lhs
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float32
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := x[idx]
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float32(ncols)
+			variance := sumSq/float32(ncols) - mean*mean
+			scale := 1 / float32(math.Sqrt(float64(variance+eps)))
+			for col := range ncols {
+				idx := row*ncols + col
+				lhs := (x[idx] - mean) * scale
+				if alpha != nil && beta != nil {
+					dst[idx] = lhs*alpha[col] + beta[col]
+				} else if alpha != nil {
+					dst[idx] = lhs * alpha[col]
+				} else if beta != nil {
+					dst[idx] = lhs + beta[col]
+				} else {
+					dst[idx] = lhs
+				}
+			}
+		}
+	})
 }
 
 // FastLayerNormF64 performs Layer normalization along the last dimension for float64 (contiguous memory)
 func FastLayerNormF64(numel int, ndims int, dims []int, eps float64, alpha, beta, x, dst []float64) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := x[idx]
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			lhs := (x[idx] - mean) * scale
-			if alpha != nil && beta != nil {
-				dst[idx] = lhs*alpha[col] + beta[col]
-			} else if alpha != nil {
-				dst[idx] = lhs * alpha[col]
-			} else if beta != nil {
-				dst[idx] = lhs + beta[col]
-			} else {
-				dst[idx] = lhs
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := x[idx]
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				lhs := (x[idx] - mean) * scale
+				if alpha != nil && beta != nil {
+					dst[idx] = lhs*alpha[col] + beta[col]
+				} else if alpha != nil {
+					dst[idx] = lhs * alpha[col]
+				} else if beta != nil {
+					dst[idx] = lhs + beta[col]
+				} else {
+					dst[idx] = lhs
+				}
+			}
+		}
+	})
 }
 
 // FastLayerNormU8 performs Layer normalization along the last dimension for uint8 (contiguous memory)
 func FastLayerNormU8(numel, ndims int, dims []int, eps float64, alpha, beta, x, dst []uint8) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := float64(x[idx])
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			lhs := (float64(x[idx]) - mean) * scale
-			var result float64
-			if alpha != nil && beta != nil {
-				result = lhs*float64(alpha[col]) + float64(beta[col])
-			} else if alpha != nil {
-				result = lhs * float64(alpha[col])
-			} else if beta != nil {
-				result = lhs + float64(beta[col])
-			} else {
-				result = lhs
-			}
-			dst[idx] = uint8(result * 255) // Scale to uint8 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := float64(x[idx])
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				lhs := (float64(x[idx]) - mean) * scale
+				var result float64
+				if alpha != nil && beta != nil {
+					result = lhs*float64(alpha[col]) + float64(beta[col])
+				} else if alpha != nil {
+					result = lhs * float64(alpha[col])
+				} else if beta != nil {
+					result = lhs + float64(beta[col])
+				} else {
+					result = lhs
+				}
+				dst[idx] = uint8(result * 255) // Scale to uint8 range
+			}
+		}
+	})
 }
 
 // FastLayerNormU32 performs Layer normalization along the last dimension for uint32 (contiguous memory)
 func FastLayerNormU32(numel, ndims int, dims []int, eps float64, alpha, beta, x, dst []uint32) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := float64(x[idx])
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			lhs := (float64(x[idx]) - mean) * scale
-			var result float64
-			if alpha != nil && beta != nil {
-				result = lhs*float64(alpha[col]) + float64(beta[col])
-			} else if alpha != nil {
-				result = lhs * float64(alpha[col])
-			} else if beta != nil {
-				result = lhs + float64(beta[col])
-			} else {
-				result = lhs
-			}
-			dst[idx] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := float64(x[idx])
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				lhs := (float64(x[idx]) - mean) * scale
+				var result float64
+				if alpha != nil && beta != nil {
+					result = lhs*float64(alpha[col]) + float64(beta[col])
+				} else if alpha != nil {
+					result = lhs * float64(alpha[col])
+				} else if beta != nil {
+					result = lhs + float64(beta[col])
+				} else {
+					result = lhs
+				}
+				dst[idx] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
+			}
+		}
+	})
 }
 
 // FastLayerNormI64 performs Layer normalization along the last dimension for int64 (contiguous memory)
 func FastLayerNormI64(numel, ndims int, dims []int, eps float64, alpha, beta, x, dst []int64) {
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			idx := row*ncols + col
-			xi := float64(x[idx])
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			idx := row*ncols + col
-			lhs := (float64(x[idx]) - mean) * scale
-			var result float64
-			if alpha != nil && beta != nil {
-				result = lhs*float64(alpha[col]) + float64(beta[col])
-			} else if alpha != nil {
-				result = lhs * float64(alpha[col])
-			} else if beta != nil {
-				result = lhs + float64(beta[col])
-			} else {
-				result = lhs
-			}
-			dst[idx] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				idx := row*ncols + col
+				xi := float64(x[idx])
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				idx := row*ncols + col
+				lhs := (float64(x[idx]) - mean) * scale
+				var result float64
+				if alpha != nil && beta != nil {
+					result = lhs*float64(alpha[col]) + float64(beta[col])
+				} else if alpha != nil {
+					result = lhs * float64(alpha[col])
+				} else if beta != nil {
+					result = lhs + float64(beta[col])
+				} else {
+					result = lhs
+				}
+				dst[idx] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
+			}
+		}
+	})
 }
 
 // FastLayerNormStrided performs strided Layer normalization along the last dimension for type T
@@ -3579,33 +3837,35 @@ func FastLayerNormStrided[T D](numel, ndims int, dims, strides []int, eps T, alp
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq T
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := x[stridedI]
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / T(ncols)
-		variance := sumSq/T(ncols) - mean*mean
-		scale := T(1) / T(math.Sqrt(float64(variance+eps)))
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			lhs := (x[stridedI] - mean) * scale
-			if alpha != nil && beta != nil {
-				dst[stridedI] = lhs*alpha[col] + beta[col]
-			} else if alpha != nil {
-				dst[stridedI] = lhs * alpha[col]
-			} else if beta != nil {
-				dst[stridedI] = lhs + beta[col]
-			} else {
-				dst[stridedI] = lhs
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq T
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := x[stridedI]
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / T(ncols)
+			variance := sumSq/T(ncols) - mean*mean
+			scale := T(1) / T(math.Sqrt(float64(variance+eps)))
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				lhs := (x[stridedI] - mean) * scale
+				if alpha != nil && beta != nil {
+					dst[stridedI] = lhs*alpha[col] + beta[col]
+				} else if alpha != nil {
+					dst[stridedI] = lhs * alpha[col]
+				} else if beta != nil {
+					dst[stridedI] = lhs + beta[col]
+				} else {
+					dst[stridedI] = lhs
+				}
+			}
+		}
+	})
 }
 
 // FastLayerNormStridedF32 performs strided Layer normalization along the last dimension for float32
@@ -3616,33 +3876,35 @@ func FastLayerNormStridedF32(numel int, ndims int, dims, strides []int, eps floa
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float32
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := x[stridedI]
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float32(ncols)
-		variance := sumSq/float32(ncols) - mean*mean
-		scale := 1 / float32(math.Sqrt(float64(variance+eps)))
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			lhs := (x[stridedI] - mean) * scale
-			if alpha != nil && beta != nil {
-				dst[stridedI] = lhs*alpha[col] + beta[col]
-			} else if alpha != nil {
-				dst[stridedI] = lhs * alpha[col]
-			} else if beta != nil {
-				dst[stridedI] = lhs + beta[col]
-			} else {
-				dst[stridedI] = lhs
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float32
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := x[stridedI]
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float32(ncols)
+			variance := sumSq/float32(ncols) - mean*mean
+			scale := 1 / float32(math.Sqrt(float64(variance+eps)))
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				lhs := (x[stridedI] - mean) * scale
+				if alpha != nil && beta != nil {
+					dst[stridedI] = lhs*alpha[col] + beta[col]
+				} else if alpha != nil {
+					dst[stridedI] = lhs * alpha[col]
+				} else if beta != nil {
+					dst[stridedI] = lhs + beta[col]
+				} else {
+					dst[stridedI] = lhs
+				}
+			}
+		}
+	})
 }
 
 // FastLayerNormStridedF64 performs strided Layer normalization along the last dimension for float64
@@ -3653,33 +3915,35 @@ func FastLayerNormStridedF64(numel int, ndims int, dims, strides []int, eps floa
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := x[stridedI]
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			lhs := (x[stridedI] - mean) * scale
-			if alpha != nil && beta != nil {
-				dst[stridedI] = lhs*alpha[col] + beta[col]
-			} else if alpha != nil {
-				dst[stridedI] = lhs * alpha[col]
-			} else if beta != nil {
-				dst[stridedI] = lhs + beta[col]
-			} else {
-				dst[stridedI] = lhs
-			}
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := x[stridedI]
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				lhs := (x[stridedI] - mean) * scale
+				if alpha != nil && beta != nil {
+					dst[stridedI] = lhs*alpha[col] + beta[col]
+				} else if alpha != nil {
+					dst[stridedI] = lhs * alpha[col]
+				} else if beta != nil {
+					dst[stridedI] = lhs + beta[col]
+				} else {
+					dst[stridedI] = lhs
+				}
+			}
+		}
+	})
 }
 
 // FastLayerNormStridedU8 performs strided Layer normalization along the last dimension for uint8
@@ -3690,35 +3954,37 @@ func FastLayerNormStridedU8(numel, ndims int, dims, strides []int, eps float64,
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := float64(x[stridedI])
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			lhs := (float64(x[stridedI]) - mean) * scale
-			var result float64
-			if alpha != nil && beta != nil {
-				result = lhs*float64(alpha[col]) + float64(beta[col])
-			} else if alpha != nil {
-				result = lhs * float64(alpha[col])
-			} else if beta != nil {
-				result = lhs + float64(beta[col])
-			} else {
-				result = lhs
-			}
-			dst[stridedI] = uint8(result * 255) // Scale to uint8 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := float64(x[stridedI])
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				lhs := (float64(x[stridedI]) - mean) * scale
+				var result float64
+				if alpha != nil && beta != nil {
+					result = lhs*float64(alpha[col]) + float64(beta[col])
+				} else if alpha != nil {
+					result = lhs * float64(alpha[col])
+				} else if beta != nil {
+					result = lhs + float64(beta[col])
+				} else {
+					result = lhs
+				}
+				dst[stridedI] = uint8(result * 255) // Scale to uint8 range
+			}
+		}
+	})
 }
 
 // FastLayerNormStridedU32 performs strided Layer normalization along the last dimension for uint32
@@ -3729,35 +3995,37 @@ func FastLayerNormStridedU32(numel, ndims int, dims, strides []int, eps float64,
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := float64(x[stridedI])
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			lhs := (float64(x[stridedI]) - mean) * scale
-			var result float64
-			if alpha != nil && beta != nil {
-				result = lhs*float64(alpha[col]) + float64(beta[col])
-			} else if alpha != nil {
-				result = lhs * float64(alpha[col])
-			} else if beta != nil {
-				result = lhs + float64(beta[col])
-			} else {
-				result = lhs
-			}
-			dst[stridedI] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := float64(x[stridedI])
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				lhs := (float64(x[stridedI]) - mean) * scale
+				var result float64
+				if alpha != nil && beta != nil {
+					result = lhs*float64(alpha[col]) + float64(beta[col])
+				} else if alpha != nil {
+					result = lhs * float64(alpha[col])
+				} else if beta != nil {
+					result = lhs + float64(beta[col])
+				} else {
+					result = lhs
+				}
+				dst[stridedI] = uint32(result * float64(math.MaxUint32)) // Scale to uint32 range
+			}
+		}
+	})
 }
 
 // FastLayerNormStridedI64 performs strided Layer normalization along the last dimension for int64
@@ -3768,35 +4036,37 @@ func FastLayerNormStridedI64(numel, ndims int, dims, strides []int, eps float64,
 	}
 	ncols := dims[ndims-1]
 	rows := numel / ncols
-	for row := range rows {
-		var sum, sumSq float64
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			xi := float64(x[stridedI])
-			sum += xi
-			sumSq += xi * xi
-		}
-		mean := sum / float64(ncols)
-		variance := sumSq/float64(ncols) - mean*mean
-		scale := 1 / math.Sqrt(variance+eps)
-		for col := range ncols {
-			i := row*ncols + col
-			stridedI := GetStridedIndex(i, ndims, dims, strides)
-			lhs := (float64(x[stridedI]) - mean) * scale
-			var result float64
-			if alpha != nil && beta != nil {
-				result = lhs*float64(alpha[col]) + float64(beta[col])
-			} else if alpha != nil {
-				result = lhs * float64(alpha[col])
-			} else if beta != nil {
-				result = lhs + float64(beta[col])
-			} else {
-				result = lhs
-			}
-			dst[stridedI] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
-		}
-	}
+	ParallelFor(rows, rowGrain(numel, rows), func(start, end int) {
+		for row := start; row < end; row++ {
+			var sum, sumSq float64
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				xi := float64(x[stridedI])
+				sum += xi
+				sumSq += xi * xi
+			}
+			mean := sum / float64(ncols)
+			variance := sumSq/float64(ncols) - mean*mean
+			scale := 1 / math.Sqrt(variance+eps)
+			for col := range ncols {
+				i := row*ncols + col
+				stridedI := GetStridedIndex(i, ndims, dims, strides)
+				lhs := (float64(x[stridedI]) - mean) * scale
+				var result float64
+				if alpha != nil && beta != nil {
+					result = lhs*float64(alpha[col]) + float64(beta[col])
+				} else if alpha != nil {
+					result = lhs * float64(alpha[col])
+				} else if beta != nil {
+					result = lhs + float64(beta[col])
+				} else {
+					result = lhs
+				}
+				dst[stridedI] = int64(result * float64(math.MaxInt64)) // Scale to int64 range
+			}
+		}
+	})
 }
 
 // RopeI performs rotary position embedding (rope_i variant) for type T (contiguous memory)
@@ -7,9 +7,11 @@ func UCopy[T D](numel int, inp, out []T) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCopyF32 performs element-wise copy for float32 (contiguous memory)
@@ -17,9 +19,11 @@ func UCopyF32(numel int, inp, out []float32) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCopyF64 performs element-wise copy for float64 (contiguous memory)
@@ -27,9 +31,11 @@ func UCopyF64(numel int, inp, out []float64) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCopyU8 performs element-wise copy for uint8 (contiguous memory)
@@ -37,9 +43,11 @@ func UCopyU8(numel int, inp, out []uint8) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCopyU32 performs element-wise copy for uint32 (contiguous memory)
@@ -47,9 +55,11 @@ func UCopyU32(numel int, inp, out []uint32) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCopyI64 performs element-wise copy for int64 (contiguous memory)
@@ -57,9 +67,11 @@ func UCopyI64(numel int, inp, out []int64) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCopyStrided performs element-wise copy for type T (strided memory)
@@ -71,10 +83,12 @@ func UCopyStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UCopyStridedF32 performs element-wise copy for float32 (strided memory)
@@ -86,10 +100,12 @@ func UCopyStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UCopyStridedF64 performs element-wise copy for float64 (strided memory)
@@ -101,10 +117,12 @@ func UCopyStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UCopyStridedU8 performs element-wise copy for uint8 (strided memory)
@@ -116,10 +134,12 @@ func UCopyStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UCopyStridedU32 performs element-wise copy for uint32 (strided memory)
@@ -131,10 +151,12 @@ func UCopyStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UCopyStridedI64 performs element-wise copy for int64 (strided memory)
@@ -146,88 +168,114 @@ func UCopyStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 	if inp == nil {
 		return // No-op for in-place
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UNeg performs element-wise negation for type T (contiguous memory)
 func UNeg[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = -inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = -inp[i]
+		}
+	})
 }
 
 // UNegF32 performs element-wise negation for float32 (contiguous memory)
 func UNegF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = -inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = -inp[i]
+		}
+	})
 }
 
 // UNegF64 performs element-wise negation for float64 (contiguous memory)
 func UNegF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = -inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = -inp[i]
+		}
+	})
 }
 
 // UNegU8 performs element-wise negation for uint8 (contiguous memory)
 func UNegU8(numel int, inp, out []uint8) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = -inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = -inp[i]
+		}
+	})
 }
 
 // UNegU32 performs element-wise negation for uint32 (contiguous memory)
 func UNegU32(numel int, inp, out []uint32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = -inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = -inp[i]
+		}
+	})
 }
 
 // UNegI64 performs element-wise negation for int64 (contiguous memory)
 func UNegI64(numel int, inp, out []int64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = -inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = -inp[i]
+		}
+	})
 }
 
 // UNegStrided performs element-wise negation for type T (strided memory)
@@ -237,15 +285,19 @@ func UNegStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = -inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = -inp[stridedI]
+		}
+	})
 }
 
 // UNegStridedF32 performs element-wise negation for float32 (strided memory)
@@ -255,15 +307,19 @@ func UNegStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = -inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = -inp[stridedI]
+		}
+	})
 }
 
 // UNegStridedF64 performs element-wise negation for float64 (strided memory)
@@ -273,15 +329,19 @@ func UNegStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = -inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = -inp[stridedI]
+		}
+	})
 }
 
 // UNegStridedU8 performs element-wise negation for uint8 (strided memory)
@@ -291,15 +351,19 @@ func UNegStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = -inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = -inp[stridedI]
+		}
+	})
 }
 
 // UNegStridedU32 performs element-wise negation for uint32 (strided memory)
@@ -309,15 +373,19 @@ func UNegStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = -inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = -inp[stridedI]
+		}
+	})
 }
 
 // UNegStridedI64 performs element-wise negation for int64 (strided memory)
@@ -327,15 +395,19 @@ func UNegStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = -out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = -out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = -inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = -inp[stridedI]
+		}
+	})
 }
 
 // URecip performs element-wise reciprocal for type T (contiguous memory)
@@ -347,40 +419,52 @@ func URecip[T D](numel int, inp, out []T) {
 		panic("urecip: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(1) / out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(1) / out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(1) / inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(1) / inp[i]
+		}
+	})
 }
 
 // URecipF32 performs element-wise reciprocal for float32 (contiguous memory)
 func URecipF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = 1.0 / out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = 1.0 / out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = 1.0 / inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = 1.0 / inp[i]
+		}
+	})
 }
 
 // URecipF64 performs element-wise reciprocal for float64 (contiguous memory)
 func URecipF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = 1.0 / out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = 1.0 / out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = 1.0 / inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = 1.0 / inp[i]
+		}
+	})
 }
 
 // URecipU8 performs element-wise reciprocal for uint8 (contiguous memory)
@@ -411,15 +495,19 @@ func URecipStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(1) / out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(1) / out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(1) / inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(1) / inp[stridedI]
+		}
+	})
 }
 
 // URecipStridedF32 performs element-wise reciprocal for float32 (strided memory)
@@ -429,15 +517,19 @@ func URecipStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = 1.0 / out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = 1.0 / out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = 1.0 / inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = 1.0 / inp[stridedI]
+		}
+	})
 }
 
 // URecipStridedF64 performs element-wise reciprocal for float64 (strided memory)
@@ -447,15 +539,19 @@ func URecipStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = 1.0 / out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = 1.0 / out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = 1.0 / inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = 1.0 / inp[stridedI]
+		}
+	})
 }
 
 // URecipStridedU8 performs element-wise reciprocal for uint8 (strided memory)
@@ -494,40 +590,52 @@ func UExp[T D](numel int, inp, out []T) {
 		panic("uexp: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Exp(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Exp(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Exp(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Exp(float64(inp[i])))
+		}
+	})
 }
 
 // UExpF32 performs element-wise exponential for float32 (contiguous memory)
 func UExpF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Exp(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Exp(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Exp(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Exp(float64(inp[i])))
+		}
+	})
 }
 
 // UExpF64 performs element-wise exponential for float64 (contiguous memory)
 func UExpF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Exp(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Exp(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Exp(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Exp(inp[i])
+		}
+	})
 }
 
 // UExpU8 performs element-wise exponential for uint8 (contiguous memory)
@@ -558,15 +666,19 @@ func UExpStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Exp(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Exp(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Exp(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Exp(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UExpStridedF32 performs element-wise exponential for float32 (strided memory)
@@ -576,15 +688,19 @@ func UExpStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Exp(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Exp(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Exp(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Exp(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UExpStridedF64 performs element-wise exponential for float64 (strided memory)
@@ -594,15 +710,19 @@ func UExpStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Exp(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Exp(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Exp(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Exp(inp[stridedI])
+		}
+	})
 }
 
 // UExpStridedU8 performs element-wise exponential for uint8 (strided memory)
@@ -641,40 +761,52 @@ func ULog[T D](numel int, inp, out []T) {
 		panic("ulog: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Log(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Log(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Log(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Log(float64(inp[i])))
+		}
+	})
 }
 
 // ULogF32 performs element-wise logarithm for float32 (contiguous memory)
 func ULogF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Log(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Log(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Log(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Log(float64(inp[i])))
+		}
+	})
 }
 
 // ULogF64 performs element-wise logarithm for float64 (contiguous memory)
 func ULogF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Log(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Log(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Log(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Log(inp[i])
+		}
+	})
 }
 
 // ULogU8 performs element-wise logarithm for uint8 (contiguous memory)
@@ -705,15 +837,19 @@ func ULogStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Log(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Log(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Log(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Log(float64(inp[stridedI])))
+		}
+	})
 }
 
 // ULogStridedF32 performs element-wise logarithm for float32 (strided memory)
@@ -723,15 +859,19 @@ func ULogStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Log(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Log(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Log(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Log(float64(inp[stridedI])))
+		}
+	})
 }
 
 // ULogStridedF64 performs element-wise logarithm for float64 (strided memory)
@@ -741,15 +881,19 @@ func ULogStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Log(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Log(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Log(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Log(inp[stridedI])
+		}
+	})
 }
 
 // ULogStridedU8 performs element-wise logarithm for uint8 (strided memory)
@@ -788,40 +932,52 @@ func USin[T D](numel int, inp, out []T) {
 		panic("usin: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Sin(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Sin(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Sin(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Sin(float64(inp[i])))
+		}
+	})
 }
 
 // USinF32 performs element-wise sine for float32 (contiguous memory)
 func USinF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Sin(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Sin(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Sin(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Sin(float64(inp[i])))
+		}
+	})
 }
 
 // USinF64 performs element-wise sine for float64 (contiguous memory)
 func USinF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Sin(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Sin(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Sin(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Sin(inp[i])
+		}
+	})
 }
 
 // USinU8 performs element-wise sine for uint8 (contiguous memory)
@@ -852,15 +1008,19 @@ func USinStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Sin(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Sin(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Sin(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Sin(float64(inp[stridedI])))
+		}
+	})
 }
 
 // USinStridedF32 performs element-wise sine for float32 (strided memory)
@@ -870,15 +1030,19 @@ func USinStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Sin(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Sin(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Sin(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Sin(float64(inp[stridedI])))
+		}
+	})
 }
 
 // USinStridedF64 performs element-wise sine for float64 (strided memory)
@@ -888,15 +1052,19 @@ func USinStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Sin(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Sin(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Sin(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Sin(inp[stridedI])
+		}
+	})
 }
 
 // USinStridedU8 performs element-wise sine for uint8 (strided memory)
@@ -935,40 +1103,52 @@ func UCos[T D](numel int, inp, out []T) {
 		panic("ucos: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Cos(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Cos(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Cos(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Cos(float64(inp[i])))
+		}
+	})
 }
 
 // UCosF32 performs element-wise cosine for float32 (contiguous memory)
 func UCosF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Cos(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Cos(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Cos(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Cos(float64(inp[i])))
+		}
+	})
 }
 
 // UCosF64 performs element-wise cosine for float64 (contiguous memory)
 func UCosF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Cos(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Cos(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Cos(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Cos(inp[i])
+		}
+	})
 }
 
 // UCosU8 performs element-wise cosine for uint8 (contiguous memory)
@@ -999,15 +1179,19 @@ func UCosStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Cos(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Cos(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Cos(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Cos(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UCosStridedF32 performs element-wise cosine for float32 (strided memory)
@@ -1017,15 +1201,19 @@ func UCosStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Cos(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Cos(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Cos(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Cos(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UCosStridedF64 performs element-wise cosine for float64 (strided memory)
@@ -1035,15 +1223,19 @@ func UCosStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Cos(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Cos(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Cos(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Cos(inp[stridedI])
+		}
+	})
 }
 
 // UCosStridedU8 performs element-wise cosine for uint8 (strided memory)
@@ -1082,40 +1274,52 @@ func UTanh[T D](numel int, inp, out []T) {
 		panic("utanh: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Tanh(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Tanh(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Tanh(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Tanh(float64(inp[i])))
+		}
+	})
 }
 
 // UTanhF32 performs element-wise tanh for float32 (contiguous memory)
 func UTanhF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Tanh(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Tanh(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Tanh(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Tanh(float64(inp[i])))
+		}
+	})
 }
 
 // UTanhF64 performs element-wise tanh for float64 (contiguous memory)
 func UTanhF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Tanh(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Tanh(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Tanh(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Tanh(inp[i])
+		}
+	})
 }
 
 // UTanhU8 performs element-wise tanh for uint8 (contiguous memory)
@@ -1146,15 +1350,19 @@ func UTanhStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Tanh(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Tanh(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Tanh(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Tanh(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UTanhStridedF32 performs element-wise tanh for float32 (strided memory)
@@ -1164,15 +1372,19 @@ func UTanhStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Tanh(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Tanh(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Tanh(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Tanh(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UTanhStridedF64 performs element-wise tanh for float64 (strided memory)
@@ -1182,15 +1394,19 @@ func UTanhStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Tanh(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Tanh(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Tanh(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Tanh(inp[stridedI])
+		}
+	})
 }
 
 // UTanhStridedU8 performs element-wise tanh for uint8 (strided memory)
@@ -1229,40 +1445,52 @@ func UErf[T D](numel int, inp, out []T) {
 		panic("uerf: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Erf(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Erf(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Erf(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Erf(float64(inp[i])))
+		}
+	})
 }
 
 // UErfF32 performs element-wise erf for float32 (contiguous memory)
 func UErfF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Erf(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Erf(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Erf(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Erf(float64(inp[i])))
+		}
+	})
 }
 
 // UErfF64 performs element-wise erf for float64 (contiguous memory)
 func UErfF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Erf(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Erf(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Erf(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Erf(inp[i])
+		}
+	})
 }
 
 // UErfU8 performs element-wise erf for uint8 (contiguous memory)
@@ -1293,15 +1521,19 @@ func UErfStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Erf(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Erf(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Erf(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Erf(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UErfStridedF32 performs element-wise erf for float32 (strided memory)
@@ -1311,15 +1543,19 @@ func UErfStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Erf(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Erf(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Erf(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Erf(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UErfStridedF64 performs element-wise erf for float64 (strided memory)
@@ -1329,15 +1565,19 @@ func UErfStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Erf(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Erf(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Erf(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Erf(inp[stridedI])
+		}
+	})
 }
 
 // UErfStridedU8 performs element-wise erf for uint8 (strided memory)
@@ -1370,40 +1610,52 @@ func UErfStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 // UCeil performs element-wise ceil for type T (contiguous memory)
 func UCeil[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Ceil(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Ceil(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Ceil(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Ceil(float64(inp[i])))
+		}
+	})
 }
 
 // UCeilF32 performs element-wise ceil for float32 (contiguous memory)
 func UCeilF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Ceil(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Ceil(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Ceil(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Ceil(float64(inp[i])))
+		}
+	})
 }
 
 // UCeilF64 performs element-wise ceil for float64 (contiguous memory)
 func UCeilF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Ceil(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Ceil(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Ceil(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Ceil(inp[i])
+		}
+	})
 }
 
 // UCeilU8 performs element-wise ceil for uint8 (contiguous memory)
@@ -1411,9 +1663,11 @@ func UCeilU8(numel int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCeilU32 performs element-wise ceil for uint32 (contiguous memory)
@@ -1421,9 +1675,11 @@ func UCeilU32(numel int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCeilI64 performs element-wise ceil for int64 (contiguous memory)
@@ -1431,9 +1687,11 @@ func UCeilI64(numel int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UCeilStrided performs element-wise ceil for type T (strided memory)
@@ -1443,15 +1701,19 @@ func UCeilStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Ceil(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Ceil(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Ceil(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Ceil(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UCeilStridedF32 performs element-wise ceil for float32 (strided memory)
@@ -1461,15 +1723,19 @@ func UCeilStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Ceil(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Ceil(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Ceil(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Ceil(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UCeilStridedF64 performs element-wise ceil for float64 (strided memory)
@@ -1479,15 +1745,19 @@ func UCeilStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Ceil(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Ceil(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Ceil(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Ceil(inp[stridedI])
+		}
+	})
 }
 
 // UCeilStridedU8 performs element-wise ceil for uint8 (strided memory)
@@ -1499,10 +1769,12 @@ func UCeilStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UCeilStridedU32 performs element-wise ceil for uint32 (strided memory)
@@ -1514,10 +1786,12 @@ func UCeilStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UCeilStridedI64 performs element-wise ceil for int64 (strided memory)
@@ -1529,49 +1803,63 @@ func UCeilStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UFloor performs element-wise floor for type T (contiguous memory)
 func UFloor[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Floor(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Floor(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Floor(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Floor(float64(inp[i])))
+		}
+	})
 }
 
 // UFloorF32 performs element-wise floor for float32 (contiguous memory)
 func UFloorF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Floor(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Floor(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Floor(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Floor(float64(inp[i])))
+		}
+	})
 }
 
 // UFloorF64 performs element-wise floor for float64 (contiguous memory)
 func UFloorF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Floor(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Floor(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Floor(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Floor(inp[i])
+		}
+	})
 }
 
 // UFloorU8 performs element-wise floor for uint8 (contiguous memory)
@@ -1579,9 +1867,11 @@ func UFloorU8(numel int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UFloorU32 performs element-wise floor for uint32 (contiguous memory)
@@ -1589,9 +1879,11 @@ func UFloorU32(numel int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UFloorI64 performs element-wise floor for int64 (contiguous memory)
@@ -1599,9 +1891,11 @@ func UFloorI64(numel int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UFloorStrided performs element-wise floor for type T (strided memory)
@@ -1611,15 +1905,19 @@ func UFloorStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Floor(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Floor(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Floor(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Floor(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UFloorStridedF32 performs element-wise floor for float32 (strided memory)
@@ -1629,15 +1927,19 @@ func UFloorStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Floor(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Floor(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Floor(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Floor(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UFloorStridedF64 performs element-wise floor for float64 (strided memory)
@@ -1647,15 +1949,19 @@ func UFloorStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Floor(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Floor(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Floor(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Floor(inp[stridedI])
+		}
+	})
 }
 
 // UFloorStridedU8 performs element-wise floor for uint8 (strided memory)
@@ -1667,10 +1973,12 @@ func UFloorStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UFloorStridedU32 performs element-wise floor for uint32 (strided memory)
@@ -1682,10 +1990,12 @@ func UFloorStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32)
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UFloorStridedI64 performs element-wise floor for int64 (strided memory)
@@ -1697,49 +2007,63 @@ func UFloorStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // URound performs element-wise round for type T (contiguous memory)
 func URound[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Round(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Round(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Round(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Round(float64(inp[i])))
+		}
+	})
 }
 
 // URoundF32 performs element-wise round for float32 (contiguous memory)
 func URoundF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Round(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Round(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Round(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Round(float64(inp[i])))
+		}
+	})
 }
 
 // URoundF64 performs element-wise round for float64 (contiguous memory)
 func URoundF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Round(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Round(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Round(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Round(inp[i])
+		}
+	})
 }
 
 // URoundU8 performs element-wise round for uint8 (contiguous memory)
@@ -1747,9 +2071,11 @@ func URoundU8(numel int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // URoundU32 performs element-wise round for uint32 (contiguous memory)
@@ -1757,9 +2083,11 @@ func URoundU32(numel int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // URoundI64 performs element-wise round for int64 (contiguous memory)
@@ -1767,9 +2095,11 @@ func URoundI64(numel int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // URoundStrided performs element-wise round for type T (strided memory)
@@ -1779,15 +2109,19 @@ func URoundStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Round(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Round(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Round(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Round(float64(inp[stridedI])))
+		}
+	})
 }
 
 // URoundStridedF32 performs element-wise round for float32 (strided memory)
@@ -1797,15 +2131,19 @@ func URoundStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Round(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Round(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Round(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Round(float64(inp[stridedI])))
+		}
+	})
 }
 
 // URoundStridedF64 performs element-wise round for float64 (strided memory)
@@ -1815,15 +2153,19 @@ func URoundStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Round(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Round(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Round(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Round(inp[stridedI])
+		}
+	})
 }
 
 // URoundStridedU8 performs element-wise round for uint8 (strided memory)
@@ -1835,10 +2177,12 @@ func URoundStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // URoundStridedU32 performs element-wise round for uint32 (strided memory)
@@ -1850,10 +2194,12 @@ func URoundStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32)
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // URoundStridedI64 performs element-wise round for int64 (strided memory)
@@ -1865,53 +2211,67 @@ func URoundStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UNormcdf performs element-wise normal CDF for type T (contiguous memory)
 func UNormcdf[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UNormcdfF32 performs element-wise normal CDF for float32 (contiguous memory)
 func UNormcdfF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UNormcdfF64 performs element-wise normal CDF for float64 (contiguous memory)
 func UNormcdfF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = 0.5 * (1 + math.Erf(out[i]/math.Sqrt(2)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = 0.5 * (1 + math.Erf(out[i]/math.Sqrt(2)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = 0.5 * (1 + math.Erf(inp[i]/math.Sqrt(2)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = 0.5 * (1 + math.Erf(inp[i]/math.Sqrt(2)))
+		}
+	})
 }
 
 // UNormcdfU8 performs element-wise normal CDF for uint8 (contiguous memory)
@@ -1919,9 +2279,11 @@ func UNormcdfU8(numel int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UNormcdfU32 performs element-wise normal CDF for uint32 (contiguous memory)
@@ -1929,9 +2291,11 @@ func UNormcdfU32(numel int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UNormcdfI64 performs element-wise normal CDF for int64 (contiguous memory)
@@ -1939,9 +2303,11 @@ func UNormcdfI64(numel int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UNormcdfStrided performs element-wise normal CDF for type T (strided memory)
@@ -1951,17 +2317,21 @@ func UNormcdfStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = T(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UNormcdfStridedF32 performs element-wise normal CDF for float32 (strided memory)
@@ -1971,17 +2341,21 @@ func UNormcdfStridedF32(numel, ndims int, dims, strides []int, inp, out []float3
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = float32(0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UNormcdfStridedF64 performs element-wise normal CDF for float64 (strided memory)
@@ -1991,15 +2365,19 @@ func UNormcdfStridedF64(numel, ndims int, dims, strides []int, inp, out []float6
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = 0.5 * (1 + math.Erf(out[i]/math.Sqrt(2)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = 0.5 * (1 + math.Erf(out[i]/math.Sqrt(2)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = 0.5 * (1 + math.Erf(inp[stridedI]/math.Sqrt(2)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = 0.5 * (1 + math.Erf(inp[stridedI]/math.Sqrt(2)))
+		}
+	})
 }
 
 // UNormcdfStridedU8 performs element-wise normal CDF for uint8 (strided memory)
@@ -2011,10 +2389,12 @@ func UNormcdfStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8)
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UNormcdfStridedU32 performs element-wise normal CDF for uint32 (strided memory)
@@ -2026,10 +2406,12 @@ func UNormcdfStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UNormcdfStridedI64 performs element-wise normal CDF for int64 (strided memory)
@@ -2041,49 +2423,63 @@ func UNormcdfStridedI64(numel, ndims int, dims, strides []int, inp, out []int64)
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UAbs performs element-wise absolute value for type T (contiguous memory)
 func UAbs[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Abs(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Abs(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Abs(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Abs(float64(inp[i])))
+		}
+	})
 }
 
 // UAbsF32 performs element-wise absolute value for float32 (contiguous memory)
 func UAbsF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Abs(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Abs(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Abs(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Abs(float64(inp[i])))
+		}
+	})
 }
 
 // UAbsF64 performs element-wise absolute value for float64 (contiguous memory)
 func UAbsF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Abs(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Abs(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Abs(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Abs(inp[i])
+		}
+	})
 }
 
 // UAbsU8 performs element-wise absolute value for uint8 (contiguous memory)
@@ -2091,9 +2487,11 @@ func UAbsU8(numel int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UAbsU32 performs element-wise absolute value for uint32 (contiguous memory)
@@ -2101,29 +2499,35 @@ func UAbsU32(numel int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UAbsI64 performs element-wise absolute value for int64 (contiguous memory)
 func UAbsI64(numel int, inp, out []int64) {
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = -out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = -out[i]
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		if x < 0 {
-			out[i] = -x
-		} else {
-			out[i] = x
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			if x < 0 {
+				out[i] = -x
+			} else {
+				out[i] = x
+			}
 		}
-	}
+	})
 }
 
 // UAbsStrided performs element-wise absolute value for type T (strided memory)
@@ -2133,15 +2537,19 @@ func UAbsStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Abs(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Abs(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Abs(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Abs(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UAbsStridedF32 performs element-wise absolute value for float32 (strided memory)
@@ -2151,15 +2559,19 @@ func UAbsStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Abs(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Abs(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Abs(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Abs(float64(inp[stridedI])))
+		}
+	})
 }
 
 // UAbsStridedF64 performs element-wise absolute value for float64 (strided memory)
@@ -2169,15 +2581,19 @@ func UAbsStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Abs(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Abs(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Abs(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Abs(inp[stridedI])
+		}
+	})
 }
 
 // UAbsStridedU8 performs element-wise absolute value for uint8 (strided memory)
@@ -2189,10 +2605,12 @@ func UAbsStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UAbsStridedU32 performs element-wise absolute value for uint32 (strided memory)
@@ -2204,10 +2622,12 @@ func UAbsStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UAbsStridedI64 performs element-wise absolute value for int64 (strided memory)
@@ -2219,93 +2639,119 @@ func UAbsStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x < 0 {
-			out[i] = -x
-		} else {
-			out[i] = x
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			if x < 0 {
+				out[i] = -x
+			} else {
+				out[i] = x
+			}
 		}
-	}
+	})
 }
 
 // USqr performs element-wise square for type T (contiguous memory)
 func USqr[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i] * inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i] * inp[i]
+		}
+	})
 }
 
 // USqrF32 performs element-wise square for float32 (contiguous memory)
 func USqrF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i] * inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i] * inp[i]
+		}
+	})
 }
 
 // USqrF64 performs element-wise square for float64 (contiguous memory)
 func USqrF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i] * inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i] * inp[i]
+		}
+	})
 }
 
 // USqrU8 performs element-wise square for uint8 (contiguous memory)
 func USqrU8(numel int, inp, out []uint8) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i] * inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i] * inp[i]
+		}
+	})
 }
 
 // USqrU32 performs element-wise square for uint32 (contiguous memory)
 func USqrU32(numel int, inp, out []uint32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i] * inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i] * inp[i]
+		}
+	})
 }
 
 // USqrI64 performs element-wise square for int64 (contiguous memory)
 func USqrI64(numel int, inp, out []int64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i] * inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i] * inp[i]
+		}
+	})
 }
 
 // USqrStrided performs element-wise square for type T (strided memory)
@@ -2315,15 +2761,19 @@ func USqrStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI] * inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI] * inp[stridedI]
+		}
+	})
 }
 
 // USqrStridedF32 performs element-wise square for float32 (strided memory)
@@ -2333,15 +2783,19 @@ func USqrStridedF32(numel, ndims int, dims, strides []int, inp, out []float32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI] * inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI] * inp[stridedI]
+		}
+	})
 }
 
 // USqrStridedF64 performs element-wise square for float64 (strided memory)
@@ -2351,15 +2805,19 @@ func USqrStridedF64(numel, ndims int, dims, strides []int, inp, out []float64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI] * inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI] * inp[stridedI]
+		}
+	})
 }
 
 // USqrStridedU8 performs element-wise square for uint8 (strided memory)
@@ -2369,15 +2827,19 @@ func USqrStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI] * inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI] * inp[stridedI]
+		}
+	})
 }
 
 // USqrStridedU32 performs element-wise square for uint32 (strided memory)
@@ -2387,15 +2849,19 @@ func USqrStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI] * inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI] * inp[stridedI]
+		}
+	})
 }
 
 // USqrStridedI64 performs element-wise square for int64 (strided memory)
@@ -2405,15 +2871,19 @@ func USqrStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = out[i] * out[i]
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = out[i] * out[i]
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI] * inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI] * inp[stridedI]
+		}
+	})
 }
 
 // USqrt performs element-wise square root for type T (contiguous memory)
@@ -2425,40 +2895,52 @@ func USqrt[T D](numel int, inp, out []T) {
 		panic("usqrt: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Sqrt(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Sqrt(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Sqrt(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Sqrt(float64(inp[i])))
+		}
+	})
 }
 
 // USqrtF32 performs element-wise square root for float32 (contiguous memory)
 func USqrtF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Sqrt(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Sqrt(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Sqrt(float64(inp[i])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Sqrt(float64(inp[i])))
+		}
+	})
 }
 
 // USqrtF64 performs element-wise square root for float64 (contiguous memory)
 func USqrtF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Sqrt(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Sqrt(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Sqrt(inp[i])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Sqrt(inp[i])
+		}
+	})
 }
 
 // USqrtU8 performs element-wise square root for uint8 (contiguous memory)
@@ -2489,15 +2971,19 @@ func USqrtStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Sqrt(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Sqrt(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Sqrt(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Sqrt(float64(inp[stridedI])))
+		}
+	})
 }
 
 // USqrtStridedF32 performs element-wise square root for float32 (strided memory)
@@ -2507,15 +2993,19 @@ func USqrtStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Sqrt(float64(out[i])))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Sqrt(float64(out[i])))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Sqrt(float64(inp[stridedI])))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Sqrt(float64(inp[stridedI])))
+		}
+	})
 }
 
 // USqrtStridedF64 performs element-wise square root for float64 (strided memory)
@@ -2525,15 +3015,19 @@ func USqrtStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Sqrt(out[i])
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Sqrt(out[i])
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Sqrt(inp[stridedI])
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Sqrt(inp[stridedI])
+		}
+	})
 }
 
 // USqrtStridedU8 performs element-wise square root for uint8 (strided memory)
@@ -2572,64 +3066,76 @@ func UGelu[T D](numel int, inp, out []T) {
 		panic("ugelu: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				xSq := x * x
+				xCube := xSq * x
+				alpha := x + 0.044715*xCube
+				out[i] = T(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
 			xSq := x * x
 			xCube := xSq * x
 			alpha := x + 0.044715*xCube
 			out[i] = T(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
 		}
-		return
-	}
-	for i := range numel {
-		x := float64(inp[i])
-		xSq := x * x
-		xCube := xSq * x
-		alpha := x + 0.044715*xCube
-		out[i] = T(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
-	}
+	})
 }
 
 // UGeluF32 performs element-wise GELU for float32 (contiguous memory)
 func UGeluF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				xSq := x * x
+				xCube := xSq * x
+				alpha := x + 0.044715*xCube
+				out[i] = float32(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
 			xSq := x * x
 			xCube := xSq * x
 			alpha := x + 0.044715*xCube
 			out[i] = float32(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
 		}
-		return
-	}
-	for i := range numel {
-		x := float64(inp[i])
-		xSq := x * x
-		xCube := xSq * x
-		alpha := x + 0.044715*xCube
-		out[i] = float32(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
-	}
+	})
 }
 
 // UGeluF64 performs element-wise GELU for float64 (contiguous memory)
 func UGeluF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				xSq := x * x
+				xCube := xSq * x
+				alpha := x + 0.044715*xCube
+				out[i] = 0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha))
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
 			xSq := x * x
 			xCube := xSq * x
 			alpha := x + 0.044715*xCube
 			out[i] = 0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha))
 		}
-		return
-	}
-	for i := range numel {
-		x := inp[i]
-		xSq := x * x
-		xCube := xSq * x
-		alpha := x + 0.044715*xCube
-		out[i] = 0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha))
-	}
+	})
 }
 
 // UGeluU8 performs element-wise GELU for uint8 (contiguous memory)
@@ -2660,23 +3166,27 @@ func UGeluStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				xSq := x * x
+				xCube := xSq * x
+				alpha := x + 0.044715*xCube
+				out[i] = T(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
 			xSq := x * x
 			xCube := xSq * x
 			alpha := x + 0.044715*xCube
 			out[i] = T(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		xSq := x * x
-		xCube := xSq * x
-		alpha := x + 0.044715*xCube
-		out[i] = T(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
-	}
+	})
 }
 
 // UGeluStridedF32 performs element-wise GELU for float32 (strided memory)
@@ -2686,23 +3196,27 @@ func UGeluStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				xSq := x * x
+				xCube := xSq * x
+				alpha := x + 0.044715*xCube
+				out[i] = float32(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
 			xSq := x * x
 			xCube := xSq * x
 			alpha := x + 0.044715*xCube
 			out[i] = float32(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		xSq := x * x
-		xCube := xSq * x
-		alpha := x + 0.044715*xCube
-		out[i] = float32(0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha)))
-	}
+	})
 }
 
 // UGeluStridedF64 performs element-wise GELU for float64 (strided memory)
@@ -2712,23 +3226,27 @@ func UGeluStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				xSq := x * x
+				xCube := xSq * x
+				alpha := x + 0.044715*xCube
+				out[i] = 0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha))
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
 			xSq := x * x
 			xCube := xSq * x
 			alpha := x + 0.044715*xCube
 			out[i] = 0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha))
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		xSq := x * x
-		xCube := xSq * x
-		alpha := x + 0.044715*xCube
-		out[i] = 0.5 * x * (1 + math.Tanh(math.Sqrt(2/math.Pi)*alpha))
-	}
+	})
 }
 
 // UGeluStridedU8 performs element-wise GELU for uint8 (strided memory)
@@ -2767,46 +3285,58 @@ func UGeluErf[T D](numel int, inp, out []T) {
 		panic("ugelu: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UGeluErfF32 performs element-wise GELU (ERF-based) for float32 (contiguous memory)
 func UGeluErfF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UGeluErfF64 performs element-wise GELU (ERF-based) for float64 (contiguous memory)
 func UGeluErfF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
+		}
+	})
 }
 
 // UGeluErfU8 performs element-wise GELU (ERF-based) for uint8 (contiguous memory)
@@ -2837,17 +3367,21 @@ func UGeluErfStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = T(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UGeluErfStridedF32 performs element-wise GELU (ERF-based) for float32 (strided memory)
@@ -2857,17 +3391,21 @@ func UGeluErfStridedF32(numel, ndims int, dims, strides []int, inp, out []float3
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = float32(x * 0.5 * (1 + math.Erf(x/math.Sqrt(2))))
+		}
+	})
 }
 
 // UGeluErfStridedF64 performs element-wise GELU (ERF-based) for float64 (strided memory)
@@ -2877,17 +3415,21 @@ func UGeluErfStridedF64(numel, ndims int, dims, strides []int, inp, out []float6
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			out[i] = x * 0.5 * (1 + math.Erf(x/math.Sqrt(2)))
+		}
+	})
 }
 
 // UGeluErfStridedU8 performs element-wise GELU (ERF-based) for uint8 (strided memory)
@@ -2920,58 +3462,70 @@ func UGeluErfStridedI64(numel, ndims int, dims, strides []int, inp, out []int64)
 // URelu performs element-wise ReLU for type T (contiguous memory)
 func URelu[T D](numel int, inp, out []T) {
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		if x < 0 {
-			x = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			if x < 0 {
+				x = 0
+			}
+			out[i] = x
 		}
-		out[i] = x
-	}
+	})
 }
 
 // UReluF32 performs element-wise ReLU for float32 (contiguous memory)
 func UReluF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		if x < 0 {
-			x = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			if x < 0 {
+				x = 0
+			}
+			out[i] = x
 		}
-		out[i] = x
-	}
+	})
 }
 
 // UReluF64 performs element-wise ReLU for float64 (contiguous memory)
 func UReluF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		if x < 0 {
-			x = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			if x < 0 {
+				x = 0
+			}
+			out[i] = x
 		}
-		out[i] = x
-	}
+	})
 }
 
 // UReluU8 performs element-wise ReLU for uint8 (contiguous memory)
@@ -2979,9 +3533,11 @@ func UReluU8(numel int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UReluU32 performs element-wise ReLU for uint32 (contiguous memory)
@@ -2989,25 +3545,31 @@ func UReluU32(numel int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		out[i] = inp[i]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = inp[i]
+		}
+	})
 }
 
 // UReluI64 performs element-wise ReLU for int64 (contiguous memory)
 func UReluI64(numel int, inp, out []int64) {
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := max(inp[i], 0)
-		out[i] = x
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := max(inp[i], 0)
+			out[i] = x
+		}
+	})
 }
 
 // UReluStrided performs element-wise ReLU for type T (strided memory)
@@ -3017,21 +3579,25 @@ func UReluStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x < 0 {
-			x = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			if x < 0 {
+				x = 0
+			}
+			out[i] = x
 		}
-		out[i] = x
-	}
+	})
 }
 
 // UReluStridedF32 performs element-wise ReLU for float32 (strided memory)
@@ -3041,21 +3607,25 @@ func UReluStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x < 0 {
-			x = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			if x < 0 {
+				x = 0
+			}
+			out[i] = x
 		}
-		out[i] = x
-	}
+	})
 }
 
 // UReluStridedF64 performs element-wise ReLU for float64 (strided memory)
@@ -3065,21 +3635,25 @@ func UReluStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x < 0 {
-			x = 0
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			if x < 0 {
+				x = 0
+			}
+			out[i] = x
 		}
-		out[i] = x
-	}
+	})
 }
 
 // UReluStridedU8 performs element-wise ReLU for uint8 (strided memory)
@@ -3091,10 +3665,12 @@ func UReluStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UReluStridedU32 performs element-wise ReLU for uint32 (strided memory)
@@ -3106,10 +3682,12 @@ func UReluStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32) {
 	if inp == nil {
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = inp[stridedI]
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = inp[stridedI]
+		}
+	})
 }
 
 // UReluStridedI64 performs element-wise ReLU for int64 (strided memory)
@@ -3119,18 +3697,22 @@ func UReluStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			if out[i] < 0 {
-				out[i] = 0
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				if out[i] < 0 {
+					out[i] = 0
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := max(inp[stridedI], 0)
-		out[i] = x
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := max(inp[stridedI], 0)
+			out[i] = x
+		}
+	})
 }
 
 // UElu performs element-wise ELU for type T with parameter alpha (contiguous memory)
@@ -3142,64 +3724,76 @@ func UElu[T D](alpha T, numel int, inp, out []T) {
 		panic("uelu: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			if x <= 0 {
-				out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x <= 0 {
+					out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = x
-		} else {
-			out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			if x > 0 {
+				out[i] = x
+			} else {
+				out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+			}
 		}
-	}
+	})
 }
 
 // UEluF32 performs element-wise ELU for float32 with parameter alpha (contiguous memory)
 func UEluF32(alpha float32, numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			if x <= 0 {
-				out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x <= 0 {
+					out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = x
-		} else {
-			out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			if x > 0 {
+				out[i] = x
+			} else {
+				out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+			}
 		}
-	}
+	})
 }
 
 // UEluF64 performs element-wise ELU for float64 with parameter alpha (contiguous memory)
 func UEluF64(alpha float64, numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			if x <= 0 {
-				out[i] = alpha * (math.Exp(x) - 1)
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x <= 0 {
+					out[i] = alpha * (math.Exp(x) - 1)
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = x
-		} else {
-			out[i] = alpha * (math.Exp(x) - 1)
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			if x > 0 {
+				out[i] = x
+			} else {
+				out[i] = alpha * (math.Exp(x) - 1)
+			}
 		}
-	}
+	})
 }
 
 // UEluU8 performs element-wise ELU for uint8 with parameter alpha (contiguous memory)
@@ -3230,23 +3824,27 @@ func UEluStrided[T D](numel, ndims int, dims, strides []int, alpha T, inp, out [
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			if x <= 0 {
-				out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x <= 0 {
+					out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = x
-		} else {
-			out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			if x > 0 {
+				out[i] = x
+			} else {
+				out[i] = alpha * (T(math.Exp(float64(x))) - 1)
+			}
 		}
-	}
+	})
 }
 
 // UEluStridedF32 performs element-wise ELU for float32 with parameter alpha (strided memory)
@@ -3256,23 +3854,27 @@ func UEluStridedF32(numel, ndims int, dims, strides []int, alpha float32, inp, o
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			if x <= 0 {
-				out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x <= 0 {
+					out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = x
-		} else {
-			out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			if x > 0 {
+				out[i] = x
+			} else {
+				out[i] = alpha * (float32(math.Exp(float64(x))) - 1)
+			}
 		}
-	}
+	})
 }
 
 // UEluStridedF64 performs element-wise ELU for float64 with parameter alpha (strided memory)
@@ -3282,23 +3884,27 @@ func UEluStridedF64(numel, ndims int, dims, strides []int, alpha float64, inp, o
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			if x <= 0 {
-				out[i] = alpha * (math.Exp(x) - 1)
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x <= 0 {
+					out[i] = alpha * (math.Exp(x) - 1)
+				}
 			}
-		}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = x
-		} else {
-			out[i] = alpha * (math.Exp(x) - 1)
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			if x > 0 {
+				out[i] = x
+			} else {
+				out[i] = alpha * (math.Exp(x) - 1)
+			}
 		}
-	}
+	})
 }
 
 // UEluStridedU8 performs element-wise ELU for uint8 with parameter alpha (strided memory)
@@ -3337,46 +3943,58 @@ func USilu[T D](numel int, inp, out []T) {
 		panic("usilu: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(x / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(x / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = T(x / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = T(x / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USiluF32 performs element-wise SiLU for float32 (contiguous memory)
 func USiluF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(x / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(x / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = float32(x / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = float32(x / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USiluF64 performs element-wise SiLU for float64 (contiguous memory)
 func USiluF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			out[i] = x / (1 + math.Exp(-x))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				out[i] = x / (1 + math.Exp(-x))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		out[i] = x / (1 + math.Exp(-x))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			out[i] = x / (1 + math.Exp(-x))
+		}
+	})
 }
 
 // USiluU8 performs element-wise SiLU for uint8 (contiguous memory)
@@ -3407,17 +4025,21 @@ func USiluStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(x / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(x / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = T(x / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = T(x / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USiluStridedF32 performs element-wise SiLU for float32 (strided memory)
@@ -3427,17 +4049,21 @@ func USiluStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(x / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(x / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = float32(x / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = float32(x / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USiluStridedF64 performs element-wise SiLU for float64 (strided memory)
@@ -3447,17 +4073,21 @@ func USiluStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			out[i] = x / (1 + math.Exp(-x))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				out[i] = x / (1 + math.Exp(-x))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		out[i] = x / (1 + math.Exp(-x))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			out[i] = x / (1 + math.Exp(-x))
+		}
+	})
 }
 
 // USiluStridedU8 performs element-wise SiLU for uint8 (strided memory)
@@ -3496,40 +4126,52 @@ func UPowf[T D](param T, numel int, inp, out []T) {
 		panic("upowf: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Pow(float64(out[i]), float64(param)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Pow(float64(out[i]), float64(param)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = T(math.Pow(float64(inp[i]), float64(param)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = T(math.Pow(float64(inp[i]), float64(param)))
+		}
+	})
 }
 
 // UPowfF32 performs element-wise power for float32 with parameter param (contiguous memory)
 func UPowfF32(param float32, numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Pow(float64(out[i]), float64(param)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Pow(float64(out[i]), float64(param)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = float32(math.Pow(float64(inp[i]), float64(param)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = float32(math.Pow(float64(inp[i]), float64(param)))
+		}
+	})
 }
 
 // UPowfF64 performs element-wise power for float64 with parameter param (contiguous memory)
 func UPowfF64(param float64, numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Pow(out[i], param)
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Pow(out[i], param)
+			}
+		})
 		return
 	}
-	for i := range numel {
-		out[i] = math.Pow(inp[i], param)
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			out[i] = math.Pow(inp[i], param)
+		}
+	})
 }
 
 // UPowfU8 performs element-wise power for uint8 with parameter param (contiguous memory)
@@ -3560,15 +4202,19 @@ func UPowfStrided[T D](numel, ndims int, dims, strides []int, param T, inp, out
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = T(math.Pow(float64(out[i]), float64(param)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = T(math.Pow(float64(out[i]), float64(param)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = T(math.Pow(float64(inp[stridedI]), float64(param)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = T(math.Pow(float64(inp[stridedI]), float64(param)))
+		}
+	})
 }
 
 // UPowfStridedF32 performs element-wise power for float32 with parameter param (strided memory)
@@ -3578,15 +4224,19 @@ func UPowfStridedF32(numel, ndims int, dims, strides []int, param float32, inp,
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = float32(math.Pow(float64(out[i]), float64(param)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = float32(math.Pow(float64(out[i]), float64(param)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = float32(math.Pow(float64(inp[stridedI]), float64(param)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = float32(math.Pow(float64(inp[stridedI]), float64(param)))
+		}
+	})
 }
 
 // UPowfStridedF64 performs element-wise power for float64 with parameter param (strided memory)
@@ -3596,15 +4246,19 @@ func UPowfStridedF64(numel, ndims int, dims, strides []int, param float64, inp,
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			out[i] = math.Pow(out[i], param)
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				out[i] = math.Pow(out[i], param)
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		out[i] = math.Pow(inp[stridedI], param)
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			out[i] = math.Pow(inp[stridedI], param)
+		}
+	})
 }
 
 // UPowfStridedU8 performs element-wise power for uint8 with parameter param (strided memory)
@@ -3637,8 +4291,23 @@ func UPowfStridedI64(numel, ndims int, dims, strides []int, param int64, inp, ou
 // USignF32 performs element-wise sign for float32 (contiguous memory)
 func USignF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else if x < 0 {
+					out[i] = -1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
 			if x > 0 {
 				out[i] = 1
 			} else if x < 0 {
@@ -3647,25 +4316,29 @@ func USignF32(numel int, inp, out []float32) {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = 1
-		} else if x < 0 {
-			out[i] = -1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignF64 performs element-wise sign for float64 (contiguous memory)
 func USignF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else if x < 0 {
+					out[i] = -1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
 			if x > 0 {
 				out[i] = 1
 			} else if x < 0 {
@@ -3674,71 +4347,83 @@ func USignF64(numel int, inp, out []float64) {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = 1
-		} else if x < 0 {
-			out[i] = -1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignU8 performs element-wise sign for uint8 (contiguous memory)
 func USignU8(numel int, inp, out []uint8) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
 			if x > 0 {
 				out[i] = 1
 			} else {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = 1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignU32 performs element-wise sign for uint32 (contiguous memory)
 func USignU32(numel int, inp, out []uint32) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
 			if x > 0 {
 				out[i] = 1
 			} else {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = 1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignI64 performs element-wise sign for int64 (contiguous memory)
 func USignI64(numel int, inp, out []int64) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else if x < 0 {
+					out[i] = -1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
 			if x > 0 {
 				out[i] = 1
 			} else if x < 0 {
@@ -3747,18 +4432,7 @@ func USignI64(numel int, inp, out []int64) {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		x := inp[i]
-		if x > 0 {
-			out[i] = 1
-		} else if x < 0 {
-			out[i] = -1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignStridedF32 performs element-wise sign for float32 (strided memory)
@@ -3768,8 +4442,24 @@ func USignStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else if x < 0 {
+					out[i] = -1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
 			if x > 0 {
 				out[i] = 1
 			} else if x < 0 {
@@ -3778,19 +4468,7 @@ func USignStridedF32(numel, ndims int, dims, strides []int, inp, out []float32)
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = 1
-		} else if x < 0 {
-			out[i] = -1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignStridedF64 performs element-wise sign for float64 (strided memory)
@@ -3800,8 +4478,24 @@ func USignStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else if x < 0 {
+					out[i] = -1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
 			if x > 0 {
 				out[i] = 1
 			} else if x < 0 {
@@ -3810,19 +4504,7 @@ func USignStridedF64(numel, ndims int, dims, strides []int, inp, out []float64)
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = 1
-		} else if x < 0 {
-			out[i] = -1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignStridedU8 performs element-wise sign for uint8 (strided memory)
@@ -3832,25 +4514,29 @@ func USignStridedU8(numel, ndims int, dims, strides []int, inp, out []uint8) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
 			if x > 0 {
 				out[i] = 1
 			} else {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = 1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignStridedU32 performs element-wise sign for uint32 (strided memory)
@@ -3860,25 +4546,29 @@ func USignStridedU32(numel, ndims int, dims, strides []int, inp, out []uint32) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
 			if x > 0 {
 				out[i] = 1
 			} else {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = 1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USignStridedI64 performs element-wise sign for int64 (strided memory)
@@ -3888,8 +4578,24 @@ func USignStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				if x > 0 {
+					out[i] = 1
+				} else if x < 0 {
+					out[i] = -1
+				} else {
+					out[i] = 0
+				}
+			}
+		})
+		return
+	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
 			if x > 0 {
 				out[i] = 1
 			} else if x < 0 {
@@ -3898,19 +4604,7 @@ func USignStridedI64(numel, ndims int, dims, strides []int, inp, out []int64) {
 				out[i] = 0
 			}
 		}
-		return
-	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		if x > 0 {
-			out[i] = 1
-		} else if x < 0 {
-			out[i] = -1
-		} else {
-			out[i] = 0
-		}
-	}
+	})
 }
 
 // USigmoid performs element-wise sigmoid for type T (contiguous memory)
@@ -3922,46 +4616,58 @@ func USigmoid[T D](numel int, inp, out []T) {
 		panic("usigmoid: unsupported type")
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(1 / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(1 / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = T(1 / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = T(1 / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USigmoidF32 performs element-wise sigmoid for float32 (contiguous memory)
 func USigmoidF32(numel int, inp, out []float32) {
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(1 / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(1 / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := float64(inp[i])
-		out[i] = float32(1 / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := float64(inp[i])
+			out[i] = float32(1 / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USigmoidF64 performs element-wise sigmoid for float64 (contiguous memory)
 func USigmoidF64(numel int, inp, out []float64) {
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			out[i] = 1 / (1 + math.Exp(-x))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				out[i] = 1 / (1 + math.Exp(-x))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		x := inp[i]
-		out[i] = 1 / (1 + math.Exp(-x))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			x := inp[i]
+			out[i] = 1 / (1 + math.Exp(-x))
+		}
+	})
 }
 
 // USigmoidU8 performs element-wise sigmoid for uint8 (contiguous memory)
@@ -3992,17 +4698,21 @@ func USigmoidStrided[T D](numel, ndims int, dims, strides []int, inp, out []T) {
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = T(1 / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = T(1 / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = T(1 / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = T(1 / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USigmoidStridedF32 performs element-wise sigmoid for float32 (strided memory)
@@ -4012,17 +4722,21 @@ func USigmoidStridedF32(numel, ndims int, dims, strides []int, inp, out []float3
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := float64(out[i])
-			out[i] = float32(1 / (1 + math.Exp(-x)))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := float64(out[i])
+				out[i] = float32(1 / (1 + math.Exp(-x)))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := float64(inp[stridedI])
-		out[i] = float32(1 / (1 + math.Exp(-x)))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := float64(inp[stridedI])
+			out[i] = float32(1 / (1 + math.Exp(-x)))
+		}
+	})
 }
 
 // USigmoidStridedF64 performs element-wise sigmoid for float64 (strided memory)
@@ -4032,17 +4746,21 @@ func USigmoidStridedF64(numel, ndims int, dims, strides []int, inp, out []float6
 		return
 	}
 	if inp == nil {
-		for i := range numel {
-			x := out[i]
-			out[i] = 1 / (1 + math.Exp(-x))
-		}
+		ParallelFor(numel, grainSize, func(start, end int) {
+			for i := start; i < end; i++ {
+				x := out[i]
+				out[i] = 1 / (1 + math.Exp(-x))
+			}
+		})
 		return
 	}
-	for i := range numel {
-		stridedI := GetStridedIndex(i, ndims, dims, strides)
-		x := inp[stridedI]
-		out[i] = 1 / (1 + math.Exp(-x))
-	}
+	ParallelFor(numel, grainSize, func(start, end int) {
+		for i := start; i < end; i++ {
+			stridedI := GetStridedIndex(i, ndims, dims, strides)
+			x := inp[stridedI]
+			out[i] = 1 / (1 + math.Exp(-x))
+		}
+	})
 }
 
 // USigmoidStridedU8 performs element-wise sigmoid for uint8 (strided memory)
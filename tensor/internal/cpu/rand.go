@@ -0,0 +1,137 @@
+package cpu
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
+)
+
+// rng is the generator of the CPU device, guarded by rngMu. There is a
+// single CPU device, so the CpuDevice instances of every element type share
+// it: ManualSeed then yields one reproducible stream whatever the dtype.
+var (
+	rngMu sync.Mutex
+	rng   = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+)
+
+// NewRand returns an independent generator seeded from the CPU generator,
+// so it replays after SetSeed.
+func NewRand() *rand.Rand {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return rand.New(rand.NewPCG(rng.Uint64(), rng.Uint64()))
+}
+
+// FillUniform fills data with samples from r, uniform in [min, max) for floats and [min, max] for integers.
+func FillUniform[T kernels.D](r *rand.Rand, data []T, min, max float64) error {
+	switch data := any(data).(type) {
+	case []float32:
+		for i := range data {
+			data[i] = float32(min + r.Float64()*(max-min))
+		}
+	case []float64:
+		for i := range data {
+			data[i] = min + r.Float64()*(max-min)
+		}
+	case []uint8:
+		minU8, maxU8 := uint8(min), uint8(max)
+		if min < 0 || max > math.MaxUint8 || max < min {
+			return errors.New("invalid range for uint8")
+		}
+		rangeSize := uint32(maxU8 - minU8 + 1)
+		for i := range data {
+			data[i] = minU8 + uint8(r.Uint32N(rangeSize))
+		}
+	case []uint32:
+		minU32, maxU32 := uint32(min), uint32(max)
+		if min < 0 || max > math.MaxUint32 || max < min {
+			return errors.New("invalid range for uint32")
+		}
+		rangeSize := maxU32 - minU32 + 1
+		for i := range data {
+			data[i] = minU32 + r.Uint32N(rangeSize)
+		}
+	case []int64:
+		minI64, maxI64 := int64(min), int64(max)
+		if max < min {
+			return errors.New("invalid range for int64")
+		}
+		rangeSize := maxI64 - minI64 + 1
+		for i := range data {
+			data[i] = minI64 + r.Int64N(rangeSize)
+		}
+	case []candy.Float16:
+		for i := range data {
+			data[i] = candy.NewFloat16(float32(min + r.Float64()*(max-min)))
+		}
+	case []candy.BFloat16:
+		for i := range data {
+			data[i] = candy.NewBFloat16(float32(min + r.Float64()*(max-min)))
+		}
+	default:
+		return errors.New("unsupported dtype")
+	}
+	return nil
+}
+
+// FillNormal fills data with normal samples from r, clamped to the range of integer types.
+func FillNormal[T kernels.D](r *rand.Rand, data []T, mean, std float64) error {
+	switch data := any(data).(type) {
+	case []float32:
+		for i := range data {
+			val := mean + std*r.NormFloat64()
+			data[i] = float32(val)
+		}
+	case []float64:
+		for i := range data {
+			val := mean + std*r.NormFloat64()
+			data[i] = val
+		}
+	case []uint8:
+		for i := range data {
+			val := mean + std*r.NormFloat64()
+			if val < 0 {
+				val = 0 // Clamp for unsigned.
+			} else if val > math.MaxUint8 {
+				val = math.MaxUint8
+			}
+			data[i] = uint8(val)
+		}
+	case []uint32:
+		for i := range data {
+			val := mean + std*r.NormFloat64()
+			if val < 0 {
+				val = 0 // Clamp for unsigned.
+			} else if val > math.MaxUint32 {
+				val = math.MaxUint32
+			}
+			data[i] = uint32(val)
+		}
+	case []int64:
+		for i := range data {
+			val := mean + std*r.NormFloat64()
+			// Check for int64 overflow (rare but possible).
+			if val < math.MinInt64 {
+				val = math.MinInt64
+			} else if val > math.MaxInt64 {
+				val = math.MaxInt64
+			}
+			data[i] = int64(val)
+		}
+	case []candy.Float16:
+		for i := range data {
+			data[i] = candy.NewFloat16(float32(mean + std*r.NormFloat64()))
+		}
+	case []candy.BFloat16:
+		for i := range data {
+			data[i] = candy.NewBFloat16(float32(mean + std*r.NormFloat64()))
+		}
+	default:
+		return errors.New("unsupported dtype")
+	}
+	return nil
+}
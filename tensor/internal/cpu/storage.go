@@ -3,6 +3,7 @@ package cpu
 import (
 	"errors"
 	"fmt"
+	"math"
 	"slices"
 
 	"github.com/gocnn/candy"
@@ -41,32 +42,57 @@ func (s *CpuStorage[T]) DType() candy.DType {
 	return s.dtype
 }
 
+// checkLayout verifies that every element addressed by layout lies within a storage of n elements.
+func checkLayout(layout *candy.Layout, n int) error {
+	if layout.Numel() == 0 {
+		return nil
+	}
+	last := layout.StartOffset()
+	stride := layout.Stride()
+	for i, d := range layout.Dims() {
+		if stride[i] < 0 {
+			return errors.New("negative strides are not supported")
+		}
+		last += (d - 1) * stride[i]
+	}
+	if layout.StartOffset() < 0 || last >= n {
+		return fmt.Errorf("layout %v exceeds storage size %d", layout, n)
+	}
+	return nil
+}
+
 // Affine performs an affine transformation on the storage.
 func (s *CpuStorage[T]) Affine(layout *candy.Layout, scale, bias T) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Affine(layout, f32(scale), f32(bias)))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	result := New(make([]T, numel))
 	kernels.AffineStrided(
-		numel,           // numel
-		layout.Rank(),   // ndims
-		layout.Dims(),   // dims
-		layout.Stride(), // strides
-		scale,           // scale
-		bias,            // bias
-		s.data,          // x
-		result.data,     // y
+		numel,                         // numel
+		layout.Rank(),                 // ndims
+		layout.Dims(),                 // dims
+		layout.Stride(),               // strides
+		scale,                         // scale
+		bias,                          // bias
+		s.data[layout.StartOffset():], // x
+		result.data,                   // y
 	)
 	return result, nil
 }
 
 // Add performs element-wise addition of two tensors.
 func (s *CpuStorage[T]) Add(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Add(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -86,15 +112,15 @@ func (s *CpuStorage[T]) Add(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.BAddStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -102,6 +128,9 @@ func (s *CpuStorage[T]) Add(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 // Sub performs element-wise subtraction of two tensors.
 func (s *CpuStorage[T]) Sub(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Sub(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -121,15 +150,15 @@ func (s *CpuStorage[T]) Sub(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.BSubStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -137,6 +166,9 @@ func (s *CpuStorage[T]) Sub(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 // Mul performs element-wise multiplication of two tensors.
 func (s *CpuStorage[T]) Mul(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Mul(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -156,15 +188,15 @@ func (s *CpuStorage[T]) Mul(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.BMulStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -172,6 +204,9 @@ func (s *CpuStorage[T]) Mul(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 // Div performs element-wise division of two tensors.
 func (s *CpuStorage[T]) Div(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Div(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -191,15 +226,15 @@ func (s *CpuStorage[T]) Div(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.BDivStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -207,6 +242,9 @@ func (s *CpuStorage[T]) Div(rhs candy.BackendStorage[T], lhsLayout *candy.Layout
 
 // Max performs element-wise maximum of two tensors.
 func (s *CpuStorage[T]) Maximum(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Maximum(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -226,15 +264,15 @@ func (s *CpuStorage[T]) Maximum(rhs candy.BackendStorage[T], lhsLayout *candy.La
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.BMaximumStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -242,6 +280,9 @@ func (s *CpuStorage[T]) Maximum(rhs candy.BackendStorage[T], lhsLayout *candy.La
 
 // Min performs element-wise minimum of two tensors.
 func (s *CpuStorage[T]) Minimum(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Minimum(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -261,15 +302,15 @@ func (s *CpuStorage[T]) Minimum(rhs candy.BackendStorage[T], lhsLayout *candy.La
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.BMinimumStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -277,6 +318,9 @@ func (s *CpuStorage[T]) Minimum(rhs candy.BackendStorage[T], lhsLayout *candy.La
 
 // Eq performs element-wise equality comparison of two tensors.
 func (s *CpuStorage[T]) Eq(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Eq(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -296,15 +340,15 @@ func (s *CpuStorage[T]) Eq(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.EqStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -312,6 +356,9 @@ func (s *CpuStorage[T]) Eq(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 // Ne performs element-wise not-equal comparison of two tensors.
 func (s *CpuStorage[T]) Ne(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Ne(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -331,15 +378,15 @@ func (s *CpuStorage[T]) Ne(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.NeStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -347,6 +394,9 @@ func (s *CpuStorage[T]) Ne(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 // Lt performs element-wise less-than comparison of two tensors.
 func (s *CpuStorage[T]) Lt(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Lt(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -366,15 +416,15 @@ func (s *CpuStorage[T]) Lt(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.LtStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -382,6 +432,9 @@ func (s *CpuStorage[T]) Lt(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 // Le performs element-wise less-than-or-equal comparison of two tensors.
 func (s *CpuStorage[T]) Le(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Le(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -401,15 +454,15 @@ func (s *CpuStorage[T]) Le(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.LeStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -417,6 +470,9 @@ func (s *CpuStorage[T]) Le(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 // Gt performs element-wise greater-than comparison of two tensors.
 func (s *CpuStorage[T]) Gt(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Gt(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -436,15 +492,15 @@ func (s *CpuStorage[T]) Gt(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.GtStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -452,6 +508,9 @@ func (s *CpuStorage[T]) Gt(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 // Ge performs element-wise greater-than-or-equal comparison of two tensors.
 func (s *CpuStorage[T]) Ge(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Ge(widen(rhs), lhsLayout, rhsLayout, resLayout))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -471,15 +530,15 @@ func (s *CpuStorage[T]) Ge(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 	result := New(make([]T, resLayout.Numel()))
 	kernels.GeStrided(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -487,6 +546,9 @@ func (s *CpuStorage[T]) Ge(rhs candy.BackendStorage[T], lhsLayout *candy.Layout,
 
 // EqU8 performs element-wise equality comparison of two tensors.
 func (s *CpuStorage[T]) EqU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[uint8], error) {
+	if s.isHalf() {
+		return widen[T](s).EqU8(widen(rhs), lhsLayout, rhsLayout, resLayout)
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -506,15 +568,15 @@ func (s *CpuStorage[T]) EqU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 	result := New(make([]uint8, resLayout.Numel()))
 	kernels.EqStridedU8(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -522,6 +584,9 @@ func (s *CpuStorage[T]) EqU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 // NeU8 performs element-wise not-equal comparison of two tensors.
 func (s *CpuStorage[T]) NeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[uint8], error) {
+	if s.isHalf() {
+		return widen[T](s).NeU8(widen(rhs), lhsLayout, rhsLayout, resLayout)
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -541,15 +606,15 @@ func (s *CpuStorage[T]) NeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 	result := New(make([]uint8, resLayout.Numel()))
 	kernels.NeStridedU8(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -557,6 +622,9 @@ func (s *CpuStorage[T]) NeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 // LtU8 performs element-wise less-than comparison of two tensors.
 func (s *CpuStorage[T]) LtU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[uint8], error) {
+	if s.isHalf() {
+		return widen[T](s).LtU8(widen(rhs), lhsLayout, rhsLayout, resLayout)
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -576,15 +644,15 @@ func (s *CpuStorage[T]) LtU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 	result := New(make([]uint8, resLayout.Numel()))
 	kernels.LtStridedU8(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -592,6 +660,9 @@ func (s *CpuStorage[T]) LtU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 // LeU8 performs element-wise less-than-or-equal comparison of two tensors.
 func (s *CpuStorage[T]) LeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[uint8], error) {
+	if s.isHalf() {
+		return widen[T](s).LeU8(widen(rhs), lhsLayout, rhsLayout, resLayout)
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -611,15 +682,15 @@ func (s *CpuStorage[T]) LeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 	result := New(make([]uint8, resLayout.Numel()))
 	kernels.LeStridedU8(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -627,6 +698,9 @@ func (s *CpuStorage[T]) LeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 // GtU8 performs element-wise greater-than comparison of two tensors.
 func (s *CpuStorage[T]) GtU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[uint8], error) {
+	if s.isHalf() {
+		return widen[T](s).GtU8(widen(rhs), lhsLayout, rhsLayout, resLayout)
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -646,15 +720,15 @@ func (s *CpuStorage[T]) GtU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 	result := New(make([]uint8, resLayout.Numel()))
 	kernels.GtStridedU8(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -662,6 +736,9 @@ func (s *CpuStorage[T]) GtU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 // GeU8 performs element-wise greater-than-or-equal comparison of two tensors.
 func (s *CpuStorage[T]) GeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layout, rhsLayout *candy.Layout, resLayout *candy.Layout) (candy.BackendStorage[uint8], error) {
+	if s.isHalf() {
+		return widen[T](s).GeU8(widen(rhs), lhsLayout, rhsLayout, resLayout)
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -681,15 +758,15 @@ func (s *CpuStorage[T]) GeU8(rhs candy.BackendStorage[T], lhsLayout *candy.Layou
 
 	result := New(make([]uint8, resLayout.Numel()))
 	kernels.GeStridedU8(
-		lhsLayout.Numel(),  // numel
-		lhsLayout.Rank(),   // ndims
-		lhsLayout.Dims(),   // dims
-		lhsLayout.Stride(), // stridesX1
-		rhsLayout.Stride(), // stridesX2
-		resLayout.Stride(), // stridesY
-		s.data,             // x1
-		rhsC.data,          // x2
-		result.data,        // y
+		lhsLayout.Numel(),                   // numel
+		lhsLayout.Rank(),                    // ndims
+		lhsLayout.Dims(),                    // dims
+		lhsLayout.Stride(),                  // stridesX1
+		rhsLayout.Stride(),                  // stridesX2
+		resLayout.Stride(),                  // stridesY
+		s.data[lhsLayout.StartOffset():],    // x1
+		rhsC.data[rhsLayout.StartOffset():], // x2
+		result.data,                         // y
 	)
 
 	return result, nil
@@ -701,14 +778,29 @@ func (s *CpuStorage[T]) ToDtype(layout *candy.Layout, dtype candy.DType) (any, e
 		return nil, errors.New("layout cannot be nil")
 	}
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	srcDtype := s.dtype
 	if srcDtype == dtype {
-		cloned, err := s.Clone()
-		return cloned, err
+		return s.Copy(layout, s)
+	}
+
+	// Half-precision values convert through float32 in either direction.
+	if s.isHalf() {
+		f := s.castHalfToF32(numel, layout)
+		if dtype == candy.F32 {
+			return f, nil
+		}
+		return f.ToDtype(candy.Contiguous(layout.Shape()), dtype)
+	}
+	if (dtype == candy.F16 || dtype == candy.BF16) && srcDtype != candy.F32 {
+		f, err := s.ToDtype(layout, candy.F32)
+		if err != nil {
+			return nil, err
+		}
+		return f.(*CpuStorage[float32]).ToDtype(candy.Contiguous(layout.Shape()), dtype)
 	}
 
 	// Handle type conversions based on source type
@@ -729,142 +821,178 @@ func (s *CpuStorage[T]) ToDtype(layout *candy.Layout, dtype candy.DType) (any, e
 }
 
 func (s *CpuStorage[T]) CastFromF32(numel int, layout *candy.Layout, dtype candy.DType) (any, error) {
-	srcData := any(s.data).([]float32)
+	srcData := any(s.data[layout.StartOffset():]).([]float32)
 	stride := layout.Stride()
+	outStride := candy.Contiguous(layout.Shape()).Stride()
 	dims := layout.Dims()
 	ndims := layout.Rank()
 
 	switch dtype {
 	case candy.F64:
 		result := New(make([]float64, numel))
-		kernels.CastStridedF32F64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF32F64(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U8:
 		result := New(make([]uint8, numel))
-		kernels.CastStridedF32U8(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF32U8(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U32:
 		result := New(make([]uint32, numel))
-		kernels.CastStridedF32U32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF32U32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.I64:
 		result := New(make([]int64, numel))
-		kernels.CastStridedF32I64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF32I64(numel, ndims, dims, stride, outStride, srcData, result.data)
+		return result, nil
+	case candy.F16:
+		result := New(make([]candy.Float16, numel))
+		kernels.CastStridedF32F16(numel, ndims, dims, stride, outStride, srcData, result.data)
+		return result, nil
+	case candy.BF16:
+		result := New(make([]candy.BFloat16, numel))
+		kernels.CastStridedF32BF16(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	}
 	return nil, errors.New("unsupported target type: " + dtype.String())
 }
 
 func (s *CpuStorage[T]) CastFromF64(numel int, layout *candy.Layout, dtype candy.DType) (any, error) {
-	srcData := any(s.data).([]float64)
+	srcData := any(s.data[layout.StartOffset():]).([]float64)
 	stride := layout.Stride()
+	outStride := candy.Contiguous(layout.Shape()).Stride()
 	dims := layout.Dims()
 	ndims := layout.Rank()
 
 	switch dtype {
 	case candy.F32:
 		result := New(make([]float32, numel))
-		kernels.CastStridedF64F32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF64F32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U8:
 		result := New(make([]uint8, numel))
-		kernels.CastStridedF64U8(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF64U8(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U32:
 		result := New(make([]uint32, numel))
-		kernels.CastStridedF64U32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF64U32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.I64:
 		result := New(make([]int64, numel))
-		kernels.CastStridedF64I64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedF64I64(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	}
 	return nil, errors.New("unsupported target type: " + dtype.String())
 }
 
 func (s *CpuStorage[T]) CastFromU8(numel int, layout *candy.Layout, dtype candy.DType) (any, error) {
-	srcData := any(s.data).([]uint8)
+	srcData := any(s.data[layout.StartOffset():]).([]uint8)
 	stride := layout.Stride()
+	outStride := candy.Contiguous(layout.Shape()).Stride()
 	dims := layout.Dims()
 	ndims := layout.Rank()
 
 	switch dtype {
 	case candy.F32:
 		result := New(make([]float32, numel))
-		kernels.CastStridedU8F32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU8F32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.F64:
 		result := New(make([]float64, numel))
-		kernels.CastStridedU8F64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU8F64(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U32:
 		result := New(make([]uint32, numel))
-		kernels.CastStridedU8U32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU8U32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.I64:
 		result := New(make([]int64, numel))
-		kernels.CastStridedU8I64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU8I64(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	}
 	return nil, errors.New("unsupported target type: " + dtype.String())
 }
 
 func (s *CpuStorage[T]) CastFromU32(numel int, layout *candy.Layout, dtype candy.DType) (any, error) {
-	srcData := any(s.data).([]uint32)
+	srcData := any(s.data[layout.StartOffset():]).([]uint32)
 	stride := layout.Stride()
+	outStride := candy.Contiguous(layout.Shape()).Stride()
 	dims := layout.Dims()
 	ndims := layout.Rank()
 
 	switch dtype {
 	case candy.F32:
 		result := New(make([]float32, numel))
-		kernels.CastStridedU32F32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU32F32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.F64:
 		result := New(make([]float64, numel))
-		kernels.CastStridedU32F64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU32F64(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U8:
 		result := New(make([]uint8, numel))
-		kernels.CastStridedU32U8(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU32U8(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.I64:
 		result := New(make([]int64, numel))
-		kernels.CastStridedU32I64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedU32I64(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	}
 	return nil, errors.New("unsupported target type: " + dtype.String())
 }
 
 func (s *CpuStorage[T]) CastFromI64(numel int, layout *candy.Layout, dtype candy.DType) (any, error) {
-	srcData := any(s.data).([]int64)
+	srcData := any(s.data[layout.StartOffset():]).([]int64)
 	stride := layout.Stride()
+	outStride := candy.Contiguous(layout.Shape()).Stride()
 	dims := layout.Dims()
 	ndims := layout.Rank()
 
 	switch dtype {
 	case candy.F32:
 		result := New(make([]float32, numel))
-		kernels.CastStridedI64F32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedI64F32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.F64:
 		result := New(make([]float64, numel))
-		kernels.CastStridedI64F64(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedI64F64(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U8:
 		result := New(make([]uint8, numel))
-		kernels.CastStridedI64U8(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedI64U8(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	case candy.U32:
 		result := New(make([]uint32, numel))
-		kernels.CastStridedI64U32(numel, ndims, dims, stride, stride, srcData, result.data)
+		kernels.CastStridedI64U32(numel, ndims, dims, stride, outStride, srcData, result.data)
 		return result, nil
 	}
 	return nil, errors.New("unsupported target type: " + dtype.String())
 }
 
-// MatMul performs matrix multiplication: C = A * B
+// castHalfToF32 widens a half-precision storage to a contiguous float32 storage.
+func (s *CpuStorage[T]) castHalfToF32(numel int, layout *candy.Layout) *CpuStorage[float32] {
+	stride := layout.Stride()
+	outStride := candy.Contiguous(layout.Shape()).Stride()
+	dims := layout.Dims()
+	ndims := layout.Rank()
+
+	result := New(make([]float32, numel))
+	switch src := any(s.data[layout.StartOffset():]).(type) {
+	case []candy.Float16:
+		kernels.CastStridedF16F32(numel, ndims, dims, stride, outStride, src, result.data)
+	case []candy.BFloat16:
+		kernels.CastStridedBF16F32(numel, ndims, dims, stride, outStride, src, result.data)
+	}
+	return result
+}
+
+// MatMul performs batched matrix multiplication. Float operands whose last two
+// strides describe a row-major or transposed matrix go straight to BLAS gemm;
+// other layouts and integer types use a tiled kernel. Batch dimensions may
+// have any strides, including zero for a matrix broadcast over the batch.
 func (s *CpuStorage[T]) MatMul(lhsLayout *candy.Layout, rhs candy.BackendStorage[T], rhsLayout *candy.Layout, b, m, n, k int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).MatMul(lhsLayout, widen(rhs), rhsLayout, b, m, n, k))
+	}
 	rhsC, ok := rhs.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("rhs storage must be CpuStorage")
@@ -878,146 +1006,63 @@ func (s *CpuStorage[T]) MatMul(lhsLayout *candy.Layout, rhs candy.BackendStorage
 		return nil, errors.New("invalid matrix dimensions")
 	}
 
-	result := New(make([]T, b*m*n))
-
-	switch any(s.data).(type) {
-	case []float32:
-		lhsData := any(s.data).([]float32)
-		rhsData := any(rhsC.data).([]float32)
-		resultData := any(result.data).([]float32)
+	lhsOffsets, err := batchOffsets(lhsLayout, b, m, k)
+	if err != nil {
+		return nil, fmt.Errorf("lhs: %w", err)
+	}
+	rhsOffsets, err := batchOffsets(rhsLayout, b, k, n)
+	if err != nil {
+		return nil, fmt.Errorf("rhs: %w", err)
+	}
+	ls, rs := lhsLayout.Stride(), rhsLayout.Stride()
+	lRow, lCol := ls[len(ls)-2], ls[len(ls)-1]
+	rRow, rCol := rs[len(rs)-2], rs[len(rs)-1]
 
-		if lhsLayout.IsContiguous() && rhsLayout.IsContiguous() {
-			kernels.NaiveBatchedMatMulF32(b, m, n, k, lhsData, rhsData, resultData)
-		} else {
-			if b == 1 {
-				ls := lhsLayout.Stride()
-				rs := rhsLayout.Stride()
-				lhsStrides := []int{0, ls[len(ls)-2], ls[len(ls)-1]} // [batch,row,col]
-				rhsStrides := []int{0, rs[len(rs)-2], rs[len(rs)-1]}
-				cStrides := []int{0, n, 1}
-				kernels.NaiveBatchedMatMulStridedF32(
-					b,
-					m,
-					n,
-					k,
-					lhsData,
-					rhsData,
-					resultData,
-					lhsStrides,
-					rhsStrides,
-					cStrides,
-				)
-			} else {
-				lhsStrides := []int{m * k, k, 1} // [batch, row, col]
-				rhsStrides := []int{k * n, n, 1}
-				cStrides := []int{m * n, n, 1}
-				kernels.NaiveBatchedMatMulStridedF32(
-					b,
-					m,
-					n,
-					k,
-					lhsData,
-					rhsData,
-					resultData,
-					lhsStrides,
-					rhsStrides,
-					cStrides,
-				)
-			}
+	result := New(make([]T, b*m*n))
+	transA, lda, okA := kernels.GemmLayout(m, k, lRow, lCol)
+	transB, ldb, okB := kernels.GemmLayout(k, n, rRow, rCol)
+	if okA && okB {
+		switch lhs := any(s.data).(type) {
+		case []float32:
+			kernels.BatchedGemmF32(transA, transB, m, n, k, lhs, lhsOffsets, lda, any(rhsC.data).([]float32), rhsOffsets, ldb, any(result.data).([]float32))
+			return result, nil
+		case []float64:
+			kernels.BatchedGemmF64(transA, transB, m, n, k, lhs, lhsOffsets, lda, any(rhsC.data).([]float64), rhsOffsets, ldb, any(result.data).([]float64))
+			return result, nil
 		}
-	case []float64:
-		lhsData := any(s.data).([]float64)
-		rhsData := any(rhsC.data).([]float64)
-		resultData := any(result.data).([]float64)
+	}
+	kernels.TiledBatchedMatMul(m, n, k, s.data, lhsOffsets, lRow, lCol, rhsC.data, rhsOffsets, rRow, rCol, result.data)
+	return result, nil
+}
 
-		if lhsLayout.IsContiguous() && rhsLayout.IsContiguous() {
-			kernels.NaiveBatchedMatMulF64(b, m, n, k, lhsData, rhsData, resultData)
-		} else {
-			if b == 1 {
-				ls := lhsLayout.Stride()
-				rs := rhsLayout.Stride()
-				lhsStrides := []int{0, ls[len(ls)-2], ls[len(ls)-1]}
-				rhsStrides := []int{0, rs[len(rs)-2], rs[len(rs)-1]}
-				cStrides := []int{0, n, 1}
-				kernels.NaiveBatchedMatMulStridedF64(
-					b,
-					m,
-					n,
-					k,
-					lhsData,
-					rhsData,
-					resultData,
-					lhsStrides,
-					rhsStrides,
-					cStrides,
-				)
-			} else {
-				lhsStrides := []int{m * k, k, 1}
-				rhsStrides := []int{k * n, n, 1}
-				cStrides := []int{m * n, n, 1}
-				kernels.NaiveBatchedMatMulStridedF64(
-					b,
-					m,
-					n,
-					k,
-					lhsData,
-					rhsData,
-					resultData,
-					lhsStrides,
-					rhsStrides,
-					cStrides,
-				)
-			}
-		}
-	case []uint8, []uint32, []int64:
-		if lhsLayout.IsContiguous() && rhsLayout.IsContiguous() {
-			kernels.NaiveBatchedMatMul(b, m, n, k, s.data, rhsC.data, result.data)
-		} else {
-			if b == 1 {
-				ls := lhsLayout.Stride()
-				rs := rhsLayout.Stride()
-				lhsStrides := []int{0, ls[len(ls)-2], ls[len(ls)-1]}
-				rhsStrides := []int{0, rs[len(rs)-2], rs[len(rs)-1]}
-				cStrides := []int{0, n, 1}
-				kernels.NaiveBatchedMatMulStrided(
-					b,
-					m,
-					n,
-					k,
-					s.data,
-					rhsC.data,
-					result.data,
-					lhsStrides,
-					rhsStrides,
-					cStrides,
-				)
-			} else {
-				lhsStrides := []int{m * k, k, 1}
-				rhsStrides := []int{k * n, n, 1}
-				cStrides := []int{m * n, n, 1}
-				kernels.NaiveBatchedMatMulStrided(
-					b,
-					m,
-					n,
-					k,
-					s.data,
-					rhsC.data,
-					result.data,
-					lhsStrides,
-					rhsStrides,
-					cStrides,
-				)
-			}
+// batchOffsets returns the start offset of each of the b rows×cols matrices
+// addressed by layout, whose leading dimensions are the batch dimensions.
+func batchOffsets(layout *candy.Layout, b, rows, cols int) ([]int, error) {
+	dims, stride := layout.Dims(), layout.Stride()
+	rank := len(dims)
+	if rank < 2 || dims[rank-2] != rows || dims[rank-1] != cols {
+		return nil, fmt.Errorf("layout %v is not a batch of %dx%d matrices", dims, rows, cols)
+	}
+	if n := candy.NewShapeFrom(dims[:rank-2]).Numel(); n != b {
+		return nil, fmt.Errorf("batch size %d does not match %d", n, b)
+	}
+	offsets := make([]int, b)
+	for i := range offsets {
+		off, rem := layout.StartOffset(), i
+		for d := rank - 3; d >= 0; d-- {
+			off += rem % dims[d] * stride[d]
+			rem /= dims[d]
 		}
-	default:
-		return nil, errors.New("unsupported type for matmul")
+		offsets[i] = off
 	}
-
-	return result, nil
+	return offsets, nil
 }
 
 // Conv1d performs 1D convolution using im2col + BLAS for supported types.
 func (s *CpuStorage[T]) Conv1d(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, params *candy.Conv1DParams) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Conv1d(layout, widen(kernel), kernelLayout, params))
+	}
 	kernelC, ok := kernel.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("kernel storage must be CpuStorage")
@@ -1040,10 +1085,12 @@ func (s *CpuStorage[T]) Conv1d(layout *candy.Layout, kernel candy.BackendStorage
 		return nil, errors.New("invalid convolution parameters: output length <= 0")
 	}
 
+	// The output is written channel-last (NLC), matching the im2col layout.
 	result := New(make([]T, params.Batch*params.OutCh*lOut))
+	contiguous := layout.IsContiguous() && kernelLayout.IsContiguous()
 	switch any(s.data).(type) {
 	case []float32:
-		if layout.IsContiguous() && kernelLayout.IsContiguous() {
+		if contiguous {
 			kernels.Im2colConv1dF32(
 				params.Batch,
 				params.InCh,
@@ -1053,27 +1100,14 @@ func (s *CpuStorage[T]) Conv1d(layout *candy.Layout, kernel candy.BackendStorage
 				params.Stride,
 				params.Pad,
 				params.Dilate,
-				any(s.data).([]float32),
-				any(kernelC.data).([]float32),
-				any(result.data).([]float32),
-			)
-		} else {
-			kernels.NaiveConv1dF32(
-				params.Batch,
-				params.InCh,
-				params.InLen,
-				params.OutCh,
-				params.KSize,
-				params.Stride,
-				params.Pad,
-				params.Dilate,
-				any(s.data).([]float32),
-				any(kernelC.data).([]float32),
+				any(s.data[layout.StartOffset():]).([]float32),
+				any(kernelC.data[kernelLayout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 			)
+			return result, nil
 		}
 	case []float64:
-		if layout.IsContiguous() && kernelLayout.IsContiguous() {
+		if contiguous {
 			kernels.Im2colConv1dF64(
 				params.Batch,
 				params.InCh,
@@ -1083,48 +1117,40 @@ func (s *CpuStorage[T]) Conv1d(layout *candy.Layout, kernel candy.BackendStorage
 				params.Stride,
 				params.Pad,
 				params.Dilate,
-				any(s.data).([]float64),
-				any(kernelC.data).([]float64),
-				any(result.data).([]float64),
-			)
-		} else {
-			kernels.NaiveConv1dF64(
-				params.Batch,
-				params.InCh,
-				params.InLen,
-				params.OutCh,
-				params.KSize,
-				params.Stride,
-				params.Pad,
-				params.Dilate,
-				any(s.data).([]float64),
-				any(kernelC.data).([]float64),
+				any(s.data[layout.StartOffset():]).([]float64),
+				any(kernelC.data[kernelLayout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 			)
+			return result, nil
 		}
 	case []uint8, []uint32, []int64:
-		kernels.NaiveConv1d(
-			params.Batch,
-			params.InCh,
-			params.InLen,
-			params.OutCh,
-			params.KSize,
-			params.Stride,
-			params.Pad,
-			params.Dilate,
-			s.data,
-			kernelC.data,
-			result.data,
-		)
 	default:
 		return nil, errors.New("unsupported data type for conv1d")
 	}
-
+	kernels.NaiveConv1dStrided(
+		params.Batch,
+		params.InCh,
+		params.InLen,
+		params.OutCh,
+		params.KSize,
+		params.Stride,
+		params.Pad,
+		params.Dilate,
+		s.data[layout.StartOffset():],
+		kernelC.data[kernelLayout.StartOffset():],
+		result.data,
+		layout.Stride(),
+		kernelLayout.Stride(),
+		[]int{params.OutCh * lOut, 1, params.OutCh},
+	)
 	return result, nil
 }
 
 // ConvTranspose1d performs 1D transposed convolution (deconvolution) for supported types.
 func (s *CpuStorage[T]) ConvTranspose1d(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, params *candy.ConvT1DParams) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).ConvTranspose1d(layout, widen(kernel), kernelLayout, params))
+	}
 	kernelC, ok := kernel.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("kernel storage must be CpuStorage")
@@ -1146,6 +1172,26 @@ func (s *CpuStorage[T]) ConvTranspose1d(layout *candy.Layout, kernel candy.Backe
 		return nil, errors.New("invalid convolution parameters: output length <= 0")
 	}
 	result := New(make([]T, params.Batch*params.OutCh*lOut))
+	if !layout.IsContiguous() || !kernelLayout.IsContiguous() {
+		kernels.NaiveConvTranspose1dStrided(
+			params.Batch,
+			params.InCh,
+			params.InLen,
+			params.OutCh,
+			params.KSize,
+			params.Stride,
+			params.Pad,
+			params.OutPad,
+			params.Dilate,
+			s.data[layout.StartOffset():],
+			kernelC.data[kernelLayout.StartOffset():],
+			result.data,
+			layout.Stride(),
+			kernelLayout.Stride(),
+			[]int{params.OutCh * lOut, lOut, 1},
+		)
+		return result, nil
+	}
 
 	switch any(s.data).(type) {
 	case []float32:
@@ -1159,8 +1205,8 @@ func (s *CpuStorage[T]) ConvTranspose1d(layout *candy.Layout, kernel candy.Backe
 			params.Pad,
 			params.OutPad,
 			params.Dilate,
-			any(s.data).([]float32),
-			any(kernelC.data).([]float32),
+			any(s.data[layout.StartOffset():]).([]float32),
+			any(kernelC.data[kernelLayout.StartOffset():]).([]float32),
 			any(result.data).([]float32),
 		)
 	case []float64:
@@ -1174,8 +1220,8 @@ func (s *CpuStorage[T]) ConvTranspose1d(layout *candy.Layout, kernel candy.Backe
 			params.Pad,
 			params.OutPad,
 			params.Dilate,
-			any(s.data).([]float64),
-			any(kernelC.data).([]float64),
+			any(s.data[layout.StartOffset():]).([]float64),
+			any(kernelC.data[kernelLayout.StartOffset():]).([]float64),
 			any(result.data).([]float64),
 		)
 	case []uint8, []uint32, []int64:
@@ -1189,8 +1235,8 @@ func (s *CpuStorage[T]) ConvTranspose1d(layout *candy.Layout, kernel candy.Backe
 			params.Pad,
 			params.OutPad,
 			params.Dilate,
-			s.data,
-			kernelC.data,
+			s.data[layout.StartOffset():],
+			kernelC.data[kernelLayout.StartOffset():],
 			result.data,
 		)
 	default:
@@ -1202,6 +1248,9 @@ func (s *CpuStorage[T]) ConvTranspose1d(layout *candy.Layout, kernel candy.Backe
 
 // Conv2d performs 2D convolution using im2col + BLAS for supported types.
 func (s *CpuStorage[T]) Conv2d(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, params *candy.Conv2DParams) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Conv2d(layout, widen(kernel), kernelLayout, params))
+	}
 	kernelC, ok := kernel.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("kernel storage must be CpuStorage")
@@ -1219,17 +1268,23 @@ func (s *CpuStorage[T]) Conv2d(layout *candy.Layout, kernel candy.BackendStorage
 		return nil, errors.New("params cannot be nil")
 	}
 
+	if err := validateConv2d(params, layout, kernelLayout); err != nil {
+		return nil, err
+	}
 	hOut := params.OutH()
 	wOut := params.OutW()
-	if hOut <= 0 || wOut <= 0 {
-		return nil, errors.New("invalid convolution parameters: output dimensions <= 0")
-	}
 
 	result := New(make([]T, params.Batch*params.OutCh*hOut*wOut))
 
-	switch any(s.data).(type) {
+	// The im2col fast paths cover the common case; everything else, including
+	// strided inputs, goes through the grouped kernel. Both write NHWC.
+	uniform := params.IsUniform() && layout.IsContiguous() && kernelLayout.IsContiguous()
+	stride, _ := params.Strides()
+	pad, _ := params.Padding()
+	dilate, _ := params.Dilations()
+	switch data := any(s.data).(type) {
 	case []float32:
-		if layout.IsContiguous() && kernelLayout.IsContiguous() {
+		if uniform {
 			kernels.Im2colConv2dF32(
 				params.Batch,
 				params.InCh,
@@ -1238,32 +1293,17 @@ func (s *CpuStorage[T]) Conv2d(layout *candy.Layout, kernel candy.BackendStorage
 				params.OutCh,
 				params.KH,
 				params.KW,
-				params.Stride,
-				params.Pad,
-				params.Dilate,
-				any(s.data).([]float32),
-				any(kernelC.data).([]float32),
-				any(result.data).([]float32),
-			)
-		} else {
-			kernels.NaiveConv2dF32(
-				params.Batch,
-				params.InCh,
-				params.InH,
-				params.InW,
-				params.OutCh,
-				params.KH,
-				params.KW,
-				params.Stride,
-				params.Pad,
-				params.Dilate,
-				any(s.data).([]float32),
-				any(kernelC.data).([]float32),
+				stride,
+				pad,
+				dilate,
+				data[layout.StartOffset():],
+				any(kernelC.data[kernelLayout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 			)
+			return result, nil
 		}
 	case []float64:
-		if layout.IsContiguous() && kernelLayout.IsContiguous() {
+		if uniform {
 			kernels.Im2colConv2dF64(
 				params.Batch,
 				params.InCh,
@@ -1272,55 +1312,125 @@ func (s *CpuStorage[T]) Conv2d(layout *candy.Layout, kernel candy.BackendStorage
 				params.OutCh,
 				params.KH,
 				params.KW,
-				params.Stride,
-				params.Pad,
-				params.Dilate,
-				any(s.data).([]float64),
-				any(kernelC.data).([]float64),
-				any(result.data).([]float64),
-			)
-		} else {
-			kernels.NaiveConv2dF64(
-				params.Batch,
-				params.InCh,
-				params.InH,
-				params.InW,
-				params.OutCh,
-				params.KH,
-				params.KW,
-				params.Stride,
-				params.Pad,
-				params.Dilate,
-				any(s.data).([]float64),
-				any(kernelC.data).([]float64),
+				stride,
+				pad,
+				dilate,
+				data[layout.StartOffset():],
+				any(kernelC.data[kernelLayout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 			)
+			return result, nil
 		}
 	case []uint8, []uint32, []int64:
-		kernels.NaiveConv2d(
-			params.Batch,
-			params.InCh,
-			params.InH,
-			params.InW,
-			params.OutCh,
-			params.KH,
-			params.KW,
-			params.Stride,
-			params.Pad,
-			params.Dilate,
-			s.data,
-			kernelC.data,
-			result.data,
-		)
 	default:
 		return nil, errors.New("unsupported data type for conv2d")
 	}
-
+	kernels.GroupedConv2d(params, s.data[layout.StartOffset():], layout.Stride(), kernelC.data[kernelLayout.StartOffset():], kernelLayout.Stride(), result.data)
 	return result, nil
 }
 
+// Conv2dBackward computes the input and kernel gradients of Conv2d from the
+// output gradient, returning them contiguous in NCHW and kernel layout.
+func (s *CpuStorage[T]) Conv2dBackward(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, grad candy.BackendStorage[T], gradLayout *candy.Layout, params *candy.Conv2DParams) (candy.BackendStorage[T], candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		dx, dw, err := widen[T](s).Conv2dBackward(layout, widen(kernel), kernelLayout, widen(grad), gradLayout, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		rdx, _ := narrow[T](dx, nil)
+		rdw, _ := narrow[T](dw, nil)
+		return rdx, rdw, nil
+	}
+	kernelC, ok := kernel.(*CpuStorage[T])
+	if !ok {
+		return nil, nil, errors.New("kernel storage must be CpuStorage")
+	}
+	gradC, ok := grad.(*CpuStorage[T])
+	if !ok {
+		return nil, nil, errors.New("grad storage must be CpuStorage")
+	}
+	if layout == nil || kernelLayout == nil || gradLayout == nil {
+		return nil, nil, errors.New("layouts cannot be nil")
+	}
+	if params == nil {
+		return nil, nil, errors.New("params cannot be nil")
+	}
+	if err := validateConv2d(params, layout, kernelLayout); err != nil {
+		return nil, nil, err
+	}
+	if err := checkConvDims("grad", gradLayout, params.OutDims()...); err != nil {
+		return nil, nil, err
+	}
+	switch any(s.data).(type) {
+	case []float32, []float64, []uint8, []uint32, []int64:
+	default:
+		return nil, nil, errors.New("unsupported data type for conv2d backward")
+	}
+	dx := New(make([]T, params.Batch*params.InCh*params.InH*params.InW))
+	dw := New(make([]T, params.OutCh*params.InCh/params.NumGroups()*params.KH*params.KW))
+	kernels.GroupedConv2dBackward(
+		params,
+		s.data[layout.StartOffset():], layout.Stride(),
+		kernelC.data[kernelLayout.StartOffset():], kernelLayout.Stride(),
+		gradC.data[gradLayout.StartOffset():], gradLayout.Stride(),
+		dx.data, dw.data,
+	)
+	return dx, dw, nil
+}
+
+// checkConvDims reports an error when layout does not have the dims a
+// convolution's parameters describe for the named operand.
+func checkConvDims(name string, layout *candy.Layout, want ...int) error {
+	if got := layout.Dims(); !slices.Equal(got, want) {
+		return fmt.Errorf("%s shape %v does not match convolution parameters %v", name, got, want)
+	}
+	return nil
+}
+
+// validateConv2d checks that the input and kernel match the parameters, the
+// output is non-empty, the groups divide the channels and the padding is
+// small enough for its mode.
+func validateConv2d(p *candy.Conv2DParams, layout, kernelLayout *candy.Layout) error {
+	sh, sw := p.Strides()
+	dh, dw := p.Dilations()
+	ph, pw := p.Padding()
+	if sh <= 0 || sw <= 0 || dh <= 0 || dw <= 0 || ph < 0 || pw < 0 {
+		return fmt.Errorf("invalid convolution parameters: stride (%d,%d), dilation (%d,%d) and padding (%d,%d)", sh, sw, dh, dw, ph, pw)
+	}
+	if p.OutH() <= 0 || p.OutW() <= 0 {
+		return errors.New("invalid convolution parameters: output dimensions <= 0")
+	}
+	if g := p.NumGroups(); p.InCh%g != 0 || p.OutCh%g != 0 {
+		return fmt.Errorf("invalid convolution parameters: %d groups do not divide %d input and %d output channels", g, p.InCh, p.OutCh)
+	}
+	if err := checkConvDims("input", layout, p.Batch, p.InCh, p.InH, p.InW); err != nil {
+		return err
+	}
+	if err := checkConvDims("kernel", kernelLayout, p.OutCh, p.InCh/p.NumGroups(), p.KH, p.KW); err != nil {
+		return err
+	}
+	switch p.PadMode {
+	case candy.PadZeros:
+	case candy.PadReflect:
+		if ph >= p.InH || pw >= p.InW {
+			return fmt.Errorf("reflect padding (%d,%d) must be smaller than the input (%d,%d)", ph, pw, p.InH, p.InW)
+		}
+	case candy.PadReplicate:
+	case candy.PadCircular:
+		if ph > p.InH || pw > p.InW {
+			return fmt.Errorf("circular padding (%d,%d) must not exceed the input (%d,%d)", ph, pw, p.InH, p.InW)
+		}
+	default:
+		return fmt.Errorf("unsupported padding mode %v", p.PadMode)
+	}
+	return nil
+}
+
 // ConvTranspose2d performs 2D transposed convolution (deconvolution) for supported types.
 func (s *CpuStorage[T]) ConvTranspose2d(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, params *candy.ConvT2DParams) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).ConvTranspose2d(layout, widen(kernel), kernelLayout, params))
+	}
 	kernelC, ok := kernel.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("kernel storage must be CpuStorage")
@@ -1345,6 +1455,28 @@ func (s *CpuStorage[T]) ConvTranspose2d(layout *candy.Layout, kernel candy.Backe
 	}
 
 	result := New(make([]T, params.Batch*params.OutCh*hOut*wOut))
+	if !layout.IsContiguous() || !kernelLayout.IsContiguous() {
+		kernels.NaiveConvTranspose2dStrided(
+			params.Batch,
+			params.InCh,
+			params.InH,
+			params.InW,
+			params.OutCh,
+			params.KH,
+			params.KW,
+			params.Stride,
+			params.Pad,
+			params.OutPad,
+			params.Dilate,
+			s.data[layout.StartOffset():],
+			kernelC.data[kernelLayout.StartOffset():],
+			result.data,
+			layout.Stride(),
+			kernelLayout.Stride(),
+			[]int{params.OutCh * hOut * wOut, hOut * wOut, wOut, 1},
+		)
+		return result, nil
+	}
 
 	switch any(s.data).(type) {
 	case []float32:
@@ -1360,8 +1492,8 @@ func (s *CpuStorage[T]) ConvTranspose2d(layout *candy.Layout, kernel candy.Backe
 			params.Pad,
 			params.OutPad,
 			params.Dilate,
-			any(s.data).([]float32),
-			any(kernelC.data).([]float32),
+			any(s.data[layout.StartOffset():]).([]float32),
+			any(kernelC.data[kernelLayout.StartOffset():]).([]float32),
 			any(result.data).([]float32),
 		)
 	case []float64:
@@ -1377,8 +1509,8 @@ func (s *CpuStorage[T]) ConvTranspose2d(layout *candy.Layout, kernel candy.Backe
 			params.Pad,
 			params.OutPad,
 			params.Dilate,
-			any(s.data).([]float64),
-			any(kernelC.data).([]float64),
+			any(s.data[layout.StartOffset():]).([]float64),
+			any(kernelC.data[kernelLayout.StartOffset():]).([]float64),
 			any(result.data).([]float64),
 		)
 	case []uint8, []uint32, []int64:
@@ -1394,8 +1526,8 @@ func (s *CpuStorage[T]) ConvTranspose2d(layout *candy.Layout, kernel candy.Backe
 			params.Pad,
 			params.OutPad,
 			params.Dilate,
-			s.data,
-			kernelC.data,
+			s.data[layout.StartOffset():],
+			kernelC.data[kernelLayout.StartOffset():],
 			result.data,
 		)
 	default:
@@ -1407,6 +1539,9 @@ func (s *CpuStorage[T]) ConvTranspose2d(layout *candy.Layout, kernel candy.Backe
 
 // AvgPool2d performs 2D average pooling for supported types.
 func (s *CpuStorage[T]) AvgPool2d(layout *candy.Layout, kH, kW, sH, sW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).AvgPool2d(layout, kH, kW, sH, sW))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -1439,14 +1574,14 @@ func (s *CpuStorage[T]) AvgPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 				kW, // kernel width
 				sH, // stride height
 				sW, // stride width
-				any(s.data).([]float32),
+				any(s.data[layout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 			)
 		} else {
 			kernels.AvgPool2dStridedF32(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				any(s.data).([]float32),
+				any(s.data[layout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 				layout.Stride(),
 				dstStrides,
@@ -1457,14 +1592,14 @@ func (s *CpuStorage[T]) AvgPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 			kernels.AvgPool2dF64(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				any(s.data).([]float64),
+				any(s.data[layout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 			)
 		} else {
 			kernels.AvgPool2dStridedF64(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				any(s.data).([]float64),
+				any(s.data[layout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 				layout.Stride(),
 				dstStrides,
@@ -1475,14 +1610,14 @@ func (s *CpuStorage[T]) AvgPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 			kernels.AvgPool2d(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				s.data,
+				s.data[layout.StartOffset():],
 				result.data,
 			)
 		} else {
 			kernels.AvgPool2dStrided(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				s.data,
+				s.data[layout.StartOffset():],
 				result.data,
 				layout.Stride(),
 				dstStrides,
@@ -1497,6 +1632,9 @@ func (s *CpuStorage[T]) AvgPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 
 // MaxPool2d performs 2D max pooling for supported types.
 func (s *CpuStorage[T]) MaxPool2d(layout *candy.Layout, kH, kW, sH, sW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).MaxPool2d(layout, kH, kW, sH, sW))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -1533,14 +1671,14 @@ func (s *CpuStorage[T]) MaxPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 				kW, // kernel width
 				sH, // stride height
 				sW, // stride width
-				any(s.data).([]float32),
+				any(s.data[layout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 			)
 		} else {
 			kernels.MaxPool2dStridedF32(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				any(s.data).([]float32),
+				any(s.data[layout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 				layout.Stride(),
 				dstStrides,
@@ -1551,14 +1689,14 @@ func (s *CpuStorage[T]) MaxPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 			kernels.MaxPool2dF64(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				any(s.data).([]float64),
+				any(s.data[layout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 			)
 		} else {
 			kernels.MaxPool2dStridedF64(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				any(s.data).([]float64),
+				any(s.data[layout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 				layout.Stride(),
 				dstStrides,
@@ -1569,14 +1707,14 @@ func (s *CpuStorage[T]) MaxPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 			kernels.MaxPool2d(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				s.data,
+				s.data[layout.StartOffset():],
 				result.data,
 			)
 		} else {
 			kernels.MaxPool2dStrided(
 				n, c, h, w,
 				kH, kW, sH, sW,
-				s.data,
+				s.data[layout.StartOffset():],
 				result.data,
 				layout.Stride(),
 				dstStrides,
@@ -1591,6 +1729,9 @@ func (s *CpuStorage[T]) MaxPool2d(layout *candy.Layout, kH, kW, sH, sW int) (can
 
 // UpsampleNearest2d performs 2D nearest neighbor upsampling for supported types.
 func (s *CpuStorage[T]) UpsampleNearest2d(layout *candy.Layout, targetH, targetW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).UpsampleNearest2d(layout, targetH, targetW))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -1619,14 +1760,14 @@ func (s *CpuStorage[T]) UpsampleNearest2d(layout *candy.Layout, targetH, targetW
 				targetW, // target width
 				scaleH,  // height scale
 				scaleW,  // width scale
-				any(s.data).([]float32),
+				any(s.data[layout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 			)
 		} else {
 			kernels.UpsampleNearest2dStridedF32(
 				b, c, srcH, srcW,
 				targetH, targetW, scaleH, scaleW,
-				any(s.data).([]float32),
+				any(s.data[layout.StartOffset():]).([]float32),
 				any(result.data).([]float32),
 				layout.Stride(),
 				dstStrides,
@@ -1637,14 +1778,14 @@ func (s *CpuStorage[T]) UpsampleNearest2d(layout *candy.Layout, targetH, targetW
 			kernels.UpsampleNearest2dF64(
 				b, c, srcH, srcW,
 				targetH, targetW, scaleH, scaleW,
-				any(s.data).([]float64),
+				any(s.data[layout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 			)
 		} else {
 			kernels.UpsampleNearest2dStridedF64(
 				b, c, srcH, srcW,
 				targetH, targetW, scaleH, scaleW,
-				any(s.data).([]float64),
+				any(s.data[layout.StartOffset():]).([]float64),
 				any(result.data).([]float64),
 				layout.Stride(),
 				dstStrides,
@@ -1655,14 +1796,14 @@ func (s *CpuStorage[T]) UpsampleNearest2d(layout *candy.Layout, targetH, targetW
 			kernels.UpsampleNearest2d(
 				b, c, srcH, srcW,
 				targetH, targetW, scaleH, scaleW,
-				s.data,
+				s.data[layout.StartOffset():],
 				result.data,
 			)
 		} else {
 			kernels.UpsampleNearest2dStrided(
 				b, c, srcH, srcW,
 				targetH, targetW, scaleH, scaleW,
-				s.data,
+				s.data[layout.StartOffset():],
 				result.data,
 				layout.Stride(),
 				dstStrides,
@@ -1675,6 +1816,290 @@ func (s *CpuStorage[T]) UpsampleNearest2d(layout *candy.Layout, targetH, targetW
 	return result, nil
 }
 
+// Conv3d performs 3D convolution using vol2col + BLAS for supported types.
+// The output is written in NDHWC order.
+func (s *CpuStorage[T]) Conv3d(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, params *candy.Conv3DParams) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Conv3d(layout, widen(kernel), kernelLayout, params))
+	}
+	kernelC, ok := kernel.(*CpuStorage[T])
+	if !ok {
+		return nil, errors.New("kernel storage must be CpuStorage")
+	}
+	if layout == nil || kernelLayout == nil {
+		return nil, errors.New("layouts cannot be nil")
+	}
+	if params == nil {
+		return nil, errors.New("params cannot be nil")
+	}
+	if err := validateConv3d(params, layout, kernelLayout); err != nil {
+		return nil, err
+	}
+	switch any(s.data).(type) {
+	case []float32, []float64, []uint8, []uint32, []int64:
+	default:
+		return nil, errors.New("unsupported data type for conv3d")
+	}
+	result := New(make([]T, params.Batch*params.OutCh*params.OutD()*params.OutH()*params.OutW()))
+	kernels.Conv3d(
+		params,
+		s.data[layout.StartOffset():], layout.Stride(),
+		kernelC.data[kernelLayout.StartOffset():], kernelLayout.Stride(),
+		result.data,
+	)
+	return result, nil
+}
+
+// Conv3dBackward computes the input and kernel gradients of Conv3d from the output gradient.
+func (s *CpuStorage[T]) Conv3dBackward(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, grad candy.BackendStorage[T], gradLayout *candy.Layout, params *candy.Conv3DParams) (candy.BackendStorage[T], candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		dx, dw, err := widen[T](s).Conv3dBackward(layout, widen(kernel), kernelLayout, widen(grad), gradLayout, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		rdx, _ := narrow[T](dx, nil)
+		rdw, _ := narrow[T](dw, nil)
+		return rdx, rdw, nil
+	}
+	kernelC, ok := kernel.(*CpuStorage[T])
+	if !ok {
+		return nil, nil, errors.New("kernel storage must be CpuStorage")
+	}
+	gradC, ok := grad.(*CpuStorage[T])
+	if !ok {
+		return nil, nil, errors.New("grad storage must be CpuStorage")
+	}
+	if layout == nil || kernelLayout == nil || gradLayout == nil {
+		return nil, nil, errors.New("layouts cannot be nil")
+	}
+	if params == nil {
+		return nil, nil, errors.New("params cannot be nil")
+	}
+	if err := validateConv3d(params, layout, kernelLayout); err != nil {
+		return nil, nil, err
+	}
+	if err := checkConvDims("grad", gradLayout, params.OutDims()...); err != nil {
+		return nil, nil, err
+	}
+	switch any(s.data).(type) {
+	case []float32, []float64, []uint8, []uint32, []int64:
+	default:
+		return nil, nil, errors.New("unsupported data type for conv3d backward")
+	}
+	dx := New(make([]T, params.Batch*params.InCh*params.InD*params.InH*params.InW))
+	dw := New(make([]T, params.OutCh*params.InCh/params.NumGroups()*params.KD*params.KH*params.KW))
+	kernels.Conv3dBackward(
+		params,
+		s.data[layout.StartOffset():], layout.Stride(),
+		kernelC.data[kernelLayout.StartOffset():], kernelLayout.Stride(),
+		gradC.data[gradLayout.StartOffset():], gradLayout.Stride(),
+		dx.data, dw.data,
+	)
+	return dx, dw, nil
+}
+
+// validateConv3d checks that the input and kernel match the parameters, the
+// output is non-empty and the groups divide the channels.
+func validateConv3d(p *candy.Conv3DParams, layout, kernelLayout *candy.Layout) error {
+	sd, sh, sw := p.Strides()
+	dd, dh, dw := p.Dilations()
+	pd, ph, pw := p.Padding()
+	if sd <= 0 || sh <= 0 || sw <= 0 || dd <= 0 || dh <= 0 || dw <= 0 || pd < 0 || ph < 0 || pw < 0 {
+		return fmt.Errorf("invalid convolution parameters: stride (%d,%d,%d), dilation (%d,%d,%d) and padding (%d,%d,%d)", sd, sh, sw, dd, dh, dw, pd, ph, pw)
+	}
+	if p.OutD() <= 0 || p.OutH() <= 0 || p.OutW() <= 0 {
+		return errors.New("invalid convolution parameters: output dimensions <= 0")
+	}
+	if g := p.NumGroups(); p.InCh%g != 0 || p.OutCh%g != 0 {
+		return fmt.Errorf("invalid convolution parameters: %d groups do not divide %d input and %d output channels", g, p.InCh, p.OutCh)
+	}
+	if err := checkConvDims("input", layout, p.Batch, p.InCh, p.InD, p.InH, p.InW); err != nil {
+		return err
+	}
+	return checkConvDims("kernel", kernelLayout, p.OutCh, p.InCh/p.NumGroups(), p.KD, p.KH, p.KW)
+}
+
+// ConvTranspose3d performs 3D transposed convolution (deconvolution) for supported types.
+func (s *CpuStorage[T]) ConvTranspose3d(layout *candy.Layout, kernel candy.BackendStorage[T], kernelLayout *candy.Layout, params *candy.ConvT3DParams) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).ConvTranspose3d(layout, widen(kernel), kernelLayout, params))
+	}
+	kernelC, ok := kernel.(*CpuStorage[T])
+	if !ok {
+		return nil, errors.New("kernel storage must be CpuStorage")
+	}
+	if layout == nil || kernelLayout == nil {
+		return nil, errors.New("layouts cannot be nil")
+	}
+	if params == nil {
+		return nil, errors.New("params cannot be nil")
+	}
+	if params.Stride <= 0 || params.Dilate <= 0 || params.Pad < 0 {
+		return nil, fmt.Errorf("invalid convolution parameters: stride %d, dilation %d and padding %d", params.Stride, params.Dilate, params.Pad)
+	}
+	if params.OutPad < 0 || params.OutPad >= params.Stride {
+		return nil, fmt.Errorf("output padding %d must be non-negative and smaller than stride %d", params.OutPad, params.Stride)
+	}
+	if params.OutD() <= 0 || params.OutH() <= 0 || params.OutW() <= 0 {
+		return nil, errors.New("invalid convolution parameters: output dimensions <= 0")
+	}
+	if err := checkConvDims("input", layout, params.Batch, params.InCh, params.InD, params.InH, params.InW); err != nil {
+		return nil, err
+	}
+	if err := checkConvDims("kernel", kernelLayout, params.InCh, params.OutCh, params.KD, params.KH, params.KW); err != nil {
+		return nil, err
+	}
+	switch any(s.data).(type) {
+	case []float32, []float64, []uint8, []uint32, []int64:
+	default:
+		return nil, errors.New("unsupported data type for conv_transpose3d")
+	}
+	result := New(make([]T, params.Batch*params.OutCh*params.OutD()*params.OutH()*params.OutW()))
+	kernels.ConvTranspose3d(
+		params,
+		s.data[layout.StartOffset():], layout.Stride(),
+		kernelC.data[kernelLayout.StartOffset():], kernelLayout.Stride(),
+		result.data,
+	)
+	return result, nil
+}
+
+// pool3dDims validates a 3D pooling of the input described by layout and
+// returns its dimensions.
+func pool3dDims(layout *candy.Layout, kD, kH, kW, sD, sH, sW int) (n, c, d, h, w int, err error) {
+	if layout == nil {
+		return 0, 0, 0, 0, 0, errors.New("layout cannot be nil")
+	}
+	n, c, d, h, w, err = layout.Dims5()
+	if err != nil {
+		return 0, 0, 0, 0, 0, fmt.Errorf("expected 5D tensor for 3d pooling, got: %w", err)
+	}
+	if kD <= 0 || kH <= 0 || kW <= 0 || sD <= 0 || sH <= 0 || sW <= 0 {
+		return 0, 0, 0, 0, 0, errors.New("kernel and stride must be positive")
+	}
+	if d < kD || h < kH || w < kW {
+		return 0, 0, 0, 0, 0, fmt.Errorf("kernel size (%d,%d,%d) is larger than input size (%d,%d,%d)", kD, kH, kW, d, h, w)
+	}
+	return n, c, d, h, w, nil
+}
+
+// AvgPool3d performs 3D average pooling for supported types.
+func (s *CpuStorage[T]) AvgPool3d(layout *candy.Layout, kD, kH, kW, sD, sH, sW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).AvgPool3d(layout, kD, kH, kW, sD, sH, sW))
+	}
+	n, c, d, h, w, err := pool3dDims(layout, kD, kH, kW, sD, sH, sW)
+	if err != nil {
+		return nil, err
+	}
+	result := New(make([]T, n*c*((d-kD)/sD+1)*((h-kH)/sH+1)*((w-kW)/sW+1)))
+	kernels.AvgPool3d(n, c, d, h, w, kD, kH, kW, sD, sH, sW, s.data[layout.StartOffset():], layout.Stride(), result.data)
+	return result, nil
+}
+
+// AvgPool3dBackward spreads the output gradient of AvgPool3d over the input described by layout.
+func (s *CpuStorage[T]) AvgPool3dBackward(layout *candy.Layout, grad candy.BackendStorage[T], gradLayout *candy.Layout, kD, kH, kW, sD, sH, sW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).AvgPool3dBackward(layout, widen(grad), gradLayout, kD, kH, kW, sD, sH, sW))
+	}
+	gradC, ok := grad.(*CpuStorage[T])
+	if !ok {
+		return nil, errors.New("grad storage must be CpuStorage")
+	}
+	n, c, d, h, w, err := pool3dDims(layout, kD, kH, kW, sD, sH, sW)
+	if err != nil {
+		return nil, err
+	}
+	if gradLayout == nil {
+		return nil, errors.New("gradLayout cannot be nil")
+	}
+	dx := New(make([]T, n*c*d*h*w))
+	kernels.AvgPool3dBackward(n, c, d, h, w, kD, kH, kW, sD, sH, sW, gradC.data[gradLayout.StartOffset():], gradLayout.Stride(), dx.data)
+	return dx, nil
+}
+
+// MaxPool3d performs 3D max pooling for supported types.
+func (s *CpuStorage[T]) MaxPool3d(layout *candy.Layout, kD, kH, kW, sD, sH, sW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).MaxPool3d(layout, kD, kH, kW, sD, sH, sW))
+	}
+	n, c, d, h, w, err := pool3dDims(layout, kD, kH, kW, sD, sH, sW)
+	if err != nil {
+		return nil, err
+	}
+	result := New(make([]T, n*c*((d-kD)/sD+1)*((h-kH)/sH+1)*((w-kW)/sW+1)))
+	kernels.MaxPool3d(n, c, d, h, w, kD, kH, kW, sD, sH, sW, s.data[layout.StartOffset():], layout.Stride(), result.data)
+	return result, nil
+}
+
+// MaxPool3dBackward routes the output gradient of MaxPool3d to the first maximum of each window.
+func (s *CpuStorage[T]) MaxPool3dBackward(layout *candy.Layout, grad candy.BackendStorage[T], gradLayout *candy.Layout, kD, kH, kW, sD, sH, sW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).MaxPool3dBackward(layout, widen(grad), gradLayout, kD, kH, kW, sD, sH, sW))
+	}
+	gradC, ok := grad.(*CpuStorage[T])
+	if !ok {
+		return nil, errors.New("grad storage must be CpuStorage")
+	}
+	n, c, d, h, w, err := pool3dDims(layout, kD, kH, kW, sD, sH, sW)
+	if err != nil {
+		return nil, err
+	}
+	if gradLayout == nil {
+		return nil, errors.New("gradLayout cannot be nil")
+	}
+	dx := New(make([]T, n*c*d*h*w))
+	kernels.MaxPool3dBackward(n, c, d, h, w, kD, kH, kW, sD, sH, sW,
+		s.data[layout.StartOffset():], layout.Stride(),
+		gradC.data[gradLayout.StartOffset():], gradLayout.Stride(),
+		dx.data)
+	return dx, nil
+}
+
+// UpsampleNearest3d performs 3D nearest neighbor upsampling for supported types.
+func (s *CpuStorage[T]) UpsampleNearest3d(layout *candy.Layout, targetD, targetH, targetW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).UpsampleNearest3d(layout, targetD, targetH, targetW))
+	}
+	if layout == nil {
+		return nil, errors.New("layout cannot be nil")
+	}
+	b, c, srcD, srcH, srcW, err := layout.Dims5()
+	if err != nil {
+		return nil, fmt.Errorf("expected 5D tensor for upsample_nearest3d, got: %w", err)
+	}
+	if targetD <= 0 || targetH <= 0 || targetW <= 0 {
+		return nil, fmt.Errorf("target dimensions must be positive, got (%d,%d,%d)", targetD, targetH, targetW)
+	}
+	result := New(make([]T, b*c*targetD*targetH*targetW))
+	kernels.UpsampleNearest3d(b, c, srcD, srcH, srcW, targetD, targetH, targetW, s.data[layout.StartOffset():], layout.Stride(), result.data)
+	return result, nil
+}
+
+// UpsampleNearest3dBackward sums the output gradient of UpsampleNearest3d into the input described by layout.
+func (s *CpuStorage[T]) UpsampleNearest3dBackward(layout *candy.Layout, grad candy.BackendStorage[T], gradLayout *candy.Layout, targetD, targetH, targetW int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).UpsampleNearest3dBackward(layout, widen(grad), gradLayout, targetD, targetH, targetW))
+	}
+	gradC, ok := grad.(*CpuStorage[T])
+	if !ok {
+		return nil, errors.New("grad storage must be CpuStorage")
+	}
+	if layout == nil || gradLayout == nil {
+		return nil, errors.New("layouts cannot be nil")
+	}
+	b, c, srcD, srcH, srcW, err := layout.Dims5()
+	if err != nil {
+		return nil, fmt.Errorf("expected 5D tensor for upsample_nearest3d, got: %w", err)
+	}
+	if targetD <= 0 || targetH <= 0 || targetW <= 0 {
+		return nil, fmt.Errorf("target dimensions must be positive, got (%d,%d,%d)", targetD, targetH, targetW)
+	}
+	dx := New(make([]T, b*c*srcD*srcH*srcW))
+	kernels.UpsampleNearest3dBackward(b, c, srcD, srcH, srcW, targetD, targetH, targetW, gradC.data[gradLayout.StartOffset():], gradLayout.Stride(), dx.data)
+	return dx, nil
+}
+
 // ConstSet sets all elements to a constant value for supported types.
 func (s *CpuStorage[T]) ConstSet(layout *candy.Layout, val T) error {
 	if layout == nil {
@@ -1692,7 +2117,7 @@ func (s *CpuStorage[T]) ConstSet(layout *candy.Layout, val T) error {
 		layout.Dims(),
 		layout.Stride(),
 		val,
-		s.data,
+		s.data[layout.StartOffset():],
 	)
 
 	return nil
@@ -1700,6 +2125,9 @@ func (s *CpuStorage[T]) ConstSet(layout *candy.Layout, val T) error {
 
 // Gather performs gather operation along a specified dimension with same-type indices
 func (s *CpuStorage[T]) Gather(layout *candy.Layout, ids candy.BackendStorage[T], idsLayout *candy.Layout, dim int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Gather(layout, widen(ids), idsLayout, dim))
+	}
 	if layout == nil || idsLayout == nil {
 		return nil, errors.New("layout and idsLayout cannot be nil")
 	}
@@ -1712,6 +2140,15 @@ func (s *CpuStorage[T]) Gather(layout *candy.Layout, ids candy.BackendStorage[T]
 		return nil, errors.New("ids storage must be CpuStorage")
 	}
 
+	xData, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
+	}
+	idsData, idsLayout, err := idsC.contiguous(idsLayout)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate dimensions
 	srcDims := layout.Dims()
 	idsDims := idsLayout.Dims()
@@ -1748,15 +2185,15 @@ func (s *CpuStorage[T]) Gather(layout *candy.Layout, ids candy.BackendStorage[T]
 	// Call kernel based on type
 	switch any(s.data).(type) {
 	case []float32:
-		kernels.GatherF32F32(numel, any(idsC.data).([]float32), any(s.data).([]float32), any(result.data).([]float32), leftSize, srcDimSize, idsDimSize, rightSize)
+		kernels.GatherF32F32(numel, any(idsData).([]float32), any(xData).([]float32), any(result.data).([]float32), leftSize, srcDimSize, idsDimSize, rightSize)
 	case []float64:
-		kernels.GatherF64F64(numel, any(idsC.data).([]float64), any(s.data).([]float64), any(result.data).([]float64), leftSize, srcDimSize, idsDimSize, rightSize)
+		kernels.GatherF64F64(numel, any(idsData).([]float64), any(xData).([]float64), any(result.data).([]float64), leftSize, srcDimSize, idsDimSize, rightSize)
 	case []uint8:
-		kernels.GatherU8U8(numel, any(idsC.data).([]uint8), any(s.data).([]uint8), any(result.data).([]uint8), leftSize, srcDimSize, idsDimSize, rightSize)
+		kernels.GatherU8U8(numel, any(idsData).([]uint8), any(xData).([]uint8), any(result.data).([]uint8), leftSize, srcDimSize, idsDimSize, rightSize)
 	case []uint32:
-		kernels.GatherU32U32(numel, any(idsC.data).([]uint32), any(s.data).([]uint32), any(result.data).([]uint32), leftSize, srcDimSize, idsDimSize, rightSize)
+		kernels.GatherU32U32(numel, any(idsData).([]uint32), any(xData).([]uint32), any(result.data).([]uint32), leftSize, srcDimSize, idsDimSize, rightSize)
 	case []int64:
-		kernels.GatherI64I64(numel, any(idsC.data).([]int64), any(s.data).([]int64), any(result.data).([]int64), leftSize, srcDimSize, idsDimSize, rightSize)
+		kernels.GatherI64I64(numel, any(idsData).([]int64), any(xData).([]int64), any(result.data).([]int64), leftSize, srcDimSize, idsDimSize, rightSize)
 	default:
 		return nil, errors.New("unsupported data type for Gather")
 	}
@@ -1766,6 +2203,9 @@ func (s *CpuStorage[T]) Gather(layout *candy.Layout, ids candy.BackendStorage[T]
 
 // Scatter performs scatter operation along a specified dimension with same-type indices
 func (s *CpuStorage[T]) Scatter(layout *candy.Layout, ids candy.BackendStorage[T], idsLayout *candy.Layout, src candy.BackendStorage[T], srcLayout *candy.Layout, dim int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Scatter(layout, widen(ids), idsLayout, widen(src), srcLayout, dim))
+	}
 	if layout == nil || idsLayout == nil || srcLayout == nil {
 		return nil, errors.New("layouts cannot be nil")
 	}
@@ -1783,6 +2223,19 @@ func (s *CpuStorage[T]) Scatter(layout *candy.Layout, ids candy.BackendStorage[T
 		return nil, errors.New("src storage must be CpuStorage")
 	}
 
+	xData, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
+	}
+	idsData, idsLayout, err := idsC.contiguous(idsLayout)
+	if err != nil {
+		return nil, err
+	}
+	srcData, srcLayout, err := srcC.contiguous(srcLayout)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate dimensions
 	dstDims := layout.Dims()
 	srcDims := srcLayout.Dims()
@@ -1796,7 +2249,7 @@ func (s *CpuStorage[T]) Scatter(layout *candy.Layout, ids candy.BackendStorage[T
 	result := New(make([]T, numel))
 
 	// Copy destination data to result first
-	copy(result.data, s.data)
+	copy(result.data, xData)
 
 	// Calculate parameters
 	leftSize := 1
@@ -1815,15 +2268,15 @@ func (s *CpuStorage[T]) Scatter(layout *candy.Layout, ids candy.BackendStorage[T
 	// Call kernel based on type
 	switch any(s.data).(type) {
 	case []float32:
-		kernels.ScatterF32F32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]float32), any(srcC.data).([]float32), any(result.data).([]float32))
+		kernels.ScatterF32F32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]float32), any(srcData).([]float32), any(result.data).([]float32))
 	case []float64:
-		kernels.ScatterF64F64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]float64), any(srcC.data).([]float64), any(result.data).([]float64))
+		kernels.ScatterF64F64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]float64), any(srcData).([]float64), any(result.data).([]float64))
 	case []uint8:
-		kernels.ScatterU8U8(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]uint8), any(srcC.data).([]uint8), any(result.data).([]uint8))
+		kernels.ScatterU8U8(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]uint8), any(srcData).([]uint8), any(result.data).([]uint8))
 	case []uint32:
-		kernels.ScatterU32U32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]uint32), any(srcC.data).([]uint32), any(result.data).([]uint32))
+		kernels.ScatterU32U32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]uint32), any(srcData).([]uint32), any(result.data).([]uint32))
 	case []int64:
-		kernels.ScatterI64I64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]int64), any(srcC.data).([]int64), any(result.data).([]int64))
+		kernels.ScatterI64I64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]int64), any(srcData).([]int64), any(result.data).([]int64))
 	default:
 		return nil, errors.New("unsupported data type for Scatter")
 	}
@@ -1833,6 +2286,9 @@ func (s *CpuStorage[T]) Scatter(layout *candy.Layout, ids candy.BackendStorage[T
 
 // ScatterAdd performs scatter-add operation along a specified dimension with same-type indices
 func (s *CpuStorage[T]) ScatterAdd(layout *candy.Layout, ids candy.BackendStorage[T], idsLayout *candy.Layout, src candy.BackendStorage[T], srcLayout *candy.Layout, dim int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).ScatterAdd(layout, widen(ids), idsLayout, widen(src), srcLayout, dim))
+	}
 	if layout == nil || idsLayout == nil || srcLayout == nil {
 		return nil, errors.New("layouts cannot be nil")
 	}
@@ -1850,6 +2306,19 @@ func (s *CpuStorage[T]) ScatterAdd(layout *candy.Layout, ids candy.BackendStorag
 		return nil, errors.New("src storage must be CpuStorage")
 	}
 
+	xData, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
+	}
+	idsData, idsLayout, err := idsC.contiguous(idsLayout)
+	if err != nil {
+		return nil, err
+	}
+	srcData, srcLayout, err := srcC.contiguous(srcLayout)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate dimensions
 	dstDims := layout.Dims()
 	srcDims := srcLayout.Dims()
@@ -1863,7 +2332,7 @@ func (s *CpuStorage[T]) ScatterAdd(layout *candy.Layout, ids candy.BackendStorag
 	result := New(make([]T, numel))
 
 	// Copy destination data to result first
-	copy(result.data, s.data)
+	copy(result.data, xData)
 
 	// Calculate parameters
 	leftSize := 1
@@ -1882,15 +2351,15 @@ func (s *CpuStorage[T]) ScatterAdd(layout *candy.Layout, ids candy.BackendStorag
 	// Call kernel based on type
 	switch any(s.data).(type) {
 	case []float32:
-		kernels.ScatterAddF32F32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]float32), any(srcC.data).([]float32), any(result.data).([]float32))
+		kernels.ScatterAddF32F32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]float32), any(srcData).([]float32), any(result.data).([]float32))
 	case []float64:
-		kernels.ScatterAddF64F64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]float64), any(srcC.data).([]float64), any(result.data).([]float64))
+		kernels.ScatterAddF64F64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]float64), any(srcData).([]float64), any(result.data).([]float64))
 	case []uint8:
-		kernels.ScatterAddU8U8(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]uint8), any(srcC.data).([]uint8), any(result.data).([]uint8))
+		kernels.ScatterAddU8U8(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]uint8), any(srcData).([]uint8), any(result.data).([]uint8))
 	case []uint32:
-		kernels.ScatterAddU32U32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]uint32), any(srcC.data).([]uint32), any(result.data).([]uint32))
+		kernels.ScatterAddU32U32(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]uint32), any(srcData).([]uint32), any(result.data).([]uint32))
 	case []int64:
-		kernels.ScatterAddI64I64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsC.data).([]int64), any(srcC.data).([]int64), any(result.data).([]int64))
+		kernels.ScatterAddI64I64(leftSize, srcDimSize, dstDimSize, rightSize, any(idsData).([]int64), any(srcData).([]int64), any(result.data).([]int64))
 	default:
 		return nil, errors.New("unsupported data type for ScatterAdd")
 	}
@@ -1898,6 +2367,290 @@ func (s *CpuStorage[T]) ScatterAdd(layout *candy.Layout, ids candy.BackendStorag
 	return result, nil
 }
 
+// ArgsortU32 returns uint32 indices that sort each row along the last dimension
+func (s *CpuStorage[T]) ArgsortU32(layout *candy.Layout, descending bool) (candy.BackendStorage[uint32], error) {
+	if s.isHalf() {
+		return widen[T](s).ArgsortU32(layout, descending)
+	}
+	if layout == nil {
+		return nil, errors.New("layout cannot be nil")
+	}
+	if layout.Rank() == 0 {
+		return nil, errors.New("cannot sort scalar tensor")
+	}
+	src, err := s.Copy(layout, s)
+	if err != nil {
+		return nil, err
+	}
+	srcC := src.(*CpuStorage[T])
+
+	result := New(make([]uint32, layout.Numel()))
+	ncols := layout.Dims()[layout.Rank()-1]
+	if ncols == 0 {
+		return result, nil
+	}
+	if descending {
+		kernels.AsortDesc(ncols, srcC.data, result.data)
+	} else {
+		kernels.AsortAsc(ncols, srcC.data, result.data)
+	}
+
+	return result, nil
+}
+
+// IndexSelect selects slices along a dimension using a 1D index storage of type uint8, uint32 or int64
+func (s *CpuStorage[T]) IndexSelect(layout *candy.Layout, ids any, idsLayout *candy.Layout, dim int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).IndexSelect(layout, ids, idsLayout, dim))
+	}
+	if layout == nil || idsLayout == nil {
+		return nil, errors.New("layout and idsLayout cannot be nil")
+	}
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
+	}
+	if idsLayout.Rank() != 1 || !idsLayout.IsContiguous() {
+		return nil, fmt.Errorf("ids must be a contiguous 1D layout, got %v", idsLayout)
+	}
+	ids, idsLayout, err := exactIds(ids, idsLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	dims := layout.Dims()
+	stride := layout.Stride()
+	if dim < 0 || dim >= len(dims) {
+		return nil, fmt.Errorf("dimension %d out of range", dim)
+	}
+
+	leftSize := 1
+	for i := range dim {
+		leftSize *= dims[i]
+	}
+	srcDimSize := dims[dim]
+	rightSize := 1
+	for i := dim + 1; i < len(dims); i++ {
+		rightSize *= dims[i]
+	}
+	idsDimSize := idsLayout.Numel()
+	numel := leftSize * idsDimSize * rightSize
+	result := New(make([]T, numel))
+
+	switch idsC := ids.(type) {
+	case *CpuStorage[uint8]:
+		if err := checkIndices(idsC.data, idsLayout, srcDimSize); err != nil {
+			return nil, err
+		}
+		switch any(s.data).(type) {
+		case []float32:
+			kernels.IndexSelectStridedU8F32(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]float32), any(result.data).([]float32), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []float64:
+			kernels.IndexSelectStridedU8F64(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]float64), any(result.data).([]float64), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []uint8:
+			kernels.IndexSelectStridedU8U8(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]uint8), any(result.data).([]uint8), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []uint32:
+			kernels.IndexSelectStridedU8U32(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]uint32), any(result.data).([]uint32), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []int64:
+			kernels.IndexSelectStridedU8I64(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]int64), any(result.data).([]int64), leftSize, srcDimSize, idsDimSize, rightSize)
+		default:
+			return nil, errors.New("unsupported data type for IndexSelect")
+		}
+	case *CpuStorage[uint32]:
+		if err := checkIndices(idsC.data, idsLayout, srcDimSize); err != nil {
+			return nil, err
+		}
+		switch any(s.data).(type) {
+		case []float32:
+			kernels.IndexSelectStridedU32F32(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]float32), any(result.data).([]float32), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []float64:
+			kernels.IndexSelectStridedU32F64(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]float64), any(result.data).([]float64), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []uint8:
+			kernels.IndexSelectStridedU32U8(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]uint8), any(result.data).([]uint8), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []uint32:
+			kernels.IndexSelectStridedU32U32(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]uint32), any(result.data).([]uint32), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []int64:
+			kernels.IndexSelectStridedU32I64(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]int64), any(result.data).([]int64), leftSize, srcDimSize, idsDimSize, rightSize)
+		default:
+			return nil, errors.New("unsupported data type for IndexSelect")
+		}
+	case *CpuStorage[int64]:
+		if err := checkIndices(idsC.data, idsLayout, srcDimSize); err != nil {
+			return nil, err
+		}
+		switch any(s.data).(type) {
+		case []float32:
+			kernels.IndexSelectStridedI64F32(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]float32), any(result.data).([]float32), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []float64:
+			kernels.IndexSelectStridedI64F64(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]float64), any(result.data).([]float64), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []uint8:
+			kernels.IndexSelectStridedI64U8(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]uint8), any(result.data).([]uint8), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []uint32:
+			kernels.IndexSelectStridedI64U32(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]uint32), any(result.data).([]uint32), leftSize, srcDimSize, idsDimSize, rightSize)
+		case []int64:
+			kernels.IndexSelectStridedI64I64(numel, len(dims), dims, stride, idsC.data[idsLayout.StartOffset():], any(s.data[layout.StartOffset():]).([]int64), any(result.data).([]int64), leftSize, srcDimSize, idsDimSize, rightSize)
+		default:
+			return nil, errors.New("unsupported data type for IndexSelect")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ids storage %T for IndexSelect", ids)
+	}
+
+	return result, nil
+}
+
+// IndexAdd adds src slices into a copy of this storage at 1D indices of type uint8, uint32 or int64 along a dimension
+func (s *CpuStorage[T]) IndexAdd(layout *candy.Layout, ids any, idsLayout *candy.Layout, src candy.BackendStorage[T], srcLayout *candy.Layout, dim int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).IndexAdd(layout, ids, idsLayout, widen(src), srcLayout, dim))
+	}
+	if layout == nil || idsLayout == nil || srcLayout == nil {
+		return nil, errors.New("layouts cannot be nil")
+	}
+	if src == nil {
+		return nil, errors.New("src cannot be nil")
+	}
+	if idsLayout.Rank() != 1 || !idsLayout.IsContiguous() {
+		return nil, fmt.Errorf("ids must be a contiguous 1D layout, got %v", idsLayout)
+	}
+	ids, idsLayout, err := exactIds(ids, idsLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	dims := layout.Dims()
+	srcDims := srcLayout.Dims()
+	if dim < 0 || dim >= len(dims) {
+		return nil, fmt.Errorf("dimension %d out of range", dim)
+	}
+	if len(srcDims) != len(dims) || srcDims[dim] != idsLayout.Numel() {
+		return nil, fmt.Errorf("src shape %v incompatible with dst %v and %d ids", srcDims, dims, idsLayout.Numel())
+	}
+
+	dst, err := s.Copy(layout, s)
+	if err != nil {
+		return nil, err
+	}
+	result := dst.(*CpuStorage[T])
+	srcCopy, err := src.Copy(srcLayout, src)
+	if err != nil {
+		return nil, err
+	}
+	srcC := srcCopy.(*CpuStorage[T])
+
+	leftSize := 1
+	for i := range dim {
+		leftSize *= dims[i]
+	}
+	dstDimSize := dims[dim]
+	rightSize := 1
+	for i := dim + 1; i < len(dims); i++ {
+		rightSize *= dims[i]
+	}
+	idsDimSize := idsLayout.Numel()
+
+	switch idsC := ids.(type) {
+	case *CpuStorage[uint8]:
+		if err := checkIndices(idsC.data, idsLayout, dstDimSize); err != nil {
+			return nil, err
+		}
+		switch any(s.data).(type) {
+		case []float32:
+			kernels.IndexAddU8F32(leftSize, idsDimSize, any(srcC.data).([]float32), any(result.data).([]float32), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []float64:
+			kernels.IndexAddU8F64(leftSize, idsDimSize, any(srcC.data).([]float64), any(result.data).([]float64), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []uint8:
+			kernels.IndexAddU8U8(leftSize, idsDimSize, any(srcC.data).([]uint8), any(result.data).([]uint8), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []uint32:
+			kernels.IndexAddU8U32(leftSize, idsDimSize, any(srcC.data).([]uint32), any(result.data).([]uint32), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []int64:
+			kernels.IndexAddU8I64(leftSize, idsDimSize, any(srcC.data).([]int64), any(result.data).([]int64), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		default:
+			return nil, errors.New("unsupported data type for IndexAdd")
+		}
+	case *CpuStorage[uint32]:
+		if err := checkIndices(idsC.data, idsLayout, dstDimSize); err != nil {
+			return nil, err
+		}
+		switch any(s.data).(type) {
+		case []float32:
+			kernels.IndexAddU32F32(leftSize, idsDimSize, any(srcC.data).([]float32), any(result.data).([]float32), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []float64:
+			kernels.IndexAddU32F64(leftSize, idsDimSize, any(srcC.data).([]float64), any(result.data).([]float64), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []uint8:
+			kernels.IndexAddU32U8(leftSize, idsDimSize, any(srcC.data).([]uint8), any(result.data).([]uint8), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []uint32:
+			kernels.IndexAddU32U32(leftSize, idsDimSize, any(srcC.data).([]uint32), any(result.data).([]uint32), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []int64:
+			kernels.IndexAddU32I64(leftSize, idsDimSize, any(srcC.data).([]int64), any(result.data).([]int64), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		default:
+			return nil, errors.New("unsupported data type for IndexAdd")
+		}
+	case *CpuStorage[int64]:
+		if err := checkIndices(idsC.data, idsLayout, dstDimSize); err != nil {
+			return nil, err
+		}
+		switch any(s.data).(type) {
+		case []float32:
+			kernels.IndexAddI64F32(leftSize, idsDimSize, any(srcC.data).([]float32), any(result.data).([]float32), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []float64:
+			kernels.IndexAddI64F64(leftSize, idsDimSize, any(srcC.data).([]float64), any(result.data).([]float64), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []uint8:
+			kernels.IndexAddI64U8(leftSize, idsDimSize, any(srcC.data).([]uint8), any(result.data).([]uint8), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []uint32:
+			kernels.IndexAddI64U32(leftSize, idsDimSize, any(srcC.data).([]uint32), any(result.data).([]uint32), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		case []int64:
+			kernels.IndexAddI64I64(leftSize, idsDimSize, any(srcC.data).([]int64), any(result.data).([]int64), dstDimSize, rightSize, idsC.data[idsLayout.StartOffset():])
+		default:
+			return nil, errors.New("unsupported data type for IndexAdd")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ids storage %T for IndexAdd", ids)
+	}
+
+	return result, nil
+}
+
+// exactIds returns uint8 and uint32 ids that contain the largest value of
+// their dtype widened to int64. The index kernels read that value as padding,
+// while callers of IndexSelect and IndexAdd mean it as an ordinary index.
+func exactIds(ids any, layout *candy.Layout) (any, *candy.Layout, error) {
+	switch c := ids.(type) {
+	case *CpuStorage[uint8]:
+		return widenMaxIds(c, layout, math.MaxUint8)
+	case *CpuStorage[uint32]:
+		return widenMaxIds(c, layout, math.MaxUint32)
+	}
+	return ids, layout, nil
+}
+
+func widenMaxIds[I uint8 | uint32](ids *CpuStorage[I], layout *candy.Layout, pad I) (any, *candy.Layout, error) {
+	if err := checkLayout(layout, len(ids.data)); err != nil {
+		return nil, nil, err
+	}
+	data := ids.data[layout.StartOffset() : layout.StartOffset()+layout.Numel()]
+	if !slices.Contains(data, pad) {
+		return ids, layout, nil
+	}
+	wide := make([]int64, len(data))
+	for i, id := range data {
+		wide[i] = int64(id)
+	}
+	return New(wide), candy.Contiguous(layout.Shape()), nil
+}
+
+// checkIndices verifies that ids covers layout and every index lies in [0, n).
+func checkIndices[I uint8 | uint32 | int64](ids []I, layout *candy.Layout, n int) error {
+	if err := checkLayout(layout, len(ids)); err != nil {
+		return err
+	}
+	for _, id := range ids[layout.StartOffset() : layout.StartOffset()+layout.Numel()] {
+		if int64(id) < 0 || int64(id) >= int64(n) {
+			return fmt.Errorf("index %d out of range for dimension of size %d", id, n)
+		}
+	}
+	return nil
+}
+
 // Copy2d copies a 2D region from source to destination for supported types.
 func (s *CpuStorage[T]) Copy2d(dst candy.BackendStorage[T], d1, d2 int, srcStride1, dstStride1, srcOffset, dstOffset int) error {
 	dstC, ok := dst.(*CpuStorage[T])
@@ -1939,13 +2692,16 @@ func (s *CpuStorage[T]) Copy2d(dst candy.BackendStorage[T], d1, d2 int, srcStrid
 
 // FastSum computes the sum over the last dimension
 func (s *CpuStorage[T]) FastSum(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).FastSum(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -1960,7 +2716,7 @@ func (s *CpuStorage[T]) FastSum(layout *candy.Layout) (candy.BackendStorage[T],
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -1969,13 +2725,16 @@ func (s *CpuStorage[T]) FastSum(layout *candy.Layout) (candy.BackendStorage[T],
 
 // FastMin computes the minimum over the last dimension
 func (s *CpuStorage[T]) FastMin(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).FastMin(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -1990,7 +2749,7 @@ func (s *CpuStorage[T]) FastMin(layout *candy.Layout) (candy.BackendStorage[T],
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -1999,13 +2758,16 @@ func (s *CpuStorage[T]) FastMin(layout *candy.Layout) (candy.BackendStorage[T],
 
 // FastMax computes the maximum over the last dimension
 func (s *CpuStorage[T]) FastMax(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).FastMax(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -2020,7 +2782,7 @@ func (s *CpuStorage[T]) FastMax(layout *candy.Layout) (candy.BackendStorage[T],
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2029,13 +2791,16 @@ func (s *CpuStorage[T]) FastMax(layout *candy.Layout) (candy.BackendStorage[T],
 
 // FastArgmin computes the indices of minimum values over the last dimension
 func (s *CpuStorage[T]) FastArgmin(layout *candy.Layout) (candy.BackendStorage[uint32], error) {
+	if s.isHalf() {
+		return widen[T](s).FastArgmin(layout)
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -2050,7 +2815,7 @@ func (s *CpuStorage[T]) FastArgmin(layout *candy.Layout) (candy.BackendStorage[u
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2059,13 +2824,16 @@ func (s *CpuStorage[T]) FastArgmin(layout *candy.Layout) (candy.BackendStorage[u
 
 // FastArgmax computes the indices of maximum values over the last dimension
 func (s *CpuStorage[T]) FastArgmax(layout *candy.Layout) (candy.BackendStorage[uint32], error) {
+	if s.isHalf() {
+		return widen[T](s).FastArgmax(layout)
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -2080,7 +2848,7 @@ func (s *CpuStorage[T]) FastArgmax(layout *candy.Layout) (candy.BackendStorage[u
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2088,6 +2856,9 @@ func (s *CpuStorage[T]) FastArgmax(layout *candy.Layout) (candy.BackendStorage[u
 }
 
 func (s *CpuStorage[T]) Sum(layout *candy.Layout, dims []int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Sum(layout, dims))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2096,8 +2867,8 @@ func (s *CpuStorage[T]) Sum(layout *candy.Layout, dims []int) (candy.BackendStor
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	outputDims := make([]int, len(layout.Dims()))
@@ -2121,7 +2892,7 @@ func (s *CpuStorage[T]) Sum(layout *candy.Layout, dims []int) (candy.BackendStor
 		layout.Dims(),
 		layout.Stride(),
 		dims,
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2130,13 +2901,16 @@ func (s *CpuStorage[T]) Sum(layout *candy.Layout, dims []int) (candy.BackendStor
 
 // Min computes the minimum over the specified dimension
 func (s *CpuStorage[T]) Min(layout *candy.Layout, dim int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Min(layout, dim))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -2164,7 +2938,7 @@ func (s *CpuStorage[T]) Min(layout *candy.Layout, dim int) (candy.BackendStorage
 		layout.Dims(),
 		layout.Stride(),
 		dim,
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2173,13 +2947,16 @@ func (s *CpuStorage[T]) Min(layout *candy.Layout, dim int) (candy.BackendStorage
 
 // Max computes the maximum over the specified dimension
 func (s *CpuStorage[T]) Max(layout *candy.Layout, dim int) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Max(layout, dim))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -2207,7 +2984,7 @@ func (s *CpuStorage[T]) Max(layout *candy.Layout, dim int) (candy.BackendStorage
 		layout.Dims(),
 		layout.Stride(),
 		dim,
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2216,13 +2993,16 @@ func (s *CpuStorage[T]) Max(layout *candy.Layout, dim int) (candy.BackendStorage
 
 // Argmin computes the index of minimum over the specified dimension
 func (s *CpuStorage[T]) Argmin(layout *candy.Layout, dim int) (candy.BackendStorage[uint32], error) {
+	if s.isHalf() {
+		return widen[T](s).Argmin(layout, dim)
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -2250,7 +3030,7 @@ func (s *CpuStorage[T]) Argmin(layout *candy.Layout, dim int) (candy.BackendStor
 		layout.Dims(),
 		layout.Stride(),
 		dim,
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2259,13 +3039,16 @@ func (s *CpuStorage[T]) Argmin(layout *candy.Layout, dim int) (candy.BackendStor
 
 // Argmax computes the index of maximum over the specified dimension
 func (s *CpuStorage[T]) Argmax(layout *candy.Layout, dim int) (candy.BackendStorage[uint32], error) {
+	if s.isHalf() {
+		return widen[T](s).Argmax(layout, dim)
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	dims := layout.Dims()
@@ -2293,7 +3076,7 @@ func (s *CpuStorage[T]) Argmax(layout *candy.Layout, dim int) (candy.BackendStor
 		layout.Dims(),
 		layout.Stride(),
 		dim,
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2302,13 +3085,21 @@ func (s *CpuStorage[T]) Argmax(layout *candy.Layout, dim int) (candy.BackendStor
 
 // FastSoftmax performs softmax along the last dimension
 func (s *CpuStorage[T]) FastSoftmax(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).FastSoftmax(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
+	}
+
+	src, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
 	}
 
 	result := New(make([]T, numel))
@@ -2317,7 +3108,7 @@ func (s *CpuStorage[T]) FastSoftmax(layout *candy.Layout) (candy.BackendStorage[
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		src,
 		result.data,
 	)
 
@@ -2326,13 +3117,16 @@ func (s *CpuStorage[T]) FastSoftmax(layout *candy.Layout) (candy.BackendStorage[
 
 // FastRmsNorm performs RMS normalization along the last dimension
 func (s *CpuStorage[T]) FastRmsNorm(layout *candy.Layout, alpha candy.BackendStorage[T], alphaLayout *candy.Layout, eps T) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).FastRmsNorm(layout, widen(alpha), alphaLayout, f32(eps)))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	alphaC, ok := alpha.(*CpuStorage[T])
@@ -2349,8 +3143,13 @@ func (s *CpuStorage[T]) FastRmsNorm(layout *candy.Layout, alpha candy.BackendSto
 		return nil, errors.New("cannot normalize scalar tensor")
 	}
 	lastDim := dims[len(dims)-1]
-	if len(alphaC.data) != lastDim {
-		return nil, fmt.Errorf("alpha size %d must match last dimension %d", len(alphaC.data), lastDim)
+	if alphaLayout.Numel() != lastDim {
+		return nil, fmt.Errorf("alpha size %d must match last dimension %d", alphaLayout.Numel(), lastDim)
+	}
+
+	src, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
 	}
 
 	result := New(make([]T, numel))
@@ -2360,8 +3159,8 @@ func (s *CpuStorage[T]) FastRmsNorm(layout *candy.Layout, alpha candy.BackendSto
 		layout.Dims(),
 		layout.Stride(),
 		eps,
-		alphaC.data,
-		s.data,
+		alphaC.data[alphaLayout.StartOffset():],
+		src,
 		result.data,
 	)
 
@@ -2370,13 +3169,16 @@ func (s *CpuStorage[T]) FastRmsNorm(layout *candy.Layout, alpha candy.BackendSto
 
 // FastLayerNorm performs Layer normalization along the last dimension
 func (s *CpuStorage[T]) FastLayerNorm(layout *candy.Layout, alpha candy.BackendStorage[T], alphaLayout *candy.Layout, beta candy.BackendStorage[T], betaLayout *candy.Layout, eps T) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).FastLayerNorm(layout, widen(alpha), alphaLayout, widen(beta), betaLayout, f32(eps)))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	alphaC, ok := alpha.(*CpuStorage[T])
@@ -2401,11 +3203,16 @@ func (s *CpuStorage[T]) FastLayerNorm(layout *candy.Layout, alpha candy.BackendS
 		return nil, errors.New("cannot normalize scalar tensor")
 	}
 	lastDim := dims[len(dims)-1]
-	if len(alphaC.data) != lastDim {
-		return nil, fmt.Errorf("alpha size %d must match last dimension %d", len(alphaC.data), lastDim)
+	if alphaLayout.Numel() != lastDim {
+		return nil, fmt.Errorf("alpha size %d must match last dimension %d", alphaLayout.Numel(), lastDim)
+	}
+	if betaLayout.Numel() != lastDim {
+		return nil, fmt.Errorf("beta size %d must match last dimension %d", betaLayout.Numel(), lastDim)
 	}
-	if len(betaC.data) != lastDim {
-		return nil, fmt.Errorf("beta size %d must match last dimension %d", len(betaC.data), lastDim)
+
+	src, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
 	}
 
 	result := New(make([]T, numel))
@@ -2415,9 +3222,9 @@ func (s *CpuStorage[T]) FastLayerNorm(layout *candy.Layout, alpha candy.BackendS
 		layout.Dims(),
 		layout.Stride(),
 		eps,
-		alphaC.data,
-		betaC.data,
-		s.data,
+		alphaC.data[alphaLayout.StartOffset():],
+		betaC.data[betaLayout.StartOffset():],
+		src,
 		result.data,
 	)
 
@@ -2426,6 +3233,9 @@ func (s *CpuStorage[T]) FastLayerNorm(layout *candy.Layout, alpha candy.BackendS
 
 // RopeI performs rotary position embedding (rope_i variant)
 func (s *CpuStorage[T]) RopeI(layout *candy.Layout, cos candy.BackendStorage[T], cosLayout *candy.Layout, sin candy.BackendStorage[T], sinLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).RopeI(layout, widen(cos), cosLayout, widen(sin), sinLayout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2447,8 +3257,8 @@ func (s *CpuStorage[T]) RopeI(layout *candy.Layout, cos candy.BackendStorage[T],
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	cosC, ok := cos.(*CpuStorage[T])
@@ -2469,30 +3279,30 @@ func (s *CpuStorage[T]) RopeI(layout *candy.Layout, cos candy.BackendStorage[T],
 		return nil, err
 	}
 
-	expectedSize := b * t * d / 2
-	if cosLayout.Numel() != expectedSize {
-		return nil, fmt.Errorf("cos size mismatch: expected %d, got %d", expectedSize, cosLayout.Numel())
+	strideB, err := ropeStrideB(cosLayout.Numel(), sinLayout.Numel(), b, t*d/2, h*t*d)
+	if err != nil {
+		return nil, err
 	}
-	if sinLayout.Numel() != expectedSize {
-		return nil, fmt.Errorf("sin size mismatch: expected %d, got %d", expectedSize, sinLayout.Numel())
+	src, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
 	}
 
 	bh := b * h
 	td := t * d
-	strideB := td
 
 	result := New(make([]T, numel))
 	kernels.RopeIStrided(
-		layout.Rank(),   // rank
-		layout.Dims(),   // dims
-		layout.Stride(), // strides
-		bh,              // bh
-		td,              // td
-		strideB,         // strideB
-		s.data,          // src
-		cosC.data,       // cos
-		sinC.data,       // sin
-		result.data,     // dst
+		layout.Rank(),                       // rank
+		layout.Dims(),                       // dims
+		layout.Stride(),                     // strides
+		bh,                                  // bh
+		td,                                  // td
+		strideB,                             // strideB
+		src,                                 // src
+		cosC.data[cosLayout.StartOffset():], // cos
+		sinC.data[sinLayout.StartOffset():], // sin
+		result.data,                         // dst
 	)
 
 	return result, nil
@@ -2500,6 +3310,9 @@ func (s *CpuStorage[T]) RopeI(layout *candy.Layout, cos candy.BackendStorage[T],
 
 // Rope performs rotary position embedding (rope variant)
 func (s *CpuStorage[T]) Rope(layout *candy.Layout, cos candy.BackendStorage[T], cosLayout *candy.Layout, sin candy.BackendStorage[T], sinLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Rope(layout, widen(cos), cosLayout, widen(sin), sinLayout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2521,8 +3334,8 @@ func (s *CpuStorage[T]) Rope(layout *candy.Layout, cos candy.BackendStorage[T],
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	cosC, ok := cos.(*CpuStorage[T])
@@ -2543,17 +3356,17 @@ func (s *CpuStorage[T]) Rope(layout *candy.Layout, cos candy.BackendStorage[T],
 		return nil, err
 	}
 
-	expectedSize := b * t * d / 2
-	if cosLayout.Numel() != expectedSize {
-		return nil, fmt.Errorf("cos size mismatch: expected %d, got %d", expectedSize, cosLayout.Numel())
+	strideB, err := ropeStrideB(cosLayout.Numel(), sinLayout.Numel(), b, t*d/2, h*t*d)
+	if err != nil {
+		return nil, err
 	}
-	if sinLayout.Numel() != expectedSize {
-		return nil, fmt.Errorf("sin size mismatch: expected %d, got %d", expectedSize, sinLayout.Numel())
+	src, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
 	}
 
 	bh := b * h
 	td := t * d
-	strideB := td
 
 	result := New(make([]T, numel))
 	kernels.RopeStrided(
@@ -2564,9 +3377,9 @@ func (s *CpuStorage[T]) Rope(layout *candy.Layout, cos candy.BackendStorage[T],
 		td,
 		d,
 		strideB,
-		s.data,
-		cosC.data,
-		sinC.data,
+		src,
+		cosC.data[cosLayout.StartOffset():],
+		sinC.data[sinLayout.StartOffset():],
 		result.data,
 	)
 
@@ -2575,6 +3388,9 @@ func (s *CpuStorage[T]) Rope(layout *candy.Layout, cos candy.BackendStorage[T],
 
 // RopeThd performs rotary position embedding (rope_thd variant)
 func (s *CpuStorage[T]) RopeThd(layout *candy.Layout, cos candy.BackendStorage[T], cosLayout *candy.Layout, sin candy.BackendStorage[T], sinLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).RopeThd(layout, widen(cos), cosLayout, widen(sin), sinLayout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2596,8 +3412,8 @@ func (s *CpuStorage[T]) RopeThd(layout *candy.Layout, cos candy.BackendStorage[T
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	cosC, ok := cos.(*CpuStorage[T])
@@ -2618,16 +3434,15 @@ func (s *CpuStorage[T]) RopeThd(layout *candy.Layout, cos candy.BackendStorage[T
 		return nil, err
 	}
 
-	expectedSize := b * t * h * d / 2
-	if cosLayout.Numel() != expectedSize {
-		return nil, fmt.Errorf("cos size mismatch: expected %d, got %d", expectedSize, cosLayout.Numel())
+	strideB, err := ropeStrideB(cosLayout.Numel(), sinLayout.Numel(), b, t*d/2, t*h*d)
+	if err != nil {
+		return nil, err
 	}
-	if sinLayout.Numel() != expectedSize {
-		return nil, fmt.Errorf("sin size mismatch: expected %d, got %d", expectedSize, sinLayout.Numel())
+	src, layout, err := s.contiguous(layout)
+	if err != nil {
+		return nil, err
 	}
 
-	strideB := t * h * d
-
 	result := New(make([]T, numel))
 	kernels.RopeThdStrided(
 		layout.Rank(),
@@ -2638,19 +3453,50 @@ func (s *CpuStorage[T]) RopeThd(layout *candy.Layout, cos candy.BackendStorage[T
 		h,
 		d,
 		strideB,
-		s.data,
-		cosC.data,
-		sinC.data,
+		src,
+		cosC.data[cosLayout.StartOffset():],
+		sinC.data[sinLayout.StartOffset():],
 		result.data,
 	)
 
 	return result, nil
 }
 
+// contiguous returns the data addressed by layout as a contiguous slice with its layout,
+// copying only when layout is strided.
+func (s *CpuStorage[T]) contiguous(layout *candy.Layout) ([]T, *candy.Layout, error) {
+	if start, end, ok := layout.ContiguousOffsets(); ok {
+		return s.data[start:end], candy.Contiguous(layout.Shape()), nil
+	}
+	c, err := s.Copy(layout, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.Data(), candy.Contiguous(layout.Shape()), nil
+}
+
+// ropeStrideB validates the cos/sin table sizes for rope, returning the source batch stride
+// for per-batch tables of b*n elements, or 0 for a table of n elements shared across the batch.
+func ropeStrideB(cosN, sinN, b, n, batchStride int) (int, error) {
+	if cosN != sinN {
+		return 0, fmt.Errorf("cos size %d does not match sin size %d", cosN, sinN)
+	}
+	switch cosN {
+	case n:
+		return 0, nil
+	case b * n:
+		return batchStride, nil
+	}
+	return 0, fmt.Errorf("cos size mismatch: expected %d or %d, got %d", n, b*n, cosN)
+}
+
 // WhereCond performs element-wise selection based on condition.
 // If s[i] != 0, result[i] = t[i], otherwise result[i] = f[i].
 // Note: s can be uint8, uint32, or int64 type (condition mask).
 func (s *CpuStorage[T]) WhereCond(condLayout *candy.Layout, t candy.BackendStorage[T], tLayout *candy.Layout, f candy.BackendStorage[T], fLayout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).WhereCond(condLayout, widen(t), tLayout, widen(f), fLayout))
+	}
 	tC, ok := t.(*CpuStorage[T])
 	if !ok {
 		return nil, errors.New("true storage must be CpuStorage")
@@ -2677,8 +3523,8 @@ func (s *CpuStorage[T]) WhereCond(condLayout *candy.Layout, t candy.BackendStora
 			elemCount, condLayout.Rank(), condLayout.Dims(),
 			condLayout.Stride(), tLayout.Stride(), fLayout.Stride(),
 			cond,
-			tC.data,
-			fC.data,
+			tC.data[tLayout.StartOffset():],
+			fC.data[fLayout.StartOffset():],
 			result.data,
 		)
 	case []float64:
@@ -2686,8 +3532,8 @@ func (s *CpuStorage[T]) WhereCond(condLayout *candy.Layout, t candy.BackendStora
 			elemCount, condLayout.Rank(), condLayout.Dims(),
 			condLayout.Stride(), tLayout.Stride(), fLayout.Stride(),
 			cond,
-			tC.data,
-			fC.data,
+			tC.data[tLayout.StartOffset():],
+			fC.data[fLayout.StartOffset():],
 			result.data,
 		)
 	case []uint8:
@@ -2695,8 +3541,8 @@ func (s *CpuStorage[T]) WhereCond(condLayout *candy.Layout, t candy.BackendStora
 			elemCount, condLayout.Rank(), condLayout.Dims(),
 			condLayout.Stride(), tLayout.Stride(), fLayout.Stride(),
 			cond,
-			tC.data,
-			fC.data,
+			tC.data[tLayout.StartOffset():],
+			fC.data[fLayout.StartOffset():],
 			result.data,
 		)
 	case []uint32:
@@ -2704,8 +3550,8 @@ func (s *CpuStorage[T]) WhereCond(condLayout *candy.Layout, t candy.BackendStora
 			elemCount, condLayout.Rank(), condLayout.Dims(),
 			condLayout.Stride(), tLayout.Stride(), fLayout.Stride(),
 			cond,
-			tC.data,
-			fC.data,
+			tC.data[tLayout.StartOffset():],
+			fC.data[fLayout.StartOffset():],
 			result.data,
 		)
 	case []int64:
@@ -2713,8 +3559,8 @@ func (s *CpuStorage[T]) WhereCond(condLayout *candy.Layout, t candy.BackendStora
 			elemCount, condLayout.Rank(), condLayout.Dims(),
 			condLayout.Stride(), tLayout.Stride(), fLayout.Stride(),
 			cond,
-			tC.data,
-			fC.data,
+			tC.data[tLayout.StartOffset():],
+			fC.data[fLayout.StartOffset():],
 			result.data,
 		)
 	default:
@@ -2730,12 +3576,17 @@ func (s *CpuStorage[T]) Copy(layout *candy.Layout, src candy.BackendStorage[T])
 		return nil, errors.New("layout cannot be nil")
 	}
 
+	srcC, ok := src.(*CpuStorage[T])
+	if !ok {
+		return nil, errors.New("src storage must be CpuStorage")
+	}
+
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(srcC.data)); err != nil {
+		return nil, err
 	}
 
-	srcData := src.(*CpuStorage[T]).data
+	srcData := srcC.data[layout.StartOffset():]
 	result := New(make([]T, numel))
 
 	kernels.UCopyStrided(
@@ -2752,13 +3603,16 @@ func (s *CpuStorage[T]) Copy(layout *candy.Layout, src candy.BackendStorage[T])
 
 // Neg performs element-wise negation operation
 func (s *CpuStorage[T]) Neg(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Neg(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	result := New(make([]T, numel))
@@ -2768,7 +3622,7 @@ func (s *CpuStorage[T]) Neg(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2777,13 +3631,16 @@ func (s *CpuStorage[T]) Neg(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Recip performs element-wise reciprocal operation
 func (s *CpuStorage[T]) Recip(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Recip(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	result := New(make([]T, numel))
@@ -2793,7 +3650,7 @@ func (s *CpuStorage[T]) Recip(layout *candy.Layout) (candy.BackendStorage[T], er
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2802,6 +3659,9 @@ func (s *CpuStorage[T]) Recip(layout *candy.Layout) (candy.BackendStorage[T], er
 
 // Exp performs element-wise exponential operation
 func (s *CpuStorage[T]) Exp(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Exp(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2814,7 +3674,7 @@ func (s *CpuStorage[T]) Exp(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2823,6 +3683,9 @@ func (s *CpuStorage[T]) Exp(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Log performs element-wise logarithm operation
 func (s *CpuStorage[T]) Log(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Log(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2835,7 +3698,7 @@ func (s *CpuStorage[T]) Log(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2844,6 +3707,9 @@ func (s *CpuStorage[T]) Log(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Sin performs element-wise sine operation
 func (s *CpuStorage[T]) Sin(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Sin(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2856,7 +3722,7 @@ func (s *CpuStorage[T]) Sin(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2865,6 +3731,9 @@ func (s *CpuStorage[T]) Sin(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Cos performs element-wise cosine operation
 func (s *CpuStorage[T]) Cos(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Cos(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2877,7 +3746,7 @@ func (s *CpuStorage[T]) Cos(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2886,6 +3755,9 @@ func (s *CpuStorage[T]) Cos(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Tanh performs element-wise hyperbolic tangent operation
 func (s *CpuStorage[T]) Tanh(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Tanh(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2898,7 +3770,7 @@ func (s *CpuStorage[T]) Tanh(layout *candy.Layout) (candy.BackendStorage[T], err
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2907,6 +3779,9 @@ func (s *CpuStorage[T]) Tanh(layout *candy.Layout) (candy.BackendStorage[T], err
 
 // Erf performs element-wise error function operation
 func (s *CpuStorage[T]) Erf(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Erf(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2919,7 +3794,7 @@ func (s *CpuStorage[T]) Erf(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2928,6 +3803,9 @@ func (s *CpuStorage[T]) Erf(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Ceil performs element-wise ceiling operation
 func (s *CpuStorage[T]) Ceil(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Ceil(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2940,7 +3818,7 @@ func (s *CpuStorage[T]) Ceil(layout *candy.Layout) (candy.BackendStorage[T], err
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2949,6 +3827,9 @@ func (s *CpuStorage[T]) Ceil(layout *candy.Layout) (candy.BackendStorage[T], err
 
 // Floor performs element-wise floor operation
 func (s *CpuStorage[T]) Floor(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Floor(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2961,7 +3842,7 @@ func (s *CpuStorage[T]) Floor(layout *candy.Layout) (candy.BackendStorage[T], er
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2970,6 +3851,9 @@ func (s *CpuStorage[T]) Floor(layout *candy.Layout) (candy.BackendStorage[T], er
 
 // Round performs element-wise round operation
 func (s *CpuStorage[T]) Round(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Round(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -2982,7 +3866,7 @@ func (s *CpuStorage[T]) Round(layout *candy.Layout) (candy.BackendStorage[T], er
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -2991,6 +3875,9 @@ func (s *CpuStorage[T]) Round(layout *candy.Layout) (candy.BackendStorage[T], er
 
 // Normcdf performs element-wise normal CDF operation
 func (s *CpuStorage[T]) Normcdf(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Normcdf(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3003,7 +3890,7 @@ func (s *CpuStorage[T]) Normcdf(layout *candy.Layout) (candy.BackendStorage[T],
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3012,6 +3899,9 @@ func (s *CpuStorage[T]) Normcdf(layout *candy.Layout) (candy.BackendStorage[T],
 
 // Abs performs element-wise absolute value operation
 func (s *CpuStorage[T]) Abs(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Abs(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3024,7 +3914,7 @@ func (s *CpuStorage[T]) Abs(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3033,6 +3923,9 @@ func (s *CpuStorage[T]) Abs(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Sqr performs element-wise square operation
 func (s *CpuStorage[T]) Sqr(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Sqr(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3045,7 +3938,7 @@ func (s *CpuStorage[T]) Sqr(layout *candy.Layout) (candy.BackendStorage[T], erro
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3054,13 +3947,16 @@ func (s *CpuStorage[T]) Sqr(layout *candy.Layout) (candy.BackendStorage[T], erro
 
 // Sqrt performs element-wise square root operation
 func (s *CpuStorage[T]) Sqrt(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Sqrt(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
 
 	numel := layout.Numel()
-	if numel != len(s.data) {
-		return nil, errors.New("layout element count does not match storage size")
+	if err := checkLayout(layout, len(s.data)); err != nil {
+		return nil, err
 	}
 
 	result := New(make([]T, numel))
@@ -3070,7 +3966,7 @@ func (s *CpuStorage[T]) Sqrt(layout *candy.Layout) (candy.BackendStorage[T], err
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3079,6 +3975,9 @@ func (s *CpuStorage[T]) Sqrt(layout *candy.Layout) (candy.BackendStorage[T], err
 
 // Gelu performs element-wise GELU activation operation
 func (s *CpuStorage[T]) Gelu(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Gelu(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3091,7 +3990,7 @@ func (s *CpuStorage[T]) Gelu(layout *candy.Layout) (candy.BackendStorage[T], err
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3100,6 +3999,9 @@ func (s *CpuStorage[T]) Gelu(layout *candy.Layout) (candy.BackendStorage[T], err
 
 // GeluErf performs element-wise GELU (ERF-based) activation operation
 func (s *CpuStorage[T]) GeluErf(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).GeluErf(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3112,7 +4014,7 @@ func (s *CpuStorage[T]) GeluErf(layout *candy.Layout) (candy.BackendStorage[T],
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3121,6 +4023,9 @@ func (s *CpuStorage[T]) GeluErf(layout *candy.Layout) (candy.BackendStorage[T],
 
 // Relu performs element-wise ReLU activation operation
 func (s *CpuStorage[T]) Relu(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Relu(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3133,7 +4038,7 @@ func (s *CpuStorage[T]) Relu(layout *candy.Layout) (candy.BackendStorage[T], err
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3142,6 +4047,9 @@ func (s *CpuStorage[T]) Relu(layout *candy.Layout) (candy.BackendStorage[T], err
 
 // Elu performs element-wise ELU activation operation with parameter alpha
 func (s *CpuStorage[T]) Elu(layout *candy.Layout, alpha T) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Elu(layout, f32(alpha)))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3155,7 +4063,7 @@ func (s *CpuStorage[T]) Elu(layout *candy.Layout, alpha T) (candy.BackendStorage
 		layout.Dims(),
 		layout.Stride(),
 		alpha,
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3164,6 +4072,9 @@ func (s *CpuStorage[T]) Elu(layout *candy.Layout, alpha T) (candy.BackendStorage
 
 // Silu performs element-wise SiLU (Swish) activation operation
 func (s *CpuStorage[T]) Silu(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Silu(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3176,7 +4087,7 @@ func (s *CpuStorage[T]) Silu(layout *candy.Layout) (candy.BackendStorage[T], err
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3185,6 +4096,9 @@ func (s *CpuStorage[T]) Silu(layout *candy.Layout) (candy.BackendStorage[T], err
 
 // Powf performs element-wise power operation with parameter param
 func (s *CpuStorage[T]) Powf(layout *candy.Layout, param T) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Powf(layout, f32(param)))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3198,7 +4112,7 @@ func (s *CpuStorage[T]) Powf(layout *candy.Layout, param T) (candy.BackendStorag
 		layout.Dims(),
 		layout.Stride(),
 		param,
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3207,6 +4121,9 @@ func (s *CpuStorage[T]) Powf(layout *candy.Layout, param T) (candy.BackendStorag
 
 // Sigmoid performs element-wise sigmoid activation operation
 func (s *CpuStorage[T]) Sigmoid(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Sigmoid(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3219,7 +4136,7 @@ func (s *CpuStorage[T]) Sigmoid(layout *candy.Layout) (candy.BackendStorage[T],
 		layout.Rank(),
 		layout.Dims(),
 		layout.Stride(),
-		s.data,
+		s.data[layout.StartOffset():],
 		result.data,
 	)
 
@@ -3228,6 +4145,9 @@ func (s *CpuStorage[T]) Sigmoid(layout *candy.Layout) (candy.BackendStorage[T],
 
 // Sign performs element-wise sign operation
 func (s *CpuStorage[T]) Sign(layout *candy.Layout) (candy.BackendStorage[T], error) {
+	if s.isHalf() {
+		return narrow[T](widen[T](s).Sign(layout))
+	}
 	if layout == nil {
 		return nil, errors.New("layout cannot be nil")
 	}
@@ -3239,27 +4159,27 @@ func (s *CpuStorage[T]) Sign(layout *candy.Layout) (candy.BackendStorage[T], err
 	case []float32:
 		kernels.USignStridedF32(
 			numel, layout.Rank(), layout.Dims(), layout.Stride(),
-			any(s.data).([]float32), any(result.data).([]float32),
+			any(s.data[layout.StartOffset():]).([]float32), any(result.data).([]float32),
 		)
 	case []float64:
 		kernels.USignStridedF64(
 			numel, layout.Rank(), layout.Dims(), layout.Stride(),
-			any(s.data).([]float64), any(result.data).([]float64),
+			any(s.data[layout.StartOffset():]).([]float64), any(result.data).([]float64),
 		)
 	case []uint8:
 		kernels.USignStridedU8(
 			numel, layout.Rank(), layout.Dims(), layout.Stride(),
-			any(s.data).([]uint8), any(result.data).([]uint8),
+			any(s.data[layout.StartOffset():]).([]uint8), any(result.data).([]uint8),
 		)
 	case []uint32:
 		kernels.USignStridedU32(
 			numel, layout.Rank(), layout.Dims(), layout.Stride(),
-			any(s.data).([]uint32), any(result.data).([]uint32),
+			any(s.data[layout.StartOffset():]).([]uint32), any(result.data).([]uint32),
 		)
 	case []int64:
 		kernels.USignStridedI64(
 			numel, layout.Rank(), layout.Dims(), layout.Stride(),
-			any(s.data).([]int64), any(result.data).([]int64),
+			any(s.data[layout.StartOffset():]).([]int64), any(result.data).([]int64),
 		)
 	default:
 		return nil, errors.New("unsupported data type for Sign")
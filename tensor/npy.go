@@ -6,11 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"math"
 	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
+	"unsafe"
 
 	"github.com/gocnn/candy"
 )
@@ -22,28 +25,70 @@ var (
 
 type npyHeader struct {
 	shape        []int
-	dtype        candy.DType
+	descr        npyDescr
 	fortranOrder bool
 }
 
-func descrToDType(descr string) (candy.DType, error) {
-	switch descr {
-	case "e", "f2":
-		return candy.F16, nil
-	case "f", "f4":
-		return candy.F32, nil
-	case "d", "f8":
-		return candy.F64, nil
-	case "q", "i8":
-		return candy.I64, nil
-	case "B", "u1":
-		return candy.U8, nil
-	case "I", "u4":
-		return candy.U32, nil
-	case "?", "b1":
-		return candy.U8, nil
+// npyDescr is a parsed NumPy type string such as "<f4" or ">i2".
+type npyDescr struct {
+	kind      byte // 'f' float, 'i' signed, 'u' unsigned, 'b' bool
+	size      int
+	bigEndian bool
+}
+
+// npyTypeChars maps single-character NumPy type codes to kind and size.
+var npyTypeChars = map[string]string{
+	"e": "f2", "f": "f4", "d": "f8",
+	"b": "i1", "h": "i2", "i": "i4", "q": "i8",
+	"B": "u1", "H": "u2", "I": "u4", "Q": "u8",
+	"?": "b1",
+}
+
+func parseDescr(descr string) (npyDescr, error) {
+	var d npyDescr
+	s := descr
+	if s != "" {
+		switch s[0] {
+		case '>':
+			d.bigEndian = true
+			s = s[1:]
+		case '<', '=', '|':
+			s = s[1:]
+		}
+	}
+	if code, ok := npyTypeChars[s]; ok {
+		s = code
+	}
+	if len(s) == 2 {
+		d.kind, d.size = s[0], int(s[1]-'0')
+	}
+	switch {
+	case d.kind == 'f' && (d.size == 2 || d.size == 4 || d.size == 8),
+		(d.kind == 'i' || d.kind == 'u') && (d.size == 1 || d.size == 2 || d.size == 4 || d.size == 8),
+		d.kind == 'b' && d.size == 1:
+		return d, nil
+	default:
+		return npyDescr{}, fmt.Errorf("npy: unrecognized descr %q", descr)
+	}
+}
+
+// dtype returns the candy dtype stored with the same bytes, if any.
+func (d npyDescr) dtype() (candy.DType, bool) {
+	switch string([]byte{d.kind, byte('0' + d.size)}) {
+	case "f2":
+		return candy.F16, true
+	case "f4":
+		return candy.F32, true
+	case "f8":
+		return candy.F64, true
+	case "u1", "b1":
+		return candy.U8, true
+	case "u4":
+		return candy.U32, true
+	case "i8":
+		return candy.I64, true
 	default:
-		return 0, fmt.Errorf("npy: unrecognized descr %q", descr)
+		return 0, false
 	}
 }
 
@@ -68,71 +113,73 @@ func dtypeToDescr(dt candy.DType) (string, error) {
 	}
 }
 
-func convertFloat64To[T candy.D](dst []T, src []float64, dtOut candy.DType) {
-	switch dtOut {
-	case candy.F32:
-		for i, v := range src {
-			dst[i] = any(float32(v)).(T)
-		}
-	case candy.F64:
-		for i, v := range src {
-			dst[i] = any(v).(T)
-		}
-	case candy.U8:
-		for i, v := range src {
-			dst[i] = any(uint8(v)).(T)
-		}
-	case candy.U32:
-		for i, v := range src {
-			dst[i] = any(uint32(v)).(T)
-		}
-	case candy.I64:
-		for i, v := range src {
-			dst[i] = any(int64(v)).(T)
-		}
-	}
-}
-
-func convertInt64To[T candy.D](dst []T, src []int64, dtOut candy.DType) {
-	switch dtOut {
-	case candy.F32:
-		for i, v := range src {
-			dst[i] = any(float32(v)).(T)
-		}
-	case candy.F64:
-		for i, v := range src {
-			dst[i] = any(float64(v)).(T)
-		}
-	case candy.U8:
-		for i, v := range src {
-			dst[i] = any(uint8(v)).(T)
-		}
-	case candy.U32:
-		for i, v := range src {
-			dst[i] = any(uint32(v)).(T)
-		}
-	case candy.I64:
-		for i, v := range src {
-			dst[i] = any(v).(T)
+// decodeNPY converts raw elements of type d into dst, byte-swapping
+// big-endian data. Elements stored as T on a little-endian host are copied.
+func decodeNPY[T candy.D](d npyDescr, raw []byte, dst []T) {
+	if len(dst) == 0 {
+		return
+	}
+	if dt, ok := d.dtype(); ok && dt == candy.DTypeOf[T]() && !d.bigEndian && hostLittleEndian {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&dst[0])), len(raw)), raw)
+		return
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if d.bigEndian {
+		order = binary.BigEndian
+	}
+	_, toInt64 := any(dst[0]).(int64)
+	for i := range dst {
+		b := raw[i*d.size:]
+		switch d.kind {
+		case 'f':
+			var v float64
+			switch d.size {
+			case 2:
+				v = float64(candy.Float16(order.Uint16(b)).Float32())
+			case 4:
+				v = float64(math.Float32frombits(order.Uint32(b)))
+			case 8:
+				v = math.Float64frombits(order.Uint64(b))
+			}
+			dst[i] = candy.FromFloat64[T](v)
+		case 'u', 'b':
+			var v uint64
+			switch d.size {
+			case 1:
+				v = uint64(b[0])
+			case 2:
+				v = uint64(order.Uint16(b))
+			case 4:
+				v = uint64(order.Uint32(b))
+			case 8:
+				v = order.Uint64(b)
+			}
+			if toInt64 {
+				dst[i] = any(int64(v)).(T)
+			} else {
+				dst[i] = candy.FromFloat64[T](float64(v))
+			}
+		case 'i':
+			var v int64
+			switch d.size {
+			case 1:
+				v = int64(int8(b[0]))
+			case 2:
+				v = int64(int16(order.Uint16(b)))
+			case 4:
+				v = int64(int32(order.Uint32(b)))
+			case 8:
+				v = int64(order.Uint64(b))
+			}
+			if toInt64 {
+				dst[i] = any(v).(T)
+			} else {
+				dst[i] = candy.FromFloat64[T](float64(v))
+			}
 		}
 	}
 }
 
-func u8ToI64(x []uint8) []int64 {
-	y := make([]int64, len(x))
-	for i, v := range x {
-		y[i] = int64(v)
-	}
-	return y
-}
-func u32ToI64(x []uint32) []int64 {
-	y := make([]int64, len(x))
-	for i, v := range x {
-		y[i] = int64(v)
-	}
-	return y
-}
-
 func readHeader(r io.Reader) (string, error) {
 	buf := make([]byte, len(npyMagic))
 	if _, err := io.ReadFull(r, buf); err != nil {
@@ -149,7 +196,7 @@ func readHeader(r io.Reader) (string, error) {
 	switch ver[0] {
 	case 1:
 		nlen = 2
-	case 2:
+	case 2, 3:
 		nlen = 4
 	default:
 		return "", fmt.Errorf("npy: unsupported version %d", ver[0])
@@ -221,11 +268,7 @@ func parseHeader(header string) (npyHeader, error) {
 	if !ok || ds == "" {
 		return npyHeader{}, errors.New("npy: no descr in header")
 	}
-	if strings.HasPrefix(ds, ">") {
-		return npyHeader{}, fmt.Errorf("npy: big-endian descr %q", ds)
-	}
-	core := strings.TrimLeft(ds, "<=|")
-	dt, err := descrToDType(core)
+	descr, err := parseDescr(ds)
 	if err != nil {
 		return npyHeader{}, err
 	}
@@ -252,80 +295,63 @@ func parseHeader(header string) (npyHeader, error) {
 	} else {
 		dims = []int{}
 	}
-	return npyHeader{dtype: dt, fortranOrder: fo, shape: dims}, nil
+	return npyHeader{descr: descr, fortranOrder: fo, shape: dims}, nil
 }
 
-func fromReader[T candy.D](shape *candy.Shape, dt candy.DType, r io.Reader) (*Tensor[T], error) {
-	n := shape.Numel()
-	if n < 0 {
-		return nil, errors.New("npy: invalid shape")
+func fromReader[T candy.D](hdr npyHeader, r io.Reader) (*Tensor[T], error) {
+	dims := slices.Clone(hdr.shape)
+	if hdr.fortranOrder {
+		slices.Reverse(dims)
 	}
+	n, err := npyNumel(dims, hdr.descr.size)
+	if err != nil {
+		return nil, fmt.Errorf("npy: invalid shape: %w", err)
+	}
+	shape := candy.NewShapeFrom(dims)
 	out := make([]T, n)
-	dtOut := candy.DTypeOf[T]()
-	switch dt {
-	case candy.F32:
-		src := make([]float32, n)
-		if err := binary.Read(r, binary.LittleEndian, src); err != nil {
-			return nil, err
-		}
-		fs := make([]float64, n)
-		for i, v := range src {
-			fs[i] = float64(v)
-		}
-		convertFloat64To(out, fs, dtOut)
-	case candy.F64:
-		src := make([]float64, n)
-		if err := binary.Read(r, binary.LittleEndian, src); err != nil {
-			return nil, err
-		}
-		convertFloat64To(out, src, dtOut)
-	case candy.U8:
-		src := make([]uint8, n)
-		if _, err := io.ReadFull(r, src); err != nil {
-			return nil, err
-		}
-		convertInt64To(out, u8ToI64(src), dtOut)
-	case candy.U32:
-		src := make([]uint32, n)
-		if err := binary.Read(r, binary.LittleEndian, src); err != nil {
+	if dt, ok := hdr.descr.dtype(); ok && dt == candy.DTypeOf[T]() && !hdr.descr.bigEndian && hostLittleEndian && n > 0 {
+		if _, err := io.ReadFull(r, unsafe.Slice((*byte)(unsafe.Pointer(&out[0])), n*hdr.descr.size)); err != nil {
 			return nil, err
 		}
-		convertInt64To(out, u32ToI64(src), dtOut)
-	case candy.I64:
-		src := make([]int64, n)
-		if err := binary.Read(r, binary.LittleEndian, src); err != nil {
+	} else {
+		raw := make([]byte, n*hdr.descr.size)
+		if _, err := io.ReadFull(r, raw); err != nil {
 			return nil, err
 		}
-		convertInt64To(out, src, dtOut)
-	case candy.F16:
-		return nil, errors.New("npy: f16 read unsupported")
-	case candy.BF16:
-		return nil, errors.New("npy: bf16 read unsupported")
-	default:
-		return nil, fmt.Errorf("npy: unsupported dtype %v", dt)
+		decodeNPY(hdr.descr, raw, out)
+	}
+	t, err := New(out, shape, candy.CPU)
+	if err != nil || !hdr.fortranOrder || len(dims) < 2 {
+		return t, err
+	}
+	// Fortran data is the C-order transpose; reversing the axes yields a
+	// column-major strided view without copying.
+	perm := make([]int, len(dims))
+	for i := range perm {
+		perm[i] = len(dims) - 1 - i
 	}
-	return New(out, shape, candy.CPU)
+	return t.Permute(perm...)
 }
 
-func ReadNPY[T candy.D](path string) (*Tensor[T], error) {
-	f, err := os.Open(path)
+func readNPY[T candy.D](r io.Reader) (*Tensor[T], error) {
+	h, err := readHeader(r)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-	h, err := readHeader(f)
+	hdr, err := parseHeader(h)
 	if err != nil {
 		return nil, err
 	}
-	hdr, err := parseHeader(h)
+	return fromReader[T](hdr, r)
+}
+
+func ReadNPY[T candy.D](path string) (*Tensor[T], error) {
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
-	if hdr.fortranOrder {
-		return nil, errors.New("npy: fortran order not supported")
-	}
-	shape := candy.NewShapeFrom(hdr.shape)
-	return fromReader[T](shape, hdr.dtype, f)
+	defer f.Close()
+	return readNPY[T](f)
 }
 
 func MustReadNPY[T candy.D](path string) *Tensor[T] {
@@ -336,12 +362,15 @@ func MustReadNPY[T candy.D](path string) *Tensor[T] {
 	return t
 }
 
-func headerString(dt candy.DType, dims []int) (string, error) {
+func headerString(dt candy.DType, dims []int, fortranOrder bool) (string, error) {
 	ds, err := dtypeToDescr(dt)
 	if err != nil {
 		return "", err
 	}
 	fo := "False"
+	if fortranOrder {
+		fo = "True"
+	}
 	var sb strings.Builder
 	sb.WriteString("{'descr': '<")
 	sb.WriteString(ds)
@@ -373,62 +402,75 @@ func bytesRepeat(b byte, n int) []byte {
 	return s
 }
 
+// npyHeaderBytes returns the magic, version, length and padded header dict
+// for an array. The result is padded to exactly minLen bytes when the header
+// fits, otherwise to the next multiple of 64 so the data starts aligned.
+func npyHeaderBytes(dt candy.DType, dims []int, fortranOrder bool, minLen int) ([]byte, error) {
+	hs, err := headerString(dt, dims, fortranOrder)
+	if err != nil {
+		return nil, err
+	}
+	h := []byte(hs)
+	// pad accounts for the newline, which is part of the header string length
+	n := len(npyMagic) + 2 + 2 + len(h) + 1
+	pad := (64 - n%64) % 64
+	if minLen >= n {
+		pad = minLen - n
+	}
+	if pad > 0 {
+		h = append(h, bytesRepeat(' ', pad)...)
+	}
+	h = append(h, '\n')
+	if len(h) > math.MaxUint16 {
+		return nil, errors.New("npy: header too long")
+	}
+	out := append(slices.Clone(npyMagic), 1, 0)
+	out = binary.LittleEndian.AppendUint16(out, uint16(len(h)))
+	return append(out, h...), nil
+}
+
 func (t *Tensor[T]) writeNPYTo(w io.Writer) error {
 	if t.Device() != candy.CPU {
 		return errors.New("npy: only CPU tensors supported")
 	}
 	layout := t.Layout()
-	if !layout.IsContiguous() {
-		return errors.New("npy: only contiguous tensors supported for write")
-	}
 	// Validate dtype before writing any bytes to avoid partial files
 	dt := t.DType()
 	switch dt {
-	case candy.U8, candy.F32, candy.F64, candy.U32, candy.I64:
+	case candy.U8, candy.F32, candy.F64, candy.U32, candy.I64, candy.F16:
 		// supported
 	default:
 		return fmt.Errorf("npy: unsupported write dtype %v", dt)
 	}
-	start, end, _ := layout.ContiguousOffsets()
-	_, _ = w.Write(npyMagic)
-	_, _ = w.Write([]byte{1, 0})
-	hs, err := headerString(dt, layout.Dims())
-	if err != nil {
-		return err
-	}
-	h := []byte(hs)
-	// pad accounts for the newline, which is part of the header string length
-	pad := (16 - ((len(npyMagic) + 2 + 2 + len(h) + 1) % 16)) % 16
-	if pad > 0 {
-		h = append(h, bytesRepeat(' ', pad)...)
+	// Column-major views are written as-is with fortran_order set; any other
+	// layout is materialized in C order.
+	src := t
+	fortran := layout.Shape().Rank() > 1 && !layout.IsContiguous() && layout.IsFortranContiguous()
+	if fortran {
+		perm := make([]int, layout.Shape().Rank())
+		for i := range perm {
+			perm[i] = len(perm) - 1 - i
+		}
+		p, err := t.Permute(perm...)
+		if err != nil {
+			return err
+		}
+		src = p
 	}
-	h = append(h, '\n')
-	var lenle [2]byte
-	binary.LittleEndian.PutUint16(lenle[:], uint16(len(h)))
-	if _, err := w.Write(lenle[:]); err != nil {
+	h, err := npyHeaderBytes(dt, layout.Dims(), fortran, 0)
+	if err != nil {
 		return err
 	}
 	if _, err := w.Write(h); err != nil {
 		return err
 	}
-	data := t.Data()
+	data := src.Data()
 	switch t.DType() {
 	case candy.U8:
-		b := any(data).([]uint8)
-		_, err = w.Write(b[start:end])
+		_, err = w.Write(any(data).([]uint8))
 		return err
-	case candy.F32:
-		b := any(data).([]float32)
-		return binary.Write(w, binary.LittleEndian, b[start:end])
-	case candy.F64:
-		b := any(data).([]float64)
-		return binary.Write(w, binary.LittleEndian, b[start:end])
-	case candy.U32:
-		b := any(data).([]uint32)
-		return binary.Write(w, binary.LittleEndian, b[start:end])
-	case candy.I64:
-		b := any(data).([]int64)
-		return binary.Write(w, binary.LittleEndian, b[start:end])
+	case candy.F32, candy.F64, candy.U32, candy.I64, candy.F16:
+		return binary.Write(w, binary.LittleEndian, data)
 	default:
 		return fmt.Errorf("npy: unsupported write dtype %v", t.DType())
 	}
@@ -440,7 +482,10 @@ func (t *Tensor[T]) WriteNPY(path string) error {
 		return err
 	}
 	defer f.Close()
-	return t.writeNPYTo(f)
+	if err := t.writeNPYTo(f); err != nil {
+		return err
+	}
+	return f.Close()
 }
 
 func (t *Tensor[T]) MustWriteNPY(path string) {
@@ -457,32 +502,17 @@ func ReadNPZ[T candy.D](path string) (map[string]*Tensor[T], error) {
 	defer zr.Close()
 	res := make(map[string]*Tensor[T])
 	for _, f := range zr.File {
-		if filepath.Ext(f.Name) != ".npy" {
+		if filepath.Ext(f.Name) != npySuffix {
 			continue
 		}
 		rc, err := f.Open()
 		if err != nil {
 			return nil, err
 		}
-		h, err := readHeader(rc)
-		if err != nil {
-			rc.Close()
-			return nil, err
-		}
-		hdr, err := parseHeader(h)
-		if err != nil {
-			rc.Close()
-			return nil, err
-		}
-		if hdr.fortranOrder {
-			rc.Close()
-			return nil, errors.New("npz: fortran order not supported")
-		}
-		shape := candy.NewShapeFrom(hdr.shape)
-		t, err := fromReader[T](shape, hdr.dtype, rc)
+		t, err := readNPY[T](rc)
 		rc.Close()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("npz: %s: %w", f.Name, err)
 		}
 		name := strings.TrimSuffix(f.Name, npySuffix)
 		res[name] = t
@@ -490,8 +520,8 @@ func ReadNPZ[T candy.D](path string) (map[string]*Tensor[T], error) {
 	return res, nil
 }
 
-func MustReadNPZ(path string) map[string]*Tensor[float32] {
-	res, err := ReadNPZ[float32](path)
+func MustReadNPZ[T candy.D](path string) map[string]*Tensor[T] {
+	res, err := ReadNPZ[T](path)
 	if err != nil {
 		panic(err)
 	}
@@ -522,62 +552,127 @@ func ReadNPZByName[T candy.D](path string, names []string) ([]*Tensor[T], error)
 		if err != nil {
 			return nil, err
 		}
-		h, err := readHeader(rc)
+		t, err := readNPY[T](rc)
+		rc.Close()
 		if err != nil {
-			rc.Close()
-			return nil, err
+			return nil, fmt.Errorf("npz: %s: %w", fname, err)
 		}
-		hdr, err := parseHeader(h)
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func MustReadNPZByName[T candy.D](path string, names []string) []*Tensor[T] {
+	res, err := ReadNPZByName[T](path, names)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// NPYInfo describes an array stored in a .npy file or .npz archive.
+type NPYInfo struct {
+	Descr        string // NumPy type string without byte order, e.g. "f4"
+	Shape        *candy.Shape
+	FortranOrder bool
+}
+
+// DType returns the candy dtype stored with the same bytes, if any.
+func (i NPYInfo) DType() (candy.DType, bool) {
+	d, err := parseDescr(i.Descr)
+	if err != nil {
+		return 0, false
+	}
+	return d.dtype()
+}
+
+// ReadNPZInfo reads the header of every array in the .npz archive at path
+// without decoding any data.
+func ReadNPZInfo(path string) (map[string]NPYInfo, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	res := make(map[string]NPYInfo)
+	for _, f := range zr.File {
+		if filepath.Ext(f.Name) != npySuffix {
+			continue
+		}
+		rc, err := f.Open()
 		if err != nil {
-			rc.Close()
 			return nil, err
 		}
-		if hdr.fortranOrder {
-			rc.Close()
-			return nil, errors.New("npz: fortran order not supported")
-		}
-		shape := candy.NewShapeFrom(hdr.shape)
-		t, err := fromReader[T](shape, hdr.dtype, rc)
+		h, err := readHeader(rc)
 		rc.Close()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("npz: %s: %w", f.Name, err)
+		}
+		hdr, err := parseHeader(h)
+		if err != nil {
+			return nil, fmt.Errorf("npz: %s: %w", f.Name, err)
+		}
+		res[strings.TrimSuffix(f.Name, npySuffix)] = NPYInfo{
+			Descr:        string([]byte{hdr.descr.kind, byte('0' + hdr.descr.size)}),
+			Shape:        candy.NewShapeFrom(hdr.shape),
+			FortranOrder: hdr.fortranOrder,
 		}
-		out = append(out, t)
 	}
-	return out, nil
+	return res, nil
 }
 
-func MustReadNPZByName(path string, names []string) []*Tensor[float32] {
-	res, err := ReadNPZByName[float32](path, names)
+// MustReadNPZInfo reads the array headers of an .npz archive, panicking on error.
+func MustReadNPZInfo(path string) map[string]NPYInfo {
+	res, err := ReadNPZInfo(path)
 	if err != nil {
 		panic(err)
 	}
 	return res
 }
 
+// WriteNPZ writes items as uncompressed .npy entries, like numpy.savez.
 func WriteNPZ[T candy.D](path string, items map[string]*Tensor[T]) error {
+	return writeNPZ(path, items, zip.Store)
+}
+
+func MustWriteNPZ[T candy.D](path string, items map[string]*Tensor[T]) {
+	if err := WriteNPZ[T](path, items); err != nil {
+		panic(err)
+	}
+}
+
+// WriteNPZCompressed writes items as deflate-compressed .npy entries, like
+// numpy.savez_compressed.
+func WriteNPZCompressed[T candy.D](path string, items map[string]*Tensor[T]) error {
+	return writeNPZ(path, items, zip.Deflate)
+}
+
+func MustWriteNPZCompressed[T candy.D](path string, items map[string]*Tensor[T]) {
+	if err := WriteNPZCompressed[T](path, items); err != nil {
+		panic(err)
+	}
+}
+
+func writeNPZ[T candy.D](path string, items map[string]*Tensor[T], method uint16) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 	zw := zip.NewWriter(f)
-	for name, t := range items {
-		w, err := zw.Create(name + npySuffix)
+	for _, name := range slices.Sorted(maps.Keys(items)) {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name + npySuffix, Method: method})
 		if err != nil {
 			zw.Close()
 			return err
 		}
-		if err := t.writeNPYTo(w); err != nil {
+		if err := items[name].writeNPYTo(w); err != nil {
 			zw.Close()
 			return err
 		}
 	}
-	return zw.Close()
-}
-
-func MustWriteNPZ[T candy.D](path string, items map[string]*Tensor[T]) {
-	if err := WriteNPZ[T](path, items); err != nil {
-		panic(err)
+	if err := zw.Close(); err != nil {
+		return err
 	}
+	return f.Close()
 }
@@ -1,9 +1,14 @@
 package tensor_test
 
 import (
+	"archive/zip"
+	"encoding/binary"
 	"math"
+	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gocnn/candy"
@@ -235,3 +240,143 @@ func TestNPZReadByNameOrder(t *testing.T) {
 		t.Fatalf("order[1] mismatch")
 	}
 }
+
+// writeRawNPY writes a version 1.0 .npy file with the given header dict and payload.
+func writeRawNPY(t *testing.T, path, dict string, payload []byte) {
+	t.Helper()
+	h := []byte(dict)
+	for (10+len(h)+1)%64 != 0 {
+		h = append(h, ' ')
+	}
+	h = append(h, '\n')
+	buf := append([]byte{0x93, 'N', 'U', 'M', 'P', 'Y', 1, 0}, binary.LittleEndian.AppendUint16(nil, uint16(len(h)))...)
+	buf = append(append(buf, h...), payload...)
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNPYForeignDescrs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	tests := []struct {
+		descr   string
+		payload []byte
+		want    []int64
+	}{
+		{"|b1", []byte{1, 0, 1}, []int64{1, 0, 1}},
+		{"?", []byte{0, 1, 1}, []int64{0, 1, 1}},
+		{"|i1", []byte{0xff, 2, 0x80}, []int64{-1, 2, -128}},
+		{"<i2", []byte{0xfe, 0xff, 3, 0, 0, 1}, []int64{-2, 3, 256}},
+		{">i2", []byte{0xff, 0xfe, 0, 3, 1, 0}, []int64{-2, 3, 256}},
+		{"<u2", []byte{0xff, 0xff, 1, 0, 0, 0}, []int64{65535, 1, 0}},
+		{">i4", []byte{0xff, 0xff, 0xff, 0xf9, 0, 0, 0, 5, 0, 1, 0, 0}, []int64{-7, 5, 65536}},
+		{"<u8", binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint64(nil, 1), 2), 3), []int64{1, 2, 3}},
+		{">i8", binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, 9), 1<<40), math.MaxUint64), []int64{9, 1 << 40, -1}},
+	}
+	for i, tt := range tests {
+		path := filepath.Join(dir, strconv.Itoa(i)+".npy")
+		writeRawNPY(t, path, "{'descr': '"+tt.descr+"', 'fortran_order': False, 'shape': (3,), }", tt.payload)
+		if got := tensor.MustReadNPY[int64](path).Data(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v want %v", tt.descr, got, tt.want)
+		}
+		want := make([]float32, len(tt.want))
+		for j, v := range tt.want {
+			want[j] = float32(v)
+		}
+		if got := tensor.MustReadNPY[float32](path).Data(); !slices.Equal(got, want) {
+			t.Errorf("%s as f32: got %v want %v", tt.descr, got, want)
+		}
+	}
+
+	path := filepath.Join(dir, "bef8.npy")
+	writeRawNPY(t, path, "{'descr': '>f8', 'fortran_order': False, 'shape': (2,), }", binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, math.Float64bits(1.5)), math.Float64bits(-2)))
+	if got := tensor.MustReadNPY[float64](path).Data(); !slices.Equal(got, []float64{1.5, -2}) {
+		t.Fatalf(">f8: got %v", got)
+	}
+}
+
+func TestNPYFortranOrder(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	// np.asfortranarray(np.arange(6, dtype='<f4').reshape(2, 3)) stores columns first.
+	path := filepath.Join(dir, "f.npy")
+	var payload []byte
+	for _, v := range []float32{0, 3, 1, 4, 2, 5} {
+		payload = binary.LittleEndian.AppendUint32(payload, math.Float32bits(v))
+	}
+	writeRawNPY(t, path, "{'descr': '<f4', 'fortran_order': True, 'shape': (2, 3), }", payload)
+	x := tensor.MustReadNPY[float32](path)
+	if !x.Shape().Equal(candy.NewShape(2, 3)) || !slices.Equal(x.Data(), []float32{0, 1, 2, 3, 4, 5}) {
+		t.Fatalf("got %v", x)
+	}
+	if !x.Layout().IsFortranContiguous() || x.Layout().IsContiguous() {
+		t.Fatalf("expected a column-major view, stride %v", x.Stride())
+	}
+
+	// Ops on the view must read it through its strides, matching a C-order copy.
+	c := tensor.MustNew([]float32{0, 1, 2, 3, 4, 5}, candy.NewShape(2, 3), candy.CPU)
+	for name, op := range map[string]func(*tensor.Tensor[float32]) *tensor.Tensor[float32]{
+		"neg relu": func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustAddScalar(-2).MustRelu() },
+		"sum":      func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustSum([]int{1}) },
+		"softmax":  func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustFastSoftmax() },
+		"matmul":   func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustMatMul(c.MustT()) },
+		"add":      func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustAdd(c) },
+	} {
+		if got, want := op(x).Data(), op(c).Data(); !slices.Equal(got, want) {
+			t.Errorf("%s on fortran array = %v, want %v", name, got, want)
+		}
+	}
+
+	// Column-major views are written back with fortran_order set.
+	out := filepath.Join(dir, "out.npy")
+	x.MustWriteNPY(out)
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw[:128]), "'fortran_order': True") || !slices.Equal(raw[len(raw)-len(payload):], payload) {
+		t.Fatalf("fortran write: %q", raw)
+	}
+	if got := tensor.MustReadNPY[float32](out).Data(); !slices.Equal(got, x.Data()) {
+		t.Fatalf("fortran round trip: %v", got)
+	}
+
+	// Other strided views are materialized in C order.
+	y := arange(t, 3, 4).MustNarrow(1, 1, 2)
+	y.MustWriteNPY(out)
+	if got := tensor.MustReadNPY[float32](out); !got.Shape().Equal(y.Shape()) || !slices.Equal(got.Data(), y.Data()) {
+		t.Fatalf("strided write: %v", got)
+	}
+}
+
+func TestNPZCompressed(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	items := map[string]*tensor.Tensor[int64]{
+		"zeros": tensor.MustZeros[int64](candy.NewShape(64, 64), candy.CPU),
+		"ids":   tensor.MustNew([]int64{3, 1, 2}, candy.NewShape(3), candy.CPU),
+	}
+	stored, compressed := filepath.Join(dir, "s.npz"), filepath.Join(dir, "c.npz")
+	tensor.MustWriteNPZ(stored, items)
+	tensor.MustWriteNPZCompressed(compressed, items)
+	for path, method := range map[string]uint16{stored: zip.Store, compressed: zip.Deflate} {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range zr.File {
+			if f.Method != method {
+				t.Errorf("%s: %s method %d, want %d", filepath.Base(path), f.Name, f.Method, method)
+			}
+		}
+		zr.Close()
+	}
+	m := tensor.MustReadNPZ[int64](compressed)
+	if !slices.Equal(m["ids"].Data(), []int64{3, 1, 2}) || m["zeros"].Shape().Numel() != 64*64 {
+		t.Fatalf("compressed read: %v", m)
+	}
+	if got := tensor.MustReadNPZByName[float64](compressed, []string{"ids"}); !slices.Equal(got[0].Data(), []float64{3, 1, 2}) {
+		t.Fatalf("by name: %v", got[0])
+	}
+}
@@ -0,0 +1,391 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+
+	"github.com/gocnn/candy"
+)
+
+// NPYFile is an open .npy file whose header has been parsed once. Element
+// ranges and row ranges are read on demand, either with positioned reads or
+// from a memory mapping of the file, and decoded to T. Files opened with
+// OpenNPYAppend or CreateNPY can grow by appending rows.
+type NPYFile[T candy.D] struct {
+	f          *os.File
+	shape      []int
+	descr      npyDescr
+	fortran    bool
+	dataOffset int64
+	writable   bool
+	mapped     []byte
+	unmap      func() error
+}
+
+// OpenNPY opens a .npy file for reading.
+func OpenNPY[T candy.D](path string) (*NPYFile[T], error) {
+	return openNPYFile[T](path, os.O_RDONLY)
+}
+
+// MustOpenNPY opens a .npy file for reading, panicking on error.
+func MustOpenNPY[T candy.D](path string) *NPYFile[T] {
+	f, err := OpenNPY[T](path)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// OpenNPYAppend opens an existing C-order .npy file for reading and appending
+// rows. The stored dtype must be the little-endian encoding of T.
+func OpenNPYAppend[T candy.D](path string) (*NPYFile[T], error) {
+	nf, err := openNPYFile[T](path, os.O_RDWR)
+	if err != nil {
+		return nil, err
+	}
+	if err := nf.checkAppendable(); err != nil {
+		nf.Close()
+		return nil, err
+	}
+	nf.writable = true
+	return nf, nil
+}
+
+// MustOpenNPYAppend opens a .npy file for appending, panicking on error.
+func MustOpenNPYAppend[T candy.D](path string) *NPYFile[T] {
+	f, err := OpenNPYAppend[T](path)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// CreateNPY creates an empty .npy file of shape (0, rowDims...) ready for
+// appending rows. The header reserves room so appends rarely move the data.
+func CreateNPY[T candy.D](path string, rowDims ...int) (*NPYFile[T], error) {
+	dt := candy.DTypeOf[T]()
+	ds, err := dtypeToDescr(dt)
+	if err != nil {
+		return nil, err
+	}
+	descr, err := parseDescr(ds)
+	if err != nil {
+		return nil, err
+	}
+	shape := append([]int{0}, rowDims...)
+	h, err := npyHeaderBytes(dt, shape, false, 0)
+	if err != nil {
+		return nil, err
+	}
+	if h, err = npyHeaderBytes(dt, shape, false, len(h)+64); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(h); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &NPYFile[T]{f: f, shape: shape, descr: descr, dataOffset: int64(len(h)), writable: true}, nil
+}
+
+// MustCreateNPY creates an empty appendable .npy file, panicking on error.
+func MustCreateNPY[T candy.D](path string, rowDims ...int) *NPYFile[T] {
+	f, err := CreateNPY[T](path, rowDims...)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func openNPYFile[T candy.D](path string, flag int) (*NPYFile[T], error) {
+	f, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return nil, err
+	}
+	h, err := readHeader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	hdr, err := parseHeader(h)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := npyNumel(hdr.shape, hdr.descr.size); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("npy: %s: %w", path, err)
+	}
+	off, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	nf := &NPYFile[T]{f: f, shape: hdr.shape, descr: hdr.descr, fortran: hdr.fortranOrder, dataOffset: off}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if need := off + int64(nf.numel()*nf.descr.size); st.Size() < need {
+		f.Close()
+		return nil, fmt.Errorf("npy: %s is truncated: %d bytes, header needs %d", path, st.Size(), need)
+	}
+	return nf, nil
+}
+
+// Close releases the mapping, if any, and closes the file.
+func (nf *NPYFile[T]) Close() error {
+	err := nf.release()
+	if cerr := nf.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Shape returns the array shape recorded in the header.
+func (nf *NPYFile[T]) Shape() *candy.Shape {
+	return candy.NewShapeFrom(slices.Clone(nf.shape))
+}
+
+// Len returns the number of rows, the size of the first dimension.
+func (nf *NPYFile[T]) Len() int {
+	if len(nf.shape) == 0 {
+		return 1
+	}
+	return nf.shape[0]
+}
+
+// FortranOrder reports whether the data is stored column-major.
+func (nf *NPYFile[T]) FortranOrder() bool {
+	return nf.fortran
+}
+
+// Mmap maps the data region into memory so later reads copy from the mapping
+// instead of issuing file reads. The mapping is refreshed after appends.
+func (nf *NPYFile[T]) Mmap() error {
+	if err := nf.release(); err != nil {
+		return err
+	}
+	data, unmap, err := mmapFile(nf.f, int(nf.dataOffset)+nf.numel()*nf.descr.size)
+	if err != nil {
+		return fmt.Errorf("npy: mmap: %w", err)
+	}
+	nf.mapped, nf.unmap = data, unmap
+	return nil
+}
+
+// ReadRange reads count elements starting at flat element start, in storage
+// order, as a 1-D tensor.
+func (nf *NPYFile[T]) ReadRange(start, count int) (*Tensor[T], error) {
+	if start < 0 || count < 0 || start > nf.numel() || count > nf.numel()-start {
+		return nil, fmt.Errorf("npy: range of %d elements at %d out of bounds for %d elements", count, start, nf.numel())
+	}
+	data, err := nf.read(start, count)
+	if err != nil {
+		return nil, err
+	}
+	return New(data, candy.NewShape(count), candy.CPU)
+}
+
+// MustReadRange reads a flat element range, panicking on error.
+func (nf *NPYFile[T]) MustReadRange(start, count int) *Tensor[T] {
+	t, err := nf.ReadRange(start, count)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ReadRows reads count rows starting at row start as a tensor of shape
+// (count, shape[1:]...). The file must be in C order.
+func (nf *NPYFile[T]) ReadRows(start, count int) (*Tensor[T], error) {
+	if nf.fortran {
+		return nil, errors.New("npy: row reads need a C-order file")
+	}
+	if len(nf.shape) == 0 {
+		return nil, errors.New("npy: row reads need at least one dimension")
+	}
+	if start < 0 || count < 0 || start > nf.shape[0] || count > nf.shape[0]-start {
+		return nil, fmt.Errorf("npy: %d rows at %d out of bounds for %d rows", count, start, nf.shape[0])
+	}
+	row := nf.rowNumel()
+	data, err := nf.read(start*row, count*row)
+	if err != nil {
+		return nil, err
+	}
+	dims := append([]int{count}, nf.shape[1:]...)
+	return New(data, candy.NewShapeFrom(dims), candy.CPU)
+}
+
+// MustReadRows reads a row range, panicking on error.
+func (nf *NPYFile[T]) MustReadRows(start, count int) *Tensor[T] {
+	t, err := nf.ReadRows(start, count)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Append writes the rows of t, whose shape must be (n, shape[1:]...), at the
+// end of the file and updates the header. When the new header no longer fits
+// in the reserved space the data is moved to make room.
+func (nf *NPYFile[T]) Append(t *Tensor[T]) error {
+	if !nf.writable {
+		return errors.New("npy: file not opened for appending")
+	}
+	dims := t.Dims()
+	if len(dims) != len(nf.shape) || len(dims) == 0 || !slices.Equal(dims[1:], nf.shape[1:]) {
+		return fmt.Errorf("npy: cannot append shape %v to %v", dims, nf.shape)
+	}
+	remap := nf.mapped != nil
+	if _, err := nf.f.WriteAt(t.littleEndianBytes(), nf.dataOffset+int64(nf.numel()*nf.descr.size)); err != nil {
+		return err
+	}
+	nf.shape[0] += dims[0]
+	if err := nf.writeHeader(); err != nil {
+		return err
+	}
+	if remap {
+		return nf.Mmap()
+	}
+	return nil
+}
+
+// MustAppend appends rows, panicking on error.
+func (nf *NPYFile[T]) MustAppend(t *Tensor[T]) {
+	if err := nf.Append(t); err != nil {
+		panic(err)
+	}
+}
+
+// npyNumel returns the element count of a header shape, rejecting negative
+// dims and shapes whose byte size of elements of size bytes overflows an int.
+func npyNumel(dims []int, size int) (int, error) {
+	n, limit := 1, math.MaxInt/max(size, 1)
+	for _, d := range dims {
+		if d < 0 {
+			return 0, fmt.Errorf("negative dim in shape %v", dims)
+		}
+		if d > 0 && n > limit/d {
+			return 0, fmt.Errorf("shape %v overflows", dims)
+		}
+		n *= d
+	}
+	return n, nil
+}
+
+func (nf *NPYFile[T]) numel() int {
+	n := 1
+	for _, d := range nf.shape {
+		n *= d
+	}
+	return n
+}
+
+func (nf *NPYFile[T]) rowNumel() int {
+	n := 1
+	for _, d := range nf.shape[1:] {
+		n *= d
+	}
+	return n
+}
+
+func (nf *NPYFile[T]) release() error {
+	if nf.unmap == nil {
+		return nil
+	}
+	err := nf.unmap()
+	nf.mapped, nf.unmap = nil, nil
+	return err
+}
+
+// read decodes count elements starting at element start.
+func (nf *NPYFile[T]) read(start, count int) ([]T, error) {
+	size := nf.descr.size
+	off := nf.dataOffset + int64(start*size)
+	var raw []byte
+	if nf.mapped != nil {
+		raw = nf.mapped[off : off+int64(count*size)]
+	} else {
+		raw = make([]byte, count*size)
+		if _, err := nf.f.ReadAt(raw, off); err != nil {
+			return nil, fmt.Errorf("npy: read: %w", err)
+		}
+	}
+	out := make([]T, count)
+	decodeNPY(nf.descr, raw, out)
+	return out, nil
+}
+
+func (nf *NPYFile[T]) checkAppendable() error {
+	if nf.fortran {
+		return errors.New("npy: cannot append to a Fortran-order file")
+	}
+	if len(nf.shape) == 0 {
+		return errors.New("npy: cannot append to a 0-d array")
+	}
+	ds, err := dtypeToDescr(candy.DTypeOf[T]())
+	if err != nil {
+		return err
+	}
+	want, err := parseDescr(ds)
+	if err != nil {
+		return err
+	}
+	if nf.descr != want {
+		return fmt.Errorf("npy: cannot append %v to stored descr %c%d", candy.DTypeOf[T](), nf.descr.kind, nf.descr.size)
+	}
+	return nil
+}
+
+// writeHeader rewrites the header for the current shape, moving the data
+// forward when the header outgrows its space.
+func (nf *NPYFile[T]) writeHeader() error {
+	dt := candy.DTypeOf[T]()
+	h, err := npyHeaderBytes(dt, nf.shape, false, int(nf.dataOffset))
+	if err != nil {
+		return err
+	}
+	if int64(len(h)) != nf.dataOffset {
+		if h, err = npyHeaderBytes(dt, nf.shape, false, len(h)+64); err != nil {
+			return err
+		}
+		if err := nf.shiftData(int64(len(h))); err != nil {
+			return err
+		}
+	}
+	_, err = nf.f.WriteAt(h, 0)
+	return err
+}
+
+// shiftData moves the data region to start at newOffset, copying backwards
+// so the regions may overlap.
+func (nf *NPYFile[T]) shiftData(newOffset int64) error {
+	if err := nf.release(); err != nil {
+		return err
+	}
+	const chunk = 1 << 20
+	n := int64(nf.numel() * nf.descr.size)
+	buf := make([]byte, chunk)
+	for end := n; end > 0; {
+		start := max(end-chunk, 0)
+		b := buf[:end-start]
+		if _, err := nf.f.ReadAt(b, nf.dataOffset+start); err != nil {
+			return err
+		}
+		if _, err := nf.f.WriteAt(b, newOffset+start); err != nil {
+			return err
+		}
+		end = start
+	}
+	nf.dataOffset = newOffset
+	return nil
+}
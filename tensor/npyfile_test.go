@@ -0,0 +1,139 @@
+package tensor_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+func TestNPYFileReadRanges(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "x.npy")
+	arange(t, 5, 2, 3).MustWriteNPY(path)
+
+	for _, mmap := range []bool{false, true} {
+		f := tensor.MustOpenNPY[float32](path)
+		if mmap {
+			if err := f.Mmap(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if f.Len() != 5 || !f.Shape().Equal(candy.NewShape(5, 2, 3)) {
+			t.Fatalf("shape %v len %d", f.Shape(), f.Len())
+		}
+		if got := f.MustReadRange(7, 4).Data(); !slices.Equal(got, []float32{7, 8, 9, 10}) {
+			t.Fatalf("mmap=%v range = %v", mmap, got)
+		}
+		rows := f.MustReadRows(3, 2)
+		if !rows.Shape().Equal(candy.NewShape(2, 2, 3)) || rows.Data()[0] != 18 || rows.Data()[11] != 29 {
+			t.Fatalf("mmap=%v rows = %v", mmap, rows)
+		}
+		if _, err := f.ReadRows(4, 2); err == nil {
+			t.Fatal("expected out of bounds error")
+		}
+		if _, err := f.ReadRange(1, math.MaxInt); err == nil {
+			t.Fatal("expected out of bounds error for an overflowing range")
+		}
+		if _, err := f.ReadRows(1, math.MaxInt); err == nil {
+			t.Fatal("expected out of bounds error for overflowing rows")
+		}
+		if err := f.Append(arange(t, 1, 2, 3)); err == nil {
+			t.Fatal("expected error appending to a read-only file")
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reads convert from the stored dtype.
+	if got := tensor.MustOpenNPY[int64](path).MustReadRange(0, 3).Data(); !slices.Equal(got, []int64{0, 1, 2}) {
+		t.Fatalf("int64 range = %v", got)
+	}
+}
+
+func TestNPYFileAppend(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "feat.npy")
+	f := tensor.MustCreateNPY[float32](path, 3)
+	if err := f.Mmap(); err != nil {
+		t.Fatal(err)
+	}
+	f.MustAppend(arange(t, 2, 3))
+	f.MustAppend(arange(t, 1, 3).MustAffine(1, 6))
+	if err := f.Append(arange(t, 2, 4)); err == nil {
+		t.Fatal("expected shape mismatch error")
+	}
+	if got := f.MustReadRows(1, 2).Data(); !slices.Equal(got, []float32{3, 4, 5, 6, 7, 8}) {
+		t.Fatalf("rows after append = %v", got)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tensor.MustReadNPY[float32](path); !got.Shape().Equal(candy.NewShape(3, 3)) || !slices.Equal(got.Data(), arange(t, 9).Data()) {
+		t.Fatalf("reread = %v", got)
+	}
+
+	// Reopen and append enough rows to lengthen the shape in the header.
+	f = tensor.MustOpenNPYAppend[float32](path)
+	for range 4 {
+		f.MustAppend(tensor.MustZeros[float32](candy.NewShape(2, 3), candy.CPU))
+	}
+	f.Close()
+	got := tensor.MustReadNPY[float32](path)
+	if !got.Shape().Equal(candy.NewShape(11, 3)) || !slices.Equal(got.Data()[:9], arange(t, 9).Data()) {
+		t.Fatalf("after reopen = %v", got)
+	}
+	if _, err := tensor.OpenNPYAppend[int64](path); err == nil {
+		t.Fatal("expected dtype mismatch error")
+	}
+}
+
+func TestNPYFileHeaderGrowth(t *testing.T) {
+	t.Parallel()
+	// An unpadded header leaves no room for the shape to grow from 9 to 10 rows.
+	path := filepath.Join(t.TempDir(), "tight.npy")
+	dict := "{'descr': '<i8', 'fortran_order': False, 'shape': (9,), }\n"
+	raw := append([]byte{0x93, 'N', 'U', 'M', 'P', 'Y', 1, 0, byte(len(dict)), 0}, dict...)
+	for i := range 9 {
+		raw = append(raw, byte(i), 0, 0, 0, 0, 0, 0, 0)
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f := tensor.MustOpenNPYAppend[int64](path)
+	f.MustAppend(tensor.MustNew([]int64{9}, candy.NewShape(1), candy.CPU))
+	if got := f.MustReadRange(0, 10).Data(); !slices.Equal(got, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatalf("range after shift = %v", got)
+	}
+	f.Close()
+	if got := tensor.MustReadNPY[int64](path).Data(); !slices.Equal(got, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatalf("reread = %v", got)
+	}
+}
+
+func TestNPYFileBadShape(t *testing.T) {
+	t.Parallel()
+	for name, shape := range map[string]string{
+		"negative": "(-1, 2)",
+		"overflow": "(4611686018427387904, 4)",
+	} {
+		path := filepath.Join(t.TempDir(), name+".npy")
+		dict := "{'descr': '<i8', 'fortran_order': False, 'shape': " + shape + ", }\n"
+		raw := append([]byte{0x93, 'N', 'U', 'M', 'P', 'Y', 1, 0, byte(len(dict)), 0}, dict...)
+		raw = append(raw, make([]byte, 16)...)
+		if err := os.WriteFile(path, raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tensor.OpenNPY[int64](path); err == nil {
+			t.Errorf("%s: expected error opening shape %s", name, shape)
+		}
+		if _, err := tensor.ReadNPY[int64](path); err == nil {
+			t.Errorf("%s: expected error reading shape %s", name, shape)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tensor
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/gocnn/candy"
 )
@@ -84,11 +85,11 @@ func AffineForward[T candy.D](scale, bias float64) ForwardFunc[T] {
 			return nil, fmt.Errorf("affine forward: expected 1 input, got %d", len(inputs))
 		}
 		x := inputs[0]
-		data, err := x.storage.Affine(x.layout, T(scale), T(bias))
+		data, err := x.storage.Affine(x.layout, candy.FromFloat64[T](scale), candy.FromFloat64[T](bias))
 		if err != nil {
 			return nil, fmt.Errorf("affine forward: failed to compute affine: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -1302,11 +1303,17 @@ func Conv1dForward[T candy.D](p *candy.Conv1DParams) ForwardFunc[T] {
 		if x.Rank() != 3 || w.Rank() != 3 {
 			return nil, fmt.Errorf("conv1d forward: tensors must be 3D")
 		}
-		s := candy.NewShapeFrom([]int{p.Batch, p.OutCh, p.OutLen()})
+		lOut := p.OutLen()
+		s := candy.NewShapeFrom([]int{p.Batch, p.OutCh, lOut})
 		data, err := x.storage.Conv1d(x.layout, w.storage, w.layout, p)
 		if err != nil {
 			return nil, fmt.Errorf("conv1d forward: failed to conv1d: %w", err)
 		}
+		// The backend writes NLC; copy it out to contiguous NCL.
+		stride := []int{p.OutCh * lOut, 1, p.OutCh}
+		if data, err = data.Copy(candy.NewLayout(s, stride, 0), data); err != nil {
+			return nil, fmt.Errorf("conv1d forward: failed to copy output: %w", err)
+		}
 		return NewFrom(data, candy.Contiguous(s), x.dtype, x.device), nil
 	}
 }
@@ -1343,20 +1350,26 @@ func Conv1dBackward[T candy.D](p *candy.Conv1DParams) BackwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("conv1d backward: failed to transpose grad: %w", err)
 		}
+		// Correlating x with the grad swaps the roles of stride and dilation.
 		kernelP := &candy.Conv1DParams{
 			Batch:  p.InCh,
 			InCh:   p.Batch,
 			InLen:  p.InLen,
 			OutCh:  p.OutCh,
 			KSize:  l,
-			Stride: p.Stride,
+			Stride: p.Dilate,
 			Pad:    p.Pad,
-			Dilate: p.Dilate,
+			Dilate: p.Stride,
 		}
 		dwt, err := xt.Conv1d(gt, kernelP)
 		if err != nil {
 			return nil, fmt.Errorf("conv1d backward: failed to compute dwt: %w", err)
 		}
+		if dwt.Dim(2) > p.KSize {
+			if dwt, err = dwt.Narrow(2, 0, p.KSize); err != nil {
+				return nil, fmt.Errorf("conv1d backward: failed to narrow dwt: %w", err)
+			}
+		}
 		dw, err := dwt.Transpose(0, 1)
 		if err != nil {
 			return nil, fmt.Errorf("conv1d backward: failed to transpose dwt: %w", err)
@@ -1413,12 +1426,13 @@ func ConvTranspose1dBackward[T candy.D](p *candy.ConvT1DParams) BackwardFunc[T]
 		if err != nil {
 			return nil, fmt.Errorf("convTranspose1d backward: failed to transpose x: %w", err)
 		}
+		// Correlate the grad with x, which acts as the kernel.
 		kernelP := &candy.Conv1DParams{
 			Batch:  p.OutCh,
 			InCh:   p.Batch,
-			InLen:  p.InLen,
+			InLen:  g.Dims()[2],
 			OutCh:  p.InCh,
-			KSize:  g.Dims()[2],
+			KSize:  p.InLen,
 			Stride: p.Dilate,
 			Pad:    p.Pad,
 			Dilate: p.Stride,
@@ -1427,6 +1441,11 @@ func ConvTranspose1dBackward[T candy.D](p *candy.ConvT1DParams) BackwardFunc[T]
 		if err != nil {
 			return nil, fmt.Errorf("convTranspose1d backward: failed to compute dwt: %w", err)
 		}
+		if dwt.Dim(2) > p.KSize {
+			if dwt, err = dwt.Narrow(2, 0, p.KSize); err != nil {
+				return nil, fmt.Errorf("convTranspose1d backward: failed to narrow dwt: %w", err)
+			}
+		}
 		dw, err := dwt.Transpose(0, 1)
 		if err != nil {
 			return nil, fmt.Errorf("convTranspose1d backward: failed to transpose dwt: %w", err)
@@ -1452,14 +1471,12 @@ func Conv2dForward[T candy.D](p *candy.Conv2DParams) ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("conv2d forward: failed to conv2d: %w", err)
 		}
-		var layout *candy.Layout
-		if x.layout.IsContiguous() && w.layout.IsContiguous() {
-			stride := []int{p.OutCh * hOut * wOut, 1, wOut * p.OutCh, p.OutCh}
-			layout = candy.NewLayout(s, stride, 0)
-		} else {
-			layout = candy.Contiguous(s)
+		// The backend writes NHWC; copy it out to contiguous NCHW.
+		stride := []int{p.OutCh * hOut * wOut, 1, wOut * p.OutCh, p.OutCh}
+		if data, err = data.Copy(candy.NewLayout(s, stride, 0), data); err != nil {
+			return nil, fmt.Errorf("conv2d forward: failed to copy output: %w", err)
 		}
-		return NewFrom(data, layout, x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(s), x.dtype, x.device), nil
 	}
 }
 
@@ -1469,56 +1486,15 @@ func Conv2dBackward[T candy.D](p *candy.Conv2DParams) BackwardFunc[T] {
 		if len(inputs) != 2 {
 			return nil, fmt.Errorf("conv2d backward: expected 2 inputs, got %d", len(inputs))
 		}
-		x, w := inputs[0].Detach(), inputs[1].Detach()
-		gh, gw := g.Dims()[2], g.Dims()[3]
-		oh := (gh-1)*p.Stride + p.Dilate*(p.KH-1) + 1 - 2*p.Pad
-		ow := (gw-1)*p.Stride + p.Dilate*(p.KW-1) + 1 - 2*p.Pad
-		gradP := &candy.ConvT2DParams{
-			Batch:  p.Batch,
-			InCh:   p.OutCh,
-			InH:    gh,
-			InW:    gw,
-			OutCh:  p.InCh,
-			KH:     p.KH,
-			KW:     p.KW,
-			Stride: p.Stride,
-			Pad:    p.Pad,
-			OutPad: max(p.InH-oh, p.InW-ow),
-			Dilate: p.Dilate,
-		}
-		dx, err := g.ConvTranspose2d(w, gradP)
-		if err != nil {
-			return nil, fmt.Errorf("conv2d backward: failed to compute dx: %w", err)
-		}
-		xt, err := x.Transpose(0, 1)
-		if err != nil {
-			return nil, fmt.Errorf("conv2d backward: failed to transpose x: %w", err)
-		}
-		gt, err := g.Transpose(0, 1)
-		if err != nil {
-			return nil, fmt.Errorf("conv2d backward: failed to transpose grad: %w", err)
-		}
-		kernelP := &candy.Conv2DParams{
-			Batch:  p.InCh,
-			InCh:   p.Batch,
-			InH:    p.InH,
-			InW:    p.InW,
-			OutCh:  p.OutCh,
-			KH:     gh,
-			KW:     gw,
-			Stride: p.Stride,
-			Pad:    p.Pad,
-			Dilate: p.Dilate,
-		}
-		dwt, err := xt.Conv2d(gt, kernelP)
-		if err != nil {
-			return nil, fmt.Errorf("conv2d backward: failed to compute dwt: %w", err)
-		}
-		dw, err := dwt.Transpose(0, 1)
+		x, w := inputs[0], inputs[1]
+		dx, dw, err := x.storage.Conv2dBackward(x.layout, w.storage, w.layout, g.storage, g.layout, p)
 		if err != nil {
-			return nil, fmt.Errorf("conv2d backward: failed to transpose dwt: %w", err)
+			return nil, fmt.Errorf("conv2d backward: %w", err)
 		}
-		return []*Tensor[T]{dx, dw}, nil
+		return []*Tensor[T]{
+			NewFrom(dx, candy.Contiguous(x.Shape()), x.dtype, x.device),
+			NewFrom(dw, candy.Contiguous(w.Shape()), w.dtype, w.device),
+		}, nil
 	}
 }
 
@@ -1572,14 +1548,15 @@ func ConvTranspose2dBackward[T candy.D](p *candy.ConvT2DParams) BackwardFunc[T]
 		if err != nil {
 			return nil, fmt.Errorf("convTranspose2d backward: failed to transpose x: %w", err)
 		}
+		// Correlate the grad with x, which acts as the kernel.
 		kernelP := &candy.Conv2DParams{
 			Batch:  p.OutCh,
 			InCh:   p.Batch,
-			InH:    p.InH,
-			InW:    p.InW,
+			InH:    g.Dims()[2],
+			InW:    g.Dims()[3],
 			OutCh:  p.InCh,
-			KH:     g.Dims()[2],
-			KW:     g.Dims()[3],
+			KH:     p.InH,
+			KW:     p.InW,
 			Stride: p.Dilate,
 			Pad:    p.Pad,
 			Dilate: p.Stride,
@@ -1588,6 +1565,14 @@ func ConvTranspose2dBackward[T candy.D](p *candy.ConvT2DParams) BackwardFunc[T]
 		if err != nil {
 			return nil, fmt.Errorf("convTranspose2d backward: failed to compute dwt: %w", err)
 		}
+		if dwt.Dim(2) > p.KH || dwt.Dim(3) > p.KW {
+			if dwt, err = dwt.Narrow(2, 0, p.KH); err != nil {
+				return nil, fmt.Errorf("convTranspose2d backward: failed to narrow dwt: %w", err)
+			}
+			if dwt, err = dwt.Narrow(3, 0, p.KW); err != nil {
+				return nil, fmt.Errorf("convTranspose2d backward: failed to narrow dwt: %w", err)
+			}
+		}
 		dw, err := dwt.Transpose(0, 1)
 		if err != nil {
 			return nil, fmt.Errorf("convTranspose2d backward: failed to transpose dwt: %w", err)
@@ -1833,6 +1818,212 @@ func UpsampleNearest2dBackward[T candy.D](h, w int) BackwardFunc[T] {
 	}
 }
 
+// Conv3dForward returns a ForwardFunc for 3D convolution.
+func Conv3dForward[T candy.D](p *candy.Conv3DParams) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 2 {
+			return nil, fmt.Errorf("conv3d forward: expected 2 inputs, got %d", len(inputs))
+		}
+		x, w := inputs[0], inputs[1]
+		if x.Rank() != 5 || w.Rank() != 5 {
+			return nil, fmt.Errorf("conv3d forward: tensors must be 5D")
+		}
+		dOut, hOut, wOut := p.OutD(), p.OutH(), p.OutW()
+		s := candy.NewShapeFrom(p.OutDims())
+		data, err := x.storage.Conv3d(x.layout, w.storage, w.layout, p)
+		if err != nil {
+			return nil, fmt.Errorf("conv3d forward: failed to conv3d: %w", err)
+		}
+		// The backend writes NDHWC; copy it out to contiguous NCDHW.
+		stride := []int{p.OutCh * dOut * hOut * wOut, 1, hOut * wOut * p.OutCh, wOut * p.OutCh, p.OutCh}
+		if data, err = data.Copy(candy.NewLayout(s, stride, 0), data); err != nil {
+			return nil, fmt.Errorf("conv3d forward: failed to copy output: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(s), x.dtype, x.device), nil
+	}
+}
+
+// Conv3dBackward returns a BackwardFunc for 3D convolution gradients.
+func Conv3dBackward[T candy.D](p *candy.Conv3DParams) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 2 {
+			return nil, fmt.Errorf("conv3d backward: expected 2 inputs, got %d", len(inputs))
+		}
+		x, w := inputs[0], inputs[1]
+		dx, dw, err := x.storage.Conv3dBackward(x.layout, w.storage, w.layout, g.storage, g.layout, p)
+		if err != nil {
+			return nil, fmt.Errorf("conv3d backward: %w", err)
+		}
+		return []*Tensor[T]{
+			NewFrom(dx, candy.Contiguous(x.Shape()), x.dtype, x.device),
+			NewFrom(dw, candy.Contiguous(w.Shape()), w.dtype, w.device),
+		}, nil
+	}
+}
+
+// ConvTranspose3dForward returns a ForwardFunc for 3D transposed convolution.
+func ConvTranspose3dForward[T candy.D](p *candy.ConvT3DParams) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 2 {
+			return nil, fmt.Errorf("convTranspose3d forward: expected 2 inputs, got %d", len(inputs))
+		}
+		x, w := inputs[0], inputs[1]
+		if x.Rank() != 5 || w.Rank() != 5 {
+			return nil, fmt.Errorf("convTranspose3d forward: tensors must be 5D")
+		}
+		s := candy.NewShapeFrom(p.OutDims())
+		data, err := x.storage.ConvTranspose3d(x.layout, w.storage, w.layout, p)
+		if err != nil {
+			return nil, fmt.Errorf("convTranspose3d forward: failed to convTranspose3d: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(s), x.dtype, x.device), nil
+	}
+}
+
+// ConvTranspose3dBackward returns a BackwardFunc for 3D transposed convolution gradients.
+func ConvTranspose3dBackward[T candy.D](p *candy.ConvT3DParams) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 2 {
+			return nil, fmt.Errorf("convTranspose3d backward: expected 2 inputs, got %d", len(inputs))
+		}
+		x, w := inputs[0].Detach(), inputs[1].Detach()
+		// The transposed convolution is the input gradient of cp, so its
+		// gradients are cp itself and cp's kernel gradient with x as output grad.
+		cp := p.Conv3D()
+		dx, err := g.Conv3d(w, &cp)
+		if err != nil {
+			return nil, fmt.Errorf("convTranspose3d backward: failed to compute dx: %w", err)
+		}
+		_, dw, err := g.storage.Conv3dBackward(g.layout, w.storage, w.layout, x.storage, x.layout, &cp)
+		if err != nil {
+			return nil, fmt.Errorf("convTranspose3d backward: failed to compute dw: %w", err)
+		}
+		return []*Tensor[T]{dx, NewFrom(dw, candy.Contiguous(w.Shape()), w.dtype, w.device)}, nil
+	}
+}
+
+// pool3dShape returns the output shape of a 3D pooling of x, checking its arguments.
+func pool3dShape[T candy.D](x *Tensor[T], kD, kH, kW, sD, sH, sW int) (*candy.Shape, error) {
+	b, c, d, h, w, err := x.Dims5()
+	if err != nil {
+		return nil, fmt.Errorf("expected 5D tensor, got: %w", err)
+	}
+	if kD <= 0 || kH <= 0 || kW <= 0 || sD <= 0 || sH <= 0 || sW <= 0 {
+		return nil, fmt.Errorf("kernel and stride must be positive")
+	}
+	if d < kD || h < kH || w < kW {
+		return nil, fmt.Errorf("kernel (%d,%d,%d) larger than input (%d,%d,%d)", kD, kH, kW, d, h, w)
+	}
+	return candy.NewShapeFrom([]int{b, c, (d-kD)/sD + 1, (h-kH)/sH + 1, (w-kW)/sW + 1}), nil
+}
+
+// AvgPool3dForward returns a ForwardFunc for 3D average pooling.
+func AvgPool3dForward[T candy.D](kD, kH, kW, sD, sH, sW int) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("avgPool3d forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		shape, err := pool3dShape(x, kD, kH, kW, sD, sH, sW)
+		if err != nil {
+			return nil, fmt.Errorf("avgPool3d forward: %w", err)
+		}
+		data, err := x.storage.AvgPool3d(x.layout, kD, kH, kW, sD, sH, sW)
+		if err != nil {
+			return nil, fmt.Errorf("avgPool3d forward: failed to avgpool3d: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(shape), x.dtype, x.device), nil
+	}
+}
+
+// AvgPool3dBackward returns a BackwardFunc for 3D average pooling gradients.
+func AvgPool3dBackward[T candy.D](kD, kH, kW, sD, sH, sW int) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("avgPool3d backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0].Detach()
+		dx, err := x.storage.AvgPool3dBackward(x.layout, g.storage, g.layout, kD, kH, kW, sD, sH, sW)
+		if err != nil {
+			return nil, fmt.Errorf("avgPool3d backward: %w", err)
+		}
+		return []*Tensor[T]{NewFrom(dx, candy.Contiguous(x.Shape()), x.dtype, x.device)}, nil
+	}
+}
+
+// MaxPool3dForward returns a ForwardFunc for 3D max pooling.
+func MaxPool3dForward[T candy.D](kD, kH, kW, sD, sH, sW int) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("maxPool3d forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		shape, err := pool3dShape(x, kD, kH, kW, sD, sH, sW)
+		if err != nil {
+			return nil, fmt.Errorf("maxPool3d forward: %w", err)
+		}
+		data, err := x.storage.MaxPool3d(x.layout, kD, kH, kW, sD, sH, sW)
+		if err != nil {
+			return nil, fmt.Errorf("maxPool3d forward: failed to maxpool3d: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(shape), x.dtype, x.device), nil
+	}
+}
+
+// MaxPool3dBackward returns a BackwardFunc for 3D max pooling gradients.
+// Each window's gradient goes to its first maximum.
+func MaxPool3dBackward[T candy.D](kD, kH, kW, sD, sH, sW int) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("maxPool3d backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0].Detach()
+		dx, err := x.storage.MaxPool3dBackward(x.layout, g.storage, g.layout, kD, kH, kW, sD, sH, sW)
+		if err != nil {
+			return nil, fmt.Errorf("maxPool3d backward: %w", err)
+		}
+		return []*Tensor[T]{NewFrom(dx, candy.Contiguous(x.Shape()), x.dtype, x.device)}, nil
+	}
+}
+
+// UpsampleNearest3dForward returns a ForwardFunc for 3D nearest neighbor upsampling.
+func UpsampleNearest3dForward[T candy.D](d, h, w int) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("upsampleNearest3d forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		if d <= 0 || h <= 0 || w <= 0 {
+			return nil, fmt.Errorf("upsampleNearest3d forward: target dims must be positive, got (%d,%d,%d)", d, h, w)
+		}
+		b, c, _, _, _, err := x.Dims5()
+		if err != nil {
+			return nil, fmt.Errorf("upsampleNearest3d forward: failed to get 5D shape: %w", err)
+		}
+		shape := candy.NewShapeFrom([]int{b, c, d, h, w})
+		data, err := x.storage.UpsampleNearest3d(x.layout, d, h, w)
+		if err != nil {
+			return nil, fmt.Errorf("upsampleNearest3d forward: failed to upsample: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(shape), x.dtype, x.device), nil
+	}
+}
+
+// UpsampleNearest3dBackward returns a BackwardFunc for 3D nearest neighbor upsampling gradients.
+func UpsampleNearest3dBackward[T candy.D](d, h, w int) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("upsampleNearest3d backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0].Detach()
+		dx, err := x.storage.UpsampleNearest3dBackward(x.layout, g.storage, g.layout, d, h, w)
+		if err != nil {
+			return nil, fmt.Errorf("upsampleNearest3d backward: %w", err)
+		}
+		return []*Tensor[T]{NewFrom(dx, candy.Contiguous(x.Shape()), x.dtype, x.device)}, nil
+	}
+}
+
 // GatherForward returns a ForwardFunc for gathering elements along a dimension.
 func GatherForward[T candy.D](dim int) ForwardFunc[T] {
 	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
@@ -1848,7 +2039,7 @@ func GatherForward[T candy.D](dim int) ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("gather forward: failed to gather: %w", err)
 		}
-		return NewFrom(data, idx.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(idx.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -1867,7 +2058,11 @@ func GatherBackward[T candy.D](dim int) BackwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("gather backward: failed to create input grad: %w", err)
 		}
-		data, err := dx.storage.ScatterAdd(dx.layout, idx.storage, idx.layout, g.storage, g.layout, d)
+		gc, err := g.Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("gather backward: failed to make grad contiguous: %w", err)
+		}
+		data, err := dx.storage.ScatterAdd(dx.layout, idx.storage, idx.layout, gc.storage, gc.layout, d)
 		if err != nil {
 			return nil, fmt.Errorf("gather backward: failed to scatter-add: %w", err)
 		}
@@ -1880,6 +2075,61 @@ func GatherBackward[T candy.D](dim int) BackwardFunc[T] {
 	}
 }
 
+// IndexSelectForward returns a ForwardFunc for selecting slices along a dimension with 1D indices.
+func IndexSelectForward[T candy.D, I candy.I](ids *Tensor[I], dim int) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("index select forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		d, err := candy.ResolveAxis(dim, x.Rank())
+		if err != nil {
+			return nil, fmt.Errorf("index select forward: failed to resolve dim: %w", err)
+		}
+		if ids.Rank() != 1 {
+			return nil, fmt.Errorf("index select forward: ids must be 1D, got shape %v", ids.Dims())
+		}
+		idc, err := ids.Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("index select forward: failed to make ids contiguous: %w", err)
+		}
+		data, err := x.storage.IndexSelect(x.layout, idc.storage, idc.layout, d)
+		if err != nil {
+			return nil, fmt.Errorf("index select forward: failed to index select: %w", err)
+		}
+		dims := x.Dims()
+		dims[d] = ids.Numel()
+		return NewFrom(data, candy.Contiguous(candy.NewShapeFrom(dims)), x.dtype, x.device), nil
+	}
+}
+
+// IndexSelectBackward returns a BackwardFunc for index select gradients: g is index-added into zeros.
+func IndexSelectBackward[T candy.D, I candy.I](ids *Tensor[I], dim int) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("index select backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		d, err := candy.ResolveAxis(dim, x.Rank())
+		if err != nil {
+			return nil, fmt.Errorf("index select backward: failed to resolve dim: %w", err)
+		}
+		idc, err := ids.Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("index select backward: failed to make ids contiguous: %w", err)
+		}
+		dx, err := Zeros[T](x.Shape(), x.Device())
+		if err != nil {
+			return nil, fmt.Errorf("index select backward: failed to create input grad: %w", err)
+		}
+		data, err := dx.storage.IndexAdd(dx.layout, idc.storage, idc.layout, g.storage, g.layout, d)
+		if err != nil {
+			return nil, fmt.Errorf("index select backward: failed to index add: %w", err)
+		}
+		return []*Tensor[T]{NewFrom(data, dx.layout, dx.dtype, dx.device)}, nil
+	}
+}
+
 // ScatterForward returns a ForwardFunc for scattering source elements into a destination tensor along a dimension.
 func ScatterForward[T candy.D](dim int) ForwardFunc[T] {
 	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
@@ -1895,7 +2145,7 @@ func ScatterForward[T candy.D](dim int) ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("scatter forward: failed to scatter: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -1936,7 +2186,7 @@ func ScatterAddForward[T candy.D](dim int) ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("scatterAdd forward: failed to scatter-add: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2305,119 +2555,509 @@ func FastMinBackward[T candy.D]() BackwardFunc[T] {
 	}
 }
 
-// FastMaxForward returns a ForwardFunc for maximum over the last dimension.
-func FastMaxForward[T candy.D]() ForwardFunc[T] {
+// FastMaxForward returns a ForwardFunc for maximum over the last dimension.
+func FastMaxForward[T candy.D]() ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("fastMax forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		if x.Rank() == 0 {
+			return nil, fmt.Errorf("fastMax forward: cannot reduce scalar")
+		}
+		data, err := x.storage.FastMax(x.layout)
+		if err != nil {
+			return nil, fmt.Errorf("fastMax forward: failed to compute max: %w", err)
+		}
+		s := make([]int, len(x.Dims()))
+		copy(s, x.Dims())
+		s[len(s)-1] = 1
+		return NewFrom(data, candy.Contiguous(candy.NewShapeFrom(s)), x.dtype, x.device), nil
+	}
+}
+
+// FastMaxBackward returns a BackwardFunc for maximum gradients over the last dimension.
+func FastMaxBackward[T candy.D]() BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("fastMax backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0].Detach()
+		d := x.Rank() - 1
+		m, err := x.FastMax()
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to compute max: %w", err)
+		}
+		mb, err := m.BroadcastAs(x.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to broadcast max: %w", err)
+		}
+		n, err := x.Eq(mb)
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to compute mask: %w", err)
+		}
+		s, err := n.Sum([]int{d})
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to sum mask: %w", err)
+		}
+		sb, err := s.BroadcastAs(x.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to broadcast sum: %w", err)
+		}
+		gb, err := g.BroadcastAs(x.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to broadcast grad: %w", err)
+		}
+		dx, err := n.Mul(gb)
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to apply mask: %w", err)
+		}
+		dx, err = dx.Div(sb)
+		if err != nil {
+			return nil, fmt.Errorf("fastMax backward: failed to normalize: %w", err)
+		}
+		return []*Tensor[T]{dx}, nil
+	}
+}
+
+// FastSoftmaxForward returns a ForwardFunc for softmax along the last dimension.
+func FastSoftmaxForward[T candy.D]() ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("fastSoftmax forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		data, err := x.storage.FastSoftmax(x.layout)
+		if err != nil {
+			return nil, fmt.Errorf("fastSoftmax forward: failed to compute softmax: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
+	}
+}
+
+// FastSoftmaxBackward returns a BackwardFunc for softmax gradients.
+func FastSoftmaxBackward[T candy.D]() BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("fastSoftmax backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0].Detach()
+		s, err := x.FastSoftmax()
+		if err != nil {
+			return nil, fmt.Errorf("fastSoftmax backward: failed to compute softmax: %w", err)
+		}
+		gs, err := g.Mul(s)
+		if err != nil {
+			return nil, fmt.Errorf("fastSoftmax backward: failed to compute g*s: %w", err)
+		}
+		r, err := gs.Sum([]int{x.Rank() - 1})
+		if err != nil {
+			return nil, fmt.Errorf("fastSoftmax backward: failed to sum: %w", err)
+		}
+		rb, err := r.BroadcastAs(x.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("fastSoftmax backward: failed to broadcast: %w", err)
+		}
+		d, err := g.Sub(rb)
+		if err != nil {
+			return nil, fmt.Errorf("fastSoftmax backward: failed to compute g-rb: %w", err)
+		}
+		dx, err := s.Mul(d)
+		if err != nil {
+			return nil, fmt.Errorf("fastSoftmax backward: failed to compute dx: %w", err)
+		}
+		return []*Tensor[T]{dx}, nil
+	}
+}
+
+// RmsNormForward returns a ForwardFunc for RMS normalization over the last dimension: x / sqrt(mean(x²) + eps) * alpha.
+func RmsNormForward[T candy.D](eps float64) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 2 {
+			return nil, fmt.Errorf("rmsNorm forward: expected 2 inputs, got %d", len(inputs))
+		}
+		x, alpha := inputs[0], inputs[1]
+		ac, err := alpha.Detach().Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm forward: failed to make alpha contiguous: %w", err)
+		}
+		data, err := x.storage.FastRmsNorm(x.layout, ac.storage, ac.layout, candy.FromFloat64[T](eps))
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm forward: failed to normalize: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
+	}
+}
+
+// RmsNormBackward returns a BackwardFunc for RMS norm gradients:
+// ∂L/∂x = r(g·α - x̂·mean(g·α·x̂)), ∂L/∂α = Σ g·x̂, with r = 1/sqrt(mean(x²) + eps) and x̂ = x·r.
+func RmsNormBackward[T candy.D](eps float64) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 2 {
+			return nil, fmt.Errorf("rmsNorm backward: expected 2 inputs, got %d", len(inputs))
+		}
+		x, alpha := inputs[0].Detach(), inputs[1].Detach()
+		last := []int{x.Rank() - 1}
+		x2, err := x.Sqr()
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to square: %w", err)
+		}
+		r, err := x2.MeanKeep(last)
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to compute mean: %w", err)
+		}
+		if r, err = invSqrt(r, eps); err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: %w", err)
+		}
+		xh, err := x.BroadcastMul(r)
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to normalize: %w", err)
+		}
+		ga, err := g.BroadcastMul(alpha)
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to scale grad: %w", err)
+		}
+		gx, err := ga.Mul(xh)
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to compute g*α*x̂: %w", err)
+		}
+		m, err := gx.MeanKeep(last)
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to compute mean: %w", err)
+		}
+		dx, err := xh.BroadcastMul(m)
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to compute x̂*mean: %w", err)
+		}
+		if dx, err = ga.Sub(dx); err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to subtract: %w", err)
+		}
+		if dx, err = dx.BroadcastMul(r); err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to compute dx: %w", err)
+		}
+		gxh, err := g.Mul(xh)
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: failed to compute g*x̂: %w", err)
+		}
+		da, err := sumLeading(gxh, alpha.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("rmsNorm backward: %w", err)
+		}
+		return []*Tensor[T]{dx, da}, nil
+	}
+}
+
+// LayerNormForward returns a ForwardFunc for layer normalization over the last dimension:
+// (x - mean(x)) / sqrt(var(x) + eps) * alpha + beta.
+func LayerNormForward[T candy.D](eps float64) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 3 {
+			return nil, fmt.Errorf("layerNorm forward: expected 3 inputs, got %d", len(inputs))
+		}
+		x, alpha, beta := inputs[0], inputs[1], inputs[2]
+		ac, err := alpha.Detach().Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm forward: failed to make alpha contiguous: %w", err)
+		}
+		bc, err := beta.Detach().Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm forward: failed to make beta contiguous: %w", err)
+		}
+		data, err := x.storage.FastLayerNorm(x.layout, ac.storage, ac.layout, bc.storage, bc.layout, candy.FromFloat64[T](eps))
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm forward: failed to normalize: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
+	}
+}
+
+// LayerNormBackward returns a BackwardFunc for layer norm gradients:
+// ∂L/∂x = r(g·α - mean(g·α) - x̂·mean(g·α·x̂)), ∂L/∂α = Σ g·x̂, ∂L/∂β = Σ g,
+// with r = 1/sqrt(var(x) + eps) and x̂ = (x - mean(x))·r.
+func LayerNormBackward[T candy.D](eps float64) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 3 {
+			return nil, fmt.Errorf("layerNorm backward: expected 3 inputs, got %d", len(inputs))
+		}
+		x, alpha, beta := inputs[0].Detach(), inputs[1].Detach(), inputs[2].Detach()
+		last := []int{x.Rank() - 1}
+		mu, err := x.MeanKeep(last)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute mean: %w", err)
+		}
+		xc, err := x.BroadcastSub(mu)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to center: %w", err)
+		}
+		xc2, err := xc.Sqr()
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to square: %w", err)
+		}
+		r, err := xc2.MeanKeep(last)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute variance: %w", err)
+		}
+		if r, err = invSqrt(r, eps); err != nil {
+			return nil, fmt.Errorf("layerNorm backward: %w", err)
+		}
+		xh, err := xc.BroadcastMul(r)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to normalize: %w", err)
+		}
+		ga, err := g.BroadcastMul(alpha)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to scale grad: %w", err)
+		}
+		m1, err := ga.MeanKeep(last)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute mean(g*α): %w", err)
+		}
+		gx, err := ga.Mul(xh)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute g*α*x̂: %w", err)
+		}
+		m2, err := gx.MeanKeep(last)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute mean(g*α*x̂): %w", err)
+		}
+		dx, err := xh.BroadcastMul(m2)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute x̂*mean: %w", err)
+		}
+		if dx, err = dx.BroadcastAdd(m1); err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to add mean: %w", err)
+		}
+		if dx, err = ga.Sub(dx); err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to subtract: %w", err)
+		}
+		if dx, err = dx.BroadcastMul(r); err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute dx: %w", err)
+		}
+		gxh, err := g.Mul(xh)
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: failed to compute g*x̂: %w", err)
+		}
+		da, err := sumLeading(gxh, alpha.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: %w", err)
+		}
+		db, err := sumLeading(g, beta.Shape())
+		if err != nil {
+			return nil, fmt.Errorf("layerNorm backward: %w", err)
+		}
+		return []*Tensor[T]{dx, da, db}, nil
+	}
+}
+
+// invSqrt returns 1/sqrt(v + eps).
+func invSqrt[T candy.D](v *Tensor[T], eps float64) (*Tensor[T], error) {
+	r, err := v.AddScalar(eps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add eps: %w", err)
+	}
+	if r, err = r.Sqrt(); err != nil {
+		return nil, fmt.Errorf("failed to compute sqrt: %w", err)
+	}
+	if r, err = r.Recip(); err != nil {
+		return nil, fmt.Errorf("failed to compute reciprocal: %w", err)
+	}
+	return r, nil
+}
+
+// sumLeading sums t over all but its last dimension and reshapes the result to s.
+func sumLeading[T candy.D](t *Tensor[T], s *candy.Shape) (*Tensor[T], error) {
+	r := t
+	if t.Rank() > 1 {
+		dims := make([]int, t.Rank()-1)
+		for i := range dims {
+			dims[i] = i
+		}
+		var err error
+		if r, err = t.Sum(dims); err != nil {
+			return nil, fmt.Errorf("failed to sum leading dims: %w", err)
+		}
+	}
+	r, err := r.Reshape(s.Dims()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reshape to %v: %w", s, err)
+	}
+	return r, nil
+}
+
+// ropeKernel applies one rope variant to x with the given cos and sin tables.
+type ropeKernel[T candy.D] func(x, cos, sin *Tensor[T]) (candy.BackendStorage[T], error)
+
+// ropeForward returns a ForwardFunc applying kernel to (x, cos, sin).
+func ropeForward[T candy.D](name string, kernel ropeKernel[T]) ForwardFunc[T] {
 	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
-		if len(inputs) != 1 {
-			return nil, fmt.Errorf("fastMax forward: expected 1 input, got %d", len(inputs))
+		if len(inputs) != 3 {
+			return nil, fmt.Errorf("%s forward: expected 3 inputs, got %d", name, len(inputs))
 		}
-		x := inputs[0]
-		if x.Rank() == 0 {
-			return nil, fmt.Errorf("fastMax forward: cannot reduce scalar")
+		x, cos, sin := inputs[0], inputs[1].Detach(), inputs[2].Detach()
+		cos, err := cos.Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("%s forward: failed to make cos contiguous: %w", name, err)
 		}
-		data, err := x.storage.FastMax(x.layout)
+		if sin, err = sin.Contiguous(); err != nil {
+			return nil, fmt.Errorf("%s forward: failed to make sin contiguous: %w", name, err)
+		}
+		data, err := kernel(x, cos, sin)
 		if err != nil {
-			return nil, fmt.Errorf("fastMax forward: failed to compute max: %w", err)
+			return nil, fmt.Errorf("%s forward: failed to rotate: %w", name, err)
 		}
-		s := make([]int, len(x.Dims()))
-		copy(s, x.Dims())
-		s[len(s)-1] = 1
-		return NewFrom(data, candy.Contiguous(candy.NewShapeFrom(s)), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
-// FastMaxBackward returns a BackwardFunc for maximum gradients over the last dimension.
-func FastMaxBackward[T candy.D]() BackwardFunc[T] {
+// ropeBackward returns a BackwardFunc for rope gradients. The input grad is g rotated by the
+// inverse angle, rope(g, cos, -sin). The table grads are the per-pair sums of g·x for cos and
+// g·rope(x, 0, 1) for sin, which pairSum reduces to (b, t, d/2) before any batch reduction.
+func ropeBackward[T candy.D](name string, kernel ropeKernel[T], pairSum func(*Tensor[T]) (*Tensor[T], error)) BackwardFunc[T] {
 	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
-		if len(inputs) != 1 {
-			return nil, fmt.Errorf("fastMax backward: expected 1 input, got %d", len(inputs))
+		if len(inputs) != 3 {
+			return nil, fmt.Errorf("%s backward: expected 3 inputs, got %d", name, len(inputs))
 		}
-		x := inputs[0].Detach()
-		d := x.Rank() - 1
-		m, err := x.FastMax()
-		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to compute max: %w", err)
+		x, cos, sin := inputs[0].Detach(), inputs[1].Detach(), inputs[2].Detach()
+		rotate := func(t, c, s *Tensor[T]) (*Tensor[T], error) {
+			return ApplyOp([]*Tensor[T]{t, c, s}, ropeForward(name, kernel), nil)
 		}
-		mb, err := m.BroadcastAs(x.Shape())
+		ns, err := sin.Neg()
 		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to broadcast max: %w", err)
+			return nil, fmt.Errorf("%s backward: failed to negate sin: %w", name, err)
 		}
-		n, err := x.Eq(mb)
+		dx, err := rotate(g, cos, ns)
 		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to compute mask: %w", err)
+			return nil, fmt.Errorf("%s backward: %w", name, err)
 		}
-		s, err := n.Sum([]int{d})
+		if !inputs[1].IsVar() && !inputs[2].IsVar() {
+			dc, err := cos.ZerosLike()
+			if err != nil {
+				return nil, fmt.Errorf("%s backward: failed to create cos grad: %w", name, err)
+			}
+			ds, err := sin.ZerosLike()
+			if err != nil {
+				return nil, fmt.Errorf("%s backward: failed to create sin grad: %w", name, err)
+			}
+			return []*Tensor[T]{dx, dc, ds}, nil
+		}
+		zc, err := cos.ZerosLike()
 		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to sum mask: %w", err)
+			return nil, fmt.Errorf("%s backward: failed to create zeros: %w", name, err)
 		}
-		sb, err := s.BroadcastAs(x.Shape())
+		ones, err := sin.OnesLike()
 		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to broadcast sum: %w", err)
+			return nil, fmt.Errorf("%s backward: failed to create ones: %w", name, err)
 		}
-		gb, err := g.BroadcastAs(x.Shape())
+		xr, err := rotate(x, zc, ones)
 		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to broadcast grad: %w", err)
+			return nil, fmt.Errorf("%s backward: %w", name, err)
 		}
-		dx, err := n.Mul(gb)
+		table := func(t *Tensor[T], s *candy.Shape) (*Tensor[T], error) {
+			p, err := g.Mul(t)
+			if err != nil {
+				return nil, err
+			}
+			if p, err = pairSum(p); err != nil {
+				return nil, err
+			}
+			if p.Numel() != s.Numel() {
+				if p, err = p.Sum([]int{0}); err != nil {
+					return nil, err
+				}
+			}
+			return p.Reshape(s.Dims()...)
+		}
+		dc, err := table(x, cos.Shape())
 		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to apply mask: %w", err)
+			return nil, fmt.Errorf("%s backward: failed to compute cos grad: %w", name, err)
 		}
-		dx, err = dx.Div(sb)
+		ds, err := table(xr, sin.Shape())
 		if err != nil {
-			return nil, fmt.Errorf("fastMax backward: failed to normalize: %w", err)
+			return nil, fmt.Errorf("%s backward: failed to compute sin grad: %w", name, err)
 		}
-		return []*Tensor[T]{dx}, nil
+		return []*Tensor[T]{dx, dc, ds}, nil
 	}
 }
 
-// FastSoftmaxForward returns a ForwardFunc for softmax along the last dimension.
-func FastSoftmaxForward[T candy.D]() ForwardFunc[T] {
-	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
-		if len(inputs) != 1 {
-			return nil, fmt.Errorf("fastSoftmax forward: expected 1 input, got %d", len(inputs))
-		}
-		x := inputs[0]
-		data, err := x.storage.FastSoftmax(x.layout)
+// RopeIForward returns a ForwardFunc for interleaved rotary embedding of a (b, h, t, d) input,
+// rotating adjacent pairs (x[2i], x[2i+1]) with cos/sin tables of shape (t, d/2) or (b, t, d/2).
+func RopeIForward[T candy.D]() ForwardFunc[T] {
+	return ropeForward("ropeI", ropeIKernel[T])
+}
+
+// RopeIBackward returns a BackwardFunc for interleaved rotary embedding gradients.
+func RopeIBackward[T candy.D]() BackwardFunc[T] {
+	return ropeBackward("ropeI", ropeIKernel[T], func(p *Tensor[T]) (*Tensor[T], error) {
+		b, h, t, d, err := p.Dims4()
 		if err != nil {
-			return nil, fmt.Errorf("fastSoftmax forward: failed to compute softmax: %w", err)
+			return nil, err
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
-	}
+		if p, err = p.Reshape(b, h, t, d/2, 2); err != nil {
+			return nil, err
+		}
+		if p, err = p.Sum([]int{4}); err != nil {
+			return nil, err
+		}
+		return p.Sum([]int{1})
+	})
 }
 
-// FastSoftmaxBackward returns a BackwardFunc for softmax gradients.
-func FastSoftmaxBackward[T candy.D]() BackwardFunc[T] {
-	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
-		if len(inputs) != 1 {
-			return nil, fmt.Errorf("fastSoftmax backward: expected 1 input, got %d", len(inputs))
-		}
-		x := inputs[0].Detach()
-		s, err := x.FastSoftmax()
+func ropeIKernel[T candy.D](x, cos, sin *Tensor[T]) (candy.BackendStorage[T], error) {
+	return x.storage.RopeI(x.layout, cos.storage, cos.layout, sin.storage, sin.layout)
+}
+
+// RopeForward returns a ForwardFunc for half-split rotary embedding of a (b, h, t, d) input,
+// rotating pairs (x[i], x[i+d/2]) with cos/sin tables of shape (t, d/2) or (b, t, d/2).
+func RopeForward[T candy.D]() ForwardFunc[T] {
+	return ropeForward("rope", ropeKernelHalf[T])
+}
+
+// RopeBackward returns a BackwardFunc for half-split rotary embedding gradients.
+func RopeBackward[T candy.D]() BackwardFunc[T] {
+	return ropeBackward("rope", ropeKernelHalf[T], func(p *Tensor[T]) (*Tensor[T], error) {
+		b, h, t, d, err := p.Dims4()
 		if err != nil {
-			return nil, fmt.Errorf("fastSoftmax backward: failed to compute softmax: %w", err)
+			return nil, err
 		}
-		gs, err := g.Mul(s)
-		if err != nil {
-			return nil, fmt.Errorf("fastSoftmax backward: failed to compute g*s: %w", err)
+		if p, err = p.Reshape(b, h, t, 2, d/2); err != nil {
+			return nil, err
 		}
-		r, err := gs.Sum([]int{x.Rank() - 1})
-		if err != nil {
-			return nil, fmt.Errorf("fastSoftmax backward: failed to sum: %w", err)
+		if p, err = p.Sum([]int{3}); err != nil {
+			return nil, err
 		}
-		rb, err := r.BroadcastAs(x.Shape())
+		return p.Sum([]int{1})
+	})
+}
+
+func ropeKernelHalf[T candy.D](x, cos, sin *Tensor[T]) (candy.BackendStorage[T], error) {
+	return x.storage.Rope(x.layout, cos.storage, cos.layout, sin.storage, sin.layout)
+}
+
+// RopeThdForward returns a ForwardFunc for half-split rotary embedding of a (b, t, h, d) input
+// with cos/sin tables of shape (t, d/2) or (b, t, d/2).
+func RopeThdForward[T candy.D]() ForwardFunc[T] {
+	return ropeForward("ropeThd", ropeThdKernel[T])
+}
+
+// RopeThdBackward returns a BackwardFunc for (b, t, h, d) rotary embedding gradients.
+func RopeThdBackward[T candy.D]() BackwardFunc[T] {
+	return ropeBackward("ropeThd", ropeThdKernel[T], func(p *Tensor[T]) (*Tensor[T], error) {
+		b, t, h, d, err := p.Dims4()
 		if err != nil {
-			return nil, fmt.Errorf("fastSoftmax backward: failed to broadcast: %w", err)
+			return nil, err
 		}
-		d, err := g.Sub(rb)
-		if err != nil {
-			return nil, fmt.Errorf("fastSoftmax backward: failed to compute g-rb: %w", err)
+		if p, err = p.Reshape(b, t, h, 2, d/2); err != nil {
+			return nil, err
 		}
-		dx, err := s.Mul(d)
-		if err != nil {
-			return nil, fmt.Errorf("fastSoftmax backward: failed to compute dx: %w", err)
+		if p, err = p.Sum([]int{3}); err != nil {
+			return nil, err
 		}
-		return []*Tensor[T]{dx}, nil
-	}
+		return p.Sum([]int{2})
+	})
+}
+
+func ropeThdKernel[T candy.D](x, cos, sin *Tensor[T]) (candy.BackendStorage[T], error) {
+	return x.storage.RopeThd(x.layout, cos.storage, cos.layout, sin.storage, sin.layout)
 }
 
 // DropoutForward returns a ForwardFunc for dropout.
@@ -2535,8 +3175,8 @@ func WhereCondBackward[T candy.D](c *Tensor[T]) BackwardFunc[T] {
 			return nil, fmt.Errorf("whereCond backward: failed to compute df: %w", err)
 		}
 		return []*Tensor[T]{
-			NewFrom(dt, g.layout.Clone(), g.dtype, g.device),
-			NewFrom(df, g.layout.Clone(), g.dtype, g.device),
+			NewFrom(dt, candy.Contiguous(g.Shape()), g.dtype, g.device),
+			NewFrom(df, candy.Contiguous(g.Shape()), g.dtype, g.device),
 		}, nil
 	}
 }
@@ -2549,7 +3189,7 @@ func CopyForward[T candy.D]() ForwardFunc[T] {
 		}
 		x := inputs[0]
 		s := candy.Contiguous(x.Shape())
-		data, err := x.storage.Copy(s, x.storage)
+		data, err := x.storage.Copy(x.layout, x.storage)
 		if err != nil {
 			return nil, fmt.Errorf("copy forward: failed to copy: %w", err)
 		}
@@ -2567,6 +3207,39 @@ func CopyBackward[T candy.D]() BackwardFunc[T] {
 	}
 }
 
+// ToForward returns a ForwardFunc for moving a tensor to another device.
+func ToForward[T candy.D](dev candy.Device) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("to forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		bd, err := LookupDevice[T](dev)
+		if err != nil {
+			return nil, fmt.Errorf("to forward: %w", err)
+		}
+		data, err := bd.StorageFromSlice(x.Data())
+		if err != nil {
+			return nil, fmt.Errorf("to forward: failed to create storage: %w", err)
+		}
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, dev), nil
+	}
+}
+
+// ToBackward returns a BackwardFunc for device transfer gradients: the gradient moves back to the source device.
+func ToBackward[T candy.D]() BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("to backward: expected 1 input, got %d", len(inputs))
+		}
+		dx, err := g.To(inputs[0].Device())
+		if err != nil {
+			return nil, fmt.Errorf("to backward: failed to move gradient: %w", err)
+		}
+		return []*Tensor[T]{dx}, nil
+	}
+}
+
 // NegForward returns a ForwardFunc for element-wise negation: -x.
 func NegForward[T candy.D]() ForwardFunc[T] {
 	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
@@ -2578,7 +3251,7 @@ func NegForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("neg forward: failed to compute neg: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2607,7 +3280,7 @@ func RecipForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("recip forward: failed to compute recip: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2645,7 +3318,7 @@ func ExpForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("exp forward: failed to compute exp: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2679,7 +3352,7 @@ func LogForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("log forward: failed to compute log: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2709,7 +3382,7 @@ func SinForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("sin forward: failed to compute sin: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2743,7 +3416,7 @@ func CosForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("cos forward: failed to compute cos: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2781,7 +3454,7 @@ func TanhForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("tanh forward: failed to compute tanh: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2827,7 +3500,7 @@ func ErfForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("erf forward: failed to compute erf: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2873,7 +3546,7 @@ func CeilForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("ceil forward: failed to compute ceil: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2902,7 +3575,7 @@ func FloorForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("floor forward: failed to compute floor: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2931,7 +3604,7 @@ func RoundForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("round forward: failed to compute round: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -2960,7 +3633,7 @@ func NormcdfForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("normcdf forward: failed to compute normcdf: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3010,7 +3683,7 @@ func AbsForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("abs forward: failed to compute abs: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3044,7 +3717,7 @@ func SqrForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("sqr forward: failed to compute sqr: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3078,7 +3751,7 @@ func SqrtForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("sqrt forward: failed to compute sqrt: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3116,7 +3789,7 @@ func GeluForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("gelu forward: failed to compute gelu: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3218,7 +3891,7 @@ func GeluErfForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("gelu erf forward: failed to compute gelu_erf: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3296,7 +3969,7 @@ func ReluForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("relu forward: failed to compute relu: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3334,11 +4007,11 @@ func EluForward[T candy.D](alpha float64) ForwardFunc[T] {
 			return nil, fmt.Errorf("elu forward: expected 1 input, got %d", len(inputs))
 		}
 		x := inputs[0]
-		data, err := x.storage.Elu(x.layout, T(alpha))
+		data, err := x.storage.Elu(x.layout, candy.FromFloat64[T](alpha))
 		if err != nil {
 			return nil, fmt.Errorf("elu forward: failed to compute elu: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3392,7 +4065,7 @@ func SiluForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("silu forward: failed to compute silu: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3442,11 +4115,11 @@ func PowfForward[T candy.D](p float64) ForwardFunc[T] {
 			return nil, fmt.Errorf("powf forward: expected 1 input, got %d", len(inputs))
 		}
 		x := inputs[0]
-		data, err := x.storage.Powf(x.layout, T(p))
+		data, err := x.storage.Powf(x.layout, candy.FromFloat64[T](p))
 		if err != nil {
 			return nil, fmt.Errorf("powf forward: failed to compute pow: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3484,7 +4157,7 @@ func SigmoidForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("sigmoid forward: failed to compute sigmoid: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3530,7 +4203,7 @@ func SignForward[T candy.D]() ForwardFunc[T] {
 		if err != nil {
 			return nil, fmt.Errorf("sign forward: failed to compute sign: %w", err)
 		}
-		return NewFrom(data, x.layout.Clone(), x.dtype, x.device), nil
+		return NewFrom(data, candy.Contiguous(x.Shape()), x.dtype, x.device), nil
 	}
 }
 
@@ -3588,6 +4261,285 @@ func TransposeBackward[T candy.D](dim1, dim2 int) BackwardFunc[T] {
 	}
 }
 
+// CatForward returns a ForwardFunc for concatenating inputs along a dimension.
+func CatForward[T candy.D](dim int) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) == 0 {
+			return nil, fmt.Errorf("cat forward: expected at least 1 input")
+		}
+		x0 := inputs[0]
+		d, err := candy.ResolveAxis(dim, x0.Rank())
+		if err != nil {
+			return nil, fmt.Errorf("cat forward: failed to resolve dim: %w", err)
+		}
+		dims := x0.Dims()
+		total := 0
+		for i, x := range inputs {
+			if x.Rank() != x0.Rank() {
+				return nil, fmt.Errorf("cat forward: input %d has rank %d, want %d", i, x.Rank(), x0.Rank())
+			}
+			if x.Device() != x0.Device() {
+				return nil, fmt.Errorf("cat forward: input %d on %s, want %s", i, x.Device(), x0.Device())
+			}
+			for j, n := range x.Dims() {
+				if j != d && n != dims[j] {
+					return nil, fmt.Errorf("cat forward: input %d shape %v incompatible with %v on dim %d", i, x.Dims(), dims, j)
+				}
+			}
+			total += x.Dim(d)
+		}
+		left, right := 1, 1
+		for _, n := range dims[:d] {
+			left *= n
+		}
+		for _, n := range dims[d+1:] {
+			right *= n
+		}
+		dims[d] = total
+		res, err := Zeros[T](candy.NewShapeFrom(dims), x0.Device())
+		if err != nil {
+			return nil, fmt.Errorf("cat forward: failed to create result: %w", err)
+		}
+		off := 0
+		for _, x := range inputs {
+			n := x.Dim(d) * right
+			if n == 0 || left == 0 {
+				continue
+			}
+			xc, err := x.Detach().Contiguous()
+			if err != nil {
+				return nil, fmt.Errorf("cat forward: failed to make contiguous: %w", err)
+			}
+			if err := xc.storage.Copy2d(res.storage, left, n, n, total*right, xc.layout.StartOffset(), off); err != nil {
+				return nil, fmt.Errorf("cat forward: failed to copy: %w", err)
+			}
+			off += n
+		}
+		return res, nil
+	}
+}
+
+// CatBackward returns a BackwardFunc for cat gradients: g is split into per-input narrow slices.
+func CatBackward[T candy.D](dim int) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) == 0 {
+			return nil, fmt.Errorf("cat backward: expected at least 1 input")
+		}
+		d, err := candy.ResolveAxis(dim, g.Rank())
+		if err != nil {
+			return nil, fmt.Errorf("cat backward: failed to resolve dim: %w", err)
+		}
+		grads := make([]*Tensor[T], len(inputs))
+		start := 0
+		for i, x := range inputs {
+			n := x.Dim(d)
+			if grads[i], err = g.Narrow(d, start, n); err != nil {
+				return nil, fmt.Errorf("cat backward: failed to narrow: %w", err)
+			}
+			start += n
+		}
+		return grads, nil
+	}
+}
+
+// PermuteForward returns a ForwardFunc for reordering dimensions.
+func PermuteForward[T candy.D](dims []int) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("permute forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		l, err := x.layout.Permute(dims)
+		if err != nil {
+			return nil, fmt.Errorf("permute forward: failed to permute: %w", err)
+		}
+		return NewFrom(x.storage, l, x.dtype, x.device), nil
+	}
+}
+
+// PermuteBackward returns a BackwardFunc for permute gradients: g is permuted by the inverse permutation.
+func PermuteBackward[T candy.D](dims []int) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("permute backward: expected 1 input, got %d", len(inputs))
+		}
+		inv := make([]int, len(dims))
+		for i, d := range dims {
+			r, err := candy.ResolveAxis(d, len(dims))
+			if err != nil {
+				return nil, fmt.Errorf("permute backward: failed to resolve dim: %w", err)
+			}
+			inv[r] = i
+		}
+		dx, err := g.Permute(inv...)
+		if err != nil {
+			return nil, fmt.Errorf("permute backward: failed to permute: %w", err)
+		}
+		return []*Tensor[T]{dx}, nil
+	}
+}
+
+// Range selects indices Start, Start+Step, ... below Stop along one dimension.
+// Negative Start and Stop count from the end, both are clamped to the dimension
+// size, and a zero Step is treated as 1.
+type Range struct {
+	Start, Stop, Step int
+}
+
+// All selects the whole extent of a dimension.
+var All = Range{Start: 0, Stop: math.MaxInt, Step: 1}
+
+// resolve returns the clamped start, length and step of r for a dimension of size n.
+func (r Range) resolve(n int) (start, length, step int, err error) {
+	step = r.Step
+	if step == 0 {
+		step = 1
+	}
+	if step < 0 {
+		return 0, 0, 0, fmt.Errorf("negative step %d not supported", step)
+	}
+	clamp := func(i int) int {
+		if i < 0 {
+			i += n
+		}
+		return min(max(i, 0), n)
+	}
+	start, stop := clamp(r.Start), clamp(r.Stop)
+	if stop > start {
+		length = (stop - start + step - 1) / step
+	}
+	return start, length, step, nil
+}
+
+// embedGrad places g into zeros whose dim has the given size, at indices start, start+step, ...
+func embedGrad[T candy.D](g *Tensor[T], dim, size, start, step int) (*Tensor[T], error) {
+	gc, err := g.Detach().Copy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy grad: %w", err)
+	}
+	dims := gc.Dims()
+	n := dims[dim]
+	dims[dim] = size
+	dx, err := Zeros[T](candy.NewShapeFrom(dims), g.Device())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zeros: %w", err)
+	}
+	if gc.Numel() == 0 {
+		return dx, nil
+	}
+	left, right := 1, 1
+	for _, d := range dims[:dim] {
+		left *= d
+	}
+	for _, d := range dims[dim+1:] {
+		right *= d
+	}
+	if step == 1 {
+		if err := gc.storage.Copy2d(dx.storage, left, n*right, n*right, size*right, 0, start*right); err != nil {
+			return nil, fmt.Errorf("failed to copy grad: %w", err)
+		}
+		return dx, nil
+	}
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(start + i*step)
+	}
+	idx, err := New(ids, candy.NewShape(n), g.Device())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create indices: %w", err)
+	}
+	data, err := dx.storage.IndexAdd(dx.layout, idx.storage, idx.layout, gc.storage, gc.layout, dim)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index-add grad: %w", err)
+	}
+	return NewFrom(data, dx.layout, dx.dtype, dx.device), nil
+}
+
+// NarrowForward returns a ForwardFunc for a narrowed view along a dimension.
+func NarrowForward[T candy.D](dim, start, length int) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("narrow forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		l, err := x.layout.Narrow(dim, start, length)
+		if err != nil {
+			return nil, fmt.Errorf("narrow forward: %w", err)
+		}
+		return NewFrom(x.storage, l, x.dtype, x.device), nil
+	}
+}
+
+// NarrowBackward returns a BackwardFunc for narrow gradients: g is written into zeros at [start, start+length).
+func NarrowBackward[T candy.D](dim, start, length int) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("narrow backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		d, err := candy.ResolveAxis(dim, x.Rank())
+		if err != nil {
+			return nil, fmt.Errorf("narrow backward: failed to resolve dim: %w", err)
+		}
+		dx, err := embedGrad(g, d, x.Dim(d), start, 1)
+		if err != nil {
+			return nil, fmt.Errorf("narrow backward: %w", err)
+		}
+		return []*Tensor[T]{dx}, nil
+	}
+}
+
+// SliceForward returns a ForwardFunc for a strided view selecting ranges along the leading dimensions.
+func SliceForward[T candy.D](ranges []Range) ForwardFunc[T] {
+	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("slice forward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		if len(ranges) > x.Rank() {
+			return nil, fmt.Errorf("slice forward: %d ranges for rank %d", len(ranges), x.Rank())
+		}
+		dims, stride, offset := x.Dims(), x.Stride(), x.layout.StartOffset()
+		for i, r := range ranges {
+			start, length, step, err := r.resolve(dims[i])
+			if err != nil {
+				return nil, fmt.Errorf("slice forward: dim %d: %w", i, err)
+			}
+			if length > 0 {
+				offset += start * stride[i]
+			}
+			dims[i] = length
+			stride[i] *= step
+		}
+		return NewFrom(x.storage, candy.NewLayout(candy.NewShapeFrom(dims), stride, offset), x.dtype, x.device), nil
+	}
+}
+
+// SliceBackward returns a BackwardFunc for slice gradients: g is scattered into zeros of the source shape.
+func SliceBackward[T candy.D](ranges []Range) BackwardFunc[T] {
+	return func(g *Tensor[T], inputs []*Tensor[T]) ([]*Tensor[T], error) {
+		if len(inputs) != 1 {
+			return nil, fmt.Errorf("slice backward: expected 1 input, got %d", len(inputs))
+		}
+		x := inputs[0]
+		dx := g
+		for i, r := range ranges {
+			n := x.Dim(i)
+			start, length, step, err := r.resolve(n)
+			if err != nil {
+				return nil, fmt.Errorf("slice backward: dim %d: %w", i, err)
+			}
+			if length == n {
+				continue
+			}
+			if dx, err = embedGrad(dx, i, n, start, step); err != nil {
+				return nil, fmt.Errorf("slice backward: dim %d: %w", i, err)
+			}
+		}
+		return []*Tensor[T]{dx}, nil
+	}
+}
+
 // SqueezeForward returns a ForwardFunc for squeezing a dimension.
 func SqueezeForward[T candy.D](dim int) ForwardFunc[T] {
 	return func(inputs []*Tensor[T]) (*Tensor[T], error) {
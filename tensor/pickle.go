@@ -0,0 +1,574 @@
+package tensor
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+)
+
+// Pickle opcodes understood by the unpickler. Only the binary opcodes that
+// torch.save emits for state dicts are supported.
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opPopMark        = '1'
+	opDup            = '2'
+	opBinFloat       = 'G'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opBinInt2        = 'M'
+	opNone           = 'N'
+	opBinPersID      = 'Q'
+	opReduce         = 'R'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opBuild          = 'b'
+	opGlobal         = 'c'
+	opDict           = 'd'
+	opEmptyDict      = '}'
+	opAppends        = 'e'
+	opBinGet         = 'h'
+	opLongBinGet     = 'j'
+	opList           = 'l'
+	opEmptyList      = ']'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opSetItem        = 's'
+	opTuple          = 't'
+	opEmptyTuple     = ')'
+	opSetItems       = 'u'
+	opProto          = 0x80
+	opNewObj         = 0x81
+	opTuple1         = 0x85
+	opTuple2         = 0x86
+	opTuple3         = 0x87
+	opNewTrue        = 0x88
+	opNewFalse       = 0x89
+	opLong1          = 0x8a
+	opLong4          = 0x8b
+	opBinBytes       = 'B'
+	opShortBinBytes  = 'C'
+	opShortBinUni    = 0x8c
+	opBinUnicode8    = 0x8d
+	opBinBytes8      = 0x8e
+	opEmptySet       = 0x8f
+	opAddItems       = 0x90
+	opFrozenSet      = 0x91
+	opNewObjEx       = 0x92
+	opStackGlobal    = 0x93
+	opMemoize        = 0x94
+	opFrame          = 0x95
+)
+
+// pyGlobal is a module-level name referenced by GLOBAL or STACK_GLOBAL.
+type pyGlobal struct {
+	module, name string
+}
+
+func (g pyGlobal) String() string { return g.module + "." + g.name }
+
+// pyTuple is an immutable Python tuple.
+type pyTuple []any
+
+// pyList is a Python list; it is a pointer type because APPEND mutates it.
+type pyList struct {
+	items []any
+}
+
+// pyDict is a Python dict. Keys must be comparable Go values.
+type pyDict map[any]any
+
+// pyObject is an instance of a class the unpickler does not interpret.
+type pyObject struct {
+	class pyGlobal
+	args  pyTuple
+	state any
+}
+
+// pickleMark separates the stack frames consumed by MARK-terminated opcodes.
+type pickleMark struct{}
+
+// unpickler is a minimal pickle virtual machine. Globals are never imported:
+// calls are delegated to reduce, and persistent ids to persistentLoad.
+type unpickler struct {
+	r              *bufio.Reader
+	stack          []any
+	memo           map[int]any
+	reduce         func(fn pyGlobal, args pyTuple) (any, error)
+	persistentLoad func(pid any) (any, error)
+}
+
+func newUnpickler(r *bufio.Reader) *unpickler {
+	return &unpickler{r: r, memo: map[int]any{}}
+}
+
+// load runs the machine until STOP and returns the top of the stack.
+func (u *unpickler) load() (any, error) {
+	u.stack = u.stack[:0]
+	clear(u.memo)
+	for {
+		op, err := u.r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("pickle: %w", noEOF(err))
+		}
+		if op == opStop {
+			return u.pop()
+		}
+		if err := u.step(op); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (u *unpickler) step(op byte) error {
+	switch op {
+	case opProto:
+		_, err := u.r.ReadByte()
+		return err
+	case opFrame:
+		_, err := u.read(8)
+		return err
+	case opMark:
+		u.push(pickleMark{})
+	case opPop:
+		_, err := u.pop()
+		return err
+	case opPopMark:
+		_, err := u.popMark()
+		return err
+	case opDup:
+		v, err := u.top()
+		if err != nil {
+			return err
+		}
+		u.push(v)
+	case opNone:
+		u.push(nil)
+	case opNewTrue:
+		u.push(true)
+	case opNewFalse:
+		u.push(false)
+	case opBinInt:
+		b, err := u.read(4)
+		if err != nil {
+			return err
+		}
+		u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+	case opBinInt1:
+		b, err := u.r.ReadByte()
+		if err != nil {
+			return noEOF(err)
+		}
+		u.push(int64(b))
+	case opBinInt2:
+		b, err := u.read(2)
+		if err != nil {
+			return err
+		}
+		u.push(int64(binary.LittleEndian.Uint16(b)))
+	case opLong1, opLong4:
+		n, err := u.readLen(op == opLong4, 1)
+		if err != nil {
+			return err
+		}
+		b, err := u.read(n)
+		if err != nil {
+			return err
+		}
+		v, err := decodeLong(b)
+		if err != nil {
+			return err
+		}
+		u.push(v)
+	case opBinFloat:
+		b, err := u.read(8)
+		if err != nil {
+			return err
+		}
+		u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+	case opShortBinString, opShortBinUni, opShortBinBytes:
+		s, err := u.readString(1)
+		if err != nil {
+			return err
+		}
+		if op == opShortBinBytes {
+			u.push([]byte(s))
+		} else {
+			u.push(s)
+		}
+	case opBinString, opBinUnicode, opBinBytes:
+		s, err := u.readString(4)
+		if err != nil {
+			return err
+		}
+		if op == opBinBytes {
+			u.push([]byte(s))
+		} else {
+			u.push(s)
+		}
+	case opBinUnicode8, opBinBytes8:
+		s, err := u.readString(8)
+		if err != nil {
+			return err
+		}
+		if op == opBinBytes8 {
+			u.push([]byte(s))
+		} else {
+			u.push(s)
+		}
+	case opEmptyTuple:
+		u.push(pyTuple{})
+	case opTuple1, opTuple2, opTuple3:
+		n := int(op-opTuple1) + 1
+		if len(u.stack) < n {
+			return errors.New("pickle: stack underflow")
+		}
+		t := make(pyTuple, n)
+		copy(t, u.stack[len(u.stack)-n:])
+		u.stack = u.stack[:len(u.stack)-n]
+		u.push(t)
+	case opTuple:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		u.push(pyTuple(items))
+	case opEmptyList:
+		u.push(&pyList{})
+	case opList:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		u.push(&pyList{items: items})
+	case opAppend:
+		v, err := u.pop()
+		if err != nil {
+			return err
+		}
+		return u.appendTo(v)
+	case opAppends:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		return u.appendTo(items...)
+	case opEmptyDict:
+		u.push(pyDict{})
+	case opDict:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		d := pyDict{}
+		u.push(d)
+		return setItems(d, items)
+	case opSetItem, opSetItems:
+		var items []any
+		if op == opSetItem {
+			if len(u.stack) < 2 {
+				return errors.New("pickle: stack underflow")
+			}
+			items = append(items, u.stack[len(u.stack)-2:]...)
+			u.stack = u.stack[:len(u.stack)-2]
+		} else {
+			var err error
+			if items, err = u.popMark(); err != nil {
+				return err
+			}
+		}
+		top, err := u.top()
+		if err != nil {
+			return err
+		}
+		d, ok := top.(pyDict)
+		if !ok {
+			return fmt.Errorf("pickle: SETITEM on %T", top)
+		}
+		return setItems(d, items)
+	case opEmptySet:
+		u.push(&pyList{})
+	case opAddItems:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		return u.appendTo(items...)
+	case opFrozenSet:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		u.push(pyTuple(items))
+	case opBinPut, opLongBinPut:
+		i, err := u.readLen(op == opLongBinPut, 1)
+		if err != nil {
+			return err
+		}
+		v, err := u.top()
+		if err != nil {
+			return err
+		}
+		u.memo[i] = v
+	case opMemoize:
+		v, err := u.top()
+		if err != nil {
+			return err
+		}
+		u.memo[len(u.memo)] = v
+	case opBinGet, opLongBinGet:
+		i, err := u.readLen(op == opLongBinGet, 1)
+		if err != nil {
+			return err
+		}
+		v, ok := u.memo[i]
+		if !ok {
+			return fmt.Errorf("pickle: memo key %d not found", i)
+		}
+		u.push(v)
+	case opGlobal:
+		module, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		name, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		u.push(pyGlobal{module, name})
+	case opStackGlobal:
+		name, err := u.pop()
+		if err != nil {
+			return err
+		}
+		module, err := u.pop()
+		if err != nil {
+			return err
+		}
+		ms, ok1 := module.(string)
+		ns, ok2 := name.(string)
+		if !ok1 || !ok2 {
+			return errors.New("pickle: STACK_GLOBAL needs string operands")
+		}
+		u.push(pyGlobal{ms, ns})
+	case opReduce, opNewObj:
+		args, err := u.pop()
+		if err != nil {
+			return err
+		}
+		fn, err := u.pop()
+		if err != nil {
+			return err
+		}
+		return u.call(fn, args)
+	case opNewObjEx:
+		if _, err := u.pop(); err != nil {
+			return err
+		}
+		args, err := u.pop()
+		if err != nil {
+			return err
+		}
+		fn, err := u.pop()
+		if err != nil {
+			return err
+		}
+		return u.call(fn, args)
+	case opBuild:
+		state, err := u.pop()
+		if err != nil {
+			return err
+		}
+		top, err := u.top()
+		if err != nil {
+			return err
+		}
+		if obj, ok := top.(*pyObject); ok {
+			obj.state = state
+		}
+	case opBinPersID:
+		pid, err := u.pop()
+		if err != nil {
+			return err
+		}
+		if u.persistentLoad == nil {
+			return errors.New("pickle: unsupported persistent id")
+		}
+		v, err := u.persistentLoad(pid)
+		if err != nil {
+			return err
+		}
+		u.push(v)
+	default:
+		return fmt.Errorf("pickle: unsupported opcode 0x%02x", op)
+	}
+	return nil
+}
+
+func (u *unpickler) call(fn, args any) error {
+	g, ok := fn.(pyGlobal)
+	if !ok {
+		return fmt.Errorf("pickle: cannot call %T", fn)
+	}
+	t, ok := args.(pyTuple)
+	if !ok {
+		return fmt.Errorf("pickle: %v called with %T arguments", g, args)
+	}
+	var v any = &pyObject{class: g, args: t}
+	if u.reduce != nil {
+		var err error
+		if v, err = u.reduce(g, t); err != nil {
+			return err
+		}
+	}
+	u.push(v)
+	return nil
+}
+
+func (u *unpickler) appendTo(items ...any) error {
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+	l, ok := top.(*pyList)
+	if !ok {
+		return fmt.Errorf("pickle: APPEND on %T", top)
+	}
+	l.items = append(l.items, items...)
+	return nil
+}
+
+func setItems(d pyDict, items []any) (err error) {
+	if len(items)%2 != 0 {
+		return errors.New("pickle: odd number of dict items")
+	}
+	defer func() {
+		if recover() != nil {
+			err = errors.New("pickle: unhashable dict key")
+		}
+	}()
+	for i := 0; i < len(items); i += 2 {
+		d[items[i]] = items[i+1]
+	}
+	return nil
+}
+
+func (u *unpickler) push(v any) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) top() (any, error) {
+	if len(u.stack) == 0 {
+		return nil, errors.New("pickle: stack underflow")
+	}
+	return u.stack[len(u.stack)-1], nil
+}
+
+func (u *unpickler) pop() (any, error) {
+	v, err := u.top()
+	if err == nil {
+		u.stack = u.stack[:len(u.stack)-1]
+	}
+	return v, err
+}
+
+// popMark pops every item above the topmost mark, and the mark itself.
+func (u *unpickler) popMark() ([]any, error) {
+	for i := len(u.stack) - 1; i >= 0; i-- {
+		if _, ok := u.stack[i].(pickleMark); ok {
+			items := append([]any(nil), u.stack[i+1:]...)
+			u.stack = u.stack[:i]
+			return items, nil
+		}
+	}
+	return nil, errors.New("pickle: mark not found")
+}
+
+func (u *unpickler) read(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(u.r, b); err != nil {
+		return nil, fmt.Errorf("pickle: %w", noEOF(err))
+	}
+	return b, nil
+}
+
+// readLen reads a little-endian unsigned length of 4 bytes when long is set,
+// otherwise of short bytes.
+func (u *unpickler) readLen(long bool, short int) (int, error) {
+	n := short
+	if long {
+		n = 4
+	}
+	b, err := u.read(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return int(v), nil
+}
+
+func (u *unpickler) readString(lenBytes int) (string, error) {
+	b, err := u.read(lenBytes)
+	if err != nil {
+		return "", err
+	}
+	var n uint64
+	for i := lenBytes - 1; i >= 0; i-- {
+		n = n<<8 | uint64(b[i])
+	}
+	if n > math.MaxInt32 {
+		return "", fmt.Errorf("pickle: string of %d bytes too long", n)
+	}
+	s, err := u.read(int(n))
+	return string(s), err
+}
+
+func (u *unpickler) readLine() (string, error) {
+	s, err := u.r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("pickle: %w", noEOF(err))
+	}
+	return s[:len(s)-1], nil
+}
+
+// decodeLong decodes a little-endian two's complement integer, returning an
+// int64 when it fits and a *big.Int otherwise.
+func decodeLong(b []byte) (any, error) {
+	if len(b) == 0 {
+		return int64(0), nil
+	}
+	if len(b) <= 8 {
+		var v uint64
+		for i := len(b) - 1; i >= 0; i-- {
+			v = v<<8 | uint64(b[i])
+		}
+		shift := 64 - 8*uint(len(b))
+		return int64(v<<shift) >> shift, nil
+	}
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	v := new(big.Int).SetBytes(be)
+	if b[len(b)-1]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	return v, nil
+}
+
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,495 @@
+package tensor
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gocnn/candy"
+)
+
+// torchStorageDTypes maps torch storage classes to safetensors dtype names,
+// which share the element encodings decodeSafetensor understands.
+var torchStorageDTypes = map[string]string{
+	"FloatStorage":    "F32",
+	"DoubleStorage":   "F64",
+	"HalfStorage":     "F16",
+	"BFloat16Storage": "BF16",
+	"LongStorage":     "I64",
+	"IntStorage":      "I32",
+	"ShortStorage":    "I16",
+	"CharStorage":     "I8",
+	"ByteStorage":     "U8",
+	"BoolStorage":     "BOOL",
+}
+
+// torchLegacyMagic is the first pickle of checkpoints written before the zip
+// format became the default in PyTorch 1.6.
+var torchLegacyMagic, _ = new(big.Int).SetString("1950a86a20f9469cfc6c", 16)
+
+// torchStorage references a serialized storage. Legacy checkpoints may refer
+// to a view starting offset elements into the root storage.
+type torchStorage struct {
+	key    string
+	dtype  string
+	offset int
+}
+
+// torchTensor is a tensor rebuilt by torch._utils._rebuild_tensor_v2: a
+// strided view of a storage.
+type torchTensor struct {
+	storage *torchStorage
+	offset  int
+	size    []int
+	stride  []int
+}
+
+// torchCheckpoint holds the tensors of a parsed checkpoint and reads the raw
+// bytes of their storages on demand.
+type torchCheckpoint struct {
+	tensors   map[string]*torchTensor
+	dtypes    map[string]string
+	bigEndian bool
+	storage   func(key string) ([]byte, error)
+	close     func() error
+}
+
+// openTorch parses the checkpoint at path, either the zip format written by
+// torch.save or the legacy sequential pickle format.
+func openTorch(path string) (*torchCheckpoint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("pytorch: %s: %w", path, noEOF(err))
+	}
+	if string(magic) == "PK\x03\x04" {
+		f.Close()
+		return openTorchZip(path)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	c, err := readTorchLegacy(bufio.NewReader(f))
+	f.Close()
+	if err != nil {
+		return nil, fmt.Errorf("pytorch: %s: %w", path, err)
+	}
+	return c, nil
+}
+
+func openTorchZip(name string) (*torchCheckpoint, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, err
+	}
+	c := &torchCheckpoint{dtypes: map[string]string{}, close: zr.Close}
+	files := make(map[string]*zip.File, len(zr.File))
+	var prefix string
+	found := false
+	for _, zf := range zr.File {
+		files[zf.Name] = zf
+		if dir, base := path.Split(zf.Name); base == "data.pkl" && !found {
+			prefix, found = dir, true
+		}
+	}
+	if !found {
+		zr.Close()
+		return nil, fmt.Errorf("pytorch: %s: no data.pkl record", name)
+	}
+	readFile := func(name string) ([]byte, error) {
+		zf, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("pytorch: missing record %s", name)
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	if _, ok := files[prefix+"byteorder"]; ok {
+		b, err := readFile(prefix + "byteorder")
+		if err != nil {
+			zr.Close()
+			return nil, err
+		}
+		c.bigEndian = strings.TrimSpace(string(b)) == "big"
+	}
+	c.storage = func(key string) ([]byte, error) {
+		return readFile(prefix + "data/" + key)
+	}
+
+	rc, err := files[prefix+"data.pkl"].Open()
+	if err != nil {
+		zr.Close()
+		return nil, err
+	}
+	defer rc.Close()
+	u := newUnpickler(bufio.NewReader(rc))
+	u.reduce, u.persistentLoad = reduceTorch, c.persistentLoad
+	obj, err := u.load()
+	if err == nil {
+		c.tensors, err = collectTorchTensors(obj)
+	}
+	if err != nil {
+		zr.Close()
+		return nil, fmt.Errorf("pytorch: %s: %w", name, err)
+	}
+	return c, nil
+}
+
+// readTorchLegacy reads the legacy format: pickles of the magic number,
+// protocol version, system info, the object and the storage keys, followed
+// by each storage as an element count and raw data.
+func readTorchLegacy(r *bufio.Reader) (*torchCheckpoint, error) {
+	u := newUnpickler(r)
+	magic, err := u.load()
+	if m, ok := magic.(*big.Int); err != nil || !ok || m.Cmp(torchLegacyMagic) != 0 {
+		return nil, errors.New("not a PyTorch checkpoint")
+	}
+	if v, err := u.load(); err != nil || v != int64(1001) {
+		return nil, fmt.Errorf("unsupported legacy protocol %v", v)
+	}
+	info, err := u.load()
+	if err != nil {
+		return nil, err
+	}
+	c := &torchCheckpoint{dtypes: map[string]string{}, close: func() error { return nil }}
+	if d, ok := info.(pyDict); ok && d["little_endian"] == false {
+		c.bigEndian = true
+	}
+	u.reduce, u.persistentLoad = reduceTorch, c.persistentLoad
+	obj, err := u.load()
+	if err != nil {
+		return nil, err
+	}
+	if c.tensors, err = collectTorchTensors(obj); err != nil {
+		return nil, err
+	}
+	u.reduce, u.persistentLoad = nil, nil
+	keys, err := u.load()
+	if err != nil {
+		return nil, err
+	}
+	l, ok := keys.(*pyList)
+	if !ok {
+		return nil, fmt.Errorf("storage keys are %T, not a list", keys)
+	}
+	data := make(map[string][]byte, len(l.items))
+	for _, k := range l.items {
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("storage key %v is not a string", k)
+		}
+		var hdr [8]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return nil, noEOF(err)
+		}
+		n := binary.LittleEndian.Uint64(hdr[:])
+		if c.bigEndian {
+			n = binary.BigEndian.Uint64(hdr[:])
+		}
+		size := safetensorsDTypes[c.dtypes[key]]
+		if size == 0 || n > uint64(1<<62)/uint64(size) {
+			return nil, fmt.Errorf("invalid storage %s", key)
+		}
+		b := make([]byte, int(n)*size)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, noEOF(err)
+		}
+		data[key] = b
+	}
+	c.storage = func(key string) ([]byte, error) {
+		b, ok := data[key]
+		if !ok {
+			return nil, fmt.Errorf("pytorch: missing storage %s", key)
+		}
+		return b, nil
+	}
+	return c, nil
+}
+
+// persistentLoad resolves the ('storage', class, key, location, numel[,
+// view]) persistent ids torch.save emits for storages.
+func (c *torchCheckpoint) persistentLoad(pid any) (any, error) {
+	t, ok := pid.(pyTuple)
+	if !ok || len(t) < 5 || t[0] != "storage" {
+		return nil, fmt.Errorf("unsupported persistent id %v", pid)
+	}
+	cls, _ := t[1].(pyGlobal)
+	dtype, ok := torchStorageDTypes[cls.name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported storage type %v", t[1])
+	}
+	key, ok := t[2].(string)
+	if !ok {
+		return nil, fmt.Errorf("storage key %v is not a string", t[2])
+	}
+	c.dtypes[key] = dtype
+	st := &torchStorage{key: key, dtype: dtype}
+	if len(t) > 5 {
+		if view, ok := t[5].(pyTuple); ok && len(view) == 3 {
+			off, _ := view[1].(int64)
+			if off < 0 {
+				return nil, fmt.Errorf("storage %s has negative view offset %d", key, off)
+			}
+			st.offset = int(off)
+		}
+	}
+	return st, nil
+}
+
+// reduceTorch interprets the callables found in state dicts. Anything else
+// becomes an opaque object.
+func reduceTorch(fn pyGlobal, args pyTuple) (any, error) {
+	switch fn.String() {
+	case "collections.OrderedDict", "builtins.dict":
+		return pyDict{}, nil
+	case "torch._utils._rebuild_tensor", "torch._utils._rebuild_tensor_v2":
+		return rebuildTensor(args)
+	case "torch._utils._rebuild_parameter", "torch._utils._rebuild_parameter_with_state":
+		if len(args) > 0 {
+			return args[0], nil
+		}
+	case "torch._tensor._rebuild_from_type_v2":
+		// (func, new_type, args, state): rebuild through func, dropping the subclass.
+		if len(args) >= 3 {
+			inner, ok1 := args[0].(pyGlobal)
+			innerArgs, ok2 := args[2].(pyTuple)
+			if ok1 && ok2 {
+				return reduceTorch(inner, innerArgs)
+			}
+		}
+	}
+	return &pyObject{class: fn, args: args}, nil
+}
+
+// rebuildTensor handles (storage, storage_offset, size, stride, ...).
+func rebuildTensor(args pyTuple) (any, error) {
+	if len(args) < 4 {
+		return nil, errors.New("_rebuild_tensor: too few arguments")
+	}
+	st, ok := args[0].(*torchStorage)
+	if !ok {
+		return nil, fmt.Errorf("_rebuild_tensor: storage is %T", args[0])
+	}
+	off, ok := args[1].(int64)
+	if !ok {
+		return nil, fmt.Errorf("_rebuild_tensor: offset is %T", args[1])
+	}
+	if off < 0 {
+		return nil, fmt.Errorf("_rebuild_tensor: negative offset %d", off)
+	}
+	size, err := pyInts(args[2])
+	if err != nil {
+		return nil, err
+	}
+	stride, err := pyInts(args[3])
+	if err != nil {
+		return nil, err
+	}
+	if len(size) != len(stride) {
+		return nil, fmt.Errorf("_rebuild_tensor: size %v and stride %v differ in rank", size, stride)
+	}
+	return &torchTensor{storage: st, offset: int(off), size: size, stride: stride}, nil
+}
+
+func pyInts(v any) ([]int, error) {
+	t, ok := v.(pyTuple)
+	if !ok {
+		return nil, fmt.Errorf("expected int tuple, got %T", v)
+	}
+	out := make([]int, len(t))
+	for i, x := range t {
+		n, ok := x.(int64)
+		if !ok || n < 0 {
+			return nil, fmt.Errorf("invalid dimension %v", x)
+		}
+		out[i] = int(n)
+	}
+	return out, nil
+}
+
+// collectTorchTensors flattens the tensors of a (possibly nested) dict into
+// dotted names. A top-level "state_dict" entry is unwrapped, as saved by
+// most training loops.
+func collectTorchTensors(obj any) (map[string]*torchTensor, error) {
+	d, ok := obj.(pyDict)
+	if !ok {
+		return nil, fmt.Errorf("checkpoint holds %T, not a dict", obj)
+	}
+	if sd, ok := d["state_dict"].(pyDict); ok {
+		d = sd
+	}
+	out := map[string]*torchTensor{}
+	var walk func(prefix string, d pyDict)
+	walk = func(prefix string, d pyDict) {
+		for k, v := range d {
+			name, ok := k.(string)
+			if !ok {
+				continue
+			}
+			switch v := v.(type) {
+			case *torchTensor:
+				out[prefix+name] = v
+			case pyDict:
+				walk(prefix+name+".", v)
+			}
+		}
+	}
+	walk("", d)
+	return out, nil
+}
+
+// torchLoader decodes storages to T once, so tensors sharing a storage
+// share the same backing memory as they do in PyTorch.
+type torchLoader[T candy.D] struct {
+	c     *torchCheckpoint
+	cache map[string]torchDecoded[T]
+}
+
+type torchDecoded[T candy.D] struct {
+	storage candy.BackendStorage[T]
+	numel   int
+}
+
+func newTorchLoader[T candy.D](c *torchCheckpoint) *torchLoader[T] {
+	return &torchLoader[T]{c: c, cache: map[string]torchDecoded[T]{}}
+}
+
+// decode returns the storage with the given key decoded to T.
+func (l *torchLoader[T]) decode(st *torchStorage) (torchDecoded[T], error) {
+	if d, ok := l.cache[st.key]; ok {
+		return d, nil
+	}
+	raw, err := l.c.storage(st.key)
+	if err != nil {
+		return torchDecoded[T]{}, err
+	}
+	size := safetensorsDTypes[st.dtype]
+	if len(raw)%size != 0 {
+		return torchDecoded[T]{}, fmt.Errorf("pytorch: storage %s has %d bytes, not a multiple of %d", st.key, len(raw), size)
+	}
+	if l.c.bigEndian && size > 1 {
+		raw = slices.Clone(raw)
+		for i := 0; i < len(raw); i += size {
+			slices.Reverse(raw[i : i+size])
+		}
+	}
+	bd, err := LookupDevice[T](candy.CPU)
+	if err != nil {
+		return torchDecoded[T]{}, err
+	}
+	n := len(raw) / size
+	storage, err := bd.StorageFromSlice(decodeSafetensor[T](st.dtype, raw, n))
+	if err != nil {
+		return torchDecoded[T]{}, err
+	}
+	d := torchDecoded[T]{storage: storage, numel: n}
+	l.cache[st.key] = d
+	return d, nil
+}
+
+func (l *torchLoader[T]) tensor(name string) (*Tensor[T], error) {
+	tt, ok := l.c.tensors[name]
+	if !ok {
+		return nil, fmt.Errorf("pytorch: no tensor %s", name)
+	}
+	st := tt.storage
+	d, err := l.decode(st)
+	if err != nil {
+		return nil, err
+	}
+	off := st.offset + tt.offset
+	if off < 0 {
+		return nil, fmt.Errorf("pytorch: tensor %s has negative offset %d", name, off)
+	}
+	last := off
+	for i, d := range tt.size {
+		if d == 0 {
+			last = -1
+			break
+		}
+		last += (d - 1) * tt.stride[i]
+	}
+	if last >= d.numel {
+		return nil, fmt.Errorf("pytorch: tensor %s (size %v, stride %v, offset %d) exceeds storage of %d elements", name, tt.size, tt.stride, off, d.numel)
+	}
+	layout := candy.NewLayout(candy.NewShapeFrom(slices.Clone(tt.size)), tt.stride, off)
+	return NewFrom(d.storage, layout, candy.DTypeOf[T](), candy.CPU), nil
+}
+
+// ReadPyTorch loads every tensor of a PyTorch checkpoint written by
+// torch.save, such as a state_dict, as type T. Both the zip format and the
+// legacy format are read without Python. Nested dicts are flattened to
+// dotted names and a top-level "state_dict" entry is unwrapped. Tensors keep
+// their PyTorch strides and offsets as views of shared storages.
+func ReadPyTorch[T candy.D](path string) (map[string]*Tensor[T], error) {
+	c, err := openTorch(path)
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+	l := newTorchLoader[T](c)
+	out := make(map[string]*Tensor[T], len(c.tensors))
+	for name := range c.tensors {
+		t, err := l.tensor(name)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = t
+	}
+	return out, nil
+}
+
+// MustReadPyTorch loads every tensor of a PyTorch checkpoint, panicking on error.
+func MustReadPyTorch[T candy.D](path string) map[string]*Tensor[T] {
+	res, err := ReadPyTorch[T](path)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ReadPyTorchByName loads the named tensors of a PyTorch checkpoint as type
+// T, in the order given, decoding only the storages they use.
+func ReadPyTorchByName[T candy.D](path string, names []string) ([]*Tensor[T], error) {
+	c, err := openTorch(path)
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+	l := newTorchLoader[T](c)
+	out := make([]*Tensor[T], 0, len(names))
+	for _, name := range names {
+		t, err := l.tensor(name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// MustReadPyTorchByName loads the named tensors of a PyTorch checkpoint, panicking on error.
+func MustReadPyTorchByName[T candy.D](path string, names []string) []*Tensor[T] {
+	res, err := ReadPyTorchByName[T](path, names)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,229 @@
+package tensor_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+// pickler emits the protocol 2 opcodes torch.save uses for state dicts.
+type pickler struct{ bytes.Buffer }
+
+func (p *pickler) op(b ...byte) *pickler { p.Write(b); return p }
+
+func (p *pickler) str(s string) *pickler {
+	p.op('X').op(binary.LittleEndian.AppendUint32(nil, uint32(len(s)))...)
+	p.WriteString(s)
+	return p
+}
+
+func (p *pickler) int(v int) *pickler {
+	return p.op('J').op(binary.LittleEndian.AppendUint32(nil, uint32(int32(v)))...)
+}
+
+func (p *pickler) global(module, name string) *pickler {
+	p.op('c')
+	p.WriteString(module + "\n" + name + "\n")
+	return p
+}
+
+func (p *pickler) ints(vs ...int) *pickler {
+	p.op('(')
+	for _, v := range vs {
+		p.int(v)
+	}
+	return p.op('t')
+}
+
+// tensor pickles torch._utils._rebuild_tensor_v2 of a persistent storage,
+// wrapped in _rebuild_parameter when param is set.
+func (p *pickler) tensor(class, key string, numel, offset int, size, stride []int, param bool) *pickler {
+	if param {
+		p.global("torch._utils", "_rebuild_parameter").op('(')
+	}
+	p.global("torch._utils", "_rebuild_tensor_v2").op('(')
+	p.op('(').str("storage").global("torch", class).str(key).str("cpu").int(numel).op('t', 'Q')
+	p.int(offset).ints(size...).ints(stride...)
+	p.op(0x89).global("collections", "OrderedDict").op(')', 'R', 't', 'R')
+	if param {
+		p.op(0x88).global("collections", "OrderedDict").op(')', 'R', 't', 'R')
+	}
+	return p
+}
+
+func f32LE(vs ...float32) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+	}
+	return b
+}
+
+// stateDict pickles {"epoch": 3, "state_dict": {...}} where the weight,
+// its transpose and the bias share one float storage.
+func stateDict() []byte {
+	p := &pickler{}
+	p.op(0x80, 2, '}', '(').str("epoch").int(3).str("state_dict")
+	p.global("collections", "OrderedDict").op(')', 'R', 'q', 1, '(')
+	p.str("fc.weight").tensor("FloatStorage", "0", 12, 0, []int{3, 4}, []int{4, 1}, true)
+	p.str("fc.weight_t").tensor("FloatStorage", "0", 12, 0, []int{4, 3}, []int{1, 4}, false)
+	p.str("fc.bias").tensor("FloatStorage", "0", 12, 8, []int{3}, []int{1}, false)
+	p.str("bn.num_batches_tracked").tensor("LongStorage", "1", 1, 0, nil, nil, false)
+	p.op('u', '}', 'b', 'u', '.')
+	return p.Bytes()
+}
+
+func checkStateDict(t *testing.T, m map[string]*tensor.Tensor[float32]) {
+	t.Helper()
+	if len(m) != 4 {
+		t.Fatalf("got %d tensors", len(m))
+	}
+	checks := []struct {
+		name string
+		dims []int
+		want []float32
+	}{
+		{"fc.weight", []int{3, 4}, []float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		{"fc.weight_t", []int{4, 3}, []float32{0, 4, 8, 1, 5, 9, 2, 6, 10, 3, 7, 11}},
+		{"fc.bias", []int{3}, []float32{8, 9, 10}},
+		{"bn.num_batches_tracked", []int{}, []float32{7}},
+	}
+	for _, c := range checks {
+		got := m[c.name]
+		if got == nil || !got.Shape().Equal(candy.NewShapeFrom(c.dims)) || !slices.Equal(got.Data(), c.want) {
+			t.Fatalf("%s = %v, want %v %v", c.name, got, c.dims, c.want)
+		}
+	}
+	if m["fc.weight_t"].Layout().IsContiguous() || m["fc.bias"].Layout().StartOffset() != 8 {
+		t.Fatal("views should keep PyTorch strides and offsets")
+	}
+	// Ops on the views must match the same ops on contiguous copies.
+	copies := map[string]*tensor.Tensor[float32]{}
+	for _, c := range checks {
+		copies[c.name] = tensor.MustNew(slices.Clone(c.want), candy.NewShapeFrom(c.dims), candy.CPU)
+	}
+	linear := func(ts map[string]*tensor.Tensor[float32]) []float32 {
+		h := ts["fc.weight"].MustMatMul(ts["fc.weight_t"].MustAddScalar(-5).MustRelu())
+		return h.MustBroadcastAdd(ts["fc.bias"].MustExp()).MustSum([]int{0}).Data()
+	}
+	if got, want := linear(m), linear(copies); !slices.Equal(got, want) {
+		t.Fatalf("ops on loaded views = %v, want %v", got, want)
+	}
+}
+
+// writeTorchZip writes a zip checkpoint with the given pickle and storages.
+func writeTorchZip(t *testing.T, path string, pkl []byte, storages ...[]byte) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	files := []struct {
+		name string
+		data []byte
+	}{{"model/data.pkl", pkl}, {"model/byteorder", []byte("little")}}
+	for i, b := range storages {
+		files = append(files, struct {
+			name string
+			data []byte
+		}{fmt.Sprintf("model/data/%d", i), b})
+	}
+	files = append(files, struct {
+		name string
+		data []byte
+	}{"model/version", []byte("3\n")})
+	for _, e := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Store})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(e.data)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadPyTorchZip(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "model.pt")
+	writeTorchZip(t, path, stateDict(), f32LE(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11), binary.LittleEndian.AppendUint64(nil, 7))
+
+	checkStateDict(t, tensor.MustReadPyTorch[float32](path))
+	got := tensor.MustReadPyTorchByName[int64](path, []string{"fc.bias", "bn.num_batches_tracked"})
+	if !slices.Equal(got[0].Data(), []int64{8, 9, 10}) || !slices.Equal(got[1].Data(), []int64{7}) {
+		t.Fatalf("by name = %v, %v", got[0], got[1])
+	}
+	if _, err := tensor.ReadPyTorchByName[float32](path, []string{"missing"}); err == nil {
+		t.Fatal("expected error for missing tensor")
+	}
+}
+
+func TestReadPyTorchNegativeOffset(t *testing.T) {
+	t.Parallel()
+	// A tensor offset of -3 would otherwise index before the storage.
+	tensorOff := &pickler{}
+	tensorOff.op(0x80, 2, '}', '(').str("w").tensor("FloatStorage", "0", 4, -3, []int{2}, []int{1}, false).op('u', '.')
+	// The same through the view offset of a legacy storage view.
+	viewOff := &pickler{}
+	viewOff.op(0x80, 2, '}', '(').str("w").global("torch._utils", "_rebuild_tensor_v2").op('(')
+	viewOff.op('(').str("storage").global("torch", "FloatStorage").str("0").str("cpu").int(4)
+	viewOff.op('(').str("0").int(-3).int(2).op('t', 't', 'Q')
+	viewOff.int(0).ints(2).ints(1).op(0x89).global("collections", "OrderedDict").op(')', 'R', 't', 'R')
+	viewOff.op('u', '.')
+
+	for name, pkl := range map[string][]byte{"tensor": tensorOff.Bytes(), "view": viewOff.Bytes()} {
+		path := filepath.Join(t.TempDir(), name+".pt")
+		writeTorchZip(t, path, pkl, f32LE(0, 1, 2, 3))
+		if _, err := tensor.ReadPyTorch[float32](path); err == nil {
+			t.Errorf("%s offset: expected error for negative offset", name)
+		}
+	}
+}
+
+func TestReadPyTorchLegacy(t *testing.T) {
+	t.Parallel()
+	p := &pickler{}
+	p.op(0x80, 2, 0x8a, 10, 0x6c, 0xfc, 0x9c, 0x46, 0xf9, 0x20, 0x6a, 0xa8, 0x50, 0x19, '.')
+	p.op(0x80, 2, 'M', 0xe9, 0x03, '.')
+	p.op(0x80, 2, '}', '(').str("little_endian").op(0x88, 'u', '.')
+	raw := p.Bytes()
+	raw = append(raw, stateDict()...)
+	p = &pickler{}
+	p.op(0x80, 2, ']', '(').str("0").str("1").op('e', '.')
+	raw = append(raw, p.Bytes()...)
+	raw = binary.LittleEndian.AppendUint64(raw, 12)
+	raw = append(raw, f32LE(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11)...)
+	raw = binary.LittleEndian.AppendUint64(raw, 1)
+	raw = binary.LittleEndian.AppendUint64(raw, 7)
+
+	path := filepath.Join(t.TempDir(), "legacy.pth")
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checkStateDict(t, tensor.MustReadPyTorch[float32](path))
+
+	if err := os.WriteFile(path, raw[:len(raw)-4], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tensor.ReadPyTorch[float32](path); err == nil {
+		t.Fatal("expected error for truncated storage")
+	}
+	if err := os.WriteFile(path, []byte("not a checkpoint"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tensor.ReadPyTorch[float32](path); err == nil {
+		t.Fatal("expected error for garbage input")
+	}
+}
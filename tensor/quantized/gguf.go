@@ -0,0 +1,673 @@
+package quantized
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+const (
+	ggufMagic            = 0x46554747 // "GGUF" little-endian
+	ggufVersion          = 3
+	ggufDefaultAlignment = 32
+	ggufAlignmentKey     = "general.alignment"
+)
+
+// ValueType is the type tag of a GGUF metadata value.
+type ValueType uint32
+
+const (
+	TypeUint8 ValueType = iota
+	TypeInt8
+	TypeUint16
+	TypeInt16
+	TypeUint32
+	TypeInt32
+	TypeFloat32
+	TypeBool
+	TypeString
+	TypeArray
+	TypeUint64
+	TypeInt64
+	TypeFloat64
+)
+
+// String returns the GGUF name of the value type.
+func (t ValueType) String() string {
+	names := [...]string{"u8", "i8", "u16", "i16", "u32", "i32", "f32", "bool", "string", "array", "u64", "i64", "f64"}
+	if int(t) < len(names) {
+		return names[t]
+	}
+	return fmt.Sprintf("gguf_type(%d)", uint32(t))
+}
+
+// Value is a typed GGUF metadata value. Arrays hold their elements as Values
+// and remember the element type so empty arrays round-trip.
+type Value struct {
+	typ  ValueType
+	elem ValueType
+	v    any
+}
+
+// ValueOf wraps a Go value as a GGUF metadata value. Supported inputs are the
+// fixed-size integer and float types, bool, string, []Value and slices of the
+// supported scalar types.
+func ValueOf(v any) (Value, error) {
+	switch x := v.(type) {
+	case uint8:
+		return Value{typ: TypeUint8, v: x}, nil
+	case int8:
+		return Value{typ: TypeInt8, v: x}, nil
+	case uint16:
+		return Value{typ: TypeUint16, v: x}, nil
+	case int16:
+		return Value{typ: TypeInt16, v: x}, nil
+	case uint32:
+		return Value{typ: TypeUint32, v: x}, nil
+	case int32:
+		return Value{typ: TypeInt32, v: x}, nil
+	case float32:
+		return Value{typ: TypeFloat32, v: x}, nil
+	case bool:
+		return Value{typ: TypeBool, v: x}, nil
+	case string:
+		return Value{typ: TypeString, v: x}, nil
+	case uint64:
+		return Value{typ: TypeUint64, v: x}, nil
+	case int64:
+		return Value{typ: TypeInt64, v: x}, nil
+	case float64:
+		return Value{typ: TypeFloat64, v: x}, nil
+	case Value:
+		return x, nil
+	case []Value:
+		if len(x) == 0 {
+			return Value{}, errors.New("gguf: cannot infer element type of empty []Value")
+		}
+		for _, e := range x[1:] {
+			if e.typ != x[0].typ {
+				return Value{}, fmt.Errorf("gguf: mixed array element types %v and %v", x[0].typ, e.typ)
+			}
+		}
+		return Value{typ: TypeArray, elem: x[0].typ, v: slices.Clone(x)}, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return Value{}, fmt.Errorf("gguf: unsupported value type %T", v)
+	}
+	elem, err := ValueOf(reflect.Zero(rv.Type().Elem()).Interface())
+	if err != nil {
+		return Value{}, err
+	}
+	vals := make([]Value, rv.Len())
+	for i := range vals {
+		if vals[i], err = ValueOf(rv.Index(i).Interface()); err != nil {
+			return Value{}, err
+		}
+	}
+	return Value{typ: TypeArray, elem: elem.typ, v: vals}, nil
+}
+
+// MustValueOf wraps a Go value as a GGUF metadata value, panicking on error.
+func MustValueOf(v any) Value {
+	val, err := ValueOf(v)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Type returns the type tag of the value.
+func (v Value) Type() ValueType {
+	return v.typ
+}
+
+// ElemType returns the element type of an array value.
+func (v Value) ElemType() ValueType {
+	return v.elem
+}
+
+// Interface returns the underlying Go value. Arrays are returned as []Value.
+func (v Value) Interface() any {
+	return v.v
+}
+
+// Int returns an integer value widened to int64.
+func (v Value) Int() (int64, error) {
+	switch x := v.v.(type) {
+	case uint8:
+		return int64(x), nil
+	case int8:
+		return int64(x), nil
+	case uint16:
+		return int64(x), nil
+	case int16:
+		return int64(x), nil
+	case uint32:
+		return int64(x), nil
+	case int32:
+		return int64(x), nil
+	case int64:
+		return x, nil
+	case uint64:
+		if x > math.MaxInt64 {
+			return 0, fmt.Errorf("gguf: value %d overflows int64", x)
+		}
+		return int64(x), nil
+	}
+	return 0, fmt.Errorf("gguf: %v value is not an integer", v.typ)
+}
+
+// Float returns a float or integer value as float64.
+func (v Value) Float() (float64, error) {
+	switch x := v.v.(type) {
+	case float32:
+		return float64(x), nil
+	case float64:
+		return x, nil
+	}
+	i, err := v.Int()
+	if err != nil {
+		return 0, fmt.Errorf("gguf: %v value is not a number", v.typ)
+	}
+	return float64(i), nil
+}
+
+// Bool returns a bool value.
+func (v Value) Bool() (bool, error) {
+	if x, ok := v.v.(bool); ok {
+		return x, nil
+	}
+	return false, fmt.Errorf("gguf: %v value is not a bool", v.typ)
+}
+
+// Str returns a string value.
+func (v Value) Str() (string, error) {
+	if x, ok := v.v.(string); ok {
+		return x, nil
+	}
+	return "", fmt.Errorf("gguf: %v value is not a string", v.typ)
+}
+
+// Array returns the elements of an array value.
+func (v Value) Array() ([]Value, error) {
+	if x, ok := v.v.([]Value); ok {
+		return x, nil
+	}
+	return nil, fmt.Errorf("gguf: %v value is not an array", v.typ)
+}
+
+// TensorInfo describes one entry of the GGUF tensor directory. Shape is in
+// row-major order, i.e. the reverse of the ggml ne[] dimensions.
+type TensorInfo struct {
+	Name   string
+	DType  GgmlDType
+	Shape  *candy.Shape
+	Offset uint64 // relative to the start of the data section
+}
+
+// Size returns the number of bytes of tensor data.
+func (ti TensorInfo) Size() (int, error) {
+	return ti.DType.byteSize(ti.Shape.Numel())
+}
+
+// File is a parsed GGUF file. Tensor data is read lazily on request.
+type File struct {
+	Version   uint32
+	Alignment uint64
+	Metadata  map[string]Value
+	Tensors   []TensorInfo // in file order
+
+	r          io.ReaderAt
+	closer     io.Closer
+	dataOffset int64
+	index      map[string]int
+}
+
+// Open opens and parses the GGUF file at path. Close releases the file.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	gf, err := Read(f, st.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	gf.closer = f
+	return gf, nil
+}
+
+// MustOpen opens and parses the GGUF file at path, panicking on error.
+func MustOpen(path string) *File {
+	f, err := Open(path)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Read parses the GGUF header, metadata and tensor directory from r, which
+// holds size bytes. Tensor data stays in r until requested.
+func Read(r io.ReaderAt, size int64) (*File, error) {
+	d := &decoder{r: bufio.NewReader(io.NewSectionReader(r, 0, size)), size: size}
+	if magic := d.u32(); d.err == nil && magic != ggufMagic {
+		return nil, fmt.Errorf("gguf: bad magic %#08x", magic)
+	}
+	f := &File{Version: d.u32(), Metadata: map[string]Value{}, r: r, index: map[string]int{}}
+	if d.err == nil && (f.Version < 1 || f.Version > ggufVersion) {
+		return nil, fmt.Errorf("gguf: unsupported version %d", f.Version)
+	}
+	d.v1 = f.Version == 1
+	nTensors, nKV := d.count(), d.count()
+	for i := uint64(0); i < nKV && d.err == nil; i++ {
+		key := d.str()
+		v := d.value(ValueType(d.u32()), 0)
+		if _, dup := f.Metadata[key]; dup && d.err == nil {
+			return nil, fmt.Errorf("gguf: duplicate metadata key %q", key)
+		}
+		f.Metadata[key] = v
+	}
+	for i := uint64(0); i < nTensors && d.err == nil; i++ {
+		ti := d.tensorInfo()
+		if _, dup := f.index[ti.Name]; dup && d.err == nil {
+			return nil, fmt.Errorf("gguf: duplicate tensor %q", ti.Name)
+		}
+		f.index[ti.Name] = len(f.Tensors)
+		f.Tensors = append(f.Tensors, ti)
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("gguf: read header: %w", d.err)
+	}
+
+	f.Alignment = ggufDefaultAlignment
+	if v, ok := f.Metadata[ggufAlignmentKey]; ok {
+		a, err := v.Int()
+		if err != nil || a <= 0 {
+			return nil, fmt.Errorf("gguf: invalid %s %v", ggufAlignmentKey, v.Interface())
+		}
+		f.Alignment = uint64(a)
+	}
+	f.dataOffset = int64(alignUp(uint64(d.n), f.Alignment))
+	for _, ti := range f.Tensors {
+		n, err := ti.Size()
+		if err != nil {
+			return nil, fmt.Errorf("gguf: tensor %q: %w", ti.Name, err)
+		}
+		if ti.Offset%f.Alignment != 0 {
+			return nil, fmt.Errorf("gguf: tensor %q offset %d is not aligned to %d", ti.Name, ti.Offset, f.Alignment)
+		}
+		if end := f.dataOffset + int64(ti.Offset) + int64(n); ti.Offset > uint64(size) || end > size {
+			return nil, fmt.Errorf("gguf: tensor %q data exceeds file size", ti.Name)
+		}
+	}
+	return f, nil
+}
+
+// Close closes the underlying file when the File was created by Open.
+func (f *File) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+// Info returns the directory entry for the named tensor.
+func (f *File) Info(name string) (TensorInfo, bool) {
+	i, ok := f.index[name]
+	if !ok {
+		return TensorInfo{}, false
+	}
+	return f.Tensors[i], true
+}
+
+// QTensor reads the raw blocks of the named tensor.
+func (f *File) QTensor(name string) (*QTensor, error) {
+	ti, ok := f.Info(name)
+	if !ok {
+		return nil, fmt.Errorf("gguf: tensor %q not found", name)
+	}
+	n, err := ti.Size()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, n)
+	if _, err := f.r.ReadAt(data, f.dataOffset+int64(ti.Offset)); err != nil {
+		return nil, fmt.Errorf("gguf: read tensor %q: %w", name, err)
+	}
+	return NewQTensor(ti.DType, ti.Shape, data)
+}
+
+// MustQTensor reads the raw blocks of the named tensor, panicking on error.
+func (f *File) MustQTensor(name string) *QTensor {
+	q, err := f.QTensor(name)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Tensor reads the named tensor and dequantizes it to float32 on device.
+func (f *File) Tensor(name string, dev candy.Device) (*tensor.Tensor[float32], error) {
+	q, err := f.QTensor(name)
+	if err != nil {
+		return nil, err
+	}
+	return q.Dequantize(dev)
+}
+
+// MustTensor reads the named tensor and dequantizes it to float32, panicking on error.
+func (f *File) MustTensor(name string, dev candy.Device) *tensor.Tensor[float32] {
+	t, err := f.Tensor(name, dev)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Write encodes metadata and tensors as a GGUF v3 file. Keys and tensor names
+// are written in sorted order; tensor data is aligned to general.alignment,
+// or 32 bytes when the key is absent.
+func Write(w io.Writer, metadata map[string]Value, tensors map[string]*QTensor) error {
+	align := uint64(ggufDefaultAlignment)
+	if v, ok := metadata[ggufAlignmentKey]; ok {
+		a, err := v.Int()
+		if err != nil || a <= 0 {
+			return fmt.Errorf("gguf: invalid %s %v", ggufAlignmentKey, v.Interface())
+		}
+		align = uint64(a)
+	}
+	keys := slices.Sorted(maps.Keys(metadata))
+	names := slices.Sorted(maps.Keys(tensors))
+	for _, name := range names {
+		if r := tensors[name].shape.Rank(); r > 4 {
+			return fmt.Errorf("gguf: tensor %q has %d dims, at most 4 are supported", name, r)
+		}
+	}
+
+	e := &encoder{w: bufio.NewWriter(w)}
+	e.u32(ggufMagic)
+	e.u32(ggufVersion)
+	e.u64(uint64(len(names)))
+	e.u64(uint64(len(keys)))
+	for _, k := range keys {
+		e.str(k)
+		e.u32(uint32(metadata[k].typ))
+		e.value(metadata[k])
+	}
+	offset := uint64(0)
+	for _, name := range names {
+		q := tensors[name]
+		dims := q.shape.Dims()
+		e.str(name)
+		e.u32(uint32(len(dims)))
+		for i := len(dims) - 1; i >= 0; i-- {
+			e.u64(uint64(dims[i]))
+		}
+		e.u32(uint32(q.dtype))
+		e.u64(offset)
+		offset = alignUp(offset+uint64(len(q.data)), align)
+	}
+	e.pad(align)
+	for _, name := range names {
+		e.bytes(tensors[name].data)
+		e.pad(align)
+	}
+	if e.err != nil {
+		return fmt.Errorf("gguf: write: %w", e.err)
+	}
+	return e.w.Flush()
+}
+
+// WriteFile encodes metadata and tensors as a GGUF file at path.
+func WriteFile(path string, metadata map[string]Value, tensors map[string]*QTensor) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, metadata, tensors); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// MustWriteFile encodes metadata and tensors as a GGUF file at path, panicking on error.
+func MustWriteFile(path string, metadata map[string]Value, tensors map[string]*QTensor) {
+	if err := WriteFile(path, metadata, tensors); err != nil {
+		panic(err)
+	}
+}
+
+func alignUp(n, align uint64) uint64 {
+	return (n + align - 1) / align * align
+}
+
+// decoder reads little-endian GGUF primitives, keeping the first error and
+// the number of bytes consumed.
+type decoder struct {
+	r    *bufio.Reader
+	size int64
+	n    int64
+	v1   bool
+	err  error
+	buf  [8]byte
+}
+
+func (d *decoder) read(n int) []byte {
+	if d.err != nil {
+		return d.buf[:n]
+	}
+	_, d.err = io.ReadFull(d.r, d.buf[:n])
+	d.n += int64(n)
+	return d.buf[:n]
+}
+
+func (d *decoder) u8() uint8   { return d.read(1)[0] }
+func (d *decoder) u16() uint16 { return binary.LittleEndian.Uint16(d.read(2)) }
+func (d *decoder) u32() uint32 { return binary.LittleEndian.Uint32(d.read(4)) }
+func (d *decoder) u64() uint64 { return binary.LittleEndian.Uint64(d.read(8)) }
+
+// count reads a length or count, which is 32-bit in GGUF v1.
+func (d *decoder) count() uint64 {
+	if d.v1 {
+		return uint64(d.u32())
+	}
+	return d.u64()
+}
+
+// limit rejects lengths that cannot fit in the rest of the file.
+func (d *decoder) limit(n uint64) int {
+	if d.err == nil && n > uint64(d.size-d.n) {
+		d.err = fmt.Errorf("length %d exceeds file size", n)
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) str() string {
+	n := d.limit(d.count())
+	if n == 0 {
+		return ""
+	}
+	b := make([]byte, n)
+	_, d.err = io.ReadFull(d.r, b)
+	d.n += int64(n)
+	return string(b)
+}
+
+func (d *decoder) value(t ValueType, depth int) Value {
+	switch t {
+	case TypeUint8:
+		return Value{typ: t, v: d.u8()}
+	case TypeInt8:
+		return Value{typ: t, v: int8(d.u8())}
+	case TypeUint16:
+		return Value{typ: t, v: d.u16()}
+	case TypeInt16:
+		return Value{typ: t, v: int16(d.u16())}
+	case TypeUint32:
+		return Value{typ: t, v: d.u32()}
+	case TypeInt32:
+		return Value{typ: t, v: int32(d.u32())}
+	case TypeFloat32:
+		return Value{typ: t, v: math.Float32frombits(d.u32())}
+	case TypeBool:
+		return Value{typ: t, v: d.u8() != 0}
+	case TypeString:
+		return Value{typ: t, v: d.str()}
+	case TypeUint64:
+		return Value{typ: t, v: d.u64()}
+	case TypeInt64:
+		return Value{typ: t, v: int64(d.u64())}
+	case TypeFloat64:
+		return Value{typ: t, v: math.Float64frombits(d.u64())}
+	case TypeArray:
+		if depth > 8 && d.err == nil {
+			d.err = errors.New("array nesting too deep")
+		}
+		elem := ValueType(d.u32())
+		n := d.limit(d.count())
+		vals := make([]Value, 0, min(n, 1<<16))
+		for i := 0; i < n && d.err == nil; i++ {
+			vals = append(vals, d.value(elem, depth+1))
+		}
+		return Value{typ: t, elem: elem, v: vals}
+	}
+	if d.err == nil {
+		d.err = fmt.Errorf("unknown value type %d", uint32(t))
+	}
+	return Value{}
+}
+
+func (d *decoder) tensorInfo() TensorInfo {
+	ti := TensorInfo{Name: d.str()}
+	nDims := d.u32()
+	if d.err == nil && nDims > 4 {
+		d.err = fmt.Errorf("tensor %q has %d dims", ti.Name, nDims)
+	}
+	if d.err != nil {
+		return ti
+	}
+	// Every encoding spends at least one bit per element, so a tensor can
+	// hold at most 8 elements per byte of the file.
+	dims, numel := make([]int, nDims), uint64(1)
+	for i := len(dims) - 1; i >= 0; i-- {
+		n := d.count()
+		if d.err == nil && n > math.MaxInt32 {
+			d.err = fmt.Errorf("tensor %q dim %d too large", ti.Name, n)
+		}
+		if d.err == nil && n > 0 && numel > uint64(d.size)*8/n {
+			d.err = fmt.Errorf("tensor %q has more elements than the file can hold", ti.Name)
+		}
+		dims[i] = int(n)
+		numel *= n
+	}
+	ti.Shape = candy.NewShapeFrom(dims)
+	ti.DType = GgmlDType(d.u32())
+	ti.Offset = d.u64()
+	return ti
+}
+
+// encoder writes little-endian GGUF primitives, keeping the first error and
+// the number of bytes written.
+type encoder struct {
+	w   *bufio.Writer
+	n   uint64
+	err error
+	buf [8]byte
+}
+
+func (e *encoder) bytes(b []byte) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.Write(b)
+	e.n += uint64(len(b))
+}
+
+func (e *encoder) u8(v uint8) { e.bytes([]byte{v}) }
+func (e *encoder) u16(v uint16) {
+	binary.LittleEndian.PutUint16(e.buf[:], v)
+	e.bytes(e.buf[:2])
+}
+func (e *encoder) u32(v uint32) {
+	binary.LittleEndian.PutUint32(e.buf[:], v)
+	e.bytes(e.buf[:4])
+}
+func (e *encoder) u64(v uint64) {
+	binary.LittleEndian.PutUint64(e.buf[:], v)
+	e.bytes(e.buf[:8])
+}
+
+func (e *encoder) str(s string) {
+	e.u64(uint64(len(s)))
+	e.bytes([]byte(s))
+}
+
+func (e *encoder) pad(align uint64) {
+	if n := alignUp(e.n, align) - e.n; n > 0 {
+		e.bytes(make([]byte, n))
+	}
+}
+
+func (e *encoder) value(v Value) {
+	switch x := v.v.(type) {
+	case uint8:
+		e.u8(x)
+	case int8:
+		e.u8(uint8(x))
+	case uint16:
+		e.u16(x)
+	case int16:
+		e.u16(uint16(x))
+	case uint32:
+		e.u32(x)
+	case int32:
+		e.u32(uint32(x))
+	case float32:
+		e.u32(math.Float32bits(x))
+	case bool:
+		if x {
+			e.u8(1)
+		} else {
+			e.u8(0)
+		}
+	case string:
+		e.str(x)
+	case uint64:
+		e.u64(x)
+	case int64:
+		e.u64(uint64(x))
+	case float64:
+		e.u64(math.Float64bits(x))
+	case []Value:
+		e.u32(uint32(v.elem))
+		e.u64(uint64(len(x)))
+		for _, el := range x {
+			e.value(el)
+		}
+	default:
+		if e.err == nil {
+			e.err = fmt.Errorf("unsupported value %T", x)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package quantized_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor/quantized"
+)
+
+func f32Bytes(vs []float32) []byte {
+	b := make([]byte, 4*len(vs))
+	for i, v := range vs {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(v))
+	}
+	return b
+}
+
+// q8Block encodes 32 values d*q for q in -16..15.
+func q8Block(d float32) ([]byte, []float32) {
+	b := make([]byte, 34)
+	binary.LittleEndian.PutUint16(b, uint16(candy.NewFloat16(d)))
+	want := make([]float32, 32)
+	for i := range 32 {
+		b[2+i] = byte(int8(i - 16))
+		want[i] = d * float32(i-16)
+	}
+	return b, want
+}
+
+func TestGGUFRoundTrip(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "model.gguf")
+
+	q0, w0 := q8Block(0.5)
+	q1, w1 := q8Block(-0.25)
+	weights := quantized.MustNewQTensor(quantized.Q8_0, candy.NewShape(2, 32), append(q0, q1...))
+	bias := quantized.MustNewQTensor(quantized.F32, candy.NewShape(3), f32Bytes([]float32{1, -2, 3.5}))
+	half := make([]byte, 4)
+	binary.LittleEndian.PutUint16(half, uint16(candy.NewFloat16(1.5)))
+	binary.LittleEndian.PutUint16(half[2:], uint16(candy.NewFloat16(-2)))
+	scale := quantized.MustNewQTensor(quantized.F16, candy.NewShape(2, 1), half)
+
+	md := map[string]quantized.Value{
+		"general.architecture": quantized.MustValueOf("llama"),
+		"general.alignment":    quantized.MustValueOf(uint32(64)),
+		"llama.block_count":    quantized.MustValueOf(uint32(2)),
+		"llama.rope.freq_base": quantized.MustValueOf(float32(10000)),
+		"llama.use_parallel":   quantized.MustValueOf(true),
+		"tokenizer.tokens":     quantized.MustValueOf([]string{"<s>", "</s>", "hi"}),
+		"tokenizer.scores":     quantized.MustValueOf([]float32{0, -1, -2.5}),
+		"tokenizer.merges":     quantized.MustValueOf([]quantized.Value{quantized.MustValueOf([]int64{1, 2}), quantized.MustValueOf([]int64{})}),
+	}
+	quantized.MustWriteFile(path, md, map[string]*quantized.QTensor{"w": weights, "b": bias, "s": scale})
+
+	f := quantized.MustOpen(path)
+	defer f.Close()
+	if f.Version != 3 || f.Alignment != 64 || len(f.Tensors) != 3 {
+		t.Fatalf("header: version %d alignment %d tensors %d", f.Version, f.Alignment, len(f.Tensors))
+	}
+	if s, err := f.Metadata["general.architecture"].Str(); err != nil || s != "llama" {
+		t.Fatalf("architecture = %q, %v", s, err)
+	}
+	if n, err := f.Metadata["llama.block_count"].Int(); err != nil || n != 2 {
+		t.Fatalf("block_count = %d, %v", n, err)
+	}
+	if v, err := f.Metadata["llama.rope.freq_base"].Float(); err != nil || v != 10000 {
+		t.Fatalf("freq_base = %v, %v", v, err)
+	}
+	if b, err := f.Metadata["llama.use_parallel"].Bool(); err != nil || !b {
+		t.Fatalf("use_parallel = %v, %v", b, err)
+	}
+	toks, err := f.Metadata["tokenizer.tokens"].Array()
+	if err != nil || len(toks) != 3 || f.Metadata["tokenizer.tokens"].ElemType() != quantized.TypeString {
+		t.Fatalf("tokens = %v, %v", toks, err)
+	}
+	if s, _ := toks[2].Str(); s != "hi" {
+		t.Fatalf("tokens[2] = %q", s)
+	}
+	merges, _ := f.Metadata["tokenizer.merges"].Array()
+	if inner, _ := merges[1].Array(); len(merges) != 2 || len(inner) != 0 || merges[1].ElemType() != quantized.TypeInt64 {
+		t.Fatalf("merges = %v", merges)
+	}
+	if _, err := f.Metadata["tokenizer.tokens"].Int(); err == nil {
+		t.Fatal("expected type error for Int on array")
+	}
+
+	info, ok := f.Info("w")
+	if !ok || info.DType != quantized.Q8_0 || !info.Shape.Equal(candy.NewShape(2, 32)) || info.Offset%64 != 0 {
+		t.Fatalf("info = %+v", info)
+	}
+	q := f.MustQTensor("w")
+	if !bytes.Equal(q.Data(), weights.Data()) {
+		t.Fatal("raw q8_0 blocks differ")
+	}
+	if got, want := f.MustTensor("w", candy.CPU).Data(), append(w0, w1...); !slices.Equal(got, want) {
+		t.Fatalf("w = %v, want %v", got, want)
+	}
+	if got := f.MustTensor("b", candy.CPU).Data(); !slices.Equal(got, []float32{1, -2, 3.5}) {
+		t.Fatalf("b = %v", got)
+	}
+	s := f.MustTensor("s", candy.CPU)
+	if !s.Shape().Equal(candy.NewShape(2, 1)) || !slices.Equal(s.Data(), []float32{1.5, -2}) {
+		t.Fatalf("s = %v", s)
+	}
+	if _, err := f.Tensor("missing", candy.CPU); err == nil {
+		t.Fatal("expected error for missing tensor")
+	}
+}
+
+func TestGGUFInvalid(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	bias := quantized.MustNewQTensor(quantized.F32, candy.NewShape(4), f32Bytes([]float32{1, 2, 3, 4}))
+	if err := quantized.Write(&buf, nil, map[string]*quantized.QTensor{"b": bias}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if _, err := quantized.Read(bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if _, err := quantized.Read(bytes.NewReader(data), int64(len(data)-24)); err == nil {
+		t.Fatal("expected error for truncated tensor data")
+	}
+	bad := slices.Clone(data)
+	bad[0] = 'X'
+	if _, err := quantized.Read(bytes.NewReader(bad), int64(len(bad))); err == nil {
+		t.Fatal("expected error for bad magic")
+	}
+	if _, err := quantized.NewQTensor(quantized.Q4_0, candy.NewShape(2, 16), make([]byte, 18)); err == nil {
+		t.Fatal("expected error for partial block row")
+	}
+
+	// A single F32 tensor header with raw dims, followed by 64 data bytes.
+	header := func(dims ...uint64) []byte {
+		b := append([]byte("GGUF"), binary.LittleEndian.AppendUint32(nil, 3)...)
+		b = binary.LittleEndian.AppendUint64(b, 1)
+		b = binary.LittleEndian.AppendUint64(b, 0)
+		b = binary.LittleEndian.AppendUint64(b, 1)
+		b = append(b, 'w')
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(dims)))
+		for _, d := range dims {
+			b = binary.LittleEndian.AppendUint64(b, d)
+		}
+		b = binary.LittleEndian.AppendUint32(b, uint32(quantized.F32))
+		b = binary.LittleEndian.AppendUint64(b, 0)
+		b = append(b, make([]byte, 32-len(b)%32)...)
+		return append(b, make([]byte, 64)...)
+	}
+	good := header(4, 4)
+	if _, err := quantized.Read(bytes.NewReader(good), int64(len(good))); err != nil {
+		t.Fatalf("Read 4x4: %v", err)
+	}
+	for name, h := range map[string][]byte{
+		"five dims":        header(1, 1, 1, 1, 4),
+		"overflowing dims": header(1<<30, 1<<30, 1<<30, 16),
+		"too many elems":   header(1<<20, 1<<20),
+	} {
+		if _, err := quantized.Read(bytes.NewReader(h), int64(len(h))); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package quantized
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
+)
+
+// GgmlDType identifies a ggml tensor encoding. Values match the ggml_type enum.
+type GgmlDType uint32
+
+const (
+	F32  GgmlDType = 0
+	F16  GgmlDType = 1
+	Q4_0 GgmlDType = 2
+	Q4_1 GgmlDType = 3
+	Q5_0 GgmlDType = 6
+	Q5_1 GgmlDType = 7
+	Q8_0 GgmlDType = 8
+	Q2K  GgmlDType = 10
+	Q3K  GgmlDType = 11
+	Q4K  GgmlDType = 12
+	Q5K  GgmlDType = 13
+	Q6K  GgmlDType = 14
+	Q8K  GgmlDType = 15
+	BF16 GgmlDType = 30
+)
+
+// Block layouts shared with the CPU kernels.
+type (
+	BlockQ4_0 = kernels.BlockQ4_0
+	BlockQ4_1 = kernels.BlockQ4_1
+	BlockQ5_0 = kernels.BlockQ5_0
+	BlockQ5_1 = kernels.BlockQ5_1
+	BlockQ8_0 = kernels.BlockQ8_0
+	BlockQ2K  = kernels.BlockQ2K
+	BlockQ3K  = kernels.BlockQ3K
+	BlockQ4K  = kernels.BlockQ4K
+	BlockQ5K  = kernels.BlockQ5K
+	BlockQ6K  = kernels.BlockQ6K
+	BlockQ8K  = kernels.BlockQ8K
+)
+
+// String returns the ggml name of the type.
+func (d GgmlDType) String() string {
+	switch d {
+	case F32:
+		return "f32"
+	case F16:
+		return "f16"
+	case BF16:
+		return "bf16"
+	case Q4_0:
+		return "q4_0"
+	case Q4_1:
+		return "q4_1"
+	case Q5_0:
+		return "q5_0"
+	case Q5_1:
+		return "q5_1"
+	case Q8_0:
+		return "q8_0"
+	case Q2K:
+		return "q2_K"
+	case Q3K:
+		return "q3_K"
+	case Q4K:
+		return "q4_K"
+	case Q5K:
+		return "q5_K"
+	case Q6K:
+		return "q6_K"
+	case Q8K:
+		return "q8_K"
+	default:
+		return fmt.Sprintf("ggml_type(%d)", uint32(d))
+	}
+}
+
+// BlockSize returns the number of values encoded by one block.
+func (d GgmlDType) BlockSize() int {
+	switch d {
+	case F32, F16, BF16:
+		return 1
+	case Q4_0, Q4_1, Q5_0, Q5_1, Q8_0:
+		return 32
+	case Q2K, Q3K, Q4K, Q5K, Q6K, Q8K:
+		return 256
+	default:
+		return 0
+	}
+}
+
+// TypeSize returns the number of bytes in one block.
+func (d GgmlDType) TypeSize() int {
+	switch d {
+	case F32:
+		return 4
+	case F16, BF16:
+		return 2
+	case Q4_0:
+		return 18
+	case Q4_1:
+		return 20
+	case Q5_0:
+		return 22
+	case Q5_1:
+		return 24
+	case Q8_0:
+		return 34
+	case Q2K:
+		return 84
+	case Q3K:
+		return 110
+	case Q4K:
+		return 144
+	case Q5K:
+		return 176
+	case Q6K:
+		return 210
+	case Q8K:
+		return 292
+	default:
+		return 0
+	}
+}
+
+// byteSize returns the encoded size of numel values, or an error when numel
+// is not a whole number of blocks.
+func (d GgmlDType) byteSize(numel int) (int, error) {
+	bs := d.BlockSize()
+	if bs == 0 {
+		return 0, fmt.Errorf("quantized: unknown dtype %v", d)
+	}
+	if numel%bs != 0 {
+		return 0, fmt.Errorf("quantized: %d elements is not a multiple of the %v block size %d", numel, d, bs)
+	}
+	return numel / bs * d.TypeSize(), nil
+}
+
+// QTensor is a tensor stored in a ggml encoding. Data holds packed blocks in
+// row-major order; the last dimension must be a multiple of the block size.
+type QTensor struct {
+	dtype GgmlDType
+	shape *candy.Shape
+	data  []byte
+}
+
+// NewQTensor wraps packed block data of the given type and shape.
+func NewQTensor(dtype GgmlDType, shape *candy.Shape, data []byte) (*QTensor, error) {
+	size, err := checkShape(dtype, shape)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != size {
+		return nil, fmt.Errorf("quantized: %v %v needs %d bytes, got %d", dtype, shape, size, len(data))
+	}
+	return &QTensor{dtype: dtype, shape: shape.Clone(), data: data}, nil
+}
+
+// checkShape verifies that shape can be stored as dtype and returns the
+// encoded size in bytes.
+func checkShape(dtype GgmlDType, shape *candy.Shape) (int, error) {
+	if bs := dtype.BlockSize(); bs > 1 && (shape.Rank() == 0 || shape.Dim(-1)%bs != 0) {
+		return 0, fmt.Errorf("quantized: last dim of %v is not a multiple of the %v block size %d", shape, dtype, bs)
+	}
+	return dtype.byteSize(shape.Numel())
+}
+
+// Quantize encodes a float32 tensor as dtype. The last dimension must be a
+// multiple of the block size.
+func Quantize(t *tensor.Tensor[float32], dtype GgmlDType) (*QTensor, error) {
+	size, err := checkShape(dtype, t.Shape())
+	if err != nil {
+		return nil, err
+	}
+	x := t.Data()
+	var data []byte
+	switch dtype {
+	case F32:
+		data = make([]byte, size)
+		for i, v := range x {
+			binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(v))
+		}
+	case F16:
+		data = make([]byte, size)
+		for i, v := range x {
+			binary.LittleEndian.PutUint16(data[2*i:], uint16(candy.NewFloat16(v)))
+		}
+	case BF16:
+		data = make([]byte, size)
+		for i, v := range x {
+			binary.LittleEndian.PutUint16(data[2*i:], uint16(candy.NewBFloat16(v)))
+		}
+	case Q4_0:
+		data = quantizeBlocks(x, kernels.QK, kernels.QuantizeBlockQ4_0F32)
+	case Q4_1:
+		data = quantizeBlocks(x, kernels.QK, kernels.QuantizeBlockQ4_1F32)
+	case Q5_0:
+		data = quantizeBlocks(x, kernels.QK, kernels.QuantizeBlockQ5_0F32)
+	case Q5_1:
+		data = quantizeBlocks(x, kernels.QK, kernels.QuantizeBlockQ5_1F32)
+	case Q8_0:
+		data = quantizeBlocks(x, kernels.QK, kernels.QuantizeBlockQ8_0F32)
+	case Q2K:
+		data = quantizeBlocks(x, kernels.QKK, kernels.QuantizeBlockQ2KF32)
+	case Q3K:
+		data = quantizeBlocks(x, kernels.QKK, kernels.QuantizeBlockQ3KF32)
+	case Q4K:
+		data = quantizeBlocks(x, kernels.QKK, kernels.QuantizeBlockQ4KF32)
+	case Q5K:
+		data = quantizeBlocks(x, kernels.QKK, kernels.QuantizeBlockQ5KF32)
+	case Q6K:
+		data = quantizeBlocks(x, kernels.QKK, kernels.QuantizeBlockQ6KF32)
+	case Q8K:
+		data = quantizeBlocks(x, kernels.QKK, kernels.QuantizeBlockQ8KF32)
+	default:
+		return nil, fmt.Errorf("quantized: quantize %v not supported", dtype)
+	}
+	return NewQTensor(dtype, t.Shape(), data)
+}
+
+// MustQuantize encodes a float32 tensor as dtype, panicking on error.
+func MustQuantize(t *tensor.Tensor[float32], dtype GgmlDType) *QTensor {
+	q, err := Quantize(t, dtype)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// MustNewQTensor wraps packed block data of the given type and shape, panicking on error.
+func MustNewQTensor(dtype GgmlDType, shape *candy.Shape, data []byte) *QTensor {
+	q, err := NewQTensor(dtype, shape, data)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// DType returns the encoding of the tensor.
+func (q *QTensor) DType() GgmlDType {
+	return q.dtype
+}
+
+// Shape returns the logical shape of the tensor.
+func (q *QTensor) Shape() *candy.Shape {
+	return q.shape
+}
+
+// Data returns the packed block data.
+func (q *QTensor) Data() []byte {
+	return q.data
+}
+
+// Dequantize decodes the tensor to float32 on device.
+func (q *QTensor) Dequantize(dev candy.Device) (*tensor.Tensor[float32], error) {
+	out := make([]float32, q.shape.Numel())
+	if err := dequantize(q.dtype, q.data, out); err != nil {
+		return nil, err
+	}
+	return tensor.New(out, q.shape, dev)
+}
+
+// MustDequantize decodes the tensor to float32 on device, panicking on error.
+func (q *QTensor) MustDequantize(dev candy.Device) *tensor.Tensor[float32] {
+	t, err := q.Dequantize(dev)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// dequantize decodes packed data of type dt into y.
+func dequantize(dt GgmlDType, data []byte, y []float32) error {
+	switch dt {
+	case F32:
+		for i := range y {
+			y[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+		}
+	case F16:
+		for i := range y {
+			y[i] = candy.Float16(binary.LittleEndian.Uint16(data[2*i:])).Float32()
+		}
+	case BF16:
+		for i := range y {
+			y[i] = candy.BFloat16(binary.LittleEndian.Uint16(data[2*i:])).Float32()
+		}
+	case Q4_0:
+		dequantizeBlocks(data, y, kernels.QK, kernels.DequantizeBlockQ4_0F32)
+	case Q4_1:
+		dequantizeBlocks(data, y, kernels.QK, kernels.DequantizeBlockQ4_1F32)
+	case Q5_0:
+		dequantizeBlocks(data, y, kernels.QK, kernels.DequantizeBlockQ5_0F32)
+	case Q5_1:
+		dequantizeBlocks(data, y, kernels.QK, kernels.DequantizeBlockQ5_1F32)
+	case Q8_0:
+		dequantizeBlocks(data, y, kernels.QK, kernels.DequantizeBlockQ8_0F32)
+	case Q2K:
+		dequantizeBlocks(data, y, kernels.QKK, kernels.DequantizeBlockQ2KF32)
+	case Q3K:
+		dequantizeBlocks(data, y, kernels.QKK, kernels.DequantizeBlockQ3KF32)
+	case Q4K:
+		dequantizeBlocks(data, y, kernels.QKK, kernels.DequantizeBlockQ4KF32)
+	case Q5K:
+		dequantizeBlocks(data, y, kernels.QKK, kernels.DequantizeBlockQ5KF32)
+	case Q6K:
+		dequantizeBlocks(data, y, kernels.QKK, kernels.DequantizeBlockQ6KF32)
+	case Q8K:
+		dequantizeBlocks(data, y, kernels.QKK, kernels.DequantizeBlockQ8KF32)
+	default:
+		return fmt.Errorf("quantized: dequantize %v not supported", dt)
+	}
+	return nil
+}
+
+func quantizeBlocks[B any](x []float32, n int, fn func([]float32, *B)) []byte {
+	blocks := make([]B, len(x)/n)
+	kernels.QuantizeBlocksF32(x, n, blocks, fn)
+	return kernels.BytesFromBlocks(blocks)
+}
+
+func dequantizeBlocks[B any](data []byte, y []float32, n int, fn func(*B, []float32)) {
+	kernels.DequantizeBlocksF32(kernels.BlocksFromBytes[B](data), n, y, fn)
+}
+
+// QMatMul computes x · wᵀ for a float32 activation x of shape (..., k) and a
+// weight w of shape (n, k), returning shape (..., n). Quantized weights are
+// dequantized one block at a time instead of materializing the matrix.
+func QMatMul(x *tensor.Tensor[float32], w *QTensor) (*tensor.Tensor[float32], error) {
+	n, k, err := w.shape.Dims2()
+	if err != nil {
+		return nil, fmt.Errorf("qmatmul: weight must be 2D: %w", err)
+	}
+	dims := slices.Clone(x.Dims())
+	if len(dims) == 0 || dims[len(dims)-1] != k {
+		return nil, fmt.Errorf("qmatmul: activation shape %v incompatible with weight %v", x.Shape(), w.shape)
+	}
+	if w.dtype.BlockSize() == 0 {
+		return nil, fmt.Errorf("qmatmul: unsupported weight dtype %v", w.dtype)
+	}
+	m := x.Shape().Numel() / k
+	dims[len(dims)-1] = n
+	if w.dtype == F32 || w.dtype == F16 || w.dtype == BF16 {
+		wt, err := w.Dequantize(x.Device())
+		if err != nil {
+			return nil, fmt.Errorf("qmatmul: %w", err)
+		}
+		xc, err := x.Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("qmatmul: %w", err)
+		}
+		x2, err := xc.Reshape(m, k)
+		if err != nil {
+			return nil, fmt.Errorf("qmatmul: %w", err)
+		}
+		wT, err := wt.T()
+		if err != nil {
+			return nil, fmt.Errorf("qmatmul: %w", err)
+		}
+		y, err := x2.MatMul(wT)
+		if err != nil {
+			return nil, fmt.Errorf("qmatmul: %w", err)
+		}
+		return y.Reshape(dims...)
+	}
+	// A contiguous activation is read in place; only views are copied.
+	var xs []float32
+	if start, end, ok := x.Layout().ContiguousOffsets(); ok {
+		xs = x.Storage().Data()[start:end]
+	} else {
+		xc, err := x.Contiguous()
+		if err != nil {
+			return nil, fmt.Errorf("qmatmul: %w", err)
+		}
+		xs = xc.Data()
+	}
+	out := make([]float32, m*n)
+	switch w.dtype {
+	case Q4_0:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QK, kernels.DequantizeBlockQ4_0F32)
+	case Q4_1:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QK, kernels.DequantizeBlockQ4_1F32)
+	case Q5_0:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QK, kernels.DequantizeBlockQ5_0F32)
+	case Q5_1:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QK, kernels.DequantizeBlockQ5_1F32)
+	case Q8_0:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QK, kernels.DequantizeBlockQ8_0F32)
+	case Q2K:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QKK, kernels.DequantizeBlockQ2KF32)
+	case Q3K:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QKK, kernels.DequantizeBlockQ3KF32)
+	case Q4K:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QKK, kernels.DequantizeBlockQ4KF32)
+	case Q5K:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QKK, kernels.DequantizeBlockQ5KF32)
+	case Q6K:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QKK, kernels.DequantizeBlockQ6KF32)
+	case Q8K:
+		qmatmul(m, n, k, xs, w.data, out, kernels.QKK, kernels.DequantizeBlockQ8KF32)
+	default:
+		return nil, fmt.Errorf("qmatmul: unsupported weight dtype %v", w.dtype)
+	}
+	return tensor.New(out, candy.NewShapeFrom(dims), x.Device())
+}
+
+// MustQMatMul computes x · wᵀ for a quantized weight w, panicking on error.
+func MustQMatMul(x *tensor.Tensor[float32], w *QTensor) *tensor.Tensor[float32] {
+	y, err := QMatMul(x, w)
+	if err != nil {
+		panic(err)
+	}
+	return y
+}
+
+func qmatmul[B any](m, n, k int, x []float32, data []byte, dst []float32, bs int, fn func(*B, []float32)) {
+	kernels.QMatMulF32(m, n, k, bs, x, kernels.BlocksFromBytes[B](data), dst, fn)
+}
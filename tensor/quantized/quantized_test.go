@@ -0,0 +1,115 @@
+package quantized_test
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+	"github.com/gocnn/candy/tensor/quantized"
+)
+
+var allTypes = []quantized.GgmlDType{
+	quantized.F32, quantized.F16, quantized.BF16,
+	quantized.Q4_0, quantized.Q4_1, quantized.Q5_0, quantized.Q5_1, quantized.Q8_0,
+	quantized.Q2K, quantized.Q3K, quantized.Q4K, quantized.Q5K, quantized.Q6K, quantized.Q8K,
+}
+
+func randn(r *rand.Rand, dims ...int) *tensor.Tensor[float32] {
+	shape := candy.NewShapeFrom(dims)
+	data := make([]float32, shape.Numel())
+	for i := range data {
+		data[i] = float32(r.NormFloat64())
+	}
+	return tensor.MustNew(data, shape, candy.CPU)
+}
+
+func TestQuantizeDequantize(t *testing.T) {
+	t.Parallel()
+	w := randn(rand.New(rand.NewPCG(5, 6)), 4, 512)
+	for _, dt := range allTypes {
+		q := quantized.MustQuantize(w, dt)
+		if len(q.Data()) != 4*512/dt.BlockSize()*dt.TypeSize() {
+			t.Fatalf("%v: %d bytes", dt, len(q.Data()))
+		}
+		got := q.MustDequantize(candy.CPU)
+		if !got.Shape().Equal(w.Shape()) {
+			t.Fatalf("%v: shape %v", dt, got.Shape())
+		}
+		var se, ss float64
+		for i, v := range w.Data() {
+			d := float64(got.Data()[i] - v)
+			se, ss = se+d*d, ss+float64(v*v)
+		}
+		if e := math.Sqrt(se / ss); e > 0.35 {
+			t.Fatalf("%v: relative rmse %.4f", dt, e)
+		}
+
+		// Quantized data survives a GGUF round trip unchanged.
+		var buf bytes.Buffer
+		if err := quantized.Write(&buf, nil, map[string]*quantized.QTensor{"w": q}); err != nil {
+			t.Fatal(err)
+		}
+		f, err := quantized.Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(f.MustTensor("w", candy.CPU).Data(), got.Data()) {
+			t.Fatalf("%v: gguf round trip changed values", dt)
+		}
+	}
+	if _, err := quantized.Quantize(randn(rand.New(rand.NewPCG(1, 1)), 2, 48), quantized.Q4_0); err == nil {
+		t.Fatal("expected error for last dim not a multiple of 32")
+	}
+}
+
+func TestQMatMul(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewPCG(7, 8))
+	x := randn(r, 2, 3, 256)
+	w := randn(r, 6, 256)
+	ref := x.MustMatMul(w.MustT().MustBroadcastLeft(2))
+	for _, dt := range allTypes {
+		q := quantized.MustQuantize(w, dt)
+		got := quantized.MustQMatMul(x, q)
+		if !got.Shape().Equal(candy.NewShape(2, 3, 6)) {
+			t.Fatalf("%v: shape %v", dt, got.Shape())
+		}
+		// Exact against the dequantized weight, approximate against f32.
+		exact := x.MustMatMul(q.MustDequantize(candy.CPU).MustT().MustBroadcastLeft(2)).Data()
+		var se, ss float64
+		for i, v := range got.Data() {
+			if math.Abs(float64(v-exact[i])) > 1e-3 {
+				t.Fatalf("%v: got %v want %v at %d", dt, v, exact[i], i)
+			}
+			d := float64(v - ref.Data()[i])
+			se, ss = se+d*d, ss+float64(ref.Data()[i]*ref.Data()[i])
+		}
+		if e := math.Sqrt(se / ss); e > 0.35 {
+			t.Fatalf("%v: relative rmse %.4f against f32", dt, e)
+		}
+	}
+	q := quantized.MustQuantize(w, quantized.Q8_0)
+	if _, err := quantized.QMatMul(randn(r, 2, 128), q); err == nil {
+		t.Fatal("expected error for mismatched inner dim")
+	}
+	if _, err := quantized.QMatMul(randn(r, 128, 3).MustT(), q); err == nil {
+		t.Fatal("expected error for mismatched inner dim of a view")
+	}
+	// Views are read through their layout, narrowed rows in place.
+	for name, v := range map[string]*tensor.Tensor[float32]{
+		"transposed": randn(r, 256, 3).MustT(),
+		"narrowed":   randn(r, 5, 256).MustNarrow(0, 1, 3),
+	} {
+		for _, dt := range []quantized.GgmlDType{quantized.Q8_0, quantized.F16, quantized.BF16} {
+			qw := quantized.MustQuantize(w, dt)
+			got, want := quantized.MustQMatMul(v, qw).Data(), quantized.MustQMatMul(v.MustContiguous(), qw).Data()
+			if !slices.Equal(got, want) {
+				t.Fatalf("%s %v: got %v want %v", name, dt, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor/internal/cpu"
+)
+
+// deviceKey identifies a registered backend by device and element type.
+type deviceKey struct {
+	dev   candy.Device
+	dtype candy.DType
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[deviceKey]any)
+)
+
+func init() {
+	RegisterDevice(candy.CPU, cpu.NewCpuDevice[float32])
+	RegisterDevice(candy.CPU, cpu.NewCpuDevice[float64])
+	RegisterDevice(candy.CPU, cpu.NewCpuDevice[uint8])
+	RegisterDevice(candy.CPU, cpu.NewCpuDevice[uint32])
+	RegisterDevice(candy.CPU, cpu.NewCpuDevice[int64])
+	RegisterDevice(candy.CPU, cpu.NewCpuDevice[candy.Float16])
+	RegisterDevice(candy.CPU, cpu.NewCpuDevice[candy.BFloat16])
+}
+
+// RegisterDevice registers factory as the backend for element type T on dev, replacing any previous one.
+// A backend supporting several element types registers a factory for each of them.
+func RegisterDevice[T candy.D](dev candy.Device, factory func() candy.BackendDevice[T]) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[deviceKey{dev, candy.DTypeOf[T]()}] = factory
+}
+
+// LookupDevice returns a backend device for element type T on dev.
+func LookupDevice[T candy.D](dev candy.Device) (candy.BackendDevice[T], error) {
+	registryMu.RLock()
+	f, ok := registry[deviceKey{dev, candy.DTypeOf[T]()}]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported device: %v", dev)
+	}
+	return f.(func() candy.BackendDevice[T])(), nil
+}
+
+// ManualSeed seeds the generator of every registered device, making random constructors,
+// weight initialization and dropout reproducible.
+func ManualSeed(seed uint64) error {
+	registryMu.RLock()
+	factories := make(map[deviceKey]any, len(registry))
+	for k, f := range registry {
+		factories[k] = f
+	}
+	registryMu.RUnlock()
+
+	var errs []error
+	for k, f := range factories {
+		if err := seedFactory(f, seed); err != nil {
+			errs = append(errs, fmt.Errorf("seed %v %v: %w", k.dev, k.dtype, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewRand returns a generator derived from the CPU device generator, for
+// randomness outside tensors such as data shuffling. Calling ManualSeed
+// first makes its sequence reproducible.
+func NewRand() *rand.Rand {
+	return cpu.NewRand()
+}
+
+// seedFactory seeds the device produced by a registered factory.
+func seedFactory(f any, seed uint64) error {
+	switch f := f.(type) {
+	case func() candy.BackendDevice[float32]:
+		return f().SetSeed(seed)
+	case func() candy.BackendDevice[float64]:
+		return f().SetSeed(seed)
+	case func() candy.BackendDevice[uint8]:
+		return f().SetSeed(seed)
+	case func() candy.BackendDevice[uint32]:
+		return f().SetSeed(seed)
+	case func() candy.BackendDevice[int64]:
+		return f().SetSeed(seed)
+	case func() candy.BackendDevice[candy.Float16]:
+		return f().SetSeed(seed)
+	case func() candy.BackendDevice[candy.BFloat16]:
+		return f().SetSeed(seed)
+	}
+	return nil
+}
@@ -0,0 +1,453 @@
+package tensor
+
+import (
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"unsafe"
+
+	"github.com/gocnn/candy"
+)
+
+const (
+	safetensorsMetadataKey = "__metadata__"
+	safetensorsMaxHeader   = 100 << 20
+)
+
+// hostLittleEndian reports whether in-memory element bytes match the
+// little-endian on-disk layout, allowing raw copies.
+var hostLittleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+// safetensorsDTypes maps safetensors dtype names to element sizes.
+var safetensorsDTypes = map[string]int{
+	"BOOL": 1, "U8": 1, "I8": 1,
+	"U16": 2, "I16": 2, "F16": 2, "BF16": 2,
+	"U32": 4, "I32": 4, "F32": 4,
+	"U64": 8, "I64": 8, "F64": 8,
+}
+
+func dtypeToSafetensors(dt candy.DType) (string, error) {
+	switch dt {
+	case candy.F32:
+		return "F32", nil
+	case candy.F64:
+		return "F64", nil
+	case candy.F16:
+		return "F16", nil
+	case candy.BF16:
+		return "BF16", nil
+	case candy.U8:
+		return "U8", nil
+	case candy.U32:
+		return "U32", nil
+	case candy.I64:
+		return "I64", nil
+	default:
+		return "", fmt.Errorf("safetensors: unsupported dtype %v", dt)
+	}
+}
+
+type safetensorsEntry struct {
+	DType       string `json:"dtype"`
+	Shape       []int  `json:"shape"`
+	DataOffsets [2]int `json:"data_offsets"`
+}
+
+// SafetensorsInfo describes one tensor stored in a safetensors file.
+type SafetensorsInfo struct {
+	DType string // safetensors dtype name, e.g. "F32" or "BF16"
+	Shape *candy.Shape
+}
+
+// Safetensors is an open safetensors file. The file is memory-mapped and
+// tensors are decoded on request, so only the tensors loaded occupy memory.
+type Safetensors struct {
+	data     []byte
+	unmap    func() error
+	entries  map[string]safetensorsEntry
+	names    []string
+	metadata map[string]string
+}
+
+// OpenSafetensors memory-maps and parses the safetensors file at path.
+func OpenSafetensors(path string) (*Safetensors, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	data, unmap, err := mmapFile(f, int(st.Size()))
+	if err != nil {
+		return nil, fmt.Errorf("safetensors: map %s: %w", path, err)
+	}
+	s, err := parseSafetensors(data)
+	if err != nil {
+		unmap()
+		return nil, err
+	}
+	s.unmap = unmap
+	return s, nil
+}
+
+// MustOpenSafetensors memory-maps and parses a safetensors file, panicking on error.
+func MustOpenSafetensors(path string) *Safetensors {
+	s, err := OpenSafetensors(path)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func parseSafetensors(data []byte) (*Safetensors, error) {
+	if len(data) < 8 {
+		return nil, errors.New("safetensors: file too short")
+	}
+	n := binary.LittleEndian.Uint64(data)
+	if n > safetensorsMaxHeader || n > uint64(len(data)-8) {
+		return nil, fmt.Errorf("safetensors: invalid header length %d", n)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data[8:8+n], &raw); err != nil {
+		return nil, fmt.Errorf("safetensors: parse header: %w", err)
+	}
+	s := &Safetensors{
+		data:    data[8+n:],
+		entries: make(map[string]safetensorsEntry, len(raw)),
+	}
+	for name, msg := range raw {
+		if name == safetensorsMetadataKey {
+			if err := json.Unmarshal(msg, &s.metadata); err != nil {
+				return nil, fmt.Errorf("safetensors: parse %s: %w", safetensorsMetadataKey, err)
+			}
+			continue
+		}
+		var e safetensorsEntry
+		if err := json.Unmarshal(msg, &e); err != nil {
+			return nil, fmt.Errorf("safetensors: parse entry %q: %w", name, err)
+		}
+		size, ok := safetensorsDTypes[e.DType]
+		if !ok {
+			return nil, fmt.Errorf("safetensors: %q has unsupported dtype %s", name, e.DType)
+		}
+		numel := 1
+		for _, d := range e.Shape {
+			if d < 0 {
+				return nil, fmt.Errorf("safetensors: %q has negative dim in shape %v", name, e.Shape)
+			}
+			if d > 0 && numel > len(s.data)/size/d {
+				return nil, fmt.Errorf("safetensors: %q shape %v exceeds the %d data bytes", name, e.Shape, len(s.data))
+			}
+			numel *= d
+		}
+		begin, end := e.DataOffsets[0], e.DataOffsets[1]
+		if begin < 0 || begin > end || end > len(s.data) || end-begin != numel*size {
+			return nil, fmt.Errorf("safetensors: %q has invalid data offsets %v for %s %v", name, e.DataOffsets, e.DType, e.Shape)
+		}
+		s.entries[name] = e
+	}
+	s.names = slices.SortedFunc(maps.Keys(s.entries), func(a, b string) int {
+		ea, eb := s.entries[a].DataOffsets, s.entries[b].DataOffsets
+		return cmp.Or(ea[0]-eb[0], ea[1]-eb[1], strings.Compare(a, b))
+	})
+	// The tensors must tile the data buffer in order, without gaps or overlaps.
+	end := 0
+	for _, name := range s.names {
+		e := s.entries[name]
+		if e.DataOffsets[0] != end {
+			return nil, fmt.Errorf("safetensors: %q has data offsets %v, expected to start at %d", name, e.DataOffsets, end)
+		}
+		end = e.DataOffsets[1]
+	}
+	if end != len(s.data) {
+		return nil, fmt.Errorf("safetensors: tensors cover %d of %d data bytes", end, len(s.data))
+	}
+	return s, nil
+}
+
+// Close releases the file mapping. Tensors already loaded remain valid.
+func (s *Safetensors) Close() error {
+	if s.unmap == nil {
+		return nil
+	}
+	err := s.unmap()
+	s.unmap, s.data = nil, nil
+	return err
+}
+
+// Metadata returns the free-form string map stored under __metadata__.
+func (s *Safetensors) Metadata() map[string]string {
+	return s.metadata
+}
+
+// Names returns the tensor names in file order.
+func (s *Safetensors) Names() []string {
+	return slices.Clone(s.names)
+}
+
+// Info returns the dtype and shape of the named tensor.
+func (s *Safetensors) Info(name string) (SafetensorsInfo, bool) {
+	e, ok := s.entries[name]
+	if !ok {
+		return SafetensorsInfo{}, false
+	}
+	return SafetensorsInfo{DType: e.DType, Shape: candy.NewShapeFrom(e.Shape)}, true
+}
+
+// LoadSafetensor decodes the named tensor from s into a CPU tensor of type T,
+// converting from the stored dtype when it differs.
+func LoadSafetensor[T candy.D](s *Safetensors, name string) (*Tensor[T], error) {
+	e, ok := s.entries[name]
+	if !ok {
+		return nil, fmt.Errorf("safetensors: no tensor named %q", name)
+	}
+	if s.unmap == nil && s.data == nil {
+		return nil, errors.New("safetensors: file is closed")
+	}
+	shape := candy.NewShapeFrom(e.Shape)
+	data := decodeSafetensor[T](e.DType, s.data[e.DataOffsets[0]:e.DataOffsets[1]], shape.Numel())
+	return New(data, shape, candy.CPU)
+}
+
+// MustLoadSafetensor decodes the named tensor from s, panicking on error.
+func MustLoadSafetensor[T candy.D](s *Safetensors, name string) *Tensor[T] {
+	t, err := LoadSafetensor[T](s, name)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// decodeSafetensor converts n little-endian elements of the safetensors dtype
+// to T. Matching dtypes are copied directly on little-endian hosts, and
+// integers are converted without passing through float64 when T is an
+// integer type.
+func decodeSafetensor[T candy.D](dtype string, raw []byte, n int) []T {
+	out := make([]T, n)
+	if n == 0 {
+		return out
+	}
+	if name, err := dtypeToSafetensors(candy.DTypeOf[T]()); err == nil && name == dtype && hostLittleEndian {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&out[0])), len(raw)), raw)
+		return out
+	}
+	toInt := candy.DTypeOf[T]().IsInteger()
+	le := binary.LittleEndian
+	for i := range out {
+		if toInt {
+			if v, ok := safetensorsInt(dtype, raw, i); ok {
+				out[i] = T(v)
+				continue
+			}
+		}
+		var v float64
+		switch dtype {
+		case "BOOL", "U8":
+			v = float64(raw[i])
+		case "I8":
+			v = float64(int8(raw[i]))
+		case "U16":
+			v = float64(le.Uint16(raw[2*i:]))
+		case "I16":
+			v = float64(int16(le.Uint16(raw[2*i:])))
+		case "F16":
+			v = float64(candy.Float16(le.Uint16(raw[2*i:])).Float32())
+		case "BF16":
+			v = float64(candy.BFloat16(le.Uint16(raw[2*i:])).Float32())
+		case "U32":
+			v = float64(le.Uint32(raw[4*i:]))
+		case "I32":
+			v = float64(int32(le.Uint32(raw[4*i:])))
+		case "F32":
+			v = float64(math.Float32frombits(le.Uint32(raw[4*i:])))
+		case "U64":
+			v = float64(le.Uint64(raw[8*i:]))
+		case "I64":
+			v = float64(int64(le.Uint64(raw[8*i:])))
+		case "F64":
+			v = math.Float64frombits(le.Uint64(raw[8*i:]))
+		}
+		out[i] = candy.FromFloat64[T](v)
+	}
+	return out
+}
+
+// safetensorsInt returns element i of raw as an int64 when dtype is an
+// integer type. U64 values above math.MaxInt64 wrap.
+func safetensorsInt(dtype string, raw []byte, i int) (int64, bool) {
+	le := binary.LittleEndian
+	switch dtype {
+	case "BOOL", "U8":
+		return int64(raw[i]), true
+	case "I8":
+		return int64(int8(raw[i])), true
+	case "U16":
+		return int64(le.Uint16(raw[2*i:])), true
+	case "I16":
+		return int64(int16(le.Uint16(raw[2*i:]))), true
+	case "U32":
+		return int64(le.Uint32(raw[4*i:])), true
+	case "I32":
+		return int64(int32(le.Uint32(raw[4*i:]))), true
+	case "U64", "I64":
+		return int64(le.Uint64(raw[8*i:])), true
+	}
+	return 0, false
+}
+
+// ReadSafetensors loads every tensor of a safetensors file as type T.
+func ReadSafetensors[T candy.D](path string) (map[string]*Tensor[T], error) {
+	s, err := OpenSafetensors(path)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	res := make(map[string]*Tensor[T], len(s.names))
+	for _, name := range s.names {
+		t, err := LoadSafetensor[T](s, name)
+		if err != nil {
+			return nil, err
+		}
+		res[name] = t
+	}
+	return res, nil
+}
+
+// MustReadSafetensors loads every tensor of a safetensors file, panicking on error.
+func MustReadSafetensors[T candy.D](path string) map[string]*Tensor[T] {
+	res, err := ReadSafetensors[T](path)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ReadSafetensorsByName loads the named tensors of a safetensors file as type
+// T, in the order given, without decoding the others.
+func ReadSafetensorsByName[T candy.D](path string, names []string) ([]*Tensor[T], error) {
+	s, err := OpenSafetensors(path)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	out := make([]*Tensor[T], 0, len(names))
+	for _, name := range names {
+		t, err := LoadSafetensor[T](s, name)
+		if err != nil {
+			return nil, fmt.Errorf("%w in %s", err, path)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// MustReadSafetensorsByName loads the named tensors of a safetensors file, panicking on error.
+func MustReadSafetensorsByName[T candy.D](path string, names []string) []*Tensor[T] {
+	res, err := ReadSafetensorsByName[T](path, names)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TensorView is a dtype-erased view of a tensor, implemented by *Tensor[T]
+// for every element type, so maps of mixed dtypes can be serialized.
+type TensorView interface {
+	DType() candy.DType
+	Dims() []int
+	littleEndianBytes() []byte
+}
+
+// littleEndianBytes returns the row-major data encoded little-endian,
+// aliasing the tensor data on little-endian hosts.
+func (t *Tensor[T]) littleEndianBytes() []byte {
+	data := t.Data()
+	if len(data) == 0 {
+		return nil
+	}
+	if hostLittleEndian {
+		return unsafe.Slice((*byte)(unsafe.Pointer(&data[0])), len(data)*int(unsafe.Sizeof(data[0])))
+	}
+	b, err := binary.Append(nil, binary.LittleEndian, data)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// WriteSafetensors writes tensors of any dtype and optional string metadata
+// to a safetensors file. Tensors are laid out in sorted name order.
+func WriteSafetensors(path string, items map[string]TensorView, metadata map[string]string) error {
+	header := make(map[string]any, len(items)+1)
+	if len(metadata) > 0 {
+		header[safetensorsMetadataKey] = metadata
+	}
+	names := slices.Sorted(maps.Keys(items))
+	offset := 0
+	for _, name := range names {
+		if name == safetensorsMetadataKey {
+			return fmt.Errorf("safetensors: reserved tensor name %q", name)
+		}
+		t := items[name]
+		dt, err := dtypeToSafetensors(t.DType())
+		if err != nil {
+			return err
+		}
+		dims := slices.Clone(t.Dims())
+		numel, elem := 1, t.DType().Size()
+		for _, d := range dims {
+			if d > 0 && numel > (math.MaxInt-offset)/elem/d {
+				return fmt.Errorf("safetensors: %q shape %v is too large to write", name, dims)
+			}
+			numel *= d
+		}
+		size := numel * elem
+		header[name] = safetensorsEntry{DType: dt, Shape: dims, DataOffsets: [2]int{offset, offset + size}}
+		offset += size
+	}
+	h, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("safetensors: encode header: %w", err)
+	}
+	if pad := (8 - len(h)%8) % 8; pad > 0 {
+		h = append(h, []byte("       ")[:pad]...)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := binary.Write(f, binary.LittleEndian, uint64(len(h))); err != nil {
+		return err
+	}
+	if _, err := f.Write(h); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, err := f.Write(items[name].littleEndianBytes()); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// MustWriteSafetensors writes tensors to a safetensors file, panicking on error.
+func MustWriteSafetensors(path string, items map[string]TensorView, metadata map[string]string) {
+	if err := WriteSafetensors(path, items, metadata); err != nil {
+		panic(err)
+	}
+}
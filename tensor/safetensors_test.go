@@ -0,0 +1,138 @@
+package tensor_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+)
+
+func TestSafetensorsRoundTrip(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "model.safetensors")
+	w := arange(t, 2, 3)
+	ids := tensor.MustNew([]int64{-4, 0, 7}, candy.NewShape(3), candy.CPU)
+	half := w.MustToBFloat16()
+	mask := tensor.MustNew([]uint8{1, 0}, candy.NewShape(2), candy.CPU)
+	tensor.MustWriteSafetensors(path, map[string]tensor.TensorView{
+		"fc.weight": w.MustTranspose(0, 1),
+		"ids":       ids,
+		"half":      half,
+		"mask":      mask,
+	}, map[string]string{"format": "pt"})
+
+	s := tensor.MustOpenSafetensors(path)
+	defer s.Close()
+	if got := s.Metadata()["format"]; got != "pt" {
+		t.Fatalf("metadata format = %q", got)
+	}
+	if got := s.Names(); !slices.Equal(got, []string{"fc.weight", "half", "ids", "mask"}) {
+		t.Fatalf("names = %v", got)
+	}
+	info, ok := s.Info("half")
+	if !ok || info.DType != "BF16" || !info.Shape.Equal(candy.NewShape(2, 3)) {
+		t.Fatalf("info = %+v", info)
+	}
+	if got := tensor.MustLoadSafetensor[float32](s, "fc.weight"); !got.Shape().Equal(candy.NewShape(3, 2)) || !slices.Equal(got.Data(), []float32{0, 3, 1, 4, 2, 5}) {
+		t.Fatalf("fc.weight = %v", got)
+	}
+	if got := tensor.MustLoadSafetensor[int64](s, "ids").Data(); !slices.Equal(got, []int64{-4, 0, 7}) {
+		t.Fatalf("ids = %v", got)
+	}
+	if got := tensor.MustLoadSafetensor[float32](s, "ids").Data(); !slices.Equal(got, []float32{-4, 0, 7}) {
+		t.Fatalf("ids as f32 = %v", got)
+	}
+	if got := tensor.MustLoadSafetensor[candy.BFloat16](s, "half").MustToFloat32().Data(); !slices.Equal(got, w.Data()) {
+		t.Fatalf("half = %v", got)
+	}
+	if _, err := tensor.LoadSafetensor[float32](s, "missing"); err == nil {
+		t.Fatal("expected error for missing tensor")
+	}
+
+	all := tensor.MustReadSafetensors[float64](path)
+	if len(all) != 4 || !slices.Equal(all["mask"].Data(), []float64{1, 0}) {
+		t.Fatalf("all = %v", all)
+	}
+	some := tensor.MustReadSafetensorsByName[float32](path, []string{"mask", "fc.weight"})
+	if len(some) != 2 || !slices.Equal(some[0].Data(), []float32{1, 0}) {
+		t.Fatalf("by name = %v", some)
+	}
+}
+
+func TestSafetensorsForeignDTypes(t *testing.T) {
+	t.Parallel()
+	header := []byte(`{"a":{"dtype":"I32","shape":[2],"data_offsets":[0,8]},"b":{"dtype":"I8","shape":[],"data_offsets":[8,9]}}`)
+	var buf []byte
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(header)))
+	buf = append(buf, header...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(0xfffffffe)) // -2
+	buf = binary.LittleEndian.AppendUint32(buf, 9)
+	buf = append(buf, 0xff) // -1
+	path := filepath.Join(t.TempDir(), "foreign.safetensors")
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ts := tensor.MustReadSafetensors[int64](path)
+	if !slices.Equal(ts["a"].Data(), []int64{-2, 9}) || !slices.Equal(ts["b"].Data(), []int64{-1}) || ts["b"].Shape().Rank() != 0 {
+		t.Fatalf("got a=%v b=%v", ts["a"], ts["b"])
+	}
+
+	bad := slices.Clone(buf)
+	copy(bad[8:], `{"a":{"dtype":"I32","shape":[3]`)
+	if err := os.WriteFile(path, bad, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tensor.OpenSafetensors(path); err == nil {
+		t.Fatal("expected error for offsets that do not match the shape")
+	}
+}
+
+func TestSafetensorsExactIntsAndOffsets(t *testing.T) {
+	t.Parallel()
+	write := func(header string, data []byte) string {
+		t.Helper()
+		buf := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+		buf = append(append(buf, header...), data...)
+		path := filepath.Join(t.TempDir(), "x.safetensors")
+		if err := os.WriteFile(path, buf, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	// 2^53+1 is not representable in float64.
+	big := int64(1)<<53 + 1
+	data := binary.LittleEndian.AppendUint64(nil, uint64(big))
+	data = binary.LittleEndian.AppendUint64(data, uint64(-big))
+	path := write(`{"u":{"dtype":"U64","shape":[1],"data_offsets":[0,8]},"i":{"dtype":"I64","shape":[1],"data_offsets":[8,16]}}`, data)
+	ts := tensor.MustReadSafetensors[int64](path)
+	if got := ts["u"].Data(); got[0] != big {
+		t.Fatalf("u64 = %v, want %d", got, big)
+	}
+	if got := ts["i"].Data(); got[0] != -big {
+		t.Fatalf("i64 = %v, want %d", got, -big)
+	}
+
+	for name, header := range map[string]string{
+		"overlap": `{"a":{"dtype":"U8","shape":[8],"data_offsets":[0,8]},"b":{"dtype":"U8","shape":[8],"data_offsets":[4,12]}}`,
+		"gap":     `{"a":{"dtype":"U8","shape":[4],"data_offsets":[0,4]},"b":{"dtype":"U8","shape":[4],"data_offsets":[8,12]}}`,
+		"tail":    `{"a":{"dtype":"U8","shape":[8],"data_offsets":[0,8]}}`,
+		// 4*(2^62+1) F32 elements wrap around to 16 bytes.
+		"overflow": `{"a":{"dtype":"F32","shape":[4,4611686018427387905],"data_offsets":[0,16]}}`,
+	} {
+		if _, err := tensor.OpenSafetensors(write(header, make([]byte, 16))); err == nil {
+			t.Errorf("%s: expected invalid data offsets error", name)
+		}
+	}
+
+	huge, err := tensor.MustZeros[float32](candy.NewShape(), candy.CPU).BroadcastAs(candy.NewShape(1<<32, 1<<32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tensor.WriteSafetensors(filepath.Join(t.TempDir(), "huge.safetensors"), map[string]tensor.TensorView{"a": huge}, nil); err == nil {
+		t.Fatal("expected error for a shape whose byte size overflows")
+	}
+}
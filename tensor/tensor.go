@@ -2,6 +2,9 @@ package tensor
 
 import (
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
 	"sync/atomic"
 
 	"github.com/gocnn/candy"
@@ -43,96 +46,103 @@ func NewFrom[T candy.D](storage candy.BackendStorage[T], layout *candy.Layout, d
 
 // New creates a tensor from data and shape on device.
 func New[T candy.D](data []T, shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
-	var storage candy.BackendStorage[T]
-	switch dev {
-	case candy.CPU:
-		storage = cpu.New(data)
-	default:
-		return nil, fmt.Errorf("unsupported device: %v", dev)
+	if len(data) != shape.Numel() {
+		return nil, fmt.Errorf("data length %d does not match shape %v", len(data), shape)
+	}
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.StorageFromSlice(data)
+	if err != nil {
+		return nil, fmt.Errorf("create storage failed: %w", err)
 	}
 	return NewFrom(storage, candy.Contiguous(shape), candy.DTypeOf[T](), dev), nil
 }
 
 // Full creates a tensor filled with value.
 func Full[T candy.D](value float64, shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
-	var storage candy.BackendStorage[T]
-	switch dev {
-	case candy.CPU:
-		var err error
-		storage, err = cpu.NewCpuDevice[T]().Full(shape, candy.DTypeOf[T](), value)
-		if err != nil {
-			return nil, fmt.Errorf("create full failed: %w", err)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported device: %v", dev)
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Full(shape, candy.DTypeOf[T](), value)
+	if err != nil {
+		return nil, fmt.Errorf("create full failed: %w", err)
 	}
 	return NewFrom(storage, candy.Contiguous(shape), candy.DTypeOf[T](), dev), nil
 }
 
 // Ones creates a tensor filled with ones.
 func Ones[T candy.D](shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
-	var storage candy.BackendStorage[T]
-	switch dev {
-	case candy.CPU:
-		var err error
-		storage, err = cpu.NewCpuDevice[T]().Ones(shape, candy.DTypeOf[T]())
-		if err != nil {
-			return nil, fmt.Errorf("create ones failed: %w", err)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported device: %v", dev)
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Ones(shape, candy.DTypeOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("create ones failed: %w", err)
 	}
 	return NewFrom(storage, candy.Contiguous(shape), candy.DTypeOf[T](), dev), nil
 }
 
 // Zeros creates a tensor filled with zeros.
 func Zeros[T candy.D](shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
-	var storage candy.BackendStorage[T]
-	switch dev {
-	case candy.CPU:
-		var err error
-		storage, err = cpu.NewCpuDevice[T]().Zeros(shape, candy.DTypeOf[T]())
-		if err != nil {
-			return nil, fmt.Errorf("create zeros failed: %w", err)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported device: %v", dev)
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Zeros(shape, candy.DTypeOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("create zeros failed: %w", err)
 	}
 	return NewFrom(storage, candy.Contiguous(shape), candy.DTypeOf[T](), dev), nil
 }
 
 // Rand creates a tensor with uniform samples in [lo, up).
 func Rand[T candy.D](lo, up float64, shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
-	var storage candy.BackendStorage[T]
-	switch dev {
-	case candy.CPU:
-		var err error
-		storage, err = cpu.NewCpuDevice[T]().RandUniform(shape, candy.DTypeOf[T](), lo, up)
-		if err != nil {
-			return nil, fmt.Errorf("create rand failed: %w", err)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported device: %v", dev)
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.RandUniform(shape, candy.DTypeOf[T](), lo, up)
+	if err != nil {
+		return nil, fmt.Errorf("create rand failed: %w", err)
 	}
 	return NewFrom(storage, candy.Contiguous(shape), candy.DTypeOf[T](), dev), nil
 }
 
 // RandN creates a tensor with normal distribution samples.
 func RandN[T candy.D](mean, std float64, shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
-	var storage candy.BackendStorage[T]
-	switch dev {
-	case candy.CPU:
-		var err error
-		storage, err = cpu.NewCpuDevice[T]().RandNormal(shape, candy.DTypeOf[T](), mean, std)
-		if err != nil {
-			return nil, fmt.Errorf("create randn failed: %w", err)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported device: %v", dev)
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.RandNormal(shape, candy.DTypeOf[T](), mean, std)
+	if err != nil {
+		return nil, fmt.Errorf("create randn failed: %w", err)
 	}
 	return NewFrom(storage, candy.Contiguous(shape), candy.DTypeOf[T](), dev), nil
 }
 
+// RandFrom creates a tensor with uniform samples in [lo, up) drawn from r instead of the device generator.
+func RandFrom[T candy.D](r *rand.Rand, lo, up float64, shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
+	data := make([]T, shape.Numel())
+	if err := cpu.FillUniform(r, data, lo, up); err != nil {
+		return nil, fmt.Errorf("create rand failed: %w", err)
+	}
+	return New(data, shape, dev)
+}
+
+// RandNFrom creates a tensor with normal samples drawn from r instead of the device generator.
+func RandNFrom[T candy.D](r *rand.Rand, mean, std float64, shape *candy.Shape, dev candy.Device) (*Tensor[T], error) {
+	data := make([]T, shape.Numel())
+	if err := cpu.FillNormal(r, data, mean, std); err != nil {
+		return nil, fmt.Errorf("create randn failed: %w", err)
+	}
+	return New(data, shape, dev)
+}
+
 // MustNew creates tensor from data and shape, panics on error.
 func MustNew[T candy.D](data []T, shape *candy.Shape, dev candy.Device) *Tensor[T] {
 	res, err := New(data, shape, dev)
@@ -187,6 +197,267 @@ func MustRandN[T candy.D](mean, std float64, shape *candy.Shape, dev candy.Devic
 	return res
 }
 
+// MustRandFrom creates tensor with uniform samples from r, panics on error.
+func MustRandFrom[T candy.D](r *rand.Rand, lo, up float64, shape *candy.Shape, dev candy.Device) *Tensor[T] {
+	res, err := RandFrom[T](r, lo, up, shape, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustRandNFrom creates tensor with normal samples from r, panics on error.
+func MustRandNFrom[T candy.D](r *rand.Rand, mean, std float64, shape *candy.Shape, dev candy.Device) *Tensor[T] {
+	res, err := RandNFrom[T](r, mean, std, shape, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Arange creates a 1D tensor of values start, start+step, ... below end.
+func Arange[T candy.D](start, end, step float64, dev candy.Device) (*Tensor[T], error) {
+	if step == 0 {
+		return nil, fmt.Errorf("arange: step must be non-zero")
+	}
+	count := math.Ceil((end - start) / step)
+	if math.IsNaN(count) || math.IsInf(count, 0) || count >= math.MaxInt {
+		return nil, fmt.Errorf("arange: %v to %v by %v does not give an int element count", start, end, step)
+	}
+	n := max(int(count), 0)
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Arange(start, step, n, candy.DTypeOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("create arange failed: %w", err)
+	}
+	return NewFrom(storage, candy.Contiguous(candy.NewShape(n)), candy.DTypeOf[T](), dev), nil
+}
+
+// Linspace creates a 1D tensor of n evenly spaced values from start to end inclusive.
+func Linspace[T candy.D](start, end float64, n int, dev candy.Device) (*Tensor[T], error) {
+	if n < 0 {
+		return nil, fmt.Errorf("linspace: n must be non-negative, got %d", n)
+	}
+	step := 0.0
+	if n > 1 {
+		step = (end - start) / float64(n-1)
+	}
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Arange(start, step, n, candy.DTypeOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("create linspace failed: %w", err)
+	}
+	return NewFrom(storage, candy.Contiguous(candy.NewShape(n)), candy.DTypeOf[T](), dev), nil
+}
+
+// Logspace creates a 1D tensor of n values base^e for e evenly spaced from start to end inclusive.
+func Logspace[T candy.D](start, end float64, n int, base float64, dev candy.Device) (*Tensor[T], error) {
+	if n < 0 {
+		return nil, fmt.Errorf("logspace: n must be non-negative, got %d", n)
+	}
+	step := 0.0
+	if n > 1 {
+		step = (end - start) / float64(n-1)
+	}
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Logspace(start, step, n, base, candy.DTypeOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("create logspace failed: %w", err)
+	}
+	return NewFrom(storage, candy.Contiguous(candy.NewShape(n)), candy.DTypeOf[T](), dev), nil
+}
+
+// Eye creates an n x n identity matrix.
+func Eye[T candy.D](n int, dev candy.Device) (*Tensor[T], error) {
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Eye(n, candy.DTypeOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("create eye failed: %w", err)
+	}
+	return NewFrom(storage, candy.Contiguous(candy.NewShape(n, n)), candy.DTypeOf[T](), dev), nil
+}
+
+// Tril creates a rows x cols mask of ones on and below the k-th diagonal.
+func Tril[T candy.D](rows, cols, k int, dev candy.Device) (*Tensor[T], error) {
+	return tri[T](rows, cols, k, true, dev)
+}
+
+// Triu creates a rows x cols mask of ones on and above the k-th diagonal.
+func Triu[T candy.D](rows, cols, k int, dev candy.Device) (*Tensor[T], error) {
+	return tri[T](rows, cols, k, false, dev)
+}
+
+// tri creates a lower or upper triangular mask.
+func tri[T candy.D](rows, cols, k int, lower bool, dev candy.Device) (*Tensor[T], error) {
+	bd, err := LookupDevice[T](dev)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := bd.Tri(rows, cols, k, lower, candy.DTypeOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("create tri failed: %w", err)
+	}
+	return NewFrom(storage, candy.Contiguous(candy.NewShape(rows, cols)), candy.DTypeOf[T](), dev), nil
+}
+
+// FromNested creates a tensor from nested slices such as [][]T or [][][]T, inferring the shape.
+func FromNested[T candy.D](data any, dev candy.Device) (*Tensor[T], error) {
+	v := reflect.ValueOf(data)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("from nested: nil data")
+	}
+	elem := reflect.TypeFor[T]()
+	var dims []int
+	typ := v.Type()
+	for cur := v; typ.Kind() == reflect.Slice; typ = typ.Elem() {
+		n := 0
+		if cur.IsValid() {
+			n = cur.Len()
+		}
+		dims = append(dims, n)
+		if n > 0 {
+			cur = cur.Index(0)
+		} else {
+			cur = reflect.Value{}
+		}
+	}
+	if typ != elem {
+		return nil, fmt.Errorf("from nested: element type %v does not match %v", typ, elem)
+	}
+	flat := make([]T, 0, candy.NewShapeFrom(dims).Numel())
+	var walk func(v reflect.Value, depth int) error
+	walk = func(v reflect.Value, depth int) error {
+		if depth == len(dims) {
+			flat = append(flat, v.Interface().(T))
+			return nil
+		}
+		if v.Len() != dims[depth] {
+			return fmt.Errorf("from nested: ragged slice at depth %d: got length %d want %d", depth, v.Len(), dims[depth])
+		}
+		for i := range v.Len() {
+			if err := walk(v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(v, 0); err != nil {
+		return nil, err
+	}
+	return New(flat, candy.NewShapeFrom(dims), dev)
+}
+
+// MustArange creates a range tensor, panics on error.
+func MustArange[T candy.D](start, end, step float64, dev candy.Device) *Tensor[T] {
+	res, err := Arange[T](start, end, step, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustLinspace creates a linspace tensor, panics on error.
+func MustLinspace[T candy.D](start, end float64, n int, dev candy.Device) *Tensor[T] {
+	res, err := Linspace[T](start, end, n, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustLogspace creates a logspace tensor, panics on error.
+func MustLogspace[T candy.D](start, end float64, n int, base float64, dev candy.Device) *Tensor[T] {
+	res, err := Logspace[T](start, end, n, base, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustEye creates an identity matrix, panics on error.
+func MustEye[T candy.D](n int, dev candy.Device) *Tensor[T] {
+	res, err := Eye[T](n, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustTril creates a lower triangular mask, panics on error.
+func MustTril[T candy.D](rows, cols, k int, dev candy.Device) *Tensor[T] {
+	res, err := Tril[T](rows, cols, k, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustTriu creates an upper triangular mask, panics on error.
+func MustTriu[T candy.D](rows, cols, k int, dev candy.Device) *Tensor[T] {
+	res, err := Triu[T](rows, cols, k, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustFromNested creates a tensor from nested slices, panics on error.
+func MustFromNested[T candy.D](data any, dev candy.Device) *Tensor[T] {
+	res, err := FromNested[T](data, dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Cat concatenates tensors along dim; all other dims must match.
+func Cat[T candy.D](ts []*Tensor[T], dim int) (*Tensor[T], error) {
+	return ApplyOp(ts, CatForward[T](dim), CatBackward[T](dim))
+}
+
+// MustCat concatenates tensors, panics on error.
+func MustCat[T candy.D](ts []*Tensor[T], dim int) *Tensor[T] {
+	res, err := Cat(ts, dim)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Stack joins tensors of equal shape along a new dim.
+func Stack[T candy.D](ts []*Tensor[T], dim int) (*Tensor[T], error) {
+	us := make([]*Tensor[T], len(ts))
+	for i, t := range ts {
+		u, err := t.Unsqueeze(dim)
+		if err != nil {
+			return nil, fmt.Errorf("stack: %w", err)
+		}
+		us[i] = u
+	}
+	return Cat(us, dim)
+}
+
+// MustStack stacks tensors, panics on error.
+func MustStack[T candy.D](ts []*Tensor[T], dim int) *Tensor[T] {
+	res, err := Stack(ts, dim)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // FullLike creates like t, filled with value.
 func (t *Tensor[T]) FullLike(value float64) (*Tensor[T], error) {
 	return Full[T](value, t.Shape(), t.device)
@@ -292,9 +563,16 @@ func (t *Tensor[T]) Device() candy.Device {
 	return t.device
 }
 
-// Data returns CPU data slice, panics if not CPU.
+// Data returns CPU data slice in row-major order, materializing strided views.
 func (t *Tensor[T]) Data() []T {
-	return t.storage.Data()
+	if start, end, ok := t.layout.ContiguousOffsets(); ok {
+		return t.storage.Data()[start:end]
+	}
+	s, err := t.storage.Copy(t.layout, t.storage)
+	if err != nil {
+		panic(fmt.Sprintf("materialize data failed: %v", err))
+	}
+	return s.Data()
 }
 
 // Stride returns strides.
@@ -436,7 +714,7 @@ func ToDtype[T, U candy.D](t *Tensor[T], dtype candy.DType) (*Tensor[U], error)
 	if err != nil {
 		return nil, fmt.Errorf("convert to %v failed: %w", dtype, err)
 	}
-	return NewFrom(s.(candy.BackendStorage[U]), t.layout.Clone(), dtype, t.device), nil
+	return NewFrom(s.(candy.BackendStorage[U]), candy.Contiguous(t.Shape()), dtype, t.device), nil
 }
 
 // ToFloat32 converts to float32.
@@ -464,6 +742,16 @@ func (t *Tensor[T]) ToInt64() (*Tensor[int64], error) {
 	return ToDtype[T, int64](t, candy.I64)
 }
 
+// ToFloat16 converts to IEEE half precision.
+func (t *Tensor[T]) ToFloat16() (*Tensor[candy.Float16], error) {
+	return ToDtype[T, candy.Float16](t, candy.F16)
+}
+
+// ToBFloat16 converts to bfloat16.
+func (t *Tensor[T]) ToBFloat16() (*Tensor[candy.BFloat16], error) {
+	return ToDtype[T, candy.BFloat16](t, candy.BF16)
+}
+
 // MustToFloat32 converts to float32, panics on error.
 func (t *Tensor[T]) MustToFloat32() *Tensor[float32] {
 	res, err := t.ToFloat32()
@@ -509,6 +797,24 @@ func (t *Tensor[T]) MustToInt64() *Tensor[int64] {
 	return res
 }
 
+// MustToFloat16 converts to half precision, panics on error.
+func (t *Tensor[T]) MustToFloat16() *Tensor[candy.Float16] {
+	res, err := t.ToFloat16()
+	if err != nil {
+		panic(fmt.Sprintf("to float16 failed: %v", err))
+	}
+	return res
+}
+
+// MustToBFloat16 converts to bfloat16, panics on error.
+func (t *Tensor[T]) MustToBFloat16() *Tensor[candy.BFloat16] {
+	res, err := t.ToBFloat16()
+	if err != nil {
+		panic(fmt.Sprintf("to bfloat16 failed: %v", err))
+	}
+	return res
+}
+
 // Affine applies y = scale * x + bias.
 func (t *Tensor[T]) Affine(scale, bias float64) (*Tensor[T], error) {
 	return ApplyOp([]*Tensor[T]{t}, AffineForward[T](scale, bias), AffineBackward[T](scale, bias))
@@ -1100,6 +1406,76 @@ func (t *Tensor[T]) MustUpsampleNearest2d(h, w int) *Tensor[T] {
 	return res
 }
 
+// Conv3d applies 3D convolution.
+func (t *Tensor[T]) Conv3d(kernel *Tensor[T], params *candy.Conv3DParams) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t, kernel}, Conv3dForward[T](params), Conv3dBackward[T](params))
+}
+
+// MustConv3d applies 3D convolution, panics on error.
+func (t *Tensor[T]) MustConv3d(kernel *Tensor[T], params *candy.Conv3DParams) *Tensor[T] {
+	res, err := t.Conv3d(kernel, params)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ConvTranspose3d applies 3D transposed convolution.
+func (t *Tensor[T]) ConvTranspose3d(kernel *Tensor[T], params *candy.ConvT3DParams) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t, kernel}, ConvTranspose3dForward[T](params), ConvTranspose3dBackward[T](params))
+}
+
+// MustConvTranspose3d applies 3D transposed convolution, panics on error.
+func (t *Tensor[T]) MustConvTranspose3d(kernel *Tensor[T], params *candy.ConvT3DParams) *Tensor[T] {
+	res, err := t.ConvTranspose3d(kernel, params)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// AvgPool3d applies 3D average pooling.
+func (t *Tensor[T]) AvgPool3d(kD, kH, kW, sD, sH, sW int) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t}, AvgPool3dForward[T](kD, kH, kW, sD, sH, sW), AvgPool3dBackward[T](kD, kH, kW, sD, sH, sW))
+}
+
+// MustAvgPool3d applies 3D average pooling, panics on error.
+func (t *Tensor[T]) MustAvgPool3d(kD, kH, kW, sD, sH, sW int) *Tensor[T] {
+	res, err := t.AvgPool3d(kD, kH, kW, sD, sH, sW)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MaxPool3d applies 3D max pooling.
+func (t *Tensor[T]) MaxPool3d(kD, kH, kW, sD, sH, sW int) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t}, MaxPool3dForward[T](kD, kH, kW, sD, sH, sW), MaxPool3dBackward[T](kD, kH, kW, sD, sH, sW))
+}
+
+// MustMaxPool3d applies 3D max pooling, panics on error.
+func (t *Tensor[T]) MustMaxPool3d(kD, kH, kW, sD, sH, sW int) *Tensor[T] {
+	res, err := t.MaxPool3d(kD, kH, kW, sD, sH, sW)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// UpsampleNearest3d upsamples 3D with nearest neighbor.
+func (t *Tensor[T]) UpsampleNearest3d(d, h, w int) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t}, UpsampleNearest3dForward[T](d, h, w), UpsampleNearest3dBackward[T](d, h, w))
+}
+
+// MustUpsampleNearest3d upsamples 3D, panics on error.
+func (t *Tensor[T]) MustUpsampleNearest3d(d, h, w int) *Tensor[T] {
+	res, err := t.UpsampleNearest3d(d, h, w)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // Gather gathers along dimension.
 func (t *Tensor[T]) Gather(idx *Tensor[T], dim int) (*Tensor[T], error) {
 	return ApplyOp([]*Tensor[T]{t, idx}, GatherForward[T](dim), GatherBackward[T](dim))
@@ -1128,6 +1504,159 @@ func (t *Tensor[T]) MustScatter(idx *Tensor[T], src *Tensor[T], dim int) *Tensor
 	return res
 }
 
+// Argsort returns uint32 indices that sort t along dim.
+func (t *Tensor[T]) Argsort(dim int, descending bool) (*Tensor[uint32], error) {
+	d, err := candy.ResolveAxis(dim, t.Rank())
+	if err != nil {
+		return nil, fmt.Errorf("argsort: %w", err)
+	}
+	last := t.Rank() - 1
+	x := t.Detach()
+	if d != last {
+		if x, err = x.Transpose(d, last); err != nil {
+			return nil, fmt.Errorf("argsort: %w", err)
+		}
+	}
+	s, err := x.storage.ArgsortU32(x.layout, descending)
+	if err != nil {
+		return nil, fmt.Errorf("argsort: %w", err)
+	}
+	idx := NewFrom(s, candy.Contiguous(x.Shape()), candy.U32, t.device)
+	if d != last {
+		if idx, err = idx.Transpose(d, last); err != nil {
+			return nil, fmt.Errorf("argsort: %w", err)
+		}
+		if idx, err = idx.Contiguous(); err != nil {
+			return nil, fmt.Errorf("argsort: %w", err)
+		}
+	}
+	return idx, nil
+}
+
+// MustArgsort argsorts, panics on error.
+func (t *Tensor[T]) MustArgsort(dim int, descending bool) *Tensor[uint32] {
+	res, err := t.Argsort(dim, descending)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Sort sorts t along dim, returning the values and their source indices.
+// Gradients flow to the input through the indices.
+func (t *Tensor[T]) Sort(dim int, descending bool) (*Tensor[T], *Tensor[uint32], error) {
+	d, err := candy.ResolveAxis(dim, t.Rank())
+	if err != nil {
+		return nil, nil, fmt.Errorf("sort: %w", err)
+	}
+	idx, err := t.Argsort(d, descending)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sort: %w", err)
+	}
+	vals, err := t.gatherU32(idx, d)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sort: %w", err)
+	}
+	return vals, idx, nil
+}
+
+// MustSort sorts, panics on error.
+func (t *Tensor[T]) MustSort(dim int, descending bool) (*Tensor[T], *Tensor[uint32]) {
+	vals, idx, err := t.Sort(dim, descending)
+	if err != nil {
+		panic(err)
+	}
+	return vals, idx
+}
+
+// TopK returns the k largest values along dim in descending order, with their source indices.
+// Gradients flow to the input through the indices.
+func (t *Tensor[T]) TopK(k, dim int) (*Tensor[T], *Tensor[uint32], error) {
+	d, err := candy.ResolveAxis(dim, t.Rank())
+	if err != nil {
+		return nil, nil, fmt.Errorf("topk: %w", err)
+	}
+	if k < 0 || k > t.Dim(d) {
+		return nil, nil, fmt.Errorf("topk: k %d out of range for dim %d of size %d", k, d, t.Dim(d))
+	}
+	idx, err := t.Argsort(d, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("topk: %w", err)
+	}
+	if idx, err = idx.Narrow(d, 0, k); err != nil {
+		return nil, nil, fmt.Errorf("topk: %w", err)
+	}
+	if idx, err = idx.Contiguous(); err != nil {
+		return nil, nil, fmt.Errorf("topk: %w", err)
+	}
+	vals, err := t.gatherU32(idx, d)
+	if err != nil {
+		return nil, nil, fmt.Errorf("topk: %w", err)
+	}
+	return vals, idx, nil
+}
+
+// MustTopK takes the top k, panics on error.
+func (t *Tensor[T]) MustTopK(k, dim int) (*Tensor[T], *Tensor[uint32]) {
+	vals, idx, err := t.TopK(k, dim)
+	if err != nil {
+		panic(err)
+	}
+	return vals, idx
+}
+
+// gatherU32 gathers along dim with uint32 indices. The indices are turned
+// into int64 offsets into the flattened t and looked up with a
+// differentiable IndexSelect, so they never round-trip through T.
+func (t *Tensor[T]) gatherU32(idx *Tensor[uint32], dim int) (*Tensor[T], error) {
+	dims := idx.Dims()
+	left, right := 1, 1
+	for _, d := range dims[:dim] {
+		left *= d
+	}
+	for _, d := range dims[dim+1:] {
+		right *= d
+	}
+	n, size := dims[dim], t.Dim(dim)
+	offsets := make([]int64, idx.Numel())
+	for i, v := range idx.Data() {
+		l, r := i/(n*right), i%right
+		offsets[i] = int64((l*size+int(v))*right + r)
+	}
+	ids, err := New(offsets, candy.NewShape(len(offsets)), t.device)
+	if err != nil {
+		return nil, err
+	}
+	x, err := t.Contiguous()
+	if err != nil {
+		return nil, err
+	}
+	if x, err = x.FlattenAll(); err != nil {
+		return nil, err
+	}
+	r, err := IndexSelect(x, ids, 0)
+	if err != nil {
+		return nil, err
+	}
+	return r.Reshape(dims...)
+}
+
+// IndexSelect selects slices of t along dim at the 1D indices ids, which may use
+// any index dtype. Every id, including the largest value of its dtype, must
+// lie in [0, t.Dim(dim)).
+func IndexSelect[T candy.D, I candy.I](t *Tensor[T], ids *Tensor[I], dim int) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t}, IndexSelectForward[T](ids, dim), IndexSelectBackward[T](ids, dim))
+}
+
+// MustIndexSelect selects along dimension, panics on error.
+func MustIndexSelect[T candy.D, I candy.I](t *Tensor[T], ids *Tensor[I], dim int) *Tensor[T] {
+	res, err := IndexSelect(t, ids, dim)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // ScatterAdd performs scatter-add operation: adds src values to dst at indices along dimension.
 func (t *Tensor[T]) ScatterAdd(idx *Tensor[T], src *Tensor[T], dim int) (*Tensor[T], error) {
 	return ApplyOp([]*Tensor[T]{t, idx, src}, ScatterAddForward[T](dim), ScatterAddBackward[T](dim))
@@ -1388,6 +1917,79 @@ func (t *Tensor[T]) MustFastSoftmax() *Tensor[T] {
 	return res
 }
 
+// RmsNorm applies RMS normalization over the last dim, scaled by alpha.
+func (t *Tensor[T]) RmsNorm(alpha *Tensor[T], eps float64) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t, alpha}, RmsNormForward[T](eps), RmsNormBackward[T](eps))
+}
+
+// MustRmsNorm applies RMS normalization, panics on error.
+func (t *Tensor[T]) MustRmsNorm(alpha *Tensor[T], eps float64) *Tensor[T] {
+	res, err := t.RmsNorm(alpha, eps)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// LayerNorm applies layer normalization over the last dim, scaled by alpha and shifted by beta.
+func (t *Tensor[T]) LayerNorm(alpha, beta *Tensor[T], eps float64) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t, alpha, beta}, LayerNormForward[T](eps), LayerNormBackward[T](eps))
+}
+
+// MustLayerNorm applies layer normalization, panics on error.
+func (t *Tensor[T]) MustLayerNorm(alpha, beta *Tensor[T], eps float64) *Tensor[T] {
+	res, err := t.LayerNorm(alpha, beta, eps)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// RopeI applies interleaved rotary embedding to a (b, h, t, d) tensor.
+// cos and sin hold (t, d/2) or (b, t, d/2) elements.
+func (t *Tensor[T]) RopeI(cos, sin *Tensor[T]) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t, cos, sin}, RopeIForward[T](), RopeIBackward[T]())
+}
+
+// MustRopeI applies interleaved rotary embedding, panics on error.
+func (t *Tensor[T]) MustRopeI(cos, sin *Tensor[T]) *Tensor[T] {
+	res, err := t.RopeI(cos, sin)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Rope applies half-split rotary embedding to a (b, h, t, d) tensor.
+// cos and sin hold (t, d/2) or (b, t, d/2) elements.
+func (t *Tensor[T]) Rope(cos, sin *Tensor[T]) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t, cos, sin}, RopeForward[T](), RopeBackward[T]())
+}
+
+// MustRope applies half-split rotary embedding, panics on error.
+func (t *Tensor[T]) MustRope(cos, sin *Tensor[T]) *Tensor[T] {
+	res, err := t.Rope(cos, sin)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// RopeThd applies half-split rotary embedding to a (b, t, h, d) tensor.
+// cos and sin hold (t, d/2) or (b, t, d/2) elements.
+func (t *Tensor[T]) RopeThd(cos, sin *Tensor[T]) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t, cos, sin}, RopeThdForward[T](), RopeThdBackward[T]())
+}
+
+// MustRopeThd applies (b, t, h, d) rotary embedding, panics on error.
+func (t *Tensor[T]) MustRopeThd(cos, sin *Tensor[T]) *Tensor[T] {
+	res, err := t.RopeThd(cos, sin)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // Softmax computes the softmax along the specified dimension.
 func (x *Tensor[T]) Softmax(dim int) (*Tensor[T], error) {
 	d, err := candy.ResolveAxis(dim, x.Rank())
@@ -1511,6 +2113,23 @@ func (t *Tensor[T]) MustCopy() *Tensor[T] {
 	return res
 }
 
+// To moves the tensor to dev, returning t itself when it already lives there.
+func (t *Tensor[T]) To(dev candy.Device) (*Tensor[T], error) {
+	if t.device == dev {
+		return t, nil
+	}
+	return ApplyOp([]*Tensor[T]{t}, ToForward[T](dev), ToBackward[T]())
+}
+
+// MustTo moves the tensor to dev, panics on error.
+func (t *Tensor[T]) MustTo(dev candy.Device) *Tensor[T] {
+	res, err := t.To(dev)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // Neg negates element-wise.
 func (t *Tensor[T]) Neg() (*Tensor[T], error) {
 	return ApplyOp([]*Tensor[T]{t}, NegForward[T](), NegBackward[T]())
@@ -1865,6 +2484,185 @@ func (t *Tensor[T]) MustT() *Tensor[T] {
 	return res
 }
 
+// Tril zeroes elements above the k-th diagonal of the last two dims.
+func (t *Tensor[T]) Tril(k int) (*Tensor[T], error) {
+	return t.triMask(k, true)
+}
+
+// MustTril keeps the lower triangle, panics on error.
+func (t *Tensor[T]) MustTril(k int) *Tensor[T] {
+	res, err := t.Tril(k)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Triu zeroes elements below the k-th diagonal of the last two dims.
+func (t *Tensor[T]) Triu(k int) (*Tensor[T], error) {
+	return t.triMask(k, false)
+}
+
+// MustTriu keeps the upper triangle, panics on error.
+func (t *Tensor[T]) MustTriu(k int) *Tensor[T] {
+	res, err := t.Triu(k)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// triMask keeps the last two dims inside a triangular mask and zeroes the
+// rest. It selects rather than multiplies so -Inf and NaN outside the
+// triangle still become zero, as in causal masks built with Triu.
+func (t *Tensor[T]) triMask(k int, lower bool) (*Tensor[T], error) {
+	if t.Rank() < 2 {
+		return nil, fmt.Errorf("need >=2 dims for tril/triu, got %d", t.Rank())
+	}
+	m, err := tri[T](t.Dim(-2), t.Dim(-1), k, lower, t.device)
+	if err != nil {
+		return nil, err
+	}
+	if m, err = m.BroadcastAs(t.Shape()); err != nil {
+		return nil, err
+	}
+	z, err := t.ZerosLike()
+	if err != nil {
+		return nil, err
+	}
+	return m.WhereCond(t, z)
+}
+
+// Permute reorders dims so that result dim i is input dim dims[i].
+func (t *Tensor[T]) Permute(dims ...int) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t}, PermuteForward[T](dims), PermuteBackward[T](dims))
+}
+
+// MustPermute permutes, panics on error.
+func (t *Tensor[T]) MustPermute(dims ...int) *Tensor[T] {
+	res, err := t.Permute(dims...)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// IsContiguous reports whether the tensor is laid out in row-major order.
+func (t *Tensor[T]) IsContiguous() bool {
+	return t.layout.IsContiguous()
+}
+
+// Contiguous returns t if it is contiguous, otherwise a contiguous copy.
+func (t *Tensor[T]) Contiguous() (*Tensor[T], error) {
+	if t.IsContiguous() {
+		return t, nil
+	}
+	return t.Copy()
+}
+
+// MustContiguous makes contiguous, panics on error.
+func (t *Tensor[T]) MustContiguous() *Tensor[T] {
+	res, err := t.Contiguous()
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Narrow returns a view of length elements along dim starting at start, sharing storage.
+func (t *Tensor[T]) Narrow(dim, start, length int) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t}, NarrowForward[T](dim, start, length), NarrowBackward[T](dim, start, length))
+}
+
+// MustNarrow narrows dim, panics on error.
+func (t *Tensor[T]) MustNarrow(dim, start, length int) *Tensor[T] {
+	res, err := t.Narrow(dim, start, length)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Slice returns a strided view selecting ranges along the leading dims, sharing storage.
+// Dims without a range are kept whole.
+func (t *Tensor[T]) Slice(ranges ...Range) (*Tensor[T], error) {
+	return ApplyOp([]*Tensor[T]{t}, SliceForward[T](ranges), SliceBackward[T](ranges))
+}
+
+// MustSlice slices, panics on error.
+func (t *Tensor[T]) MustSlice(ranges ...Range) *Tensor[T] {
+	res, err := t.Slice(ranges...)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Chunk splits into the given number of views along dim; the last chunk may be smaller.
+func (t *Tensor[T]) Chunk(chunks, dim int) ([]*Tensor[T], error) {
+	if chunks <= 0 {
+		return nil, fmt.Errorf("chunk: chunks must be positive, got %d", chunks)
+	}
+	d, err := candy.ResolveAxis(dim, t.Rank())
+	if err != nil {
+		return nil, fmt.Errorf("chunk: %w", err)
+	}
+	n := t.Dim(d)
+	size := (n + chunks - 1) / chunks
+	var sizes []int
+	for s := 0; s < n; s += size {
+		sizes = append(sizes, min(size, n-s))
+	}
+	return t.Split(sizes, d)
+}
+
+// MustChunk chunks, panics on error.
+func (t *Tensor[T]) MustChunk(chunks, dim int) []*Tensor[T] {
+	res, err := t.Chunk(chunks, dim)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Split splits into views of the given sizes along dim; sizes must sum to the dim size.
+func (t *Tensor[T]) Split(sizes []int, dim int) ([]*Tensor[T], error) {
+	d, err := candy.ResolveAxis(dim, t.Rank())
+	if err != nil {
+		return nil, fmt.Errorf("split: %w", err)
+	}
+	total := 0
+	for _, s := range sizes {
+		if s < 0 {
+			return nil, fmt.Errorf("split: negative size in %v", sizes)
+		}
+		total += s
+	}
+	if total != t.Dim(d) {
+		return nil, fmt.Errorf("split: sizes %v do not sum to dim %d of size %d", sizes, d, t.Dim(d))
+	}
+	res := make([]*Tensor[T], 0, len(sizes))
+	start := 0
+	for _, s := range sizes {
+		r, err := t.Narrow(d, start, s)
+		if err != nil {
+			return nil, fmt.Errorf("split: %w", err)
+		}
+		res = append(res, r)
+		start += s
+	}
+	return res, nil
+}
+
+// MustSplit splits, panics on error.
+func (t *Tensor[T]) MustSplit(sizes []int, dim int) []*Tensor[T] {
+	res, err := t.Split(sizes, dim)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // BroadcastAs broadcasts to shape.
 func (t *Tensor[T]) BroadcastAs(s *candy.Shape) (*Tensor[T], error) {
 	if t.Shape().Equal(s) {
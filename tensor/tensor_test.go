@@ -1 +1,659 @@
 package tensor_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/candy"
+	"github.com/gocnn/candy/tensor"
+	"github.com/gocnn/candy/tensor/internal/cpu/kernels"
+)
+
+func arange(t *testing.T, dims ...int) *tensor.Tensor[float32] {
+	t.Helper()
+	shape := candy.NewShapeFrom(dims)
+	data := make([]float32, shape.Numel())
+	for i := range data {
+		data[i] = float32(i)
+	}
+	x, err := tensor.New(data, shape, candy.CPU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x
+}
+
+func TestNarrow(t *testing.T) {
+	t.Parallel()
+	x := arange(t, 3, 4)
+	y, err := x.Narrow(1, 1, 2)
+	if err != nil {
+		t.Fatalf("Narrow: %v", err)
+	}
+	if want := []int{3, 2}; !slices.Equal(y.Dims(), want) {
+		t.Fatalf("dims: got %v want %v", y.Dims(), want)
+	}
+	if want := []float32{1, 2, 5, 6, 9, 10}; !slices.Equal(y.Data(), want) {
+		t.Fatalf("data: got %v want %v", y.Data(), want)
+	}
+	z, err := y.Narrow(0, 1, 2)
+	if err != nil {
+		t.Fatalf("Narrow: %v", err)
+	}
+	if want := []float32{5, 6, 9, 10}; !slices.Equal(z.Data(), want) {
+		t.Fatalf("nested data: got %v want %v", z.Data(), want)
+	}
+	if _, err := x.Narrow(0, 2, 2); err == nil {
+		t.Fatalf("expected out of range error")
+	}
+}
+
+func TestSlice(t *testing.T) {
+	t.Parallel()
+	x := arange(t, 4, 5)
+	y, err := x.Slice(tensor.Range{Start: 1, Stop: 4, Step: 2}, tensor.Range{Start: -4, Stop: 100, Step: 2})
+	if err != nil {
+		t.Fatalf("Slice: %v", err)
+	}
+	if want := []int{2, 2}; !slices.Equal(y.Dims(), want) {
+		t.Fatalf("dims: got %v want %v", y.Dims(), want)
+	}
+	if want := []float32{6, 8, 16, 18}; !slices.Equal(y.Data(), want) {
+		t.Fatalf("data: got %v want %v", y.Data(), want)
+	}
+	s, err := y.Sum([]int{1})
+	if err != nil {
+		t.Fatalf("Sum: %v", err)
+	}
+	if want := []float32{14, 34}; !slices.Equal(s.Data(), want) {
+		t.Fatalf("sum: got %v want %v", s.Data(), want)
+	}
+	e, err := x.Slice(tensor.All, tensor.Range{Start: 3, Stop: 3})
+	if err != nil {
+		t.Fatalf("Slice: %v", err)
+	}
+	if want := []int{4, 0}; !slices.Equal(e.Dims(), want) {
+		t.Fatalf("empty dims: got %v want %v", e.Dims(), want)
+	}
+}
+
+func TestChunkSplit(t *testing.T) {
+	t.Parallel()
+	x := arange(t, 5, 2)
+	chunks, err := x.Chunk(2, 0)
+	if err != nil {
+		t.Fatalf("Chunk: %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("chunks: got %d want 2", len(chunks))
+	}
+	if want := []float32{6, 7, 8, 9}; !slices.Equal(chunks[1].Data(), want) {
+		t.Fatalf("chunk data: got %v want %v", chunks[1].Data(), want)
+	}
+	parts, err := x.Split([]int{1, 1}, -1)
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+	if want := []float32{1, 3, 5, 7, 9}; !slices.Equal(parts[1].Data(), want) {
+		t.Fatalf("split data: got %v want %v", parts[1].Data(), want)
+	}
+	if _, err := x.Split([]int{1, 3}, 0); err == nil {
+		t.Fatalf("expected size mismatch error")
+	}
+}
+
+// viewOps are applied to strided views and to contiguous copies of them in TestOpsOnViews.
+var viewOps = map[string]func(v *tensor.Tensor[float32]) *tensor.Tensor[float32]{
+	"relu":        (*tensor.Tensor[float32]).MustRelu,
+	"exp":         (*tensor.Tensor[float32]).MustExp,
+	"neg":         (*tensor.Tensor[float32]).MustNeg,
+	"sigmoid":     (*tensor.Tensor[float32]).MustSigmoid,
+	"fastSoftmax": (*tensor.Tensor[float32]).MustFastSoftmax,
+	"affine":      func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustAffine(2, 1) },
+	"powf":        func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustAbs().MustPowf(1.5) },
+	"softmax0":    func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustSoftmax(0) },
+	"logSoftmax":  func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustLogSoftmax(-1) },
+	"sum":         func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustSum([]int{1}) },
+	"add":         func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] { return v.MustAdd(v.MustContiguous()) },
+	"where": func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] {
+		return v.MustGt(v.MustZerosLike()).MustWhereCond(v, v.MustNeg())
+	},
+	"triu": func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] {
+		return v.MustTriu(0)
+	},
+	"gather": func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] {
+		return v.MustGather(v.MustNarrow(1, 0, 1).MustZerosLike().MustAddScalar(1), 1)
+	},
+	"scatterAdd": func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] {
+		return v.MustScatterAdd(v.MustNarrow(1, 0, 1).MustZerosLike(), v.MustNarrow(1, 1, 1), 1)
+	},
+	"grad": func(v *tensor.Tensor[float32]) *tensor.Tensor[float32] {
+		y := v.Detach()
+		y.SetIsVar(true)
+		gs, err := y.MustExp().MustMul(y).MustSumAll().Backward()
+		if err != nil {
+			panic(err)
+		}
+		return gs.Get(y)
+	},
+}
+
+func TestOpsOnViews(t *testing.T) {
+	t.Parallel()
+	x := arange(t, 4, 5, 6).MustAffine(0.1, -5)
+	views := map[string]*tensor.Tensor[float32]{
+		"narrow":        x.MustNarrow(2, 1, 3),
+		"narrow outer":  x.MustNarrow(0, 1, 2),
+		"slice":         x.MustSlice(tensor.All, tensor.Range{Start: 1, Stop: 5, Step: 2}, tensor.Range{Start: 0, Stop: 6, Step: 3}),
+		"permute":       x.MustPermute(2, 0, 1),
+		"transpose":     x.MustTranspose(0, 2),
+		"permute slice": x.MustNarrow(1, 1, 3).MustPermute(1, 2, 0),
+	}
+	for vn, v := range views {
+		for on, op := range viewOps {
+			got, want := op(v).Data(), op(v.MustContiguous()).Data()
+			if len(got) != len(want) {
+				t.Fatalf("%s/%s: got %d values, want %d", vn, on, len(got), len(want))
+			}
+			for i := range want {
+				if math.Abs(float64(got[i]-want[i])) > 1e-5 {
+					t.Fatalf("%s/%s: [%d] = %v, want %v", vn, on, i, got[i], want[i])
+				}
+			}
+		}
+	}
+	y := tensor.MustArange[float32](0, 12, 1, candy.CPU).MustNarrow(0, 8, 3).MustRelu()
+	if want := []float32{8, 9, 10}; !slices.Equal(y.Data(), want) {
+		t.Fatalf("relu of narrow: got %v want %v", y.Data(), want)
+	}
+}
+
+func TestPermuteContiguous(t *testing.T) {
+	t.Parallel()
+	x := arange(t, 2, 3, 4)
+	y, err := x.Permute(2, 0, 1)
+	if err != nil {
+		t.Fatalf("Permute: %v", err)
+	}
+	if want := []int{4, 2, 3}; !slices.Equal(y.Dims(), want) {
+		t.Fatalf("dims: got %v want %v", y.Dims(), want)
+	}
+	if y.IsContiguous() {
+		t.Fatalf("permuted tensor reported contiguous")
+	}
+	c, err := y.Contiguous()
+	if err != nil {
+		t.Fatalf("Contiguous: %v", err)
+	}
+	if !c.IsContiguous() {
+		t.Fatalf("Contiguous result not contiguous")
+	}
+	if want := []float32{0, 4, 8, 12, 16, 20, 1, 5, 9, 13, 17, 21}; !slices.Equal(c.Data()[:12], want) {
+		t.Fatalf("data: got %v want %v", c.Data()[:12], want)
+	}
+	if x.MustContiguous() != x {
+		t.Fatalf("Contiguous copied a contiguous tensor")
+	}
+	if _, err := x.Permute(0, 0, 1); err == nil {
+		t.Fatalf("expected duplicate dim error")
+	}
+}
+
+func TestCatStack(t *testing.T) {
+	t.Parallel()
+	a := arange(t, 2, 2)
+	b := arange(t, 2, 3)
+	c, err := tensor.Cat([]*tensor.Tensor[float32]{a, b}, 1)
+	if err != nil {
+		t.Fatalf("Cat: %v", err)
+	}
+	if want := []float32{0, 1, 0, 1, 2, 2, 3, 3, 4, 5}; !slices.Equal(c.Data(), want) {
+		t.Fatalf("cat data: got %v want %v", c.Data(), want)
+	}
+	if _, err := tensor.Cat([]*tensor.Tensor[float32]{a, b}, 0); err == nil {
+		t.Fatalf("expected shape mismatch error")
+	}
+	s, err := tensor.Stack([]*tensor.Tensor[float32]{a, a.MustT()}, -1)
+	if err != nil {
+		t.Fatalf("Stack: %v", err)
+	}
+	if want := []int{2, 2, 2}; !slices.Equal(s.Dims(), want) {
+		t.Fatalf("stack dims: got %v want %v", s.Dims(), want)
+	}
+	if want := []float32{0, 0, 1, 2, 2, 1, 3, 3}; !slices.Equal(s.Data(), want) {
+		t.Fatalf("stack data: got %v want %v", s.Data(), want)
+	}
+}
+
+func TestIndexSelect(t *testing.T) {
+	t.Parallel()
+	x := arange(t, 3, 2)
+	ids := tensor.MustNew([]uint32{2, 0, 2}, candy.NewShapeFrom([]int{3}), candy.CPU)
+	y, err := tensor.IndexSelect(x, ids, 0)
+	if err != nil {
+		t.Fatalf("IndexSelect: %v", err)
+	}
+	if want := []float32{4, 5, 0, 1, 4, 5}; !slices.Equal(y.Data(), want) {
+		t.Fatalf("data: got %v want %v", y.Data(), want)
+	}
+	cols := tensor.MustNew([]int64{1}, candy.NewShapeFrom([]int{1}), candy.CPU)
+	z, err := tensor.IndexSelect(x.MustT(), cols, 1)
+	if err != nil {
+		t.Fatalf("IndexSelect: %v", err)
+	}
+	if want := []float32{2, 3}; !slices.Equal(z.Data(), want) {
+		t.Fatalf("strided data: got %v want %v", z.Data(), want)
+	}
+	bad := tensor.MustNew([]uint8{3}, candy.NewShapeFrom([]int{1}), candy.CPU)
+	if _, err := tensor.IndexSelect(x, bad, 0); err == nil {
+		t.Fatalf("expected out of range error")
+	}
+
+	// The largest id of a dtype is an ordinary index, not padding.
+	table := tensor.MustArange[float32](0, 300, 1, candy.CPU).MustReshape(300, 1)
+	table.SetIsVar(true)
+	sel := tensor.MustIndexSelect(table, tensor.MustNew([]uint8{255, 3}, candy.NewShape(2), candy.CPU), 0)
+	if want := []float32{255, 3}; !slices.Equal(sel.Data(), want) {
+		t.Fatalf("uint8 id 255: got %v want %v", sel.Data(), want)
+	}
+	gs := sel.MustSumAll().MustBackward()
+	if g := gs.Get(table).Data(); g[255] != 1 || g[3] != 1 {
+		t.Fatalf("uint8 id 255: grad[255] = %v, grad[3] = %v, want 1", g[255], g[3])
+	}
+	if _, err := tensor.IndexSelect(table, tensor.MustNew([]uint32{math.MaxUint32}, candy.NewShape(1), candy.CPU), 0); err == nil {
+		t.Fatalf("expected out of range error for uint32 max id")
+	}
+}
+
+func TestSortTopK(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustNew([]float32{3, 1, 2, 0, 5, 4}, candy.NewShapeFrom([]int{2, 3}), candy.CPU)
+	idx, err := x.Argsort(-1, false)
+	if err != nil {
+		t.Fatalf("Argsort: %v", err)
+	}
+	if want := []uint32{1, 2, 0, 0, 2, 1}; !slices.Equal(idx.Data(), want) {
+		t.Fatalf("argsort: got %v want %v", idx.Data(), want)
+	}
+	vals, idx, err := x.Sort(0, true)
+	if err != nil {
+		t.Fatalf("Sort: %v", err)
+	}
+	if want := []float32{3, 5, 4, 0, 1, 2}; !slices.Equal(vals.Data(), want) {
+		t.Fatalf("sort values: got %v want %v", vals.Data(), want)
+	}
+	if want := []uint32{0, 1, 1, 1, 0, 0}; !slices.Equal(idx.Data(), want) {
+		t.Fatalf("sort indices: got %v want %v", idx.Data(), want)
+	}
+	if vals, idx, err = x.Sort(-1, false); err != nil {
+		t.Fatalf("Sort(-1): %v", err)
+	}
+	if want := []float32{1, 2, 3, 0, 4, 5}; !slices.Equal(vals.Data(), want) {
+		t.Fatalf("sort(-1) values: got %v want %v", vals.Data(), want)
+	}
+	if want := []uint32{1, 2, 0, 0, 2, 1}; !slices.Equal(idx.Data(), want) {
+		t.Fatalf("sort(-1) indices: got %v want %v", idx.Data(), want)
+	}
+	vals, idx, err = x.TopK(2, 1)
+	if err != nil {
+		t.Fatalf("TopK: %v", err)
+	}
+	if want := []float32{3, 2, 5, 4}; !slices.Equal(vals.Data(), want) {
+		t.Fatalf("topk values: got %v want %v", vals.Data(), want)
+	}
+	if want := []uint32{0, 2, 1, 2}; !slices.Equal(idx.Data(), want) {
+		t.Fatalf("topk indices: got %v want %v", idx.Data(), want)
+	}
+	if _, _, err := x.TopK(4, 1); err == nil {
+		t.Fatalf("expected k out of range error")
+	}
+}
+
+func TestSortLongHalfRow(t *testing.T) {
+	t.Parallel()
+	// Positions above 256 are not representable in bf16; sorted values must
+	// still match the values at their returned indices.
+	const n = 600
+	x := tensor.MustRandN[float32](0, 1, candy.NewShape(2, n), candy.CPU).MustToBFloat16()
+	vals, idx := x.MustSort(1, false)
+	src, got, ids := x.Data(), vals.Data(), idx.Data()
+	for i := range got {
+		row := i / n
+		if v := src[row*n+int(ids[i])]; got[i] != v {
+			t.Fatalf("vals[%d] = %v, want x[%d, %d] = %v", i, got[i], row, ids[i], v)
+		}
+		if i%n > 0 && got[i].Float32() < got[i-1].Float32() {
+			t.Fatalf("vals[%d] = %v is below its predecessor %v", i, got[i], got[i-1])
+		}
+	}
+	top, _ := x.MustTopK(3, 1)
+	for r := range 2 {
+		if g, w := top.Data()[r*3], got[r*n+n-1]; g != w {
+			t.Fatalf("row %d top = %v, want %v", r, g, w)
+		}
+	}
+}
+
+func TestNorms(t *testing.T) {
+	t.Parallel()
+	x := tensor.MustNew([]float64{3, 4, 1, 3}, candy.NewShapeFrom([]int{2, 2}), candy.CPU)
+	a := tensor.MustNew([]float64{1, 2}, candy.NewShapeFrom([]int{2}), candy.CPU)
+	b := tensor.MustNew([]float64{0, 1}, candy.NewShapeFrom([]int{2}), candy.CPU)
+	want := []float64{3 / math.Sqrt(12.5), 8 / math.Sqrt(12.5), 1 / math.Sqrt(5), 6 / math.Sqrt(5)}
+	for i, v := range x.MustRmsNorm(a, 0).Data() {
+		if math.Abs(v-want[i]) > 1e-9 {
+			t.Fatalf("rmsNorm: got %v want %v", v, want[i])
+		}
+	}
+	strided := x.MustT().MustContiguous().MustT()
+	for i, v := range strided.MustRmsNorm(a, 0).Data() {
+		if math.Abs(v-want[i]) > 1e-9 {
+			t.Fatalf("strided rmsNorm: got %v want %v", v, want[i])
+		}
+	}
+	want = []float64{-1, 3, -1, 3}
+	for i, v := range x.MustLayerNorm(a, b, 0).Data() {
+		if math.Abs(v-want[i]) > 1e-9 {
+			t.Fatalf("layerNorm: got %v want %v", v, want[i])
+		}
+	}
+}
+
+func TestCreation(t *testing.T) {
+	t.Parallel()
+	if got, want := tensor.MustArange[int64](2, 9, 3, candy.CPU).Data(), []int64{2, 5, 8}; !slices.Equal(got, want) {
+		t.Fatalf("arange: got %v want %v", got, want)
+	}
+	for _, r := range [][3]float64{{0, math.Inf(1), 1}, {0, 1, math.NaN()}, {math.NaN(), 1, 1}, {0, 1e19, 1}, {-1e308, 1e308, 1e-300}} {
+		if _, err := tensor.Arange[float32](r[0], r[1], r[2], candy.CPU); err == nil {
+			t.Errorf("arange%v: expected error for an element count that is not an int", r)
+		}
+	}
+	if got, want := tensor.MustLinspace[float32](0, 1, 5, candy.CPU).Data(), []float32{0, 0.25, 0.5, 0.75, 1}; !slices.Equal(got, want) {
+		t.Fatalf("linspace: got %v want %v", got, want)
+	}
+	if got, want := tensor.MustLogspace[float64](0, 3, 4, 10, candy.CPU).Data(), []float64{1, 10, 100, 1000}; !slices.Equal(got, want) {
+		t.Fatalf("logspace: got %v want %v", got, want)
+	}
+	if got, want := tensor.MustEye[uint8](2, candy.CPU).Data(), []uint8{1, 0, 0, 1}; !slices.Equal(got, want) {
+		t.Fatalf("eye: got %v want %v", got, want)
+	}
+	if got, want := tensor.MustTril[float32](2, 3, 0, candy.CPU).Data(), []float32{1, 0, 0, 1, 1, 0}; !slices.Equal(got, want) {
+		t.Fatalf("tril: got %v want %v", got, want)
+	}
+	x := arange(t, 2, 3, 3)
+	if got, want := x.MustTriu(1).Data()[9:], []float32{0, 10, 11, 0, 0, 14, 0, 0, 0}; !slices.Equal(got, want) {
+		t.Fatalf("triu: got %v want %v", got, want)
+	}
+	inf := float32(math.Inf(-1))
+	mask := tensor.MustFull[float32](math.Inf(-1), candy.NewShape(3, 3), candy.CPU).MustTriu(1)
+	if got, want := mask.Data(), []float32{0, inf, inf, 0, 0, inf, 0, 0, 0}; !slices.Equal(got, want) {
+		t.Fatalf("triu -inf: got %v want %v", got, want)
+	}
+	nan := tensor.MustFull[float32](math.NaN(), candy.NewShape(2, 2), candy.CPU).MustTril(-1).Data()
+	if nan[0] != 0 || nan[1] != 0 || nan[3] != 0 || !math.IsNaN(float64(nan[2])) {
+		t.Fatalf("tril nan: got %v", nan)
+	}
+	x.SetIsVar(true)
+	gs, err := x.MustTril(0).MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("tril backward: %v", err)
+	}
+	if got, want := gs.Get(x).Data()[9:], []float32{1, 0, 0, 1, 1, 0, 1, 1, 1}; !slices.Equal(got, want) {
+		t.Fatalf("tril grad: got %v want %v", got, want)
+	}
+	n, err := tensor.FromNested[float64]([][][]float64{{{1, 2}}, {{3, 4}}, {{5, 6}}}, candy.CPU)
+	if err != nil {
+		t.Fatalf("FromNested: %v", err)
+	}
+	if want := []int{3, 1, 2}; !slices.Equal(n.Dims(), want) {
+		t.Fatalf("nested dims: got %v want %v", n.Dims(), want)
+	}
+	if _, err := tensor.FromNested[float64]([][]float64{{1, 2}, {3}}, candy.CPU); err == nil {
+		t.Fatalf("expected ragged error")
+	}
+	if _, err := tensor.FromNested[float32]([][]float64{{1}}, candy.CPU); err == nil {
+		t.Fatalf("expected element type error")
+	}
+}
+
+func TestRegistryTo(t *testing.T) {
+	t.Parallel()
+	aux := candy.Device(7)
+	if _, err := tensor.Zeros[float32](candy.NewShape(2), aux); err == nil {
+		t.Fatalf("expected unsupported device error")
+	}
+	tensor.RegisterDevice(aux, func() candy.BackendDevice[float32] {
+		d, _ := tensor.LookupDevice[float32](candy.CPU)
+		return d
+	})
+	x := arange(t, 2, 3)
+	x.SetIsVar(true)
+	y := x.MustTranspose(0, 1).MustTo(aux)
+	if y.Device() != aux {
+		t.Fatalf("device: got %v want %v", y.Device(), aux)
+	}
+	if want := []float32{0, 3, 1, 4, 2, 5}; !slices.Equal(y.Data(), want) {
+		t.Fatalf("to: got %v want %v", y.Data(), want)
+	}
+	if y.MustTo(aux) != y {
+		t.Fatalf("expected same-device To to be a no-op")
+	}
+	grads, err := y.MustMul(y).MustSumAll().Backward()
+	if err != nil {
+		t.Fatalf("Backward: %v", err)
+	}
+	g := grads.Get(x)
+	if g.Device() != candy.CPU {
+		t.Fatalf("grad device: got %v want %v", g.Device(), candy.CPU)
+	}
+	if want := []float32{0, 2, 4, 6, 8, 10}; !slices.Equal(g.Data(), want) {
+		t.Fatalf("grad: got %v want %v", g.Data(), want)
+	}
+}
+
+func TestManualSeed(t *testing.T) {
+	shape := candy.NewShape(4, 5)
+	draw := func() ([]float32, []float64, []float32, []int) {
+		if err := tensor.ManualSeed(42); err != nil {
+			t.Fatalf("ManualSeed: %v", err)
+		}
+		u := tensor.MustRand[float32](-1, 1, shape, candy.CPU).Data()
+		n := tensor.MustRandN[float64](0, 1, shape, candy.CPU).Data()
+		d := tensor.MustOnes[float32](shape, candy.CPU).MustDropout(0.5).Data()
+		p := tensor.NewRand().Perm(16)
+		return u, n, d, p
+	}
+	u1, n1, d1, p1 := draw()
+	u2, n2, d2, p2 := draw()
+	if !slices.Equal(u1, u2) || !slices.Equal(n1, n2) || !slices.Equal(d1, d2) || !slices.Equal(p1, p2) {
+		t.Fatalf("seeded draws differ")
+	}
+	a := tensor.MustRandNFrom[float32](rand.New(rand.NewPCG(1, 2)), 0, 1, shape, candy.CPU).Data()
+	b := tensor.MustRandNFrom[float32](rand.New(rand.NewPCG(1, 2)), 0, 1, shape, candy.CPU).Data()
+	if !slices.Equal(a, b) {
+		t.Fatalf("explicit generator draws differ")
+	}
+	for _, v := range tensor.MustRandFrom[uint8](rand.New(rand.NewPCG(3, 4)), 2, 5, shape, candy.CPU).Data() {
+		if v < 2 || v > 5 {
+			t.Fatalf("uint8 sample %d out of range", v)
+		}
+	}
+}
+
+func naiveMatMul[T candy.D](a, b *tensor.Tensor[T]) []T {
+	ad, bd := a.Dims(), b.Dims()
+	m, k, n := ad[len(ad)-2], ad[len(ad)-1], bd[len(bd)-1]
+	x, y := a.Data(), b.Data()
+	batch := len(x) / (m * k)
+	out := make([]T, batch*m*n)
+	for bb := range batch {
+		for i := range m {
+			for j := range n {
+				var sum T
+				for l := range k {
+					sum += x[bb*m*k+i*k+l] * y[bb*k*n+l*n+j]
+				}
+				out[bb*m*n+i*n+j] = sum
+			}
+		}
+	}
+	return out
+}
+
+func TestMatMulLayouts(t *testing.T) {
+	t.Parallel()
+	a := arange(t, 2, 3, 70)
+	w := arange(t, 5, 70)
+	wt := w.MustTranspose(0, 1)
+	cases := map[string][2]*tensor.Tensor[float32]{
+		"contiguous":     {a, arange(t, 2, 70, 5)},
+		"transposed":     {a, wt.MustBroadcastLeft(2)},
+		"transposed lhs": {arange(t, 2, 70, 3).MustTranspose(1, 2), arange(t, 2, 70, 5)},
+		"batch view":     {arange(t, 4, 3, 70).MustNarrow(0, 1, 2), arange(t, 2, 70, 5)},
+		"strided":        {arange(t, 2, 3, 140).MustSlice(tensor.All, tensor.All, tensor.Range{Start: 0, Stop: 140, Step: 2}), arange(t, 2, 70, 5)},
+	}
+	for name, c := range cases {
+		got := c[0].MustMatMul(c[1]).Data()
+		want := naiveMatMul(c[0].MustContiguous(), c[1].MustContiguous())
+		for i := range want {
+			if math.Abs(float64(got[i]-want[i])) > 1e-3*math.Abs(float64(want[i]))+1e-3 {
+				t.Fatalf("%s: got %v want %v at %d", name, got[i], want[i], i)
+			}
+		}
+	}
+	x := tensor.MustArange[int64](0, 2*67*3, 1, candy.CPU).MustReshape(2, 67, 3)
+	y := tensor.MustArange[int64](0, 2*5*67, 1, candy.CPU).MustReshape(2, 5, 67).MustTranspose(1, 2)
+	if got, want := x.MustTranspose(1, 2).MustMatMul(y).Data(), naiveMatMul(x.MustTranspose(1, 2).MustContiguous(), y.MustContiguous()); !slices.Equal(got, want) {
+		t.Fatalf("int64 matmul mismatch")
+	}
+	for _, c := range []struct{ a, b []int }{{[]int{3, 2}, []int{2, 3}}, {[]int{2, 3}, []int{4, 2}}, {[]int{2, 70, 3}, []int{2, 5, 70}}} {
+		a := tensor.MustArange[float64](1, float64(candy.NewShapeFrom(c.a).Numel()+1), 1, candy.CPU).MustReshape(c.a...)
+		b := tensor.MustArange[float64](1, float64(candy.NewShapeFrom(c.b).Numel()+1), 1, candy.CPU).MustReshape(c.b...)
+		at, bt := a.MustTranspose(-2, -1), b.MustTranspose(-2, -1)
+		if got, want := at.MustMatMul(bt).Data(), naiveMatMul(at.MustContiguous(), bt.MustContiguous()); !slices.Equal(got, want) {
+			t.Fatalf("f64 %v^T @ %v^T: got %v want %v", c.a, c.b, got, want)
+		}
+	}
+}
+
+func TestHalf(t *testing.T) {
+	t.Parallel()
+	for _, c := range []struct {
+		v    float32
+		bits uint16
+	}{{1, 0x3c00}, {-2, 0xc000}, {65504, 0x7bff}, {1e5, 0x7c00}, {5.960464477539063e-08, 0x0001}} {
+		if h := candy.NewFloat16(c.v); uint16(h) != c.bits {
+			t.Fatalf("NewFloat16(%v) = %#04x, want %#04x", c.v, uint16(h), c.bits)
+		}
+	}
+	if got := candy.NewBFloat16(1.5).Float32(); got != 1.5 {
+		t.Fatalf("bf16 round trip = %v", got)
+	}
+
+	x := arange(t, 2, 3)
+	h := x.MustToFloat16()
+	if h.DType() != candy.F16 {
+		t.Fatalf("dtype = %v", h.DType())
+	}
+	if got := h.MustToFloat32().Data(); !slices.Equal(got, x.Data()) {
+		t.Fatalf("f16 round trip = %v", got)
+	}
+	b := x.MustToBFloat16()
+	if got := b.MustAdd(b).MustToFloat32().Data(); !slices.Equal(got, []float32{0, 2, 4, 6, 8, 10}) {
+		t.Fatalf("bf16 add = %v", got)
+	}
+	mm := h.MustMatMul(h.MustTranspose(0, 1)).MustToFloat32().Data()
+	if !slices.Equal(mm, []float32{5, 14, 14, 50}) {
+		t.Fatalf("f16 matmul = %v", mm)
+	}
+	if got := tensor.MustFull[candy.Float16](0.5, candy.NewShapeFrom([]int{2}), candy.CPU).MustSumAll().MustToFloat32().Data(); got[0] != 1 {
+		t.Fatalf("f16 sum = %v", got)
+	}
+
+	path := t.TempDir() + "/h.npy"
+	h.MustWriteNPY(path)
+	if got := tensor.MustReadNPY[candy.Float16](path).MustToFloat32().Data(); !slices.Equal(got, x.Data()) {
+		t.Fatalf("f16 npy = %v", got)
+	}
+}
+
+func TestConvActivation(t *testing.T) {
+	t.Parallel()
+	// The backends write channels-last; the outputs must still come back
+	// contiguous so elementwise ops chained after them see the right values.
+	relu := func(v []float32) []float32 {
+		for i := range v {
+			v[i] = max(v[i], 0)
+		}
+		return v
+	}
+	check := func(name string, y *tensor.Tensor[float32], want []float32) {
+		t.Helper()
+		if !y.IsContiguous() {
+			t.Errorf("%s: output is not contiguous", name)
+		}
+		got := y.MustRelu().Data()
+		for i := range want {
+			if math.Abs(float64(got[i]-want[i])) > 1e-4 {
+				t.Fatalf("%s: output[%d] = %v, want %v", name, i, got[i], want[i])
+			}
+		}
+	}
+
+	x1 := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 3, 9), candy.CPU)
+	w1 := tensor.MustRandN[float32](0, 1, candy.NewShape(4, 3, 3), candy.CPU)
+	p1 := &candy.Conv1DParams{Batch: 2, InCh: 3, InLen: 9, OutCh: 4, KSize: 3, Pad: 1, Stride: 2, Dilate: 1}
+	want1 := make([]float32, 2*4*p1.OutLen())
+	kernels.NaiveConv1d(2, 3, 9, 4, 3, 2, 1, 1, x1.Data(), w1.Data(), want1)
+	check("conv1d", x1.MustConv1d(w1, p1), relu(want1))
+
+	x2 := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 3, 6, 5), candy.CPU)
+	w2 := tensor.MustRandN[float32](0, 1, candy.NewShape(4, 3, 3, 3), candy.CPU)
+	p2 := &candy.Conv2DParams{Batch: 2, InCh: 3, InH: 6, InW: 5, OutCh: 4, KH: 3, KW: 3, Pad: 1, Stride: 1, Dilate: 2}
+	want2 := make([]float32, 2*4*p2.OutH()*p2.OutW())
+	kernels.NaiveConv2d(2, 3, 6, 5, 4, 3, 3, 1, 1, 2, x2.Data(), w2.Data(), want2)
+	check("conv2d", x2.MustConv2d(w2, p2), relu(want2))
+
+	x3 := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 4, 4, 5, 3), candy.CPU)
+	w3 := tensor.MustRandN[float32](0, 1, candy.NewShape(6, 2, 2, 3, 2), candy.CPU)
+	p3 := &candy.Conv3DParams{Batch: 2, InCh: 4, InD: 4, InH: 5, InW: 3, OutCh: 6, KD: 2, KH: 3, KW: 2,
+		Pad: 1, StrideH: 2, Stride: 1, Dilate: 1, Groups: 2}
+	want3 := make([]float32, candy.NewShapeFrom(p3.OutDims()).Numel())
+	kernels.NaiveConv3d(p3, x3.Data(), x3.Stride(), w3.Data(), w3.Stride(), want3)
+	check("conv3d", x3.MustConv3d(w3, p3), relu(want3))
+}
+
+func TestConvShapeMismatch(t *testing.T) {
+	t.Parallel()
+	// Parameters that disagree with the tensors must fail instead of
+	// indexing past the input.
+	x2 := tensor.MustRandN[float32](0, 1, candy.NewShape(1, 2, 3, 3), candy.CPU)
+	w2 := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 1, 2, 2), candy.CPU)
+	for name, p := range map[string]candy.Conv2DParams{
+		"spatial": {Batch: 1, InCh: 2, InH: 5, InW: 5, OutCh: 2, KH: 2, KW: 2, Stride: 1, Dilate: 1, Groups: 2},
+		"batch":   {Batch: 2, InCh: 2, InH: 3, InW: 3, OutCh: 2, KH: 2, KW: 2, Stride: 1, Dilate: 1, Groups: 2},
+		"kernel":  {Batch: 1, InCh: 2, InH: 3, InW: 3, OutCh: 2, KH: 2, KW: 2, Stride: 1, Dilate: 1},
+	} {
+		if _, err := x2.Conv2d(w2, &p); err == nil {
+			t.Errorf("conv2d %s: expected shape mismatch error", name)
+		}
+	}
+
+	x3 := tensor.MustRandN[float32](0, 1, candy.NewShape(1, 2, 3, 3, 3), candy.CPU)
+	w3 := tensor.MustRandN[float32](0, 1, candy.NewShape(2, 2, 2, 2, 2), candy.CPU)
+	for name, p := range map[string]candy.Conv3DParams{
+		"spatial":  {Batch: 1, InCh: 2, InD: 5, InH: 5, InW: 5, OutCh: 2, KD: 2, KH: 2, KW: 2, Stride: 1, Dilate: 1},
+		"channels": {Batch: 1, InCh: 4, InD: 3, InH: 3, InW: 3, OutCh: 2, KD: 2, KH: 2, KW: 2, Stride: 1, Dilate: 1},
+	} {
+		if _, err := x3.Conv3d(w3, &p); err == nil {
+			t.Errorf("conv3d %s: expected shape mismatch error", name)
+		}
+	}
+	pt := &candy.ConvT3DParams{Batch: 1, InCh: 2, InD: 5, InH: 5, InW: 5, OutCh: 2, KD: 2, KH: 2, KW: 2, Stride: 1, Dilate: 1}
+	if _, err := x3.ConvTranspose3d(w3, pt); err == nil {
+		t.Errorf("conv_transpose3d: expected shape mismatch error")
+	}
+}
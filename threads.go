@@ -0,0 +1,23 @@
+package candy
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// numThreads is the thread count set by SetNumThreads; zero means GOMAXPROCS.
+var numThreads atomic.Int64
+
+// SetNumThreads sets the number of goroutines CPU kernels may use. A value of
+// n <= 0 restores the default of GOMAXPROCS.
+func SetNumThreads(n int) {
+	numThreads.Store(int64(max(n, 0)))
+}
+
+// NumThreads returns the number of goroutines CPU kernels may use.
+func NumThreads() int {
+	if n := numThreads.Load(); n > 0 {
+		return int(n)
+	}
+	return runtime.GOMAXPROCS(0)
+}